@@ -0,0 +1,54 @@
+package langfuse
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// credentials are the API keys the requests are authenticated with.
+type credentials struct {
+	publicKey string
+	secretKey string
+}
+
+// installCredentials authenticates the requests of the resty client with the current
+// credentials, unless a request sets its own basic auth, e.g. an organization key.
+//
+// The credentials are read for each request, instead of being set on the resty client,
+// so they can be swapped while requests are being sent.
+func installCredentials(cli *resty.Client, current *atomic.Pointer[credentials]) {
+	cli.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		if req.UserInfo == nil {
+			creds := current.Load()
+			req.SetBasicAuth(creds.publicKey, creds.secretKey)
+		}
+		return nil
+	})
+}
+
+// UpdateCredentials swaps the API keys of the client, e.g. when they are rotated by a
+// secret manager, without recreating the client and losing the queued traces.
+//
+// The requests started afterward use the new keys, including the ones sending the traces
+// which are already queued. The keys must belong to the same project, since the project
+// ID used by TraceURL is cached.
+//
+// Example:
+//
+//	secrets.OnRotate(func(publicKey, secretKey string) {
+//		if err := client.UpdateCredentials(publicKey, secretKey); err != nil {
+//			log.Printf("failed to rotate the Langfuse keys: %v", err)
+//		}
+//	})
+func (c *Langfuse) UpdateCredentials(publicKey, secretKey string) error {
+	if publicKey == "" {
+		return errors.New("'publicKey' is required")
+	}
+	if secretKey == "" {
+		return errors.New("'secretKey' is required")
+	}
+	c.credentials.Store(&credentials{publicKey: publicKey, secretKey: secretKey})
+	return nil
+}
@@ -0,0 +1,53 @@
+package langfuse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateCredentials(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		publicKey, secretKey, _ := r.BasicAuth()
+		mu.Lock()
+		keys = append(keys, r.URL.Path+" "+publicKey+":"+secretKey)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/public/ingestion":
+			_, _ = w.Write([]byte(`{"successes": [], "errors": []}`))
+		case "/api/public/organizations/projects":
+			_, _ = w.Write([]byte(`{"projects": []}`))
+		default:
+			_, _ = w.Write([]byte(`{"status": "OK"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "pk-1", "sk-1", WithOrganizationKey("org-1", "pk-org", "sk-org"))
+	defer client.Close()
+	ctx := context.Background()
+
+	_, err := client.Health().Check(ctx)
+	require.NoError(t, err)
+
+	// The queued traces are sent with the new keys
+	client.StartTrace(ctx, "chat").End()
+	require.NoError(t, client.UpdateCredentials("pk-2", "sk-2"))
+	require.NoError(t, client.FlushContext(ctx))
+	_, err = client.Organizations().ListProjects(ctx, "org-1")
+	require.NoError(t, err)
+
+	require.EqualError(t, client.UpdateCredentials("pk-3", ""), "'secretKey' is required")
+	require.Equal(t, []string{
+		"/api/public/health pk-1:sk-1",
+		"/api/public/ingestion pk-2:sk-2",
+		"/api/public/organizations/projects pk-org:sk-org",
+	}, keys)
+}
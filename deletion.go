@@ -0,0 +1,124 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+const (
+	// defaultDeletionPollInterval is the interval of PollDeletion if none is given.
+	defaultDeletionPollInterval = time.Second
+	// defaultDeletionTimeout bounds PollDeletion if the context has no deadline.
+	defaultDeletionTimeout = 5 * time.Minute
+)
+
+// ErrDeletionPending is wrapped by the error of PollDeletion when the resource still
+// exists once the context is done.
+var ErrDeletionPending = errors.New("deletion is still pending")
+
+// DeletionResource is a kind of resource which is deleted asynchronously, see PollDeletion.
+type DeletionResource string
+
+const (
+	// DeletionResourceTrace is a trace deleted with Traces().Delete or Traces().DeleteMany.
+	DeletionResourceTrace DeletionResource = "trace"
+	// DeletionResourceSession is a session whose traces are deleted with Sessions().Delete.
+	DeletionResourceSession DeletionResource = "session"
+	// DeletionResourceProject is a project deleted with Projects().Delete, which requires
+	// an organization-scoped API key.
+	DeletionResourceProject DeletionResource = "project"
+)
+
+// PollDeletion checks every interval whether the resource with the given ID is gone, and
+// returns once it is, so that cleanup scripts can block until an asynchronous deletion
+// completes. If interval is not positive, it defaults to 1 second.
+//
+// Polling stops when the context is done, with an error wrapping ErrDeletionPending and
+// the context error. If the context has no deadline, polling is bounded by a default
+// timeout of 5 minutes. Failed checks are returned immediately.
+//
+// Example:
+//
+//	if _, err := client.Traces().Delete(ctx, traceID); err != nil {
+//		return err
+//	}
+//	if err := client.PollDeletion(ctx, langfuse.DeletionResourceTrace, traceID, time.Second); err != nil {
+//		return err
+//	}
+func (c *Langfuse) PollDeletion(ctx context.Context, resource DeletionResource, id string, interval time.Duration) error {
+	if id == "" {
+		return errors.New("'id' is required")
+	}
+	if interval <= 0 {
+		interval = defaultDeletionPollInterval
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultDeletionTimeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		exists, err := c.resourceExists(ctx, resource, id)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return fmt.Errorf("%s %s: %w: %w", resource, id, ErrDeletionPending, ctxErr)
+			}
+			return err
+		}
+		if !exists {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s %s: %w: %w", resource, id, ErrDeletionPending, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// resourceExists reports whether the resource still exists.
+func (c *Langfuse) resourceExists(ctx context.Context, resource DeletionResource, id string) (bool, error) {
+	var rsp *resty.Response
+	var err error
+	switch resource {
+	case DeletionResourceTrace:
+		rsp, err = c.restyCli.R().
+			SetContext(ctx).
+			SetPathParam("traceID", id).
+			Get("/traces/{traceID}")
+	case DeletionResourceProject:
+		rsp, err = c.restyCli.R().
+			SetContext(ctx).
+			SetPathParam("projectID", id).
+			Get("/projects/{projectID}/apiKeys")
+	case DeletionResourceSession:
+		// Sessions only exist through their traces
+		sessionTraces, err := c.trace.List(ctx, traces.ListParams{Limit: 1, SessionID: id, Fields: "core"})
+		if err != nil {
+			return false, err
+		}
+		return len(sessionTraces.Data) > 0, nil
+	default:
+		return false, fmt.Errorf("unsupported deletion resource %q", resource)
+	}
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case rsp.StatusCode() == http.StatusNotFound:
+		return false, nil
+	case rsp.IsError():
+		return false, fmt.Errorf("poll deletion failed: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	}
+	return true, nil
+}
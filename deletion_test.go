@@ -0,0 +1,63 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollDeletion(t *testing.T) {
+	var traceChecks, sessionChecks atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/public/traces/trace-1":
+			if traceChecks.Add(1) < 3 {
+				_, _ = w.Write([]byte(`{"id": "trace-1"}`))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/public/traces":
+			require.Equal(t, "session-1", r.URL.Query().Get("sessionId"))
+			if sessionChecks.Add(1) < 2 {
+				_, _ = w.Write([]byte(`{"data": [{"id": "trace-1"}], "meta": {}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data": [], "meta": {}}`))
+		case "/api/public/projects/project-1/apiKeys":
+			_, _ = w.Write([]byte(`{"apiKeys": []}`))
+		case "/api/public/projects/project-2/apiKeys":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "forbidden"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "pk", "sk")
+	defer client.Close()
+	ctx := context.Background()
+
+	require.NoError(t, client.PollDeletion(ctx, DeletionResourceTrace, "trace-1", time.Millisecond))
+	require.EqualValues(t, 3, traceChecks.Load())
+	require.NoError(t, client.PollDeletion(ctx, DeletionResourceSession, "session-1", time.Millisecond))
+	require.EqualValues(t, 2, sessionChecks.Load())
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	err := client.PollDeletion(timeoutCtx, DeletionResourceProject, "project-1", 5*time.Millisecond)
+	require.True(t, errors.Is(err, ErrDeletionPending))
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	err = client.PollDeletion(ctx, DeletionResourceProject, "project-2", time.Millisecond)
+	require.EqualError(t, err, `poll deletion failed: {"message": "forbidden"}, got status code: 403`)
+	require.EqualError(t, client.PollDeletion(ctx, "dataset", "dataset-1", 0), `unsupported deletion resource "dataset"`)
+	require.EqualError(t, client.PollDeletion(ctx, DeletionResourceTrace, "", 0), "'id' is required")
+}
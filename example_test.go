@@ -0,0 +1,40 @@
+package langfuse_test
+
+import (
+	"context"
+	"log"
+
+	langfuse "github.com/git-hulk/langfuse-go"
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+func ExampleNewClient() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...",
+		langfuse.WithCostComputation())
+	defer client.Close()
+
+	trace := client.StartTrace(context.Background(), "chat",
+		traces.WithUser("user-id"),
+		traces.WithTags("production"))
+	generation := trace.StartGeneration("llm")
+	generation.Model = "gpt-4o"
+	generation.Input = "Hello"
+	generation.Output = "Hi, how can I help?"
+	generation.End()
+	trace.End()
+}
+
+func ExampleLangfuse_StartSession() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...")
+	defer client.Close()
+
+	session := client.StartSession("chat-42")
+	session.SetUser("user-id")
+	for _, message := range []string{"Hi", "What's the weather?"} {
+		trace := session.StartTrace(context.Background(), "chat-turn", traces.WithInput(message))
+		trace.End()
+	}
+	if err := client.FlushContext(context.Background()); err != nil {
+		log.Printf("failed to flush traces: %v", err)
+	}
+}
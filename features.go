@@ -0,0 +1,80 @@
+package langfuse
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ErrFeatureDisabled is wrapped by the errors of the API calls of disabled features,
+// see WithDisabledFeatures.
+var ErrFeatureDisabled = errors.New("feature is disabled")
+
+// Feature is an optional subsystem of the client which can be disabled.
+type Feature string
+
+const (
+	// FeatureMedia is the media API, including the offloading of large values.
+	FeatureMedia Feature = "media"
+	// FeatureScores is the scores and score configs API.
+	FeatureScores Feature = "scores"
+	// FeaturePrompts is the prompts API.
+	FeaturePrompts Feature = "prompts"
+	// FeatureDatasets is the datasets, dataset items and dataset runs API.
+	FeatureDatasets Feature = "datasets"
+	// FeatureModels is the models API, including the client-side cost computation.
+	FeatureModels Feature = "models"
+)
+
+// featurePaths are the API paths of the features, relative to the API base path.
+var featurePaths = map[Feature][]string{
+	FeatureMedia:    {"/media"},
+	FeatureScores:   {"/scores", "/v2/scores", "/score-configs"},
+	FeaturePrompts:  {"/v2/prompts"},
+	FeatureDatasets: {"/datasets", "/v2/datasets", "/dataset-items", "/dataset-run-items"},
+	FeatureModels:   {"/models"},
+}
+
+// WithDisabledFeatures disables features of the client, e.g. in security-restricted
+// environments which may only send traces.
+//
+// The API calls of disabled features fail with ErrFeatureDisabled before a request is
+// sent, and the options which depend on them are ignored: large values are truncated
+// instead of being offloaded if FeatureMedia is disabled, and no cost is computed if
+// FeatureModels is disabled.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey,
+//		langfuse.WithDisabledFeatures(langfuse.FeatureMedia, langfuse.FeatureScores))
+func WithDisabledFeatures(features ...Feature) ClientOption {
+	return func(config *clientConfig) {
+		if config.disabledFeatures == nil {
+			config.disabledFeatures = make(map[Feature]bool, len(features))
+		}
+		for _, feature := range features {
+			config.disabledFeatures[feature] = true
+		}
+	}
+}
+
+// installFeatureGuard rejects the requests to the API paths of the disabled features.
+func installFeatureGuard(cli *resty.Client, disabled map[Feature]bool) {
+	cli.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		u, err := url.Parse(req.URL)
+		if err != nil {
+			return nil
+		}
+		for feature := range disabled {
+			for _, path := range featurePaths[feature] {
+				if u.Path == path || strings.HasPrefix(u.Path, path+"/") {
+					return fmt.Errorf("%w: %s", ErrFeatureDisabled, feature)
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,41 @@
+package langfuse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/prompts"
+	"github.com/git-hulk/langfuse-go/pkg/scores"
+)
+
+func TestWithDisabledFeatures(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "OK"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "public-key", "secret-key",
+		WithDisabledFeatures(FeatureScores, FeaturePrompts), WithCostComputation())
+	defer client.Close()
+	ctx := context.Background()
+
+	_, err := client.Scores().Get(ctx, "score-1")
+	require.ErrorIs(t, err, ErrFeatureDisabled)
+	_, err = client.Scores().Create(ctx, &scores.CreateScoreRequest{Name: "accuracy", TraceID: "trace-1", Value: 1})
+	require.ErrorIs(t, err, ErrFeatureDisabled)
+	_, err = client.Prompts().Get(ctx, prompts.GetParams{Name: "chat"})
+	require.ErrorIs(t, err, ErrFeatureDisabled)
+	require.Zero(t, requests.Load())
+
+	_, err = client.Health().Check(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, requests.Load())
+}
@@ -0,0 +1,107 @@
+package langfuse
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"go.uber.org/zap"
+
+	"github.com/git-hulk/langfuse-go/pkg/logger"
+)
+
+// IngestionTransportConfig tunes the connections which send the traces to the ingestion
+// API, see WithIngestionTransport.
+type IngestionTransportConfig struct {
+	// MaxIdleConnsPerHost is the number of idle keep-alive connections kept per host.
+	// Default is the value of the HTTP transport of the client.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the number of connections per host, including the ones in
+	// use. Zero means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout closes the idle connections after this duration. Default is the
+	// value of the HTTP transport of the client.
+	IdleConnTimeout time.Duration
+	// Compress gzips the bodies of the ingestion requests. The server, or the proxy in
+	// front of it, must accept gzip-encoded request bodies.
+	Compress bool
+}
+
+// ingestionTransport sends the ingestion requests through a dedicated connection pool,
+// so that heavy flushes don't starve the other API calls, and the other requests through
+// the base transport.
+type ingestionTransport struct {
+	base      http.RoundTripper
+	ingestion http.RoundTripper
+	compress  bool
+}
+
+// installIngestionTransport makes the resty client send its ingestion requests through a
+// dedicated clone of its transport, tuned by the config. HTTP/2 is attempted on the clone
+// even with a custom TLS configuration, which disables it by default.
+func installIngestionTransport(cli *resty.Client, config IngestionTransportConfig) {
+	base, err := cli.Transport()
+	if err != nil {
+		logger.Get().Error("Failed to apply the ingestion transport configuration", zap.Error(err))
+		return
+	}
+	ingestion := base.Clone()
+	ingestion.ForceAttemptHTTP2 = true
+	if config.MaxIdleConnsPerHost > 0 {
+		ingestion.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+		ingestion.MaxIdleConns = max(ingestion.MaxIdleConns, config.MaxIdleConnsPerHost)
+	}
+	if config.MaxConnsPerHost > 0 {
+		ingestion.MaxConnsPerHost = config.MaxConnsPerHost
+	}
+	if config.IdleConnTimeout > 0 {
+		ingestion.IdleConnTimeout = config.IdleConnTimeout
+	}
+	cli.SetTransport(&ingestionTransport{base: base, ingestion: ingestion, compress: config.Compress})
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ingestionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, ingestionPath) {
+		return t.base.RoundTrip(req)
+	}
+	if t.compress && req.Body != nil && req.Header.Get("Content-Encoding") == "" {
+		compressed, err := gzipRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		req = compressed
+	}
+	return t.ingestion.RoundTrip(req)
+}
+
+// gzipRequest returns a copy of the request with its body gzip-compressed.
+func gzipRequest(req *http.Request) (*http.Request, error) {
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	compressed := req.Clone(req.Context())
+	data := buf.Bytes()
+	compressed.Body = io.NopCloser(bytes.NewReader(data))
+	compressed.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	compressed.ContentLength = int64(len(data))
+	compressed.Header.Set("Content-Encoding", "gzip")
+	return compressed, nil
+}
@@ -0,0 +1,68 @@
+package langfuse
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithIngestionTransport(t *testing.T) {
+	var mu sync.Mutex
+	var encodings []string
+	var batch struct {
+		Batch []json.RawMessage `json:"batch"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		encodings = append(encodings, r.URL.Path+" "+r.Header.Get("Content-Encoding"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/public/ingestion" {
+			reader, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(body, &batch))
+			_, _ = w.Write([]byte(`{"successes": [], "errors": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "OK"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "pk", "sk", WithIngestionTransport(IngestionTransportConfig{
+		MaxIdleConnsPerHost: 16,
+		MaxConnsPerHost:     32,
+		IdleConnTimeout:     time.Minute,
+		Compress:            true,
+	}))
+	defer client.Close()
+
+	transport, ok := client.restyCli.GetClient().Transport.(*ingestionTransport)
+	require.True(t, ok)
+	ingestion := transport.ingestion.(*http.Transport)
+	require.NotSame(t, transport.base, ingestion)
+	require.True(t, ingestion.ForceAttemptHTTP2)
+	require.Equal(t, 16, ingestion.MaxIdleConnsPerHost)
+	require.Equal(t, 32, ingestion.MaxConnsPerHost)
+	require.Equal(t, time.Minute, ingestion.IdleConnTimeout)
+
+	ctx := context.Background()
+	_, err := client.Health().Check(ctx)
+	require.NoError(t, err)
+	client.StartTrace(ctx, "chat").End()
+	require.NoError(t, client.FlushContext(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"/api/public/health ", "/api/public/ingestion gzip"}, encodings)
+	require.Len(t, batch.Batch, 1)
+}
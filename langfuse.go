@@ -18,16 +18,35 @@ package langfuse
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/go-resty/resty/v2"
+	"go.uber.org/zap"
 
 	"github.com/git-hulk/langfuse-go/pkg/organizations"
 
+	"github.com/git-hulk/langfuse-go/pkg/batch"
+	"github.com/git-hulk/langfuse-go/pkg/circuitbreaker"
 	"github.com/git-hulk/langfuse-go/pkg/comments"
+	"github.com/git-hulk/langfuse-go/pkg/common"
 	"github.com/git-hulk/langfuse-go/pkg/datasets"
 	"github.com/git-hulk/langfuse-go/pkg/health"
+	"github.com/git-hulk/langfuse-go/pkg/httpcache"
 	"github.com/git-hulk/langfuse-go/pkg/llmconnections"
+	"github.com/git-hulk/langfuse-go/pkg/logger"
 	"github.com/git-hulk/langfuse-go/pkg/media"
 	"github.com/git-hulk/langfuse-go/pkg/models"
 	"github.com/git-hulk/langfuse-go/pkg/projects"
@@ -35,6 +54,7 @@ import (
 	"github.com/git-hulk/langfuse-go/pkg/scores"
 	"github.com/git-hulk/langfuse-go/pkg/sessions"
 	"github.com/git-hulk/langfuse-go/pkg/traces"
+	"github.com/git-hulk/langfuse-go/pkg/users"
 )
 
 // Langfuse is the main client for interacting with the Langfuse platform.
@@ -47,6 +67,7 @@ import (
 // for trace ingestion with automatic flushing and graceful shutdown capabilities.
 type Langfuse struct {
 	ingestor      *traces.Ingestor
+	trace         *traces.Client
 	prompt        *prompts.Client
 	model         *models.Client
 	project       *projects.Client
@@ -58,21 +79,79 @@ type Langfuse struct {
 	organization  *organizations.Client
 	health        *health.Client
 	media         *media.Client
+	user          *users.Client
 	restyCli      *resty.Client
+	credentials   *atomic.Pointer[credentials]
+
+	host      string
+	projectMu sync.Mutex
+	projectID string
 }
 
+// traceURLTimeout bounds the project lookup when a trace URL is built without a context.
+const traceURLTimeout = 5 * time.Second
+
+// defaultAPIBasePath is the path of the public API relative to the host.
+const defaultAPIBasePath = "/api/public"
+
 // ClientOption is a function that configures a Langfuse client.
 type ClientOption func(*clientConfig)
 
 // clientConfig holds configuration options for the Langfuse client.
 type clientConfig struct {
-	httpClient *http.Client
+	httpClient                *http.Client
+	costComputationEnabled    bool
+	circuitBreaker            *circuitbreaker.CircuitBreaker
+	clock                     traces.Clock
+	skewCorrectionEnabled     bool
+	projectID                 string
+	batchConfig               *batch.Config
+	responseCache             httpcache.Cache
+	timeouts                  *TimeoutConfig
+	proxyURL                  string
+	rootCAs                   *x509.CertPool
+	rootCAFiles               []string
+	clientCertificates        []tls.Certificate
+	usageEstimationEnabled    bool
+	tokenizer                 traces.Tokenizer
+	mediaUploadRetries        int
+	mediaUploadRetryWait      time.Duration
+	traceExporters            []traces.TraceExporter
+	serializerOptions         []traces.IngestorOption
+	redactionOptions          []traces.RedactionOption
+	redaction                 bool
+	sizeBudget                int
+	loopThreshold             int
+	dryRunWriter              io.Writer
+	ingestionTransport        *IngestionTransportConfig
+	apiBasePath               string
+	apiURL                    string
+	startupHealthCheckTimeout time.Duration
+	limits                    *traces.Limits
+	traceEventsGrouping       bool
+	inheritedMetadataKeys     []string
+	traceDecorators           []func(*traces.Trace)
+	idGenerator               traces.Generator
+	largeValuesMaxBytes       int
+	largeValueHandler         traces.LargeValueHandler
+	largeValueOffloading      bool
+	ingestionErrorHandler     func(err error)
+	healthMonitor             *traces.HealthMonitorConfig
+	transport                 http.RoundTripper
+	organizationOptions       []organizations.ClientOption
+	scoreOptions              []scores.ClientOption
+	datasetOptions            []datasets.ClientOption
+	sampleRate                *float64
+	samplingStrategy          traces.SamplingStrategy
+	latencyBuckets            []time.Duration
+	disabledFeatures          map[Feature]bool
 }
 
 // WithHTTPClient sets a custom HTTP client for the Langfuse client.
 //
 // This allows you to customize timeout settings, transport configuration,
 // and other HTTP client behavior. If not provided, resty will use its default HTTP client.
+// The client is copied, so the options which wrap or configure its transport don't modify it.
 //
 // Example:
 //
@@ -90,9 +169,613 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
+// WithCostComputation enables client-side cost computation for generations.
+//
+// When enabled, generations that record a model and usage without CostDetails
+// get their cost computed from the model pricing returned by the models API.
+// The pricing is cached in memory and refreshed periodically. This is useful for
+// self-hosted setups where the server does not compute costs.
+//
+// Example:
+//
+//	client := langfuse.NewClient("https://cloud.langfuse.com", "public-key", "secret-key", langfuse.WithCostComputation())
+func WithCostComputation() ClientOption {
+	return func(config *clientConfig) {
+		config.costComputationEnabled = true
+	}
+}
+
+// WithUsageEstimation enables the estimation of the token usage of generations which don't
+// report one, e.g. because the model provider doesn't return usage for streamed responses.
+//
+// The estimated generations are marked with the traces.MetadataUsageEstimatedKey metadata.
+// Pass nil to use the traces.ApproximateTokenizer, or an exact tokenizer for the models in use.
+//
+// Example:
+//
+//	client := langfuse.NewClient("https://cloud.langfuse.com", "public-key", "secret-key", langfuse.WithUsageEstimation(nil))
+func WithUsageEstimation(tokenizer traces.Tokenizer) ClientOption {
+	return func(config *clientConfig) {
+		config.usageEstimationEnabled = true
+		config.tokenizer = tokenizer
+	}
+}
+
+// WithCircuitBreaker protects the ingestion and API calls with a circuit breaker.
+//
+// After a number of consecutive failures the circuit opens and requests fail fast
+// with circuitbreaker.ErrOpen instead of waiting for the unavailable server. Use
+// the OnStateChange hook of the circuit breaker config to observe state changes.
+//
+// Example:
+//
+//	breaker := circuitbreaker.New(circuitbreaker.Config{
+//		FailureThreshold: 5,
+//		OpenTimeout:      30 * time.Second,
+//		OnStateChange: func(from, to circuitbreaker.State) {
+//			log.Printf("langfuse circuit breaker: %s -> %s", from, to)
+//		},
+//	})
+//	client := langfuse.NewClient("https://cloud.langfuse.com", "public-key", "secret-key", langfuse.WithCircuitBreaker(breaker))
+func WithCircuitBreaker(breaker *circuitbreaker.CircuitBreaker) ClientOption {
+	return func(config *clientConfig) {
+		config.circuitBreaker = breaker
+	}
+}
+
+// WithClock sets the clock used for trace and observation timestamps.
+//
+// This is mainly useful in tests to get deterministic timestamps. If not provided,
+// the system clock is used.
+func WithClock(clock traces.Clock) ClientOption {
+	return func(config *clientConfig) {
+		config.clock = clock
+	}
+}
+
+// WithTimeSkewCorrection enables the correction of the local clock by the skew to the Langfuse server.
+//
+// The skew is detected from the Date header of the API responses, and trace and
+// observation timestamps are shifted by it. This keeps timestamps and latencies
+// consistent on hosts whose clock drifts from the server clock.
+func WithTimeSkewCorrection() ClientOption {
+	return func(config *clientConfig) {
+		config.skewCorrectionEnabled = true
+	}
+}
+
+// WithProjectID sets the ID of the project the API keys belong to.
+//
+// The project ID is used to build links to the Langfuse UI, see TraceURL. If not
+// provided, it's looked up from the projects API the first time it's needed.
+func WithProjectID(projectID string) ClientOption {
+	return func(config *clientConfig) {
+		config.projectID = projectID
+	}
+}
+
+// WithBatchConfig sets the batching behavior of the trace ingestion.
+//
+// Zero fields keep their defaults. Smaller batches and flush intervals reduce the
+// number of traces lost when the process exits unexpectedly, see the serverless
+// package for defaults suited to FaaS environments.
+//
+// Example:
+//
+//	client := langfuse.NewClient("https://cloud.langfuse.com", "public-key", "secret-key",
+//		langfuse.WithBatchConfig(batch.Config{MaxBatchSize: 10, FlushInterval: time.Second}))
+func WithBatchConfig(config batch.Config) ClientOption {
+	return func(c *clientConfig) {
+		c.batchConfig = &config
+	}
+}
+
+// WithResponseCache caches the responses of the read-heavy endpoints, i.e. prompts,
+// score configs and models, in the given cache.
+//
+// Cached responses are revalidated with the ETag and Last-Modified headers, so the
+// server only sends them again when they changed. Use httpcache.NewMemoryCache for
+// an in-memory cache, or implement httpcache.Cache to share the cache between processes.
+//
+// Example:
+//
+//	client := langfuse.NewClient("https://cloud.langfuse.com", "public-key", "secret-key",
+//		langfuse.WithResponseCache(httpcache.NewMemoryCache(500)))
+func WithResponseCache(cache httpcache.Cache) ClientOption {
+	return func(config *clientConfig) {
+		config.responseCache = cache
+	}
+}
+
+// WithTimeouts sets the timeouts of the API requests per operation class.
+//
+// Small reads, writes, media uploads and ingestion flushes have different latencies,
+// so a single timeout of the HTTP client is either too short for some or too long
+// for others. Each timeout applies to a single attempt of a request.
+//
+// Example:
+//
+//	client := langfuse.NewClient("https://cloud.langfuse.com", "public-key", "secret-key",
+//		langfuse.WithTimeouts(langfuse.TimeoutConfig{
+//			Read:   5 * time.Second,
+//			Write:  10 * time.Second,
+//			Upload: time.Minute,
+//			Flush:  30 * time.Second,
+//		}))
+func WithTimeouts(timeouts TimeoutConfig) ClientOption {
+	return func(config *clientConfig) {
+		config.timeouts = &timeouts
+	}
+}
+
+// WithIngestionTransport sends the traces through a dedicated connection pool, tuned by the
+// config, so that heavy flushes don't starve interactive API calls like prompt fetches.
+// HTTP/2 is attempted on the pool where the server supports it, and the batches can be
+// gzip-compressed. It's ignored with WithTransport, which owns the connections.
+//
+// Example:
+//
+//	client := langfuse.NewClient("https://cloud.langfuse.com", "public-key", "secret-key",
+//		langfuse.WithIngestionTransport(langfuse.IngestionTransportConfig{
+//			MaxIdleConnsPerHost: 16,
+//			IdleConnTimeout:     time.Minute,
+//		}))
+func WithIngestionTransport(config IngestionTransportConfig) ClientOption {
+	return func(c *clientConfig) {
+		c.ingestionTransport = &config
+	}
+}
+
+// WithAPIBasePath sets the path of the public API relative to the host, for deployments
+// behind a reverse proxy which serves the API under another path. Default is "/api/public".
+//
+// A Langfuse deployed under a sub-path, e.g. "https://ops.example.com/langfuse", doesn't
+// need this option, as the path of the host is kept.
+func WithAPIBasePath(basePath string) ClientOption {
+	return func(config *clientConfig) {
+		config.apiBasePath = basePath
+	}
+}
+
+// WithAPIURL sets the full URL of the public API, e.g. when the API is exposed by a gateway
+// on another host than the Langfuse UI. It takes precedence over WithAPIBasePath, and the
+// host passed to NewClient is still used to build the links to the Langfuse UI.
+//
+// Example:
+//
+//	client := langfuse.NewClient("https://langfuse.example.com", "public-key", "secret-key",
+//		langfuse.WithAPIURL("https://gateway.example.com/langfuse/api/public"))
+func WithAPIURL(apiURL string) ClientOption {
+	return func(config *clientConfig) {
+		config.apiURL = apiURL
+	}
+}
+
+// baseURL returns the base URL of the public API without trailing slashes.
+func (config *clientConfig) baseURL(host string) string {
+	if config.apiURL != "" {
+		return strings.TrimRight(config.apiURL, "/")
+	}
+	basePath := defaultAPIBasePath
+	if path := strings.Trim(config.apiBasePath, "/"); path != "" {
+		basePath = "/" + path
+	}
+	return strings.TrimRight(host, "/") + basePath
+}
+
+// basePath returns the path of the public API.
+func (config *clientConfig) basePath(host string) string {
+	baseURL, err := url.Parse(config.baseURL(host))
+	if err != nil || baseURL.Path == "" {
+		return defaultAPIBasePath
+	}
+	return baseURL.Path
+}
+
+// WithTraceDecorator adds a function which is called on every started trace, including the
+// traces of sessions, see traces.WithTraceDecorator.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithTraceDecorator(func(trace *traces.Trace) {
+//		if tenant := tenantFromEnv(); tenant != "" {
+//			trace.Tags = append(trace.Tags, "tenant:"+tenant)
+//		}
+//	}))
+func WithTraceDecorator(decorate func(*traces.Trace)) ClientOption {
+	return func(config *clientConfig) {
+		config.traceDecorators = append(config.traceDecorators, decorate)
+	}
+}
+
+// WithIDGenerator sets the generator of the trace and observation IDs, see traces.WithIDGenerator.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithIDGenerator(traces.NewSeededIDGenerator(42)))
+func WithIDGenerator(generator traces.Generator) ClientOption {
+	return func(config *clientConfig) {
+		config.idGenerator = generator
+	}
+}
+
+// WithLargeValues replaces the strings of trace and observation inputs and outputs which are
+// larger than maxBytes with the result of the handler, see traces.WithLargeValues.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithLargeValues(256*1024, traces.CompressLargeValues(0)))
+func WithLargeValues(maxBytes int, handler traces.LargeValueHandler) ClientOption {
+	return func(config *clientConfig) {
+		config.largeValuesMaxBytes = maxBytes
+		config.largeValueHandler = handler
+		config.largeValueOffloading = false
+	}
+}
+
+// WithLargeValueOffloading uploads the strings of trace and observation inputs and outputs
+// which are larger than maxBytes as media, and replaces them by a preview and the media
+// reference, see media.NewLargeValueOffloader.
+func WithLargeValueOffloading(maxBytes int) ClientOption {
+	return func(config *clientConfig) {
+		config.largeValuesMaxBytes = maxBytes
+		config.largeValueHandler = nil
+		config.largeValueOffloading = true
+	}
+}
+
+// WithIngestionErrorHandler sets a function which is called with the error of every failed
+// ingestion request, see traces.WithIngestionErrorHandler.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithIngestionErrorHandler(func(err error) {
+//		var failure *traces.IngestionFailure
+//		if errors.As(err, &failure) {
+//			droppedEvents.Add(float64(len(failure.Result.Errors)))
+//		}
+//	}))
+func WithIngestionErrorHandler(handler func(err error)) ClientOption {
+	return func(config *clientConfig) {
+		config.ingestionErrorHandler = handler
+	}
+}
+
+// WithHealthMonitor pauses the ingestion while the Langfuse server is unhealthy and queues
+// the batches until it recovers, see traces.WithHealthMonitor.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithHealthMonitor(traces.HealthMonitorConfig{
+//		OnPause: func() { log.Println("langfuse is unhealthy, queuing traces") },
+//	}))
+func WithHealthMonitor(monitorConfig traces.HealthMonitorConfig) ClientOption {
+	return func(config *clientConfig) {
+		config.healthMonitor = &monitorConfig
+	}
+}
+
+// WithOrganizationKey registers the organization-scoped API key of an organization for the
+// org-scoped methods of the organizations client, see organizations.WithOrganizationKey.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey,
+//		langfuse.WithOrganizationKey("org-1", orgPublicKey, orgSecretKey))
+//	projects, err := client.Organizations().ListProjects(ctx, "org-1")
+func WithOrganizationKey(orgID, publicKey, secretKey string) ClientOption {
+	return func(config *clientConfig) {
+		config.organizationOptions = append(config.organizationOptions,
+			organizations.WithOrganizationKey(orgID, publicKey, secretKey))
+	}
+}
+
+// WithScoreValuePrecision rounds the numeric values of the scores created by the scores
+// client to the given number of decimal places, see scores.WithValuePrecision.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithScoreValuePrecision(2))
+func WithScoreValuePrecision(decimals int) ClientOption {
+	return func(config *clientConfig) {
+		config.scoreOptions = append(config.scoreOptions, scores.WithValuePrecision(decimals))
+	}
+}
+
+// WithScoreDecimalStrings makes the scores client send the numeric values of CATEGORICAL
+// scores as decimal strings, rounded to the precision of WithScoreValuePrecision if set,
+// see scores.WithDecimalStrings.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey,
+//		langfuse.WithScoreValuePrecision(2), langfuse.WithScoreDecimalStrings())
+func WithScoreDecimalStrings() ClientOption {
+	return func(config *clientConfig) {
+		config.scoreOptions = append(config.scoreOptions, scores.WithDecimalStrings())
+	}
+}
+
+// WithStrictScoreNames makes the scores client reject the scores whose name has no score
+// config, so that a typo doesn't silently create a new metric, see scores.StrictScoreNames.
+// The names are loaded from the score configs of the project on the first created score.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithStrictScoreNames())
+func WithStrictScoreNames() ClientOption {
+	return func(config *clientConfig) {
+		config.scoreOptions = append(config.scoreOptions, scores.StrictScoreNames(nil))
+	}
+}
+
+// WithDatasetItemValidator registers a validator for the items of the given dataset, so
+// that the malformed items are rejected before they're created, see datasets.WithItemValidator.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithDatasetItemValidator("qa-eval",
+//		datasets.RequireInputKeys("question", "context")))
+func WithDatasetItemValidator(datasetName string, validator datasets.ItemValidator) ClientOption {
+	return func(config *clientConfig) {
+		config.datasetOptions = append(config.datasetOptions,
+			datasets.WithItemValidator(datasetName, validator))
+	}
+}
+
+// WithInheritedMetadata copies the given keys of the trace metadata to every observation
+// of the trace, see traces.WithInheritedMetadata.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithInheritedMetadata("tenant_id", "request_id"))
+//	trace := client.StartTrace(ctx, "handler", traces.WithMetadata(map[string]any{
+//		"tenant_id":  tenantID,
+//		"request_id": requestID,
+//	}))
+//	span := trace.StartSpan("query") // span.Metadata holds tenant_id and request_id
+func WithInheritedMetadata(keys ...string) ClientOption {
+	return func(config *clientConfig) {
+		config.inheritedMetadataKeys = append(config.inheritedMetadataKeys, keys...)
+	}
+}
+
+// WithTraceEventsGrouping keeps all events of a trace in the same ingestion request,
+// see traces.WithTraceEventsGrouping.
+func WithTraceEventsGrouping() ClientOption {
+	return func(config *clientConfig) {
+		config.traceEventsGrouping = true
+	}
+}
+
+// WithLimits checks the names and tags of the traces against client-side limits when they
+// end, see traces.Limits. Violations are logged as warnings, or reject the trace in strict mode.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithLimits(traces.Limits{
+//		MaxNameLength: 200,
+//		MaxTags:       20,
+//		Strict:        os.Getenv("ENV") == "development",
+//	}))
+func WithLimits(limits traces.Limits) ClientOption {
+	return func(config *clientConfig) {
+		config.limits = &limits
+	}
+}
+
+// WithSampleRate keeps only a fraction of the traces, between 0 and 1, see traces.WithSampleRate.
+// The rate can be overridden for a request with traces.ForceSample and traces.NeverSample.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithSampleRate(0.1))
+//	// Always sample the requests of VIP customers
+//	trace := client.StartTrace(traces.ForceSample(ctx), "checkout")
+func WithSampleRate(rate float64) ClientOption {
+	return func(config *clientConfig) {
+		config.sampleRate = &rate
+	}
+}
+
+// WithSamplingStrategy sets when the sample rate is applied, see traces.WithSamplingStrategy.
+//
+// Example:
+//
+//	// Keep 10% of the successful traces and all the failed ones
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithSampleRate(0.1),
+//		langfuse.WithSamplingStrategy(traces.SampleErrorBiased))
+func WithSamplingStrategy(strategy traces.SamplingStrategy) ClientOption {
+	return func(config *clientConfig) {
+		config.samplingStrategy = strategy
+	}
+}
+
+// WithLatencyBuckets adds the latency bucket of every observation to its metadata when it
+// ends, e.g. "<=500ms", see traces.WithLatencyBuckets.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey,
+//		langfuse.WithLatencyBuckets(100*time.Millisecond, 500*time.Millisecond, 2*time.Second))
+func WithLatencyBuckets(bounds ...time.Duration) ClientOption {
+	return func(config *clientConfig) {
+		config.latencyBuckets = bounds
+	}
+}
+
+// WithTraceExporter double-writes every batch of traces sent to Langfuse to the exporter,
+// e.g. an otlp.Exporter sending them to an existing observability backend.
+//
+// Export failures are logged and don't affect the ingestion into Langfuse.
+func WithTraceExporter(exporter traces.TraceExporter) ClientOption {
+	return func(config *clientConfig) {
+		config.traceExporters = append(config.traceExporters, exporter)
+	}
+}
+
+// WithSerializer registers a custom serialization for the values of type T in the input,
+// output, metadata and model parameters of traces and observations, e.g. to encode protobuf
+// messages with protojson, see traces.WithSerializer.
+func WithSerializer[T any](serialize func(value T) (any, error)) ClientOption {
+	return func(config *clientConfig) {
+		config.serializerOptions = append(config.serializerOptions, traces.WithSerializer(serialize))
+	}
+}
+
+// WithRedaction replaces the secret-looking strings of the input, output and metadata of
+// traces and observations, like API keys, bearer tokens and emails, see traces.WithRedaction.
+func WithRedaction(options ...traces.RedactionOption) ClientOption {
+	return func(config *clientConfig) {
+		config.redaction = true
+		config.redactionOptions = options
+	}
+}
+
+// WithTraceSizeBudget limits the accumulated size of the input, output and metadata of a
+// trace and its observations, summarizing the input and output of its middle observations
+// when it's exceeded, see traces.WithSizeBudget.
+func WithTraceSizeBudget(maxBytes int) ClientOption {
+	return func(config *clientConfig) {
+		config.sizeBudget = maxBytes
+	}
+}
+
+// WithLoopDetection tags the traces with repeated identical tool calls or generation
+// outputs as "loop-detected" when they end, see traces.WithLoopDetection.
+func WithLoopDetection(threshold int) ClientOption {
+	return func(config *clientConfig) {
+		config.loopThreshold = max(threshold, 1)
+	}
+}
+
+// WithDryRun writes the ingestion requests to the writer as indented JSON instead of
+// sending them, to debug exactly what would be sent, see traces.WithDryRun.
+//
+// The other side effects of sending the traces are disabled as well: the trace exporters
+// aren't called, and neither media nor large values are uploaded, the large values of
+// WithLargeValueOffloading are truncated to a preview instead.
+func WithDryRun(w io.Writer) ClientOption {
+	return func(config *clientConfig) {
+		config.dryRunWriter = w
+	}
+}
+
+// WithMediaUploadRetries retries failed uploads of media content up to maxRetries times,
+// waiting for wait before the first retry and doubling the wait for every further retry.
+// See media.WithUploadRetries for the errors which are retried.
+func WithMediaUploadRetries(maxRetries int, wait time.Duration) ClientOption {
+	return func(config *clientConfig) {
+		config.mediaUploadRetries = maxRetries
+		config.mediaUploadRetryWait = wait
+	}
+}
+
+// WithProxy sends the requests through the HTTP(S) proxy at the given URL.
+//
+// By default, the proxy is taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+// environment variables.
+//
+// Example:
+//
+//	client := langfuse.NewClient("https://langfuse.internal", "public-key", "secret-key",
+//		langfuse.WithProxy("http://proxy.internal:3128"))
+func WithProxy(proxyURL string) ClientOption {
+	return func(config *clientConfig) {
+		config.proxyURL = proxyURL
+	}
+}
+
+// WithRootCAs sets the root certificate authorities used to verify the server certificate,
+// e.g. for a self-hosted Langfuse with certificates issued by a corporate CA.
+//
+// The pool replaces the system roots, use x509.SystemCertPool to extend them instead.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(config *clientConfig) {
+		config.rootCAs = pool
+	}
+}
+
+// WithRootCAFiles adds the PEM encoded root certificates in the given files to the
+// root certificate authorities used to verify the server certificate.
+//
+// Example:
+//
+//	client := langfuse.NewClient("https://langfuse.internal", "public-key", "secret-key",
+//		langfuse.WithRootCAFiles("/etc/ssl/certs/corporate-ca.pem"))
+func WithRootCAFiles(pemFiles ...string) ClientOption {
+	return func(config *clientConfig) {
+		config.rootCAFiles = append(config.rootCAFiles, pemFiles...)
+	}
+}
+
+// WithClientCertificates sets the client certificates presented to the server for
+// mutual TLS authentication, e.g. by a gateway in front of a self-hosted Langfuse.
+//
+// Example:
+//
+//	cert, err := tls.LoadX509KeyPair("client.crt", "client.key")
+//	if err != nil {
+//		return err
+//	}
+//	client := langfuse.NewClient("https://langfuse.internal", "public-key", "secret-key",
+//		langfuse.WithClientCertificates(cert))
+func WithClientCertificates(certs ...tls.Certificate) ClientOption {
+	return func(config *clientConfig) {
+		config.clientCertificates = append(config.clientCertificates, certs...)
+	}
+}
+
+// applyTransport applies the proxy and TLS settings to a clone of the transport of the
+// resty client, so that a transport shared with the caller, e.g. http.DefaultTransport,
+// isn't modified.
+func (config *clientConfig) applyTransport(restyCli *resty.Client) error {
+	if config.proxyURL == "" && config.rootCAs == nil && len(config.rootCAFiles) == 0 &&
+		len(config.clientCertificates) == 0 {
+		return nil
+	}
+	base, err := restyCli.Transport()
+	if err != nil {
+		return fmt.Errorf("failed to apply the proxy and TLS configuration: %w", err)
+	}
+	transport := base.Clone()
+	restyCli.SetTransport(transport)
+	if config.proxyURL != "" {
+		restyCli.SetProxy(config.proxyURL)
+	}
+	if config.rootCAs == nil && len(config.rootCAFiles) == 0 && len(config.clientCertificates) == 0 {
+		return nil
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if config.rootCAs != nil {
+		// Clone the pool, so that the files below don't modify it
+		transport.TLSClientConfig.RootCAs = config.rootCAs.Clone()
+	}
+	if len(config.rootCAFiles) > 0 {
+		if transport.TLSClientConfig.RootCAs == nil {
+			transport.TLSClientConfig.RootCAs = x509.NewCertPool()
+		}
+		for _, pemFile := range config.rootCAFiles {
+			pemData, err := os.ReadFile(pemFile)
+			if err != nil {
+				return fmt.Errorf("failed to read the root certificates: %w", err)
+			}
+			if !transport.TLSClientConfig.RootCAs.AppendCertsFromPEM(pemData) {
+				return fmt.Errorf("no PEM encoded certificate found in %s", pemFile)
+			}
+		}
+	}
+	transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, config.clientCertificates...)
+	return nil
+}
+
 // NewClient creates a new Langfuse client instance with the specified host and credentials.
 //
-// The host should be the base URL of your Langfuse instance (e.g., "https://cloud.langfuse.com").
+// The host should be the base URL of your Langfuse instance (e.g., "https://cloud.langfuse.com"),
+// including its path if it's deployed under a sub-path. The API is expected at /api/public
+// below the host, see WithAPIBasePath and WithAPIURL for other setups.
 // The publicKey and secretKey are obtained from your Langfuse project settings.
 // Optional configuration can be provided using ClientOption functions.
 //
@@ -103,43 +786,286 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 //
 //	httpClient := &http.Client{Timeout: 30 * time.Second}
 //	client := langfuse.NewClient("https://cloud.langfuse.com", "public-key", "secret-key", langfuse.WithHTTPClient(httpClient))
+//
+// If a startup health check is enabled with WithStartupHealthCheck, a failed check is logged
+// as a warning, and an invalid proxy or TLS configuration, e.g. an unreadable file of
+// WithRootCAFiles, is logged as an error. Use New to get the failures as errors instead.
 func NewClient(host string, publicKey string, secretKey string, options ...ClientOption) *Langfuse {
+	config := newClientConfig(options)
+	client, err := newClient(host, publicKey, secretKey, config)
+	if err != nil {
+		logger.Get().With(
+			zap.Error(err),
+			zap.String("host", client.host),
+		).Error("Invalid Langfuse transport configuration")
+	}
+	if config.startupHealthCheckTimeout > 0 {
+		if err := client.checkStartup(config.startupHealthCheckTimeout); err != nil {
+			logger.Get().With(
+				zap.Error(err),
+				zap.String("host", client.host),
+			).Warn("Langfuse startup health check failed")
+		}
+	}
+	return client
+}
+
+// New creates a new Langfuse client like NewClient, but returns an error if the proxy or TLS
+// configuration is invalid, or the startup health check enabled with WithStartupHealthCheck
+// fails, so that a misconfigured host or credentials stop the application at boot.
+//
+// Example:
+//
+//	client, err := langfuse.New(host, publicKey, secretKey, langfuse.WithStartupHealthCheck(5*time.Second))
+//	if err != nil {
+//		log.Fatalf("failed to connect to Langfuse: %v", err)
+//	}
+func New(host string, publicKey string, secretKey string, options ...ClientOption) (*Langfuse, error) {
+	config := newClientConfig(options)
+	client, err := newClient(host, publicKey, secretKey, config)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	if config.startupHealthCheckTimeout > 0 {
+		if err := client.checkStartup(config.startupHealthCheckTimeout); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+func newClientConfig(options []ClientOption) *clientConfig {
 	config := &clientConfig{}
 	for _, option := range options {
 		option(config)
 	}
+	return config
+}
 
+// newClient creates the client, which is usable even if the transport configuration failed.
+func newClient(host string, publicKey string, secretKey string, config *clientConfig) (*Langfuse, error) {
 	var restyCli *resty.Client
 	if config.httpClient != nil {
-		restyCli = resty.NewWithClient(config.httpClient)
+		// The transport of the client is wrapped below, so copy the client of the caller
+		httpClient := *config.httpClient
+		restyCli = resty.NewWithClient(&httpClient)
 	} else {
 		restyCli = resty.New()
 	}
 
-	restyCli.SetBaseURL(host+"/api/public").
-		SetBasicAuth(publicKey, secretKey)
+	restyCli.SetBaseURL(config.baseURL(host))
+	currentCredentials := &atomic.Pointer[credentials]{}
+	currentCredentials.Store(&credentials{publicKey: publicKey, secretKey: secretKey})
+	installCredentials(restyCli, currentCredentials)
+	// The disabled features are rejected before the circuit breaker counts the request
+	if len(config.disabledFeatures) > 0 {
+		installFeatureGuard(restyCli, config.disabledFeatures)
+	}
+	// The proxy and TLS settings modify the *http.Transport, so they must be applied
+	// before the transport is wrapped by the timeouts or the response cache.
+	var transportErr error
+	if config.transport != nil {
+		restyCli.SetTransport(config.transport)
+	} else {
+		transportErr = config.applyTransport(restyCli)
+	}
+	// The media content is uploaded to the storage provider with the proxy and TLS
+	// settings of the API, but without the wrappers of the API requests below.
+	uploadClient := &http.Client{Transport: restyCli.GetClient().Transport}
+	if config.transport == nil && config.ingestionTransport != nil {
+		installIngestionTransport(restyCli, *config.ingestionTransport)
+	}
+	if config.circuitBreaker != nil {
+		config.circuitBreaker.Install(restyCli)
+	}
+	common.InstallRequestHeaders(restyCli)
+	mediaOptions := []media.ClientOption{media.WithUploadHTTPClient(uploadClient)}
+	if config.timeouts != nil {
+		installTimeouts(restyCli, *config.timeouts)
+		mediaOptions = append(mediaOptions, media.WithUploadTimeout(config.timeouts.Upload))
+	}
+	if config.mediaUploadRetries > 0 {
+		mediaOptions = append(mediaOptions, media.WithUploadRetries(config.mediaUploadRetries, config.mediaUploadRetryWait))
+	}
+	if config.responseCache != nil {
+		httpcache.New(nil, httpcache.Config{
+			Cache:    config.responseCache,
+			BasePath: config.basePath(host),
+		}).Install(restyCli)
+	}
 
-	return &Langfuse{
-		ingestor:      traces.NewIngestor(restyCli),
+	modelCli := models.NewClient(restyCli)
+	ingestorOptions := make([]traces.IngestorOption, 0)
+	clock := config.clock
+	if config.skewCorrectionEnabled {
+		skewCorrectedClock := traces.NewSkewCorrectedClock(clock, 0)
+		skewCorrectedClock.Install(restyCli)
+		clock = skewCorrectedClock
+	}
+	if clock != nil {
+		ingestorOptions = append(ingestorOptions, traces.WithClock(clock))
+	}
+	if config.batchConfig != nil {
+		ingestorOptions = append(ingestorOptions, traces.WithBatchConfig(*config.batchConfig))
+	}
+	if config.usageEstimationEnabled {
+		ingestorOptions = append(ingestorOptions, traces.WithTokenizer(config.tokenizer))
+	}
+	if config.costComputationEnabled && !config.disabledFeatures[FeatureModels] {
+		ingestorOptions = append(ingestorOptions, traces.WithCostCalculator(traces.NewCostCalculator(modelCli, 0)))
+	}
+
+	client := &Langfuse{
+		trace:         traces.NewClient(restyCli),
 		prompt:        prompts.NewClient(restyCli),
-		model:         models.NewClient(restyCli),
+		model:         modelCli,
 		project:       projects.NewClient(restyCli),
 		comment:       comments.NewClient(restyCli),
-		dataset:       datasets.NewClient(restyCli),
+		dataset:       datasets.NewClient(restyCli, config.datasetOptions...),
 		session:       sessions.NewClient(restyCli),
-		score:         scores.NewClient(restyCli),
+		score:         scores.NewClient(restyCli, config.scoreOptions...),
 		llmConnection: llmconnections.NewClient(restyCli),
-		organization:  organizations.NewClient(restyCli),
+		organization:  organizations.NewClient(restyCli, config.organizationOptions...),
 		health:        health.NewClient(restyCli),
-		media:         media.NewClient(restyCli),
+		media:         media.NewClient(restyCli, mediaOptions...),
+		user:          users.NewClient(restyCli),
 		restyCli:      restyCli,
+		credentials:   currentCredentials,
+		host:          strings.TrimRight(host, "/"),
+		projectID:     config.projectID,
+	}
+	for _, decorate := range config.traceDecorators {
+		ingestorOptions = append(ingestorOptions, traces.WithTraceDecorator(decorate))
+	}
+	if config.idGenerator != nil {
+		ingestorOptions = append(ingestorOptions, traces.WithIDGenerator(config.idGenerator))
+	}
+	if len(config.inheritedMetadataKeys) > 0 {
+		ingestorOptions = append(ingestorOptions, traces.WithInheritedMetadata(config.inheritedMetadataKeys...))
+	}
+	// A dry run must not have side effects, so nothing is uploaded nor exported
+	dryRun := config.dryRunWriter != nil
+	uploadMedia := !config.disabledFeatures[FeatureMedia] && !dryRun
+	if config.largeValuesMaxBytes > 0 {
+		handler := config.largeValueHandler
+		if config.largeValueOffloading && uploadMedia {
+			handler = media.NewLargeValueOffloader(client.media, 0)
+		}
+		ingestorOptions = append(ingestorOptions, traces.WithLargeValues(config.largeValuesMaxBytes, handler))
+	}
+	if config.ingestionErrorHandler != nil {
+		ingestorOptions = append(ingestorOptions, traces.WithIngestionErrorHandler(config.ingestionErrorHandler))
+	}
+	if config.healthMonitor != nil {
+		ingestorOptions = append(ingestorOptions, traces.WithHealthMonitor(*config.healthMonitor))
+	}
+	if config.traceEventsGrouping {
+		ingestorOptions = append(ingestorOptions, traces.WithTraceEventsGrouping())
+	}
+	if config.limits != nil {
+		ingestorOptions = append(ingestorOptions, traces.WithLimits(*config.limits))
+	}
+	if config.sampleRate != nil {
+		ingestorOptions = append(ingestorOptions, traces.WithSampleRate(*config.sampleRate))
+	}
+	if len(config.latencyBuckets) > 0 {
+		ingestorOptions = append(ingestorOptions, traces.WithLatencyBuckets(config.latencyBuckets...))
+	}
+	if config.samplingStrategy != traces.SampleOnStart {
+		ingestorOptions = append(ingestorOptions, traces.WithSamplingStrategy(config.samplingStrategy))
 	}
+	ingestorOptions = append(ingestorOptions, config.serializerOptions...)
+	if config.dryRunWriter != nil {
+		ingestorOptions = append(ingestorOptions, traces.WithDryRun(config.dryRunWriter))
+	}
+	if config.loopThreshold > 0 {
+		ingestorOptions = append(ingestorOptions, traces.WithLoopDetection(config.loopThreshold))
+	}
+	if config.sizeBudget > 0 {
+		ingestorOptions = append(ingestorOptions, traces.WithSizeBudget(config.sizeBudget))
+	}
+	if config.redaction {
+		ingestorOptions = append(ingestorOptions, traces.WithRedaction(config.redactionOptions...))
+	}
+	if !dryRun {
+		for _, exporter := range config.traceExporters {
+			ingestorOptions = append(ingestorOptions, traces.WithTraceExporter(exporter))
+		}
+	}
+	if uploadMedia {
+		ingestorOptions = append(ingestorOptions, traces.WithMediaUploader(media.NewMediaUploader(client.media)))
+	}
+	ingestorOptions = append(ingestorOptions, traces.WithTraceURLBuilder(client.buildTraceURL))
+	client.ingestor = traces.NewIngestor(restyCli, ingestorOptions...)
+	return client, transportErr
 }
 
 func (c *Langfuse) Flush() {
 	c.ingestor.Flush()
 }
 
+// FlushContext sends all pending traces and waits until they have been sent, or the context is done.
+// It returns traces.ErrPaused if the ingestion is paused.
+func (c *Langfuse) FlushContext(ctx context.Context) error {
+	return c.ingestor.FlushContext(ctx)
+}
+
+// FlushWithResult sends all pending traces, waits until they have been sent, and returns
+// the outcome of the ingestion requests sent since the previous call, see
+// traces.Ingestor.FlushWithResult.
+func (c *Langfuse) FlushWithResult(ctx context.Context) (*traces.FlushResult, error) {
+	return c.ingestor.FlushWithResult(ctx)
+}
+
+// IngestFromReader sends the traces and observations read from newline-delimited JSON,
+// e.g. to backfill historical data from logs, see traces.Ingestor.IngestFromReader.
+func (c *Langfuse) IngestFromReader(ctx context.Context, r io.Reader) (*traces.BackfillResult, error) {
+	return c.ingestor.IngestFromReader(ctx, r)
+}
+
+// QueueStats returns the statistics of the traces which have been ended but not sent yet,
+// see traces.Ingestor.QueueStats. The memory retained by the queue can be capped with
+// batch.Config.MaxQueuedBytes.
+func (c *Langfuse) QueueStats() batch.QueueStats {
+	return c.ingestor.QueueStats()
+}
+
+// ReplayTrace fetches a stored trace and runs the handler again with its input, recording
+// the replay in a new trace tagged as a replay and linked to the original, see
+// traces.Ingestor.Replay.
+//
+// Example:
+//
+//	replay, err := client.ReplayTrace(ctx, traceID, func(ctx context.Context, trace *traces.Trace, input any) (any, error) {
+//		return answer(ctx, input)
+//	})
+func (c *Langfuse) ReplayTrace(ctx context.Context, traceID string, handler traces.ReplayHandler) (*traces.Trace, error) {
+	original, err := c.trace.Get(ctx, traceID)
+	if err != nil {
+		return nil, err
+	}
+	return c.ingestor.Replay(ctx, original, handler)
+}
+
+// Pause stops sending the traces, which are kept in the queue until Resume is called,
+// e.g. from an admin endpoint during maintenance windows, see traces.Ingestor.Pause.
+func (c *Langfuse) Pause() {
+	c.ingestor.Pause()
+}
+
+// Resume resumes sending the traces after Pause.
+func (c *Langfuse) Resume() {
+	c.ingestor.Resume()
+}
+
+// Drain sends the queued traces and waits until they have been sent or the context is
+// done, without closing the client, see traces.Ingestor.Drain.
+func (c *Langfuse) Drain(ctx context.Context) error {
+	return c.ingestor.Drain(ctx)
+}
+
 // StartTrace creates a new trace with the given name.
 //
 // A trace represents a single execution flow in your application and can contain
@@ -147,8 +1073,97 @@ func (c *Langfuse) Flush() {
 // Langfuse for efficient ingestion.
 //
 // Returns a Trace instance that you can use to add observations and metadata.
-func (c *Langfuse) StartTrace(ctx context.Context, name string) *traces.Trace {
-	return c.ingestor.StartTrace(ctx, name)
+// Options describe the trace atomically at creation, for example:
+//
+//	trace := client.StartTrace(ctx, "chat",
+//		traces.WithUser("user-id"),
+//		traces.WithSession("session-id"),
+//		traces.WithTags("production", "chat"),
+//		traces.WithInput(request),
+//	)
+func (c *Langfuse) StartTrace(ctx context.Context, name string, options ...traces.TraceOption) *traces.Trace {
+	return c.ingestor.StartTrace(ctx, name, options...)
+}
+
+// StartSession returns a handle for the session with the given ID, whose traces are
+// grouped into the session automatically. If the ID is empty, a new one is generated.
+//
+// Example:
+//
+//	session := client.StartSession(conversationID)
+//	session.SetUser("user-id")
+//	for _, message := range messages {
+//		trace := session.StartTrace(ctx, "chat-turn", traces.WithInput(message))
+//		// ...
+//		trace.End()
+//	}
+func (c *Langfuse) StartSession(id string) *traces.Session {
+	return c.ingestor.StartSession(id)
+}
+
+// TraceURL returns the link to the trace in the Langfuse UI.
+//
+// The link is built from the configured host and project, which makes it handy for
+// error reports and alerts. If the project ID wasn't set with WithProjectID, it's
+// looked up once from the projects API and cached.
+//
+// Example:
+//
+//	traceURL, err := client.TraceURL(ctx, trace.ID)
+//	if err == nil {
+//		log.Printf("request failed, see %s", traceURL)
+//	}
+func (c *Langfuse) TraceURL(ctx context.Context, traceID string) (string, error) {
+	if traceID == "" {
+		return "", errors.New("'traceID' is required")
+	}
+	projectID, err := c.getProjectID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/project/%s/traces/%s", c.host, url.PathEscape(projectID), url.PathEscape(traceID)), nil
+}
+
+// buildTraceURL is used by traces to build their URL, it returns an empty string on failure.
+func (c *Langfuse) buildTraceURL(traceID string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), traceURLTimeout)
+	defer cancel()
+
+	traceURL, err := c.TraceURL(ctx, traceID)
+	if err != nil {
+		logger.Get().With(
+			zap.Error(err),
+			zap.String("trace_id", traceID),
+		).Warn("Failed to build trace URL")
+		return ""
+	}
+	return traceURL
+}
+
+func (c *Langfuse) getProjectID(ctx context.Context) (string, error) {
+	c.projectMu.Lock()
+	defer c.projectMu.Unlock()
+
+	if c.projectID != "" {
+		return c.projectID, nil
+	}
+	listProjects, err := c.project.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(listProjects.Data) == 0 || listProjects.Data[0].ID == "" {
+		return "", errors.New("no project found for the API key")
+	}
+	c.projectID = listProjects.Data[0].ID
+	return c.projectID, nil
+}
+
+// Traces returns a client for managing traces that have already been ingested.
+//
+// Use this client to delete individual traces or multiple traces at once,
+// for example to fulfill data-subject deletion requests.
+func (c *Langfuse) Traces() *traces.Client {
+	return c.trace
 }
 
 // Prompts returns a client for managing prompt templates and versions.
@@ -239,6 +1254,115 @@ func (c *Langfuse) Media() *media.Client {
 	return c.media
 }
 
+// Users returns a client for looking up users and their aggregated usage.
+//
+// Use this client to list the distinct users attached to traces along with
+// their trace counts and costs, or to look up the usage of a single user.
+func (c *Langfuse) Users() *users.Client {
+	return c.user
+}
+
+// HandleSignals closes the client when one of the given signals is received, so
+// pending traces are flushed on shutdown. Default is os.Interrupt and syscall.SIGTERM.
+//
+// After the client is closed, the signal handler is removed and the signal is raised
+// again, so the default behavior of the signal (usually terminating the process) or
+// other handlers of the application still apply. The returned function removes the
+// handler without closing the client. It's safe to call from multiple goroutines.
+//
+// Example:
+//
+//	client := langfuse.NewClient("https://cloud.langfuse.com", "public-key", "secret-key")
+//	stop := client.HandleSignals(os.Interrupt, syscall.SIGTERM)
+//	defer stop()
+func (c *Langfuse) HandleSignals(signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	signalCh := make(chan os.Signal, 1)
+	stopCh := make(chan struct{})
+	signal.Notify(signalCh, signals...)
+
+	go func() {
+		select {
+		case sig := <-signalCh:
+			signal.Stop(signalCh)
+			if err := c.Close(); err != nil {
+				logger.Get().With(
+					zap.Error(err),
+					zap.String("signal", sig.String()),
+				).Error("Failed to close the client on signal")
+			}
+			if process, err := os.FindProcess(os.Getpid()); err == nil {
+				_ = process.Signal(sig)
+			}
+		case <-stopCh:
+			signal.Stop(signalCh)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stopCh) })
+	}
+}
+
+// DumpPending writes the traces which haven't been sent yet to w as newline-delimited
+// JSON, which can be ingested again with IngestFromReader, see traces.Ingestor.DumpPending.
+func (c *Langfuse) DumpPending(w io.Writer) (int, error) {
+	return c.ingestor.DumpPending(w)
+}
+
+// CrashDump recovers a panic, writes the traces which haven't been sent yet to the file
+// at path and panics again with the recovered value. It must be deferred directly, and
+// does nothing if the function returns normally.
+//
+// Unlike Close, it doesn't send the pending traces, since the process is crashing and
+// the network may be the cause. The file can be ingested again on the next start.
+//
+// Example:
+//
+//	func main() {
+//		client := langfuse.NewClient("https://cloud.langfuse.com", "public-key", "secret-key")
+//		defer client.CrashDump("langfuse-dump.ndjson")
+//
+//		if file, err := os.Open("langfuse-dump.ndjson"); err == nil {
+//			_, _ = client.IngestFromReader(ctx, file)
+//			file.Close()
+//			os.Remove("langfuse-dump.ndjson")
+//		}
+//		...
+//	}
+func (c *Langfuse) CrashDump(path string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	c.writeCrashDump(path)
+	panic(r)
+}
+
+func (c *Langfuse) writeCrashDump(path string) {
+	file, err := os.Create(path)
+	if err != nil {
+		logger.Get().Error("Failed to create the crash dump", zap.String("path", path), zap.Error(err))
+		return
+	}
+	defer file.Close()
+
+	lines, err := c.DumpPending(file)
+	if err != nil {
+		logger.Get().Error("Failed to dump the pending traces",
+			zap.String("path", path), zap.Int("lines", lines), zap.Error(err))
+		return
+	}
+	if err := file.Sync(); err != nil {
+		logger.Get().Error("Failed to sync the crash dump", zap.String("path", path), zap.Error(err))
+		return
+	}
+	logger.Get().Info("Dumped the pending traces", zap.String("path", path), zap.Int("lines", lines))
+}
+
 // Close gracefully shuts down the client and flushes all pending traces.
 //
 // This method ensures that all batched traces are sent to Langfuse before
@@ -2,10 +2,28 @@ package langfuse
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/git-hulk/langfuse-go/pkg/batch"
+	"github.com/git-hulk/langfuse-go/pkg/circuitbreaker"
+	"github.com/git-hulk/langfuse-go/pkg/httpcache"
+	"github.com/git-hulk/langfuse-go/pkg/media"
+	"github.com/git-hulk/langfuse-go/pkg/prompts"
+	"github.com/git-hulk/langfuse-go/pkg/scores"
 	"github.com/git-hulk/langfuse-go/pkg/traces"
 	"github.com/stretchr/testify/require"
 )
@@ -16,6 +34,7 @@ func TestNewClient_WithoutOptions(t *testing.T) {
 	require.NotNil(t, client)
 	require.NotNil(t, client.restyCli)
 	require.NotNil(t, client.ingestor)
+	require.NotNil(t, client.trace)
 	require.NotNil(t, client.prompt)
 	require.NotNil(t, client.model)
 	require.NotNil(t, client.project)
@@ -27,6 +46,7 @@ func TestNewClient_WithoutOptions(t *testing.T) {
 	require.NotNil(t, client.organization)
 	require.NotNil(t, client.health)
 	require.NotNil(t, client.media)
+	require.NotNil(t, client.user)
 }
 
 func TestNewClient_WithHTTPClient(t *testing.T) {
@@ -50,6 +70,7 @@ func TestNewClient_WithHTTPClient(t *testing.T) {
 
 	// Verify that all subclients are properly initialized
 	require.NotNil(t, client.ingestor)
+	require.NotNil(t, client.trace)
 	require.NotNil(t, client.prompt)
 	require.NotNil(t, client.model)
 	require.NotNil(t, client.project)
@@ -61,6 +82,7 @@ func TestNewClient_WithHTTPClient(t *testing.T) {
 	require.NotNil(t, client.organization)
 	require.NotNil(t, client.health)
 	require.NotNil(t, client.media)
+	require.NotNil(t, client.user)
 }
 
 func TestNewClient_WithMultipleOptions(t *testing.T) {
@@ -72,10 +94,11 @@ func TestNewClient_WithMultipleOptions(t *testing.T) {
 
 	require.NotNil(t, client)
 
-	// Verify that the custom HTTP client is being used
+	// Verify that a copy of the custom HTTP client is being used, without modifying it
 	restyHTTPClient := client.restyCli.GetClient()
-	require.Equal(t, customHTTPClient, restyHTTPClient)
+	require.NotSame(t, customHTTPClient, restyHTTPClient)
 	require.Equal(t, 45*time.Second, restyHTTPClient.Timeout)
+	require.Nil(t, customHTTPClient.Transport)
 }
 
 func TestWithHTTPClient(t *testing.T) {
@@ -90,9 +113,221 @@ func TestWithHTTPClient(t *testing.T) {
 	require.Equal(t, customHTTPClient, config.httpClient)
 }
 
+func TestWithCostComputation(t *testing.T) {
+	config := &clientConfig{}
+	WithCostComputation()(config)
+	require.True(t, config.costComputationEnabled)
+
+	client := NewClient("https://cloud.langfuse.com", "public-key", "secret-key", WithCostComputation())
+	require.NotNil(t, client.ingestor)
+}
+
+func TestWithScoreDecimalStrings(t *testing.T) {
+	var values []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Value json.RawMessage `json:"value"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		values = append(values, string(request.Value))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "score-1"}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	grade := func() *scores.CreateScoreRequest {
+		return &scores.CreateScoreRequest{TraceID: "trace-1", Name: "grade", Value: 0.5, DataType: scores.ScoreDataTypeCategorical}
+	}
+
+	// The precision alone doesn't allow numeric values for CATEGORICAL scores
+	client := NewClient(server.URL, "public-key", "secret-key", WithScoreValuePrecision(2))
+	defer client.Close()
+	_, err := client.Scores().Create(ctx, grade())
+	require.EqualError(t, err, "value must be a string for CATEGORICAL data type")
+	_, err = client.Scores().Create(ctx, &scores.CreateScoreRequest{TraceID: "trace-1", Name: "accuracy", Value: 0.1 + 0.2})
+	require.NoError(t, err)
+
+	client = NewClient(server.URL, "public-key", "secret-key", WithScoreValuePrecision(2), WithScoreDecimalStrings())
+	defer client.Close()
+	_, err = client.Scores().Create(ctx, grade())
+	require.NoError(t, err)
+	require.Equal(t, []string{`0.3`, `"0.50"`}, values)
+}
+
+type countingExporter struct {
+	exported atomic.Int32
+}
+
+func (e *countingExporter) Export(_ context.Context, traceList []*traces.Trace) error {
+	e.exported.Add(int32(len(traceList)))
+	return nil
+}
+
+func TestWithDryRun_NoSideEffects(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var out strings.Builder
+	exporter := &countingExporter{}
+	client := NewClient(server.URL, "public-key", "secret-key",
+		WithDryRun(&out),
+		WithTraceExporter(exporter),
+		WithLargeValueOffloading(64),
+	)
+	trace := client.StartTrace(context.Background(), "dry-run")
+	trace.Input = strings.Repeat("x", 1024)
+	trace.End()
+	require.NoError(t, client.Close())
+
+	require.Contains(t, out.String(), `"name": "dry-run"`)
+	// The large value is truncated instead of uploaded, and the trace isn't exported
+	require.NotContains(t, out.String(), strings.Repeat("x", 1024))
+	require.Zero(t, requests.Load())
+	require.Zero(t, exporter.exported.Load())
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	breaker := circuitbreaker.New(circuitbreaker.Config{})
+	config := &clientConfig{}
+	WithCircuitBreaker(breaker)(config)
+	require.Equal(t, breaker, config.circuitBreaker)
+
+	client := NewClient("https://cloud.langfuse.com", "public-key", "secret-key", WithCircuitBreaker(breaker))
+	require.NotNil(t, client.restyCli)
+}
+
+func TestWithClock(t *testing.T) {
+	clock := traces.SystemClock()
+	config := &clientConfig{}
+	WithClock(clock)(config)
+	require.Equal(t, clock, config.clock)
+
+	WithTimeSkewCorrection()(config)
+	require.True(t, config.skewCorrectionEnabled)
+
+	client := NewClient("https://cloud.langfuse.com", "public-key", "secret-key", WithClock(clock), WithTimeSkewCorrection())
+	require.NotNil(t, client.ingestor)
+}
+
+func TestWithResponseCache_TimeSkewCorrection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		// The cached response was sent an hour ago
+		w.Header().Set("Date", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"greeting","type":"text","prompt":"Hi","version":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "public-key", "secret-key",
+		WithResponseCache(httpcache.NewMemoryCache(0)), WithTimeSkewCorrection())
+	defer func() { _ = client.Close() }()
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Prompts().Get(context.Background(), prompts.GetParams{Name: "greeting"})
+		require.NoError(t, err)
+	}
+	// The skew is detected from the Date of the revalidation, not the one of the cached response
+	trace := client.StartTrace(context.Background(), "skew")
+	require.WithinDuration(t, time.Now(), trace.Timestamp, time.Minute)
+}
+
+func TestTraceURL(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/public/projects", r.URL.Path)
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"id":"project-1","name":"test"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "public-key", "secret-key")
+	traceURL, err := client.TraceURL(context.Background(), "trace-1")
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/project/project-1/traces/trace-1", traceURL)
+
+	trace := client.StartTrace(context.Background(), "test")
+	require.Equal(t, server.URL+"/project/project-1/traces/"+trace.ID, trace.URL())
+	require.EqualValues(t, 1, calls.Load())
+
+	_, err = client.TraceURL(context.Background(), "")
+	require.EqualError(t, err, "'traceID' is required")
+}
+
+func TestTraceURL_WithProjectID(t *testing.T) {
+	client := NewClient("https://cloud.langfuse.com", "public-key", "secret-key", WithProjectID("project-1"))
+	traceURL, err := client.TraceURL(context.Background(), "trace-1")
+	require.NoError(t, err)
+	require.Equal(t, "https://cloud.langfuse.com/project/project-1/traces/trace-1", traceURL)
+}
+
+func TestHandleSignals(t *testing.T) {
+	// Catch the re-raised signal, which would terminate the test otherwise
+	signalCh := make(chan os.Signal, 2)
+	signal.Notify(signalCh, syscall.SIGUSR1)
+	defer signal.Stop(signalCh)
+
+	client := NewClient("https://cloud.langfuse.com", "public-key", "secret-key")
+	stop := client.HandleSignals(syscall.SIGUSR1)
+	defer stop()
+
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+	require.Eventually(t, func() bool {
+		return errors.Is(client.FlushContext(context.Background()), batch.ErrProcessorClosed)
+	}, time.Second, 10*time.Millisecond)
+	// The signal is delivered once more after the client has been closed
+	require.Eventually(t, func() bool { return len(signalCh) == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestHandleSignals_Stop(t *testing.T) {
+	client := NewClient("https://cloud.langfuse.com", "public-key", "secret-key")
+	stop := client.HandleSignals()
+	stop()
+	stop()
+	require.NoError(t, client.FlushContext(context.Background()))
+	require.NoError(t, client.Close())
+}
+
+func TestCrashDump(t *testing.T) {
+	client := NewClient("http://localhost:3000", "public-key", "secret-key",
+		WithBatchConfig(batch.Config{FlushInterval: time.Hour}))
+	defer client.Close()
+	path := filepath.Join(t.TempDir(), "dump.ndjson")
+
+	trace := client.StartTrace(context.Background(), "chat")
+	crash := func() {
+		defer client.CrashDump(path)
+		trace.End()
+		panic("boom")
+	}
+	require.PanicsWithValue(t, "boom", crash)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `{"trace":{"id":"`+trace.ID+`"`)
+
+	// Nothing is dumped without a panic
+	noCrash := filepath.Join(t.TempDir(), "no-crash.ndjson")
+	func() { defer client.CrashDump(noCrash) }()
+	require.NoFileExists(t, noCrash)
+}
+
 func TestClientConfig_Default(t *testing.T) {
 	config := &clientConfig{}
 	require.Nil(t, config.httpClient)
+	require.False(t, config.costComputationEnabled)
+	require.Nil(t, config.circuitBreaker)
+	require.Nil(t, config.clock)
+	require.False(t, config.skewCorrectionEnabled)
 }
 
 func TestTrace(t *testing.T) {
@@ -200,3 +435,210 @@ func TestTrace(t *testing.T) {
 	agentDuration := agent.EndTime.Sub(agent.StartTime)
 	require.True(t, agentDuration >= 0, "Agent duration should be non-negative")
 }
+
+func TestClientConfig_baseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		options []ClientOption
+		want    string
+	}{
+		{name: "default", host: "https://cloud.langfuse.com", want: "https://cloud.langfuse.com/api/public"},
+		{name: "trailing slash", host: "https://cloud.langfuse.com/", want: "https://cloud.langfuse.com/api/public"},
+		{name: "sub-path", host: "https://ops.example.com/langfuse/", want: "https://ops.example.com/langfuse/api/public"},
+		{
+			name:    "base path",
+			host:    "https://ops.example.com",
+			options: []ClientOption{WithAPIBasePath("langfuse-api/")},
+			want:    "https://ops.example.com/langfuse-api",
+		},
+		{
+			name:    "API URL",
+			host:    "https://langfuse.example.com",
+			options: []ClientOption{WithAPIBasePath("/ignored"), WithAPIURL("https://gateway.example.com/langfuse/")},
+			want:    "https://gateway.example.com/langfuse",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &clientConfig{}
+			for _, option := range tt.options {
+				option(config)
+			}
+			require.Equal(t, tt.want, config.baseURL(tt.host))
+		})
+	}
+}
+
+func TestNewClient_WithAPIURL(t *testing.T) {
+	var paths, etags []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		etags = append(etags, r.Header.Get("If-None-Match"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"name":"greeting","type":"text","prompt":"Hi","version":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("https://langfuse.example.com/", "public-key", "secret-key",
+		WithAPIURL(server.URL+"/langfuse/api/"),
+		WithResponseCache(httpcache.NewMemoryCache(10)))
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Prompts().Get(context.Background(), prompts.GetParams{Name: "greeting"})
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"/langfuse/api/v2/prompts/greeting", "/langfuse/api/v2/prompts/greeting"}, paths)
+	// The response cache matches the paths relative to the custom base path
+	require.Equal(t, []string{"", `"v1"`}, etags)
+	require.Equal(t, "https://langfuse.example.com", client.host)
+	require.Equal(t, "/langfuse/api", (&clientConfig{apiURL: server.URL + "/langfuse/api/"}).basePath(""))
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.Host
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"OK","version":"3.0.0"}`))
+	}))
+	defer proxy.Close()
+
+	client := NewClient("http://langfuse.internal", "public-key", "secret-key", WithProxy(proxy.URL))
+	defer client.Close()
+
+	rsp, err := client.restyCli.R().Get("/health")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rsp.StatusCode())
+	require.Equal(t, "langfuse.internal", proxiedHost)
+}
+
+func TestWithRootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Run("unknown authority", func(t *testing.T) {
+		client := NewClient(server.URL, "public-key", "secret-key")
+		defer client.Close()
+		_, err := client.restyCli.R().Get("/health")
+		require.Error(t, err)
+	})
+
+	t.Run("cert pool", func(t *testing.T) {
+		pool := x509.NewCertPool()
+		pool.AddCert(server.Certificate())
+		client := NewClient(server.URL, "public-key", "secret-key", WithRootCAs(pool))
+		defer client.Close()
+		rsp, err := client.restyCli.R().Get("/health")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rsp.StatusCode())
+	})
+
+	t.Run("pem file", func(t *testing.T) {
+		pemFile := filepath.Join(t.TempDir(), "ca.pem")
+		pemData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		require.NoError(t, os.WriteFile(pemFile, pemData, 0o600))
+
+		client := NewClient(server.URL, "public-key", "secret-key", WithRootCAFiles(pemFile))
+		defer client.Close()
+		rsp, err := client.restyCli.R().Get("/health")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rsp.StatusCode())
+	})
+}
+
+func TestApplyTransport_SharedTransport(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	shared := &http.Transport{}
+	httpClient := &http.Client{Transport: shared}
+	pool := x509.NewCertPool()
+	client := NewClient("http://langfuse.internal", "public-key", "secret-key", WithHTTPClient(httpClient),
+		WithProxy(proxy.URL), WithRootCAs(pool))
+	defer client.Close()
+
+	rsp, err := client.restyCli.R().Get("/health")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rsp.StatusCode())
+	// The transport of the caller isn't modified
+	require.Nil(t, shared.Proxy)
+	// The HTTP/2 defaults may set a TLS config on the transport, but not the root certificates
+	if shared.TLSClientConfig != nil {
+		require.Nil(t, shared.TLSClientConfig.RootCAs)
+	}
+}
+
+func TestWithRootCAFiles_Invalid(t *testing.T) {
+	_, err := New("https://langfuse.internal", "public-key", "secret-key",
+		WithRootCAFiles(filepath.Join(t.TempDir(), "missing.pem")))
+	require.ErrorContains(t, err, "failed to read the root certificates")
+
+	pemFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(pemFile, []byte("not a certificate"), 0o600))
+	_, err = New("https://langfuse.internal", "public-key", "secret-key", WithRootCAFiles(pemFile))
+	require.EqualError(t, err, "no PEM encoded certificate found in "+pemFile)
+}
+
+func TestWithRootCAs_MediaUpload(t *testing.T) {
+	var uploaded atomic.Bool
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/public/media":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"uploadUrl":"` + server.URL + `/upload","mediaId":"media-123"}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/upload":
+			uploaded.Store(true)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	client := NewClient(server.URL, "public-key", "secret-key", WithRootCAs(pool))
+	defer client.Close()
+
+	// The presigned upload URL is verified with the root CAs of the client too
+	_, err := client.Media().UploadFromBytes(context.Background(), &media.UploadFromBytesRequest{
+		TraceID:     "trace-123",
+		ContentType: media.ContentTypeImagePNG,
+		Field:       "input",
+		Data:        []byte("image"),
+	})
+	require.NoError(t, err)
+	require.True(t, uploaded.Load())
+}
+
+func TestWithClientCertificates(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	// The certificate of the test server is good enough to authenticate the client
+	clientCert := server.TLS.Certificates[0]
+
+	client := NewClient(server.URL, "public-key", "secret-key", WithRootCAs(pool), WithClientCertificates(clientCert))
+	defer client.Close()
+	rsp, err := client.restyCli.R().Get("/health")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rsp.StatusCode())
+}
@@ -0,0 +1,196 @@
+package annotations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+)
+
+const (
+	defaultBatchChunkSize   = 100
+	defaultBatchConcurrency = 8
+)
+
+type batchConfig struct {
+	chunkSize   int
+	concurrency int
+	progress    common.ProgressFunc
+}
+
+// BatchOption is a function that configures CreateBatch and UpdateStatusBulk.
+type BatchOption func(*batchConfig)
+
+// WithBatchChunkSize sets the number of items processed per chunk. Default is 100.
+func WithBatchChunkSize(chunkSize int) BatchOption {
+	return func(c *batchConfig) {
+		c.chunkSize = chunkSize
+	}
+}
+
+// WithBatchConcurrency sets the number of requests sent in parallel within a chunk. Default is 8.
+func WithBatchConcurrency(concurrency int) BatchOption {
+	return func(c *batchConfig) {
+		c.concurrency = concurrency
+	}
+}
+
+// WithBatchProgress reports the number of items processed after each chunk, together with
+// the total number of items. The failed items count as processed, see the result for them.
+func WithBatchProgress(progress common.ProgressFunc) BatchOption {
+	return func(c *batchConfig) {
+		c.progress = progress
+	}
+}
+
+func newBatchConfig(options []BatchOption) *batchConfig {
+	config := &batchConfig{
+		chunkSize:   defaultBatchChunkSize,
+		concurrency: defaultBatchConcurrency,
+	}
+	for _, option := range options {
+		option(config)
+	}
+	if config.chunkSize <= 0 {
+		config.chunkSize = defaultBatchChunkSize
+	}
+	if config.concurrency <= 0 {
+		config.concurrency = defaultBatchConcurrency
+	}
+	return config
+}
+
+// CreateBatchResult summarizes the outcome of CreateBatch.
+//
+// Items is aligned with the requests, the item of a failed request is nil and its
+// error is in Failed by the index of the request.
+type CreateBatchResult struct {
+	Items  []*Item
+	Failed map[int]error
+}
+
+// UpdateStatusResult summarizes the outcome of UpdateStatusBulk.
+type UpdateStatusResult struct {
+	Updated []string
+	Failed  map[string]error
+}
+
+// CreateBatch adds the items to an annotation queue.
+//
+// The API creates one item per request, so the requests are sent in chunks with
+// several requests in parallel. All requests are validated before any is sent, and
+// failing items don't stop the process, their errors are joined and returned together
+// with the result. Chunks which aren't started before the context is done fail with
+// the error of the context.
+//
+// Example:
+//
+//	requests := make([]*annotations.CreateItemRequest, 0, len(traceIDs))
+//	for _, traceID := range traceIDs {
+//		requests = append(requests, &annotations.CreateItemRequest{ObjectID: traceID, ObjectType: annotations.ObjectTypeTrace})
+//	}
+//	result, err := itemClient.CreateBatch(ctx, "queue-id", requests)
+func (c *ItemClient) CreateBatch(ctx context.Context, queueID string, requests []*CreateItemRequest, options ...BatchOption) (*CreateBatchResult, error) {
+	if queueID == "" {
+		return nil, errors.New("'queueID' is required")
+	}
+	if len(requests) == 0 {
+		return nil, errors.New("'requests' is required")
+	}
+	for i, request := range requests {
+		if request == nil {
+			return nil, fmt.Errorf("invalid request %d: request is nil", i)
+		}
+		if err := request.validate(); err != nil {
+			return nil, fmt.Errorf("invalid request %d: %w", i, err)
+		}
+	}
+
+	result := &CreateBatchResult{
+		Items:  make([]*Item, len(requests)),
+		Failed: make(map[int]error),
+	}
+	errs := forEachChunk(ctx, len(requests), newBatchConfig(options), func(i int) error {
+		item, err := c.Create(ctx, queueID, requests[i])
+		if err != nil {
+			return fmt.Errorf("create item %d: %w", i, err)
+		}
+		result.Items[i] = item
+		return nil
+	})
+	for i, err := range errs {
+		if err != nil {
+			result.Failed[i] = err
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// UpdateStatusBulk sets the status of the items of an annotation queue, e.g. to mark
+// a reviewed set of items as completed.
+//
+// The items are updated in chunks with several requests in parallel, like CreateBatch.
+//
+// Example:
+//
+//	result, err := itemClient.UpdateStatusBulk(ctx, "queue-id", itemIDs, annotations.StatusCompleted)
+func (c *ItemClient) UpdateStatusBulk(ctx context.Context, queueID string, itemIDs []string, status QueueStatus, options ...BatchOption) (*UpdateStatusResult, error) {
+	if queueID == "" {
+		return nil, errors.New("'queueID' is required")
+	}
+	if len(itemIDs) == 0 {
+		return nil, errors.New("'itemIDs' is required")
+	}
+	if status == "" {
+		return nil, errors.New("'status' is required")
+	}
+	updateRequest := &UpdateItemRequest{Status: status}
+	if err := updateRequest.validate(); err != nil {
+		return nil, err
+	}
+
+	errs := forEachChunk(ctx, len(itemIDs), newBatchConfig(options), func(i int) error {
+		if _, err := c.Update(ctx, queueID, itemIDs[i], updateRequest); err != nil {
+			return fmt.Errorf("update item %s: %w", itemIDs[i], err)
+		}
+		return nil
+	})
+	result := &UpdateStatusResult{
+		Updated: make([]string, 0, len(itemIDs)),
+		Failed:  make(map[string]error),
+	}
+	for i, err := range errs {
+		if err != nil {
+			result.Failed[itemIDs[i]] = err
+		} else {
+			result.Updated = append(result.Updated, itemIDs[i])
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// forEachChunk calls fn for each index below n, chunk by chunk with up to concurrency
+// calls in parallel, and returns the error of each call. The indexes of the chunks which
+// aren't started before the context is done fail with the error of the context.
+func forEachChunk(ctx context.Context, n int, config *batchConfig, fn func(i int) error) []error {
+	errs := make([]error, n)
+	for start := 0; start < n; start += config.chunkSize {
+		end := min(start+config.chunkSize, n)
+		if err := ctx.Err(); err != nil {
+			for i := start; i < n; i++ {
+				errs[i] = err
+			}
+			break
+		}
+
+		chunkErrs := common.ForEach(ctx, end-start, config.concurrency, func(i int) error {
+			return fn(start + i)
+		})
+		copy(errs[start:end], chunkErrs)
+		if config.progress != nil {
+			config.progress(end, n)
+		}
+	}
+	return errs
+}
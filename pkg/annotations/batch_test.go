@@ -0,0 +1,105 @@
+package annotations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemClient_CreateBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/annotation-queues/queue-1/items", r.URL.Path)
+		var req CreateItemRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.ObjectID == "trace-3" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Item{ID: "item-" + req.ObjectID, ObjectID: req.ObjectID})
+	}))
+	defer server.Close()
+
+	requests := make([]*CreateItemRequest, 0)
+	for _, traceID := range []string{"trace-1", "trace-2", "trace-3", "trace-4", "trace-5"} {
+		requests = append(requests, &CreateItemRequest{ObjectID: traceID, ObjectType: ObjectTypeTrace})
+	}
+	var progress [][2]int
+	client := NewItemClient(resty.New().SetBaseURL(server.URL))
+	result, err := client.CreateBatch(context.Background(), "queue-1", requests,
+		WithBatchChunkSize(2),
+		WithBatchProgress(func(processed, total int) {
+			progress = append(progress, [2]int{processed, total})
+		}),
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "create item 2")
+	require.Len(t, result.Failed, 1)
+	require.Contains(t, result.Failed, 2)
+	require.Nil(t, result.Items[2])
+	require.Equal(t, "item-trace-5", result.Items[4].ID)
+	require.Equal(t, [][2]int{{2, 5}, {4, 5}, {5, 5}}, progress)
+}
+
+func TestItemClient_CreateBatch_ValidationError(t *testing.T) {
+	client := NewItemClient(resty.New())
+	_, err := client.CreateBatch(context.Background(), "queue-1", []*CreateItemRequest{
+		{ObjectID: "trace-1", ObjectType: ObjectTypeTrace},
+		{ObjectID: "trace-2"},
+	})
+	require.EqualError(t, err, "invalid request 1: 'objectType' is required")
+
+	_, err = client.CreateBatch(context.Background(), "", nil)
+	require.EqualError(t, err, "'queueID' is required")
+}
+
+func TestItemClient_UpdateStatusBulk(t *testing.T) {
+	var mu sync.Mutex
+	updated := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPatch, r.Method)
+		var req UpdateItemRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, StatusCompleted, req.Status)
+		itemID := strings.TrimPrefix(r.URL.Path, "/annotation-queues/queue-1/items/")
+		if itemID == "item-2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		mu.Lock()
+		updated = append(updated, itemID)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Item{ID: itemID, Status: req.Status})
+	}))
+	defer server.Close()
+
+	client := NewItemClient(resty.New().SetBaseURL(server.URL))
+	result, err := client.UpdateStatusBulk(context.Background(), "queue-1",
+		[]string{"item-1", "item-2", "item-3"}, StatusCompleted, WithBatchConcurrency(2))
+	require.Error(t, err)
+	require.Equal(t, []string{"item-1", "item-3"}, result.Updated)
+	require.Contains(t, result.Failed, "item-2")
+	require.ElementsMatch(t, []string{"item-1", "item-3"}, updated)
+
+	_, err = client.UpdateStatusBulk(context.Background(), "queue-1", []string{"item-1"}, "DONE")
+	require.EqualError(t, err, "invalid 'status': DONE, must be one of [PENDING, COMPLETED]")
+}
+
+func TestItemClient_UpdateStatusBulk_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := NewItemClient(resty.New().SetBaseURL("http://127.0.0.1:0"))
+	result, err := client.UpdateStatusBulk(ctx, "queue-1", []string{"item-1", "item-2"}, StatusCompleted)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, result.Updated)
+	require.Len(t, result.Failed, 2)
+}
@@ -0,0 +1,229 @@
+package annotations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+const (
+	defaultEnqueueBatchSize   = 50
+	defaultEnqueueConcurrency = 4
+)
+
+// TraceFilter defines which traces are added to an annotation queue by EnqueueByFilter.
+//
+// All non-zero fields are combined, so only traces matching every filter are enqueued.
+// Tags matches traces which include all of the given tags. MaxTraces limits the number
+// of traces that are enqueued, 0 means no limit.
+type TraceFilter struct {
+	UserID        string
+	Name          string
+	SessionID     string
+	Tags          []string
+	Environment   []string
+	FromTimestamp time.Time
+	ToTimestamp   time.Time
+	MaxTraces     int
+}
+
+// SetTimeRange sets FromTimestamp and ToTimestamp from the time window, e.g.
+// common.LastDays(7).
+func (f *TraceFilter) SetTimeRange(r common.TimeRange) {
+	f.FromTimestamp = r.From
+	f.ToTimestamp = r.To
+}
+
+// listParams converts the TraceFilter to the params listing the given page of traces.
+func (f *TraceFilter) listParams(page, limit int) traces.ListParams {
+	return traces.ListParams{
+		Page:          page,
+		Limit:         limit,
+		UserID:        f.UserID,
+		Name:          f.Name,
+		SessionID:     f.SessionID,
+		Tags:          f.Tags,
+		Environment:   f.Environment,
+		FromTimestamp: f.FromTimestamp,
+		ToTimestamp:   f.ToTimestamp,
+		Fields:        "core",
+	}
+}
+
+// EnqueueProgress reports the progress of EnqueueByFilter after each batch.
+//
+// Total is the number of traces matching the filter as reported by the server.
+type EnqueueProgress struct {
+	Total    int
+	Enqueued int
+	Skipped  int
+	Failed   int
+}
+
+// EnqueueResult summarizes the outcome of EnqueueByFilter.
+//
+// Skipped counts the traces which were already in the queue.
+type EnqueueResult struct {
+	Enqueued int
+	Skipped  int
+	Failed   int
+}
+
+type enqueueConfig struct {
+	batchSize   int
+	concurrency int
+	onProgress  func(EnqueueProgress)
+}
+
+// EnqueueOption is a function that configures EnqueueByFilter.
+type EnqueueOption func(*enqueueConfig)
+
+// WithEnqueueBatchSize sets the number of traces listed and enqueued per batch. Default is 50.
+func WithEnqueueBatchSize(batchSize int) EnqueueOption {
+	return func(c *enqueueConfig) {
+		c.batchSize = batchSize
+	}
+}
+
+// WithEnqueueConcurrency sets the number of queue items created in parallel. Default is 4.
+func WithEnqueueConcurrency(concurrency int) EnqueueOption {
+	return func(c *enqueueConfig) {
+		c.concurrency = concurrency
+	}
+}
+
+// WithEnqueueProgress sets a callback which is called after each batch has been enqueued.
+func WithEnqueueProgress(onProgress func(EnqueueProgress)) EnqueueOption {
+	return func(c *enqueueConfig) {
+		c.onProgress = onProgress
+	}
+}
+
+// EnqueueByFilter lists the traces matching the filter and adds them to the annotation queue.
+//
+// Traces are processed in batches, and traces which are already in the queue are skipped.
+// Failing items don't stop the process, their errors are joined and returned together
+// with the result once all matching traces have been processed.
+//
+// Example:
+//
+//	result, err := queueClient.EnqueueByFilter(ctx, "queue-id", annotations.TraceFilter{
+//		Tags:          []string{"needs-review"},
+//		FromTimestamp: time.Now().Add(-7 * 24 * time.Hour),
+//	}, annotations.WithEnqueueProgress(func(p annotations.EnqueueProgress) {
+//		log.Printf("enqueued %d/%d traces", p.Enqueued+p.Skipped, p.Total)
+//	}))
+func (c *QueueClient) EnqueueByFilter(ctx context.Context, queueID string, filter TraceFilter, options ...EnqueueOption) (*EnqueueResult, error) {
+	if queueID == "" {
+		return nil, errors.New("'queueID' is required")
+	}
+	config := &enqueueConfig{batchSize: defaultEnqueueBatchSize, concurrency: defaultEnqueueConcurrency}
+	for _, option := range options {
+		option(config)
+	}
+	if config.batchSize <= 0 {
+		config.batchSize = defaultEnqueueBatchSize
+	}
+	if config.concurrency <= 0 {
+		config.concurrency = defaultEnqueueConcurrency
+	}
+
+	queuedIDs, err := c.listQueuedObjectIDs(ctx, queueID, config.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	itemCli := NewItemClient(c.restyCli)
+	tracesCli := traces.NewClient(c.restyCli)
+	result := &EnqueueResult{}
+	var errs []error
+	processed := 0
+	for page := 1; ; page++ {
+		listResponse, err := tracesCli.List(ctx, filter.listParams(page, config.batchSize))
+		if err != nil {
+			return result, errors.Join(append(errs, err)...)
+		}
+
+		traceIDs := make([]string, 0, len(listResponse.Data))
+		for _, trace := range listResponse.Data {
+			if filter.MaxTraces > 0 && processed >= filter.MaxTraces {
+				break
+			}
+			processed++
+			if queuedIDs[trace.ID] {
+				result.Skipped++
+				continue
+			}
+			traceIDs = append(traceIDs, trace.ID)
+		}
+
+		for _, err := range c.enqueueTraces(ctx, itemCli, queueID, traceIDs, config.concurrency) {
+			if err != nil {
+				result.Failed++
+				errs = append(errs, err)
+			} else {
+				result.Enqueued++
+			}
+		}
+
+		if config.onProgress != nil {
+			total := listResponse.Metadata.TotalItems
+			if filter.MaxTraces > 0 && filter.MaxTraces < total {
+				total = filter.MaxTraces
+			}
+			config.onProgress(EnqueueProgress{
+				Total:    total,
+				Enqueued: result.Enqueued,
+				Skipped:  result.Skipped,
+				Failed:   result.Failed,
+			})
+		}
+
+		if page >= listResponse.Metadata.TotalPages || (filter.MaxTraces > 0 && processed >= filter.MaxTraces) {
+			break
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// enqueueTraces creates a queue item for each trace and returns the error of each creation.
+func (c *QueueClient) enqueueTraces(ctx context.Context, itemCli *ItemClient, queueID string, traceIDs []string, concurrency int) []error {
+	config := &batchConfig{chunkSize: len(traceIDs), concurrency: concurrency}
+	return forEachChunk(ctx, len(traceIDs), config, func(i int) error {
+		_, err := itemCli.Create(ctx, queueID, &CreateItemRequest{
+			ObjectID:   traceIDs[i],
+			ObjectType: ObjectTypeTrace,
+		})
+		if err != nil {
+			return fmt.Errorf("enqueue trace %s: %w", traceIDs[i], err)
+		}
+		return nil
+	})
+}
+
+// listQueuedObjectIDs returns the IDs of all trace objects which are already in the queue.
+func (c *QueueClient) listQueuedObjectIDs(ctx context.Context, queueID string, pageSize int) (map[string]bool, error) {
+	itemCli := NewItemClient(c.restyCli)
+	queuedIDs := make(map[string]bool)
+	params := ItemListParams{Page: 1, Limit: pageSize}
+	for {
+		listItems, err := itemCli.List(ctx, queueID, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range listItems.Data {
+			if item.ObjectType == ObjectTypeTrace {
+				queuedIDs[item.ObjectID] = true
+			}
+		}
+		if params.Page >= listItems.Metadata.TotalPages {
+			break
+		}
+		params.Page++
+	}
+	return queuedIDs, nil
+}
@@ -0,0 +1,113 @@
+package annotations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+)
+
+func TestTraceFilter_listParams(t *testing.T) {
+	filter := TraceFilter{
+		UserID:        "user-1",
+		Tags:          []string{"review", "prod"},
+		FromTimestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	params := filter.listParams(2, 10)
+	require.Equal(t,
+		"page=2&limit=10&userId=user-1&tags=review&tags=prod&fromTimestamp=2024-01-01T00%3A00%3A00Z&fields=core",
+		params.ToQueryString())
+
+	filter.SetTimeRange(common.TimeRange{To: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)})
+	params = filter.listParams(1, 10)
+	require.True(t, params.FromTimestamp.IsZero())
+	require.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), params.ToTimestamp)
+}
+
+func TestQueueClient_EnqueueByFilter(t *testing.T) {
+	var mu sync.Mutex
+	created := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/annotation-queues/queue-1/items" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"meta":{"page":1,"totalPages":1},"data":[
+				{"id":"item-0","objectId":"trace-2","objectType":"TRACE"}]}`))
+		case r.URL.Path == "/annotation-queues/queue-1/items" && r.Method == http.MethodPost:
+			var req CreateItemRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, ObjectTypeTrace, req.ObjectType)
+			if req.ObjectID == "trace-4" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			mu.Lock()
+			created = append(created, req.ObjectID)
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(Item{ID: "item-" + req.ObjectID, ObjectID: req.ObjectID})
+		case r.URL.Path == "/traces":
+			require.Equal(t, "review", r.URL.Query().Get("tags"))
+			require.Equal(t, "2", r.URL.Query().Get("limit"))
+			switch r.URL.Query().Get("page") {
+			case "1":
+				_, _ = w.Write([]byte(`{"meta":{"page":1,"totalItems":4,"totalPages":2},"data":[{"id":"trace-1"},{"id":"trace-2"}]}`))
+			case "2":
+				_, _ = w.Write([]byte(`{"meta":{"page":2,"totalItems":4,"totalPages":2},"data":[{"id":"trace-3"},{"id":"trace-4"}]}`))
+			}
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewQueueClient(resty.New().SetBaseURL(server.URL))
+	var progress []EnqueueProgress
+	result, err := client.EnqueueByFilter(context.Background(), "queue-1",
+		TraceFilter{Tags: []string{"review"}},
+		WithEnqueueBatchSize(2),
+		WithEnqueueProgress(func(p EnqueueProgress) {
+			progress = append(progress, p)
+		}),
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "enqueue trace trace-4")
+	require.Equal(t, &EnqueueResult{Enqueued: 2, Skipped: 1, Failed: 1}, result)
+	require.ElementsMatch(t, []string{"trace-1", "trace-3"}, created)
+	require.Equal(t, []EnqueueProgress{
+		{Total: 4, Enqueued: 1, Skipped: 1},
+		{Total: 4, Enqueued: 2, Skipped: 1, Failed: 1},
+	}, progress)
+}
+
+func TestQueueClient_EnqueueByFilter_MaxTraces(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/annotation-queues/queue-1/items" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"meta":{"page":1,"totalPages":0},"data":[]}`))
+		case r.URL.Path == "/annotation-queues/queue-1/items" && r.Method == http.MethodPost:
+			_ = json.NewEncoder(w).Encode(Item{ID: "item"})
+		case r.URL.Path == "/traces":
+			require.Equal(t, "1", r.URL.Query().Get("page"))
+			_, _ = w.Write([]byte(`{"meta":{"page":1,"totalItems":10,"totalPages":5},"data":[{"id":"trace-1"},{"id":"trace-2"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewQueueClient(resty.New().SetBaseURL(server.URL))
+	result, err := client.EnqueueByFilter(context.Background(), "queue-1",
+		TraceFilter{MaxTraces: 1}, WithEnqueueBatchSize(2))
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Enqueued)
+
+	_, err = client.EnqueueByFilter(context.Background(), "", TraceFilter{})
+	require.EqualError(t, err, "'queueID' is required")
+}
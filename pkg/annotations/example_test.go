@@ -0,0 +1,30 @@
+package annotations_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/git-hulk/langfuse-go/pkg/annotations"
+)
+
+func ExampleQueueClient_EnqueueByFilter() {
+	restyCli := resty.New().
+		SetBaseURL("https://cloud.langfuse.com/api/public").
+		SetBasicAuth("pk-lf-...", "sk-lf-...")
+	queueClient := annotations.NewQueueClient(restyCli)
+
+	result, err := queueClient.EnqueueByFilter(context.Background(), "queue-id", annotations.TraceFilter{
+		Name:          "checkout-assistant",
+		Tags:          []string{"escalated"},
+		FromTimestamp: time.Now().Add(-24 * time.Hour),
+		MaxTraces:     50,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(result.Enqueued, result.Skipped)
+}
@@ -87,6 +87,8 @@ type ItemListParams struct {
 	Status QueueStatus
 	Page   int
 	Limit  int
+
+	pagination common.Pagination
 }
 
 // ToQueryString converts the ItemListParams to a URL query string.
@@ -95,15 +97,27 @@ func (query *ItemListParams) ToQueryString() string {
 	if query.Status != "" {
 		parts = append(parts, "status="+string(query.Status))
 	}
-	if query.Page != 0 {
+	if query.pagination.HasPage(query.Page) {
 		parts = append(parts, "page="+strconv.Itoa(query.Page))
 	}
-	if query.Limit != 0 {
+	if query.pagination.HasLimit(query.Limit) {
 		parts = append(parts, "limit="+strconv.Itoa(query.Limit))
 	}
 	return strings.Join(parts, "&")
 }
 
+// SetPage sets the page number, even if it is 0.
+func (query *ItemListParams) SetPage(page int) {
+	query.Page = page
+	query.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (query *ItemListParams) SetLimit(limit int) {
+	query.Limit = limit
+	query.pagination.MarkLimit()
+}
+
 // ListItems represents the response from listing annotation queue items.
 type ListItems struct {
 	Metadata common.ListMetadata `json:"meta"`
@@ -9,6 +9,8 @@ import (
 
 	"github.com/go-resty/resty/v2"
 	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
 )
 
 func TestItemListParams_ToQueryString(t *testing.T) {
@@ -21,6 +23,7 @@ func TestItemListParams_ToQueryString(t *testing.T) {
 		{"with status only", ItemListParams{Status: StatusCompleted}, "status=COMPLETED"},
 		{"with page and limit", ItemListParams{Page: 1, Limit: 10}, "page=1&limit=10"},
 		{"no params", ItemListParams{}, ""},
+		{"with explicit zero page", common.NewListParams(common.WithPage[ItemListParams](0)), "page=0"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -55,20 +55,34 @@ func (r *CreateQueueRequest) validate() error {
 type QueueListParams struct {
 	Page  int
 	Limit int
+
+	pagination common.Pagination
 }
 
 // ToQueryString converts the QueueListParams to a URL query string.
 func (query *QueueListParams) ToQueryString() string {
 	parts := make([]string, 0)
-	if query.Page != 0 {
+	if query.pagination.HasPage(query.Page) {
 		parts = append(parts, "page="+strconv.Itoa(query.Page))
 	}
-	if query.Limit != 0 {
+	if query.pagination.HasLimit(query.Limit) {
 		parts = append(parts, "limit="+strconv.Itoa(query.Limit))
 	}
 	return strings.Join(parts, "&")
 }
 
+// SetPage sets the page number, even if it is 0.
+func (query *QueueListParams) SetPage(page int) {
+	query.Page = page
+	query.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (query *QueueListParams) SetLimit(limit int) {
+	query.Limit = limit
+	query.pagination.MarkLimit()
+}
+
 // ListQueues represents the response from listing annotation queues.
 type ListQueues struct {
 	Metadata common.ListMetadata `json:"meta"`
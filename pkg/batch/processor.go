@@ -11,6 +11,7 @@ package batch
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -24,8 +25,36 @@ var (
 	ErrProcessorClosed = errors.New("batch processor is closed")
 	ErrBufferFull      = errors.New("event recordCh is full")
 	ErrShutdownTimeout = errors.New("shutdown timeout exceeded")
+	ErrQueueFull       = errors.New("queued records exceed the max queued bytes")
+	ErrPaused          = errors.New("batch processor is paused")
 )
 
+// EvictionPolicy defines what happens when a submitted record would exceed MaxQueuedBytes.
+type EvictionPolicy int
+
+const (
+	// EvictNewest rejects the submitted record with ErrQueueFull.
+	EvictNewest EvictionPolicy = iota
+	// EvictOldest drops the oldest records which haven't been batched yet to make room
+	// for the submitted record.
+	EvictOldest
+)
+
+// QueueStats describes the records which have been submitted but not sent yet.
+type QueueStats struct {
+	// Records is the number of queued records.
+	Records int
+	// Bytes is the estimated size of the queued records. It's only tracked when the Sender
+	// implements Sizer and either MaxBatchBytes or MaxQueuedBytes is set.
+	Bytes int
+	// OldestAge is the time since the oldest queued record was submitted.
+	OldestAge time.Duration
+	// Evicted is the number of records dropped by EvictOldest.
+	Evicted int
+	// Rejected is the number of records rejected because the queue was full.
+	Rejected int
+}
+
 // Sender defines the interface for sending batched records to an external service.
 //
 // Implementations should handle the actual HTTP requests or other transport mechanisms
@@ -35,6 +64,15 @@ type Sender[T any] interface {
 	Send(ctx context.Context, records []T) error
 }
 
+// Sizer defines the interface for estimating the serialized size of a record in bytes.
+//
+// If the Sender passed to NewProcessor also implements Sizer and MaxBatchBytes is set,
+// the processor tracks the accumulated size of the current batch and flushes it
+// before the size cap would be exceeded.
+type Sizer[T any] interface {
+	Size(record T) int
+}
+
 // Config holds the configuration for the batch processor.
 type Config struct {
 	// MaxBatchSize defines the maximum number of records to send in a single batch.
@@ -55,6 +93,18 @@ type Config struct {
 	// If the processor does not shut down within this time, an error will be returned.
 	// Default is 30 seconds.
 	ShutdownTimeout time.Duration
+	// MaxBatchBytes defines the maximum accumulated size in bytes of a single batch.
+	// It only takes effect when the Sender implements Sizer.
+	// Default is 0, which disables size-based flushing.
+	MaxBatchBytes int
+	// MaxQueuedBytes caps the accumulated size in bytes of the queued records, i.e. the
+	// records which have been submitted but not sent yet.
+	// It only takes effect when the Sender implements Sizer.
+	// Default is 0, which disables the cap.
+	MaxQueuedBytes int
+	// EvictionPolicy defines what happens when a submitted record would exceed MaxQueuedBytes.
+	// Default is EvictNewest.
+	EvictionPolicy EvictionPolicy
 }
 
 func (c *Config) normalize() {
@@ -73,6 +123,12 @@ func (c *Config) normalize() {
 	if c.NumWorkers <= 0 {
 		c.NumWorkers = 1
 	}
+	if c.MaxBatchBytes < 0 {
+		c.MaxBatchBytes = 0
+	}
+	if c.MaxQueuedBytes < 0 {
+		c.MaxQueuedBytes = 0
+	}
 }
 
 func defaultConfig() *Config {
@@ -96,19 +152,53 @@ func defaultConfig() *Config {
 type Processor[T any] struct {
 	config       *Config
 	sender       Sender[T]
-	batchRecords []T
+	sizer        Sizer[T]
+	batchRecords []queuedRecord[T]
+	batchBytes   int
 
-	recordCh  chan T
-	pendingCh chan []T
-	flushCh   chan struct{}
+	recordCh  chan queuedRecord[T]
+	pendingCh chan []queuedRecord[T]
+	flushCh   chan chan struct{}
 	quitCh    chan struct{}
 
+	// paused stops dispatching batches, resumeCh wakes up the collector on Resume.
+	paused   atomic.Bool
+	resumeCh chan struct{}
+
+	// inflight counts the dispatched batches which haven't been sent yet,
+	// idleCh is closed once it drops to zero.
+	inflightMu sync.Mutex
+	inflight   int
+	idleCh     chan struct{}
+
+	// queueMu guards the accounting of the queued records, which are tracked by
+	// their sequence number from Submit until they have been sent or evicted.
+	queueMu     sync.Mutex
+	nextSeq     uint64
+	queued      map[uint64]queuedRecord[T]
+	queuedBytes int
+	evicted     int
+	rejected    int
+
 	wg     sync.WaitGroup
 	closed atomic.Bool
 }
 
+// queuedRecord is a submitted record along with its accounting.
+type queuedRecord[T any] struct {
+	record      T
+	size        int
+	seq         uint64
+	submittedAt time.Time
+	// dispatched is set once the record's batch has been handed to the workers.
+	dispatched bool
+}
+
 type applyOption func(*Config)
 
+// Option configures a Processor, see the With* functions.
+type Option = applyOption
+
 // NewProcessor creates a new Processor instance with the provided Sender and optional configuration.
 //
 // The processor is immediately started with the configured number of worker goroutines.
@@ -132,11 +222,18 @@ func NewProcessor[T any](sender Sender[T], options ...applyOption) *Processor[T]
 	p := &Processor[T]{
 		config:       config,
 		sender:       sender,
-		batchRecords: make([]T, 0, config.MaxBatchSize),
-		recordCh:     make(chan T, config.BufferSize),
-		pendingCh:    make(chan []T, config.NumWorkers*2),
-		flushCh:      make(chan struct{}),
+		batchRecords: make([]queuedRecord[T], 0, config.MaxBatchSize),
+		recordCh:     make(chan queuedRecord[T], config.BufferSize),
+		pendingCh:    make(chan []queuedRecord[T], config.NumWorkers*2),
+		flushCh:      make(chan chan struct{}),
 		quitCh:       make(chan struct{}),
+		resumeCh:     make(chan struct{}, 1),
+		idleCh:       make(chan struct{}),
+		queued:       make(map[uint64]queuedRecord[T]),
+	}
+	close(p.idleCh)
+	if sizer, ok := sender.(Sizer[T]); ok && (config.MaxBatchBytes > 0 || config.MaxQueuedBytes > 0) {
+		p.sizer = sizer
 	}
 
 	ctx := context.Background()
@@ -190,27 +287,138 @@ func WithShutdownTimeout(shutdownTimeout time.Duration) applyOption {
 	}
 }
 
+// WithMaxBatchBytes sets the maximum accumulated size in bytes of a single batch.
+// It only takes effect when the Sender implements Sizer. Default is 0 (disabled).
+func WithMaxBatchBytes(maxBatchBytes int) applyOption {
+	return func(c *Config) {
+		c.MaxBatchBytes = maxBatchBytes
+	}
+}
+
+// WithMaxQueuedBytes caps the accumulated size in bytes of the queued records, and sets
+// what happens when a submitted record would exceed it.
+// It only takes effect when the Sender implements Sizer. Default is 0 (disabled).
+func WithMaxQueuedBytes(maxQueuedBytes int, policy EvictionPolicy) applyOption {
+	return func(c *Config) {
+		c.MaxQueuedBytes = maxQueuedBytes
+		c.EvictionPolicy = policy
+	}
+}
+
 // Submit adds a record to the processor's recordCh. If the recordCh is full, it returns an error.
+// If the record would exceed MaxQueuedBytes, the configured EvictionPolicy applies.
 func (p *Processor[T]) Submit(record T) error {
 	if p.closed.Load() {
 		return ErrProcessorClosed
 	}
 
+	queued := queuedRecord[T]{record: record}
+	if p.sizer != nil {
+		queued.size = p.sizer.Size(record)
+	}
+
+	p.queueMu.Lock()
+	defer p.queueMu.Unlock()
+	if err := p.makeRoom(queued.size); err != nil {
+		p.rejected++
+		return err
+	}
+	queued.seq = p.nextSeq
+	queued.submittedAt = time.Now()
 	select {
-	case p.recordCh <- record:
-		return nil
+	case p.recordCh <- queued:
 	default:
+		p.rejected++
 		return ErrBufferFull
 	}
+	p.nextSeq++
+	p.queued[queued.seq] = queued
+	p.queuedBytes += queued.size
+	return nil
+}
+
+// makeRoom checks whether a record of the given size fits into MaxQueuedBytes, evicting
+// the oldest records which haven't been batched yet if the policy allows it.
+// It must be called with the queueMu held.
+func (p *Processor[T]) makeRoom(size int) error {
+	maxBytes := p.config.MaxQueuedBytes
+	if maxBytes <= 0 || p.sizer == nil || p.queuedBytes+size <= maxBytes {
+		return nil
+	}
+	if p.config.EvictionPolicy != EvictOldest || size > maxBytes {
+		return ErrQueueFull
+	}
+	for p.queuedBytes+size > maxBytes {
+		select {
+		case evicted := <-p.recordCh:
+			p.dequeue(evicted)
+			p.evicted++
+		default:
+			// The remaining records are batched already and can't be evicted
+			return ErrQueueFull
+		}
+	}
+	return nil
+}
+
+// dequeue removes the record from the queue accounting. It must be called with the queueMu held.
+func (p *Processor[T]) dequeue(record queuedRecord[T]) {
+	delete(p.queued, record.seq)
+	p.queuedBytes -= record.size
+}
+
+// QueueStats returns the statistics of the records which have been submitted but not sent yet.
+func (p *Processor[T]) QueueStats() QueueStats {
+	p.queueMu.Lock()
+	defer p.queueMu.Unlock()
+
+	stats := QueueStats{
+		Records:  len(p.queued),
+		Bytes:    p.queuedBytes,
+		Evicted:  p.evicted,
+		Rejected: p.rejected,
+	}
+	var oldest time.Time
+	for _, queued := range p.queued {
+		if oldest.IsZero() || queued.submittedAt.Before(oldest) {
+			oldest = queued.submittedAt
+		}
+	}
+	if !oldest.IsZero() {
+		stats.OldestAge = time.Since(oldest)
+	}
+	return stats
 }
 
-// Close gracefully shuts down the processor, ensuring all pendingCh records are sent.
+// Pending returns the records which have been submitted but not dispatched yet in the
+// order they were submitted, without removing them from the queue. The records of the
+// batches which are being sent are excluded, since the Sender may modify them meanwhile.
+func (p *Processor[T]) Pending() []T {
+	p.queueMu.Lock()
+	seqs := make([]uint64, 0, len(p.queued))
+	for seq, queued := range p.queued {
+		if !queued.dispatched {
+			seqs = append(seqs, seq)
+		}
+	}
+	slices.Sort(seqs)
+	records := make([]T, len(seqs))
+	for i, seq := range seqs {
+		records[i] = p.queued[seq].record
+	}
+	p.queueMu.Unlock()
+	return records
+}
+
+// Close gracefully shuts down the processor, ensuring all pendingCh records are sent,
+// even if it's paused.
 // It waits for the shutdown to complete or times out based on the configured ShutdownTimeout.
 func (p *Processor[T]) Close() error {
 	if !p.closed.CompareAndSwap(false, true) {
 		return nil
 	}
 
+	p.paused.Store(false)
 	close(p.quitCh)
 
 	done := make(chan struct{})
@@ -227,24 +435,126 @@ func (p *Processor[T]) Close() error {
 	}
 }
 
+// Pause stops dispatching batches to the Sender. The submitted records are kept in the
+// recordCh, so Submit fails with ErrBufferFull once it's full, and the batches which are
+// being sent complete. Flush doesn't dispatch the records while paused and FlushContext
+// returns ErrPaused, but Close sends them.
+func (p *Processor[T]) Pause() {
+	p.paused.Store(true)
+}
+
+// Resume dispatches the records kept during Pause and resumes the batching.
+// It does nothing if the processor isn't paused.
+func (p *Processor[T]) Resume() {
+	if !p.paused.CompareAndSwap(true, false) {
+		return
+	}
+	select {
+	case p.resumeCh <- struct{}{}:
+	default:
+	}
+}
+
+// Paused reports whether the processor is paused by Pause.
+func (p *Processor[T]) Paused() bool {
+	return p.paused.Load()
+}
+
 func (p *Processor[T]) Flush() {
-	p.flushCh <- struct{}{}
+	p.flushCh <- nil
+}
+
+// FlushContext flushes the buffered records and waits until all dispatched batches
+// have been sent, or the context is done.
+//
+// Unlike Flush, it returns only after the records have been handed to the Sender,
+// which makes it suitable for environments where the process may be frozen right
+// after a request has been handled. It returns ErrPaused if the processor is paused,
+// as the records aren't dispatched until Resume.
+func (p *Processor[T]) FlushContext(ctx context.Context) error {
+	if p.closed.Load() {
+		return ErrProcessorClosed
+	}
+	if p.paused.Load() {
+		return ErrPaused
+	}
+
+	flushed := make(chan struct{})
+	select {
+	case p.flushCh <- flushed:
+	case <-p.quitCh:
+		return ErrProcessorClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-flushed:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	// The flush dispatches nothing if the processor was paused in the meantime
+	if p.paused.Load() {
+		return ErrPaused
+	}
+
+	p.inflightMu.Lock()
+	idleCh := p.idleCh
+	p.inflightMu.Unlock()
+	select {
+	case <-idleCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// appendRecord adds the record to the current batch and dispatches the batch
+// once it reaches either the count or the size limit. If adding the record would
+// exceed either limit, the current batch is dispatched first. The batch is kept
+// while paused, e.g. for a record received right before Pause.
+func (p *Processor[T]) appendRecord(record queuedRecord[T]) {
+	paused := p.paused.Load()
+	limitBytes := p.sizer != nil && p.config.MaxBatchBytes > 0
+	if !paused && len(p.batchRecords) > 0 && (len(p.batchRecords) >= p.config.MaxBatchSize ||
+		(limitBytes && p.batchBytes+record.size > p.config.MaxBatchBytes)) {
+		p.dispatchBatch()
+	}
+
+	p.batchRecords = append(p.batchRecords, record)
+	p.batchBytes += record.size
+	if !paused && (len(p.batchRecords) >= p.config.MaxBatchSize ||
+		(limitBytes && p.batchBytes >= p.config.MaxBatchBytes)) {
+		p.dispatchBatch()
+	}
+}
+
+func (p *Processor[T]) dispatchBatch() {
+	pendingRecords := p.batchRecords
+	p.queueMu.Lock()
+	for _, record := range pendingRecords {
+		if queued, ok := p.queued[record.seq]; ok {
+			queued.dispatched = true
+			p.queued[record.seq] = queued
+		}
+	}
+	p.queueMu.Unlock()
+	p.inflightMu.Lock()
+	if p.inflight == 0 {
+		p.idleCh = make(chan struct{})
+	}
+	p.inflight++
+	p.inflightMu.Unlock()
+	p.pendingCh <- pendingRecords
+	p.batchRecords = make([]queuedRecord[T], 0, p.config.MaxBatchSize)
+	p.batchBytes = 0
 }
 
 func (p *Processor[T]) flushPendingRecords() {
 	for len(p.recordCh) > 0 {
-		record := <-p.recordCh
-		p.batchRecords = append(p.batchRecords, record)
-		if len(p.batchRecords) >= p.config.MaxBatchSize {
-			pendingRecords := p.batchRecords
-			p.pendingCh <- pendingRecords
-			p.batchRecords = make([]T, 0, p.config.MaxBatchSize)
-		}
+		p.appendRecord(<-p.recordCh)
 	}
 	if len(p.batchRecords) > 0 {
-		pendingRecords := p.batchRecords
-		p.pendingCh <- pendingRecords
-		p.batchRecords = make([]T, 0, p.config.MaxBatchSize)
+		p.dispatchBatch()
 	}
 }
 
@@ -255,17 +565,26 @@ func (p *Processor[T]) collectRecords() {
 	defer tick.Stop()
 
 	for {
+		// The records are left in the recordCh while paused
+		recordCh := p.recordCh
+		if p.paused.Load() {
+			recordCh = nil
+		}
 		select {
-		case record := <-p.recordCh:
-			p.batchRecords = append(p.batchRecords, record)
-			if len(p.batchRecords) >= p.config.MaxBatchSize {
-				pendingRecords := p.batchRecords
-				p.pendingCh <- pendingRecords
-				p.batchRecords = make([]T, 0, p.config.MaxBatchSize)
-			}
+		case record := <-recordCh:
+			p.appendRecord(record)
 		case <-tick.C:
-			p.flushPendingRecords()
-		case <-p.flushCh:
+			if !p.paused.Load() {
+				p.flushPendingRecords()
+			}
+		case flushed := <-p.flushCh:
+			if !p.paused.Load() {
+				p.flushPendingRecords()
+			}
+			if flushed != nil {
+				close(flushed)
+			}
+		case <-p.resumeCh:
 			p.flushPendingRecords()
 		case <-p.quitCh:
 			p.flushPendingRecords()
@@ -294,11 +613,34 @@ func (p *Processor[T]) sendBatchLoop(ctx context.Context) {
 	}
 }
 
-func (p *Processor[T]) sendBatch(ctx context.Context, records []T) {
-	if len(records) == 0 {
+func (p *Processor[T]) sendBatch(ctx context.Context, batch []queuedRecord[T]) {
+	defer p.batchDone()
+
+	if len(batch) == 0 {
 		return
 	}
+	records := make([]T, len(batch))
+	for i, queued := range batch {
+		records[i] = queued.record
+	}
+	defer func() {
+		p.queueMu.Lock()
+		defer p.queueMu.Unlock()
+		for _, queued := range batch {
+			p.dequeue(queued)
+		}
+	}()
 	if err := p.sender.Send(ctx, records); err != nil {
 		logger.Get().Error("Failed to send batch", zap.Error(err))
 	}
 }
+
+func (p *Processor[T]) batchDone() {
+	p.inflightMu.Lock()
+	defer p.inflightMu.Unlock()
+
+	p.inflight--
+	if p.inflight == 0 {
+		close(p.idleCh)
+	}
+}
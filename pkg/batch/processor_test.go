@@ -225,3 +225,201 @@ func TestProcessor_DefaultConfig(t *testing.T) {
 
 	require.Equal(t, 100, processor.config.MaxBatchSize)
 }
+
+type sizedSender struct {
+	mockSender
+}
+
+func (s *sizedSender) Size(record any) int {
+	return len(record.(string))
+}
+
+func TestProcessor_MaxBatchBytes(t *testing.T) {
+	sender := &sizedSender{}
+	processor := NewProcessor[any](sender,
+		WithMaxBatchSize(100),
+		WithMaxBatchBytes(10),
+		WithFlushInterval(time.Hour),
+	)
+
+	// 4 + 4 bytes fit into one batch, the third record would exceed the cap.
+	require.NoError(t, processor.Submit("aaaa"))
+	require.NoError(t, processor.Submit("bbbb"))
+	require.NoError(t, processor.Submit("cccc"))
+	// A record larger than the cap is sent in a batch of its own.
+	require.NoError(t, processor.Submit("dddddddddddd"))
+	require.NoError(t, processor.Submit("e"))
+	require.NoError(t, processor.Close())
+
+	batches := sender.getBatches()
+	require.Equal(t, [][]any{
+		{"aaaa", "bbbb"},
+		{"cccc"},
+		{"dddddddddddd"},
+		{"e"},
+	}, batches)
+}
+
+func TestProcessor_MaxBatchBytes_DisabledWithoutSizer(t *testing.T) {
+	sender := &mockSender{}
+	processor := NewProcessor[any](sender,
+		WithMaxBatchSize(100),
+		WithMaxBatchBytes(1),
+		WithFlushInterval(time.Hour),
+	)
+	require.Nil(t, processor.sizer)
+
+	require.NoError(t, processor.Submit("aaaa"))
+	require.NoError(t, processor.Submit("bbbb"))
+	require.NoError(t, processor.Close())
+
+	require.Equal(t, [][]any{{"aaaa", "bbbb"}}, sender.getBatches())
+}
+
+func TestProcessor_FlushContext(t *testing.T) {
+	sender := &mockSender{sendDelay: 50 * time.Millisecond}
+	processor := NewProcessor[any](sender,
+		WithMaxBatchSize(100),
+		WithFlushInterval(time.Hour),
+	)
+	defer func() { require.NoError(t, processor.Close()) }()
+
+	require.NoError(t, processor.FlushContext(context.Background()))
+	require.NoError(t, processor.Submit("event1"))
+	require.NoError(t, processor.Submit("event2"))
+	require.NoError(t, processor.FlushContext(context.Background()))
+	require.Equal(t, [][]any{{"event1", "event2"}}, sender.getBatches())
+
+	require.NoError(t, processor.Submit("event3"))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, processor.FlushContext(ctx), context.DeadlineExceeded)
+}
+
+func TestProcessor_Pause(t *testing.T) {
+	sender := &mockSender{}
+	processor := NewProcessor[any](sender,
+		WithMaxBatchSize(1),
+		WithNumWorkers(1),
+		WithFlushInterval(10*time.Millisecond),
+	)
+	defer func() { require.NoError(t, processor.Close()) }()
+
+	processor.Pause()
+	require.True(t, processor.Paused())
+	// More batches than the workers and the pendingCh can hold
+	for i := 0; i < 10; i++ {
+		require.NoError(t, processor.Submit(i))
+	}
+	processor.Flush()
+	require.ErrorIs(t, processor.FlushContext(context.Background()), ErrPaused)
+	time.Sleep(30 * time.Millisecond)
+	require.Zero(t, sender.getSendCount())
+	require.Equal(t, 10, processor.QueueStats().Records)
+
+	processor.Resume()
+	require.False(t, processor.Paused())
+	require.NoError(t, processor.FlushContext(context.Background()))
+	require.Len(t, sender.getBatches(), 10)
+	require.Zero(t, processor.QueueStats().Records)
+}
+
+func TestProcessor_ClosePaused(t *testing.T) {
+	sender := &mockSender{}
+	processor := NewProcessor[any](sender, WithMaxBatchSize(2), WithFlushInterval(time.Hour))
+	processor.Pause()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, processor.Submit(i))
+	}
+	require.NoError(t, processor.Close())
+	require.Equal(t, [][]any{{0, 1}, {2, 3}, {4}}, sender.getBatches())
+}
+
+func TestProcessor_FlushContext_Closed(t *testing.T) {
+	processor := NewProcessor[any](&mockSender{})
+	require.NoError(t, processor.Close())
+	require.Equal(t, ErrProcessorClosed, processor.FlushContext(context.Background()))
+}
+
+func TestProcessor_MaxQueuedBytes(t *testing.T) {
+	sender := &sizedSender{}
+	processor := NewProcessor[any](sender,
+		WithMaxBatchSize(100),
+		WithFlushInterval(time.Hour),
+		WithMaxQueuedBytes(10, EvictNewest),
+	)
+
+	require.NoError(t, processor.Submit("aaaa"))
+	require.NoError(t, processor.Submit("bbbb"))
+	require.Equal(t, ErrQueueFull, processor.Submit("cccc"))
+	require.NoError(t, processor.Submit("dd"))
+
+	stats := processor.QueueStats()
+	require.Equal(t, 3, stats.Records)
+	require.Equal(t, 10, stats.Bytes)
+	require.Equal(t, []any{"aaaa", "bbbb", "dd"}, processor.Pending())
+	require.Equal(t, 1, stats.Rejected)
+	require.Positive(t, stats.OldestAge)
+
+	require.NoError(t, processor.FlushContext(context.Background()))
+	require.Equal(t, QueueStats{Rejected: 1}, processor.QueueStats())
+	require.Empty(t, processor.Pending())
+	require.NoError(t, processor.Submit("cccc"))
+	require.NoError(t, processor.Close())
+
+	require.Equal(t, [][]any{{"aaaa", "bbbb", "dd"}, {"cccc"}}, sender.getBatches())
+}
+
+func TestProcessor_MaxQueuedBytes_EvictOldest(t *testing.T) {
+	// Without a running collector the submitted records stay in the recordCh
+	processor := &Processor[any]{
+		config:   &Config{MaxQueuedBytes: 10, EvictionPolicy: EvictOldest},
+		sizer:    &sizedSender{},
+		recordCh: make(chan queuedRecord[any], 10),
+		queued:   make(map[uint64]queuedRecord[any]),
+	}
+
+	require.NoError(t, processor.Submit("aaaa"))
+	require.NoError(t, processor.Submit("bbbb"))
+	require.NoError(t, processor.Submit("cccccc"))
+	// A record larger than the cap is rejected without evicting the others
+	require.Equal(t, ErrQueueFull, processor.Submit("ddddddddddd"))
+
+	stats := processor.QueueStats()
+	require.Equal(t, 2, stats.Records)
+	require.Equal(t, 10, stats.Bytes)
+	require.Equal(t, 1, stats.Evicted)
+	require.Equal(t, 1, stats.Rejected)
+	require.Equal(t, "bbbb", (<-processor.recordCh).record)
+	require.Equal(t, "cccccc", (<-processor.recordCh).record)
+}
+
+type blockingSender struct {
+	started chan []any
+	release chan struct{}
+}
+
+func (s *blockingSender) Send(_ context.Context, records []any) error {
+	s.started <- records
+	<-s.release
+	return nil
+}
+
+func TestProcessor_Pending_ExcludesDispatched(t *testing.T) {
+	sender := &blockingSender{started: make(chan []any, 1), release: make(chan struct{})}
+	processor := NewProcessor[any](sender, WithMaxBatchSize(2), WithFlushInterval(time.Hour))
+
+	require.NoError(t, processor.Submit("a"))
+	require.NoError(t, processor.Submit("b"))
+	require.Equal(t, []any{"a", "b"}, <-sender.started)
+	require.NoError(t, processor.Submit("c"))
+
+	// The records of the batch which is being sent are still queued, but not pending
+	require.Eventually(t, func() bool { return processor.QueueStats().Records == 3 }, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool { return len(processor.Pending()) == 1 }, time.Second, time.Millisecond)
+	require.Equal(t, []any{"c"}, processor.Pending())
+
+	close(sender.release)
+	require.NoError(t, processor.Close())
+	require.Empty(t, processor.Pending())
+}
@@ -0,0 +1,244 @@
+// Package circuitbreaker provides a circuit breaker for the Langfuse API calls.
+//
+// When the Langfuse server is unavailable, every request (including the periodic
+// ingestion flushes) would otherwise wait for a timeout or go through retries.
+// The circuit breaker opens after a number of consecutive failures and rejects
+// requests immediately, then lets a limited number of probe requests through
+// after a cool-down period to detect when the server has recovered.
+package circuitbreaker
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ErrOpen is returned for requests that are rejected because the circuit is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State represents the state of the circuit breaker.
+type State int
+
+const (
+	// StateClosed lets all requests through and counts consecutive failures.
+	StateClosed State = iota
+	// StateOpen rejects all requests until the open timeout has elapsed.
+	StateOpen
+	// StateHalfOpen lets a limited number of probe requests through.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Config holds the configuration for the circuit breaker.
+type Config struct {
+	// FailureThreshold defines the number of consecutive failures after which the circuit opens.
+	// Default is 5.
+	FailureThreshold int
+	// OpenTimeout defines how long the circuit stays open before probe requests are allowed.
+	// Default is 30 seconds.
+	OpenTimeout time.Duration
+	// HalfOpenMaxRequests defines the number of concurrent probe requests allowed in the half-open state.
+	// Default is 1.
+	HalfOpenMaxRequests int
+	// OnStateChange is called whenever the circuit changes its state.
+	// It is called synchronously from the request path, so it should return quickly.
+	OnStateChange func(from, to State)
+}
+
+func (c *Config) normalize() {
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = 5
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = 30 * time.Second
+	}
+	if c.HalfOpenMaxRequests <= 0 {
+		c.HalfOpenMaxRequests = 1
+	}
+}
+
+// CircuitBreaker tracks the outcome of requests and decides whether new requests are allowed.
+//
+// The circuit breaker is thread-safe and can be shared by multiple clients.
+type CircuitBreaker struct {
+	mu               sync.Mutex
+	config           Config
+	state            State
+	failures         int
+	halfOpenRequests int
+	openedAt         time.Time
+	now              func() time.Time
+}
+
+// New creates a new circuit breaker in the closed state.
+//
+// Zero values in the config are replaced with the defaults.
+//
+// Example:
+//
+//	breaker := circuitbreaker.New(circuitbreaker.Config{
+//		FailureThreshold: 3,
+//		OpenTimeout:      time.Minute,
+//		OnStateChange: func(from, to circuitbreaker.State) {
+//			log.Printf("langfuse circuit breaker: %s -> %s", from, to)
+//		},
+//	})
+func New(config Config) *CircuitBreaker {
+	config.normalize()
+	return &CircuitBreaker{
+		config: config,
+		state:  StateClosed,
+		now:    time.Now,
+	}
+}
+
+// State returns the current state of the circuit breaker.
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == StateOpen && cb.now().Sub(cb.openedAt) >= cb.config.OpenTimeout {
+		return StateHalfOpen
+	}
+	return cb.state
+}
+
+// Allow reports whether a new request may be sent. It returns ErrOpen if the request is rejected.
+//
+// Every allowed request must be followed by a call to either Success or Failure.
+func (cb *CircuitBreaker) Allow() error {
+	cb.mu.Lock()
+	var transitions []transition
+	defer func() {
+		cb.mu.Unlock()
+		cb.notify(transitions)
+	}()
+
+	switch cb.state {
+	case StateOpen:
+		if cb.now().Sub(cb.openedAt) < cb.config.OpenTimeout {
+			return ErrOpen
+		}
+		transitions = cb.setState(transitions, StateHalfOpen)
+		fallthrough
+	case StateHalfOpen:
+		if cb.halfOpenRequests >= cb.config.HalfOpenMaxRequests {
+			return ErrOpen
+		}
+		cb.halfOpenRequests++
+	}
+	return nil
+}
+
+// Success records a successful request.
+func (cb *CircuitBreaker) Success() {
+	cb.mu.Lock()
+	var transitions []transition
+	defer func() {
+		cb.mu.Unlock()
+		cb.notify(transitions)
+	}()
+
+	cb.failures = 0
+	if cb.state == StateHalfOpen {
+		transitions = cb.setState(transitions, StateClosed)
+	}
+}
+
+// Failure records a failed request.
+func (cb *CircuitBreaker) Failure() {
+	cb.mu.Lock()
+	var transitions []transition
+	defer func() {
+		cb.mu.Unlock()
+		cb.notify(transitions)
+	}()
+
+	switch cb.state {
+	case StateClosed:
+		cb.failures++
+		if cb.failures >= cb.config.FailureThreshold {
+			transitions = cb.setState(transitions, StateOpen)
+		}
+	case StateHalfOpen:
+		transitions = cb.setState(transitions, StateOpen)
+	}
+}
+
+type transition struct {
+	from, to State
+}
+
+// setState must be called with the lock held. The returned transitions are
+// reported to OnStateChange by notify once the lock is released.
+func (cb *CircuitBreaker) setState(transitions []transition, state State) []transition {
+	if cb.state == state {
+		return transitions
+	}
+	transitions = append(transitions, transition{from: cb.state, to: state})
+	cb.state = state
+	cb.failures = 0
+	cb.halfOpenRequests = 0
+	if state == StateOpen {
+		cb.openedAt = cb.now()
+	}
+	return transitions
+}
+
+func (cb *CircuitBreaker) notify(transitions []transition) {
+	if cb.config.OnStateChange == nil {
+		return
+	}
+	for _, t := range transitions {
+		cb.config.OnStateChange(t.from, t.to)
+	}
+}
+
+// Install registers the circuit breaker on the resty client.
+//
+// Requests are rejected with ErrOpen while the circuit is open. Network errors,
+// 5xx responses and 429 responses are recorded as failures; all other responses
+// are recorded as successes since they prove the server is reachable.
+func (cb *CircuitBreaker) Install(cli *resty.Client) {
+	cli.OnBeforeRequest(func(_ *resty.Client, _ *resty.Request) error {
+		return cb.Allow()
+	})
+	cli.OnSuccess(func(_ *resty.Client, rsp *resty.Response) {
+		if isFailureStatus(rsp.StatusCode()) {
+			cb.Failure()
+		} else {
+			cb.Success()
+		}
+	})
+	cli.OnError(func(_ *resty.Request, err error) {
+		if errors.Is(err, ErrOpen) {
+			return
+		}
+		var rspErr *resty.ResponseError
+		if errors.As(err, &rspErr) && rspErr.Response != nil && rspErr.Response.RawResponse != nil &&
+			!isFailureStatus(rspErr.Response.StatusCode()) {
+			cb.Success()
+			return
+		}
+		cb.Failure()
+	})
+}
+
+func isFailureStatus(statusCode int) bool {
+	return statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,123 @@
+package circuitbreaker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker_StateTransitions(t *testing.T) {
+	var changes [][2]State
+	cb := New(Config{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+		OnStateChange: func(from, to State) {
+			changes = append(changes, [2]State{from, to})
+		},
+	})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+
+	require.Equal(t, StateClosed, cb.State())
+	require.NoError(t, cb.Allow())
+	cb.Failure()
+	require.NoError(t, cb.Allow())
+	cb.Success()
+	// A success resets the consecutive failure count.
+	require.NoError(t, cb.Allow())
+	cb.Failure()
+	require.Equal(t, StateClosed, cb.State())
+	require.NoError(t, cb.Allow())
+	cb.Failure()
+	require.Equal(t, StateOpen, cb.State())
+	require.ErrorIs(t, cb.Allow(), ErrOpen)
+
+	// After the open timeout, a single probe request is allowed.
+	now = now.Add(time.Minute)
+	require.Equal(t, StateHalfOpen, cb.State())
+	require.NoError(t, cb.Allow())
+	require.ErrorIs(t, cb.Allow(), ErrOpen)
+	cb.Failure()
+	require.Equal(t, StateOpen, cb.State())
+	require.ErrorIs(t, cb.Allow(), ErrOpen)
+
+	now = now.Add(time.Minute)
+	require.NoError(t, cb.Allow())
+	cb.Success()
+	require.Equal(t, StateClosed, cb.State())
+
+	require.Equal(t, [][2]State{
+		{StateClosed, StateOpen},
+		{StateOpen, StateHalfOpen},
+		{StateHalfOpen, StateOpen},
+		{StateOpen, StateHalfOpen},
+		{StateHalfOpen, StateClosed},
+	}, changes)
+}
+
+func TestCircuitBreaker_OnStateChangeCanReadState(t *testing.T) {
+	var cb *CircuitBreaker
+	cb = New(Config{
+		FailureThreshold: 1,
+		OnStateChange: func(from, to State) {
+			require.Equal(t, to, cb.State())
+		},
+	})
+	cb.Failure()
+	require.Equal(t, StateOpen, cb.State())
+}
+
+func TestCircuitBreaker_Install(t *testing.T) {
+	var requestCount atomic.Int32
+	var statusCode atomic.Int32
+	statusCode.Store(http.StatusInternalServerError)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.WriteHeader(int(statusCode.Load()))
+	}))
+	defer server.Close()
+
+	cb := New(Config{FailureThreshold: 2, OpenTimeout: time.Minute})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+	cli := resty.New().SetBaseURL(server.URL)
+	cb.Install(cli)
+
+	for i := 0; i < 2; i++ {
+		rsp, err := cli.R().Get("/health")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, rsp.StatusCode())
+	}
+	require.Equal(t, StateOpen, cb.State())
+
+	_, err := cli.R().Get("/health")
+	require.ErrorIs(t, err, ErrOpen)
+	require.Equal(t, int32(2), requestCount.Load())
+
+	// Client errors prove the server is reachable and close the circuit.
+	now = now.Add(time.Minute)
+	statusCode.Store(http.StatusNotFound)
+	rsp, err := cli.R().Get("/health")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, rsp.StatusCode())
+	require.Equal(t, StateClosed, cb.State())
+	require.Equal(t, int32(3), requestCount.Load())
+}
+
+func TestCircuitBreaker_Install_NetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	cb := New(Config{FailureThreshold: 1})
+	cli := resty.New().SetBaseURL(server.URL)
+	cb.Install(cli)
+
+	_, err := cli.R().Get("/health")
+	require.Error(t, err)
+	require.Equal(t, StateOpen, cb.State())
+}
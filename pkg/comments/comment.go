@@ -3,12 +3,17 @@
 // This package allows you to add contextual comments to your traces and observations
 // for collaboration and debugging purposes. Comments can be attached to various
 // object types including traces, observations, sessions, and prompts.
+//
+// The public API has no reply or mention fields, so comment threads and mentions of
+// the Langfuse UI are not supported.
 package comments
 
 import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -90,22 +95,31 @@ func (c *CreateCommentRequest) validate() error {
 
 // ListParams defines the query parameters for filtering and paginating comment listings.
 //
-// Use ObjectType and ObjectID to filter comments for specific objects.
-// Page and Limit control pagination.
+// Use ObjectType and ObjectID to filter comments for specific objects, AuthorUserID for
+// the comments of a specific author, and FromTimestamp and ToTimestamp to filter by
+// creation time. Page and Limit control pagination.
+//
+// The comments API has no time range filter, so FromTimestamp and ToTimestamp aren't sent,
+// and List filters the comments of the page by CreatedAt instead.
 type ListParams struct {
-	Page       int
-	Limit      int
-	ObjectType CommentObjectType
-	ObjectID   string
+	Page          int
+	Limit         int
+	ObjectType    CommentObjectType
+	ObjectID      string
+	AuthorUserID  string
+	FromTimestamp time.Time
+	ToTimestamp   time.Time
+
+	pagination common.Pagination
 }
 
 // ToQueryString converts the ListParams to a URL query string.
 func (query *ListParams) ToQueryString() string {
 	parts := make([]string, 0)
-	if query.Page != 0 {
+	if query.pagination.HasPage(query.Page) {
 		parts = append(parts, "page="+strconv.Itoa(query.Page))
 	}
-	if query.Limit != 0 {
+	if query.pagination.HasLimit(query.Limit) {
 		parts = append(parts, "limit="+strconv.Itoa(query.Limit))
 	}
 	if query.ObjectType != "" {
@@ -114,9 +128,63 @@ func (query *ListParams) ToQueryString() string {
 	if query.ObjectID != "" {
 		parts = append(parts, "objectId="+query.ObjectID)
 	}
+	if query.AuthorUserID != "" {
+		parts = append(parts, "authorUserId="+url.QueryEscape(query.AuthorUserID))
+	}
 	return strings.Join(parts, "&")
 }
 
+// inTimeRange reports whether the comment was created on or after FromTimestamp and
+// before ToTimestamp.
+func (query *ListParams) inTimeRange(comment *CommentEntry) bool {
+	if !query.FromTimestamp.IsZero() && comment.CreatedAt.Before(query.FromTimestamp) {
+		return false
+	}
+	if !query.ToTimestamp.IsZero() && !comment.CreatedAt.Before(query.ToTimestamp) {
+		return false
+	}
+	return true
+}
+
+// SetPage sets the page number, even if it is 0.
+func (query *ListParams) SetPage(page int) {
+	query.Page = page
+	query.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (query *ListParams) SetLimit(limit int) {
+	query.Limit = limit
+	query.pagination.MarkLimit()
+}
+
+// ListOption configures ListParams, see common.ListOption.
+type ListOption = common.ListOption[ListParams]
+
+// NewListParams creates ListParams for listing comments from the provided options.
+func NewListParams(options ...ListOption) ListParams {
+	return common.NewListParams(options...)
+}
+
+// WithPage sets the page number, even if it is 0.
+func WithPage(page int) ListOption {
+	return common.WithPage[ListParams](page)
+}
+
+// WithLimit sets the page size, even if it is 0.
+func WithLimit(limit int) ListOption {
+	return common.WithLimit[ListParams](limit)
+}
+
+// WithTimeRange sets FromTimestamp and ToTimestamp from the time window, e.g.
+// common.LastDays(14), which List filters on the client side.
+func WithTimeRange(r common.TimeRange) ListOption {
+	return func(query *ListParams) {
+		query.FromTimestamp = r.From
+		query.ToTimestamp = r.To
+	}
+}
+
 // ListComments represents the paginated response from the list comments API.
 //
 // It contains pagination metadata and an array of comments matching the query criteria.
@@ -163,6 +231,10 @@ func (c *Client) Get(ctx context.Context, id string) (*CommentEntry, error) {
 }
 
 // List retrieves a list of comments based on the provided parameters.
+//
+// The comments of the page are filtered by FromTimestamp and ToTimestamp on the client
+// side, so a page may hold fewer than Limit comments, and the metadata counts the
+// comments regardless of their creation time.
 func (c *Client) List(ctx context.Context, params ListParams) (*ListComments, error) {
 	var listResponse ListComments
 	rsp, err := c.restyCli.R().
@@ -177,6 +249,11 @@ func (c *Client) List(ctx context.Context, params ListParams) (*ListComments, er
 	if rsp.IsError() {
 		return nil, fmt.Errorf("list comments failed: %s, got status code: %d", rsp.String(), rsp.StatusCode())
 	}
+	if !params.FromTimestamp.IsZero() || !params.ToTimestamp.IsZero() {
+		listResponse.Data = slices.DeleteFunc(listResponse.Data, func(comment CommentEntry) bool {
+			return !params.inTimeRange(&comment)
+		})
+	}
 	return &listResponse, nil
 }
 
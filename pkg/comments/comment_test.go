@@ -2,7 +2,14 @@ package comments
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
 )
 
 func TestCommentEntry_validate(t *testing.T) {
@@ -145,6 +152,15 @@ func TestListParams_ToQueryString(t *testing.T) {
 			},
 			want: "objectType=OBSERVATION",
 		},
+		{
+			name: "author and time range",
+			params: ListParams{
+				AuthorUserID:  "user 1",
+				FromTimestamp: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+				ToTimestamp:   time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC),
+			},
+			want: "authorUserId=user+1",
+		},
 	}
 
 	for _, tt := range tests {
@@ -156,6 +172,32 @@ func TestListParams_ToQueryString(t *testing.T) {
 	}
 }
 
+func TestClient_List_TimeRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("fromTimestamp"); got != "" {
+			t.Errorf("unexpected fromTimestamp: %s", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"meta":{"page":1,"totalPages":1,"totalItems":3},"data":[
+			{"id":"before","createdAt":"2025-03-02T23:59:59Z"},
+			{"id":"from","createdAt":"2025-03-03T00:00:00Z"},
+			{"id":"to","createdAt":"2025-03-17T00:00:00Z"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	listComments, err := client.List(context.Background(), NewListParams(WithTimeRange(common.TimeRange{
+		From: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC),
+	})))
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(listComments.Data) != 1 || listComments.Data[0].ID != "from" {
+		t.Errorf("List() = %v, want the comment created at FromTimestamp", listComments.Data)
+	}
+}
+
 func TestCommentObjectType(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,26 @@
+package comments_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	langfuse "github.com/git-hulk/langfuse-go"
+	"github.com/git-hulk/langfuse-go/pkg/comments"
+)
+
+func ExampleClient_Create() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...")
+	defer client.Close()
+
+	comment, err := client.Comments().Create(context.Background(), &comments.CreateCommentRequest{
+		ProjectID:  "project-id",
+		ObjectType: comments.ObjectTypeTrace,
+		ObjectID:   "trace-id",
+		Content:    "The answer cites an outdated policy",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(comment.ID)
+}
@@ -0,0 +1,21 @@
+package common_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+)
+
+func ExampleFetchAll() {
+	pages := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+	items, err := common.FetchAll(context.Background(), func(ctx context.Context, page int) ([]string, common.ListMetadata, error) {
+		return pages[page-1], common.ListMetadata{Page: page, Limit: 2, TotalItems: 5, TotalPages: len(pages)}, nil
+	}, common.WithOrderedResults())
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(items)
+	// Output: [a b c d e]
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type requestHeadersKey struct{}
+
+// WithRequestHeader returns a copy of ctx which sets the header on the API requests
+// made with it, e.g. to pass correlation headers to an API gateway for a single call
+// without changing the configuration of the client.
+//
+// The header replaces a header of the same name set by the client. Calling it again
+// on the returned context adds further headers.
+//
+// Example:
+//
+//	ctx = common.WithRequestHeader(ctx, "X-Request-ID", requestID)
+//	prompt, err := client.Prompts().Get(ctx, prompts.GetParams{Name: "chat"})
+func WithRequestHeader(ctx context.Context, key, value string) context.Context {
+	headers := RequestHeaders(ctx).Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	headers.Set(key, value)
+	return context.WithValue(ctx, requestHeadersKey{}, headers)
+}
+
+// RequestHeaders returns the headers set on ctx with WithRequestHeader, or nil if there are none.
+func RequestHeaders(ctx context.Context) http.Header {
+	headers, _ := ctx.Value(requestHeadersKey{}).(http.Header)
+	return headers
+}
+
+// InstallRequestHeaders adds a middleware to the resty client which sets the headers of
+// the request context, see WithRequestHeader.
+//
+// The Langfuse client installs it on its resty client, it only needs to be installed on
+// resty clients which are passed to the API clients directly.
+func InstallRequestHeaders(cli *resty.Client) {
+	cli.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		for key, values := range RequestHeaders(req.Context()) {
+			req.Header[key] = append([]string(nil), values...)
+		}
+		return nil
+	})
+}
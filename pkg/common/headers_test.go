@@ -0,0 +1,36 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequestHeader(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	cli := resty.New().SetBaseURL(server.URL).SetHeader("X-Tenant", "global")
+	InstallRequestHeaders(cli)
+
+	parent := WithRequestHeader(context.Background(), "X-Request-ID", "request-1")
+	ctx := WithRequestHeader(parent, "X-Tenant", "tenant-1")
+	_, err := cli.R().SetContext(ctx).Get("/health")
+	require.NoError(t, err)
+	require.Equal(t, "request-1", got.Get("X-Request-ID"))
+	require.Equal(t, "tenant-1", got.Get("X-Tenant"))
+	// The parent context isn't modified by adding further headers
+	require.Empty(t, RequestHeaders(parent).Get("X-Tenant"))
+
+	_, err = cli.R().SetContext(context.Background()).Get("/health")
+	require.NoError(t, err)
+	require.Empty(t, got.Get("X-Request-ID"))
+	require.Equal(t, "global", got.Get("X-Tenant"))
+}
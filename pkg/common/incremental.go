@@ -0,0 +1,25 @@
+package common
+
+import "time"
+
+// UpdatedSince returns the items which were updated after since, together with the
+// high-watermark, i.e. the latest update time of the returned items, or since if no
+// item was updated after it.
+//
+// Pass the high-watermark as since of the next call to fetch only the items which
+// changed in between, e.g. to replicate entities incrementally into another system.
+func UpdatedSince[T any](items []T, since time.Time, updatedAt func(T) time.Time) ([]T, time.Time) {
+	updated := make([]T, 0)
+	watermark := since
+	for _, item := range items {
+		t := updatedAt(item)
+		if !t.After(since) {
+			continue
+		}
+		updated = append(updated, item)
+		if t.After(watermark) {
+			watermark = t
+		}
+	}
+	return updated, watermark
+}
@@ -0,0 +1,27 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdatedSince(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := []time.Time{
+		since.Add(-time.Hour),
+		since,
+		since.Add(2 * time.Hour),
+		since.Add(time.Hour),
+	}
+	identity := func(t time.Time) time.Time { return t }
+
+	updated, watermark := UpdatedSince(items, since, identity)
+	require.Equal(t, []time.Time{since.Add(2 * time.Hour), since.Add(time.Hour)}, updated)
+	require.Equal(t, since.Add(2*time.Hour), watermark)
+
+	updated, watermark = UpdatedSince(items, watermark, identity)
+	require.Empty(t, updated)
+	require.Equal(t, since.Add(2*time.Hour), watermark)
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var knownFieldsCache sync.Map // map[reflect.Type]map[string]struct{}
+
+// UnknownFields returns the top-level fields of the JSON object in data which
+// don't map to any field of the struct v, or nil if there are none.
+//
+// It's used by custom UnmarshalJSON methods to preserve attributes added by newer
+// server versions in an Extra field instead of silently dropping them. Like
+// encoding/json, field names are matched case-insensitively.
+func UnknownFields(data []byte, v any) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	known := knownFields(reflect.TypeOf(v))
+	var unknown map[string]json.RawMessage
+	for name, value := range fields {
+		if _, ok := known[strings.ToLower(name)]; ok {
+			continue
+		}
+		if unknown == nil {
+			unknown = make(map[string]json.RawMessage)
+		}
+		unknown[name] = value
+	}
+	return unknown, nil
+}
+
+func knownFields(typ reflect.Type) map[string]struct{} {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if cached, ok := knownFieldsCache.Load(typ); ok {
+		return cached.(map[string]struct{})
+	}
+
+	known := make(map[string]struct{})
+	collectKnownFields(typ, known)
+	knownFieldsCache.Store(typ, known)
+	return known
+}
+
+func collectKnownFields(typ reflect.Type, known map[string]struct{}) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" && field.Anonymous {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				collectKnownFields(embedded, known)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[strings.ToLower(name)] = struct{}{}
+	}
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type embeddedEntry struct {
+	ID string `json:"id"`
+}
+
+type testEntry struct {
+	embeddedEntry
+	Name     string `json:"name,omitempty"`
+	Untagged string
+	Ignored  string `json:"-"`
+}
+
+func TestUnknownFields(t *testing.T) {
+	data := []byte(`{"id":"1","NAME":"test","untagged":"x","ignored":"y","newField":{"a":1}}`)
+	unknown, err := UnknownFields(data, testEntry{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]json.RawMessage{
+		"ignored":  json.RawMessage(`"y"`),
+		"newField": json.RawMessage(`{"a":1}`),
+	}, unknown)
+
+	unknown, err = UnknownFields([]byte(`{"id":"1","name":"test"}`), &testEntry{})
+	require.NoError(t, err)
+	require.Nil(t, unknown)
+
+	_, err = UnknownFields([]byte(`[]`), testEntry{})
+	require.Error(t, err)
+}
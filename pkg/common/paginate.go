@@ -0,0 +1,237 @@
+package common
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultFetchWorkers = 4
+
+// PageFetcher retrieves a single page of a paginated list. Pages start at 1.
+type PageFetcher[T any] func(ctx context.Context, page int) ([]T, ListMetadata, error)
+
+type fetchConfig struct {
+	workers  int
+	ordered  bool
+	maxPages int
+	progress ProgressFunc
+}
+
+// FetchOption configures FetchPages and FetchAll.
+type FetchOption func(*fetchConfig)
+
+// WithFetchWorkers sets the number of pages fetched in parallel. Default is 4.
+func WithFetchWorkers(workers int) FetchOption {
+	return func(c *fetchConfig) {
+		c.workers = workers
+	}
+}
+
+// WithOrderedResults makes the results follow the page order. By default, the pages are
+// handled in the order they're fetched, which doesn't need to buffer out of order pages.
+func WithOrderedResults() FetchOption {
+	return func(c *fetchConfig) {
+		c.ordered = true
+	}
+}
+
+// WithMaxPages limits the number of fetched pages, 0 means no limit.
+func WithMaxPages(maxPages int) FetchOption {
+	return func(c *fetchConfig) {
+		c.maxPages = maxPages
+	}
+}
+
+// WithFetchProgress reports the number of items handled after each page, together with
+// the total number of items of the list.
+func WithFetchProgress(progress ProgressFunc) FetchOption {
+	return func(c *fetchConfig) {
+		c.progress = progress
+	}
+}
+
+type fetchedPage[T any] struct {
+	page  int
+	items []T
+	err   error
+}
+
+// FetchPages fetches all pages of a paginated list with a pool of workers and passes
+// the items of each page to handle.
+//
+// The first page is fetched alone to learn the total number of pages, then the remaining
+// pages are fetched in parallel. handle is never called concurrently. The first error of
+// either fetch or handle cancels the pending fetches and is returned.
+//
+// Example:
+//
+//	err := common.FetchPages(ctx, func(ctx context.Context, page int) ([]scores.Score, common.ListMetadata, error) {
+//		listScores, err := client.List(ctx, scores.ListParams{Page: page, Limit: 100})
+//		if err != nil {
+//			return nil, common.ListMetadata{}, err
+//		}
+//		return listScores.Data, listScores.Metadata, nil
+//	}, func(page int, items []scores.Score) error {
+//		return export(items)
+//	}, common.WithFetchWorkers(8))
+func FetchPages[T any](ctx context.Context, fetch PageFetcher[T], handle func(page int, items []T) error, options ...FetchOption) error {
+	config := &fetchConfig{workers: defaultFetchWorkers}
+	for _, option := range options {
+		option(config)
+	}
+	if config.workers <= 0 {
+		config.workers = defaultFetchWorkers
+	}
+
+	items, metadata, err := fetch(ctx, 1)
+	if err != nil {
+		return err
+	}
+	totalPages := metadata.TotalPages
+	totalItems := metadata.TotalItems
+	if config.maxPages > 0 && totalPages > config.maxPages {
+		totalPages = config.maxPages
+		if metadata.Limit > 0 && totalItems > totalPages*metadata.Limit {
+			totalItems = totalPages * metadata.Limit
+		}
+	}
+	if config.progress != nil {
+		processed := 0
+		handleItems := handle
+		handle = func(page int, items []T) error {
+			if err := handleItems(page, items); err != nil {
+				return err
+			}
+			processed += len(items)
+			config.progress(processed, totalItems)
+			return nil
+		}
+	}
+	if err := handle(1, items); err != nil {
+		return err
+	}
+	if totalPages <= 1 {
+		return nil
+	}
+
+	parentCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pageCh := make(chan int)
+	resultCh := make(chan fetchedPage[T])
+	var wg sync.WaitGroup
+	for i := 0; i < config.workers && i < totalPages-1; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pageCh {
+				items, _, err := fetch(ctx, page)
+				select {
+				case resultCh <- fetchedPage[T]{page: page, items: items, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(pageCh)
+		for page := 2; page <= totalPages; page++ {
+			select {
+			case pageCh <- page:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	pending := make(map[int][]T)
+	nextPage := 2
+	for result := range resultCh {
+		if result.err != nil {
+			return result.err
+		}
+		if !config.ordered {
+			if err := handle(result.page, result.items); err != nil {
+				return err
+			}
+			continue
+		}
+		pending[result.page] = result.items
+		for {
+			items, ok := pending[nextPage]
+			if !ok {
+				break
+			}
+			delete(pending, nextPage)
+			if err := handle(nextPage, items); err != nil {
+				return err
+			}
+			nextPage++
+		}
+	}
+	// Workers stop without reporting a result when the parent context is done
+	return parentCtx.Err()
+}
+
+// FetchAll fetches all pages of a paginated list with a pool of workers and returns the
+// items of all pages, see FetchPages.
+func FetchAll[T any](ctx context.Context, fetch PageFetcher[T], options ...FetchOption) ([]T, error) {
+	all := make([]T, 0)
+	err := FetchPages(ctx, fetch, func(_ int, items []T) error {
+		all = append(all, items...)
+		return nil
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// ForEach calls fn for each index below n with up to workers calls in parallel, and returns
+// the error of each call. Once the context is done, the indexes which aren't dispatched yet
+// aren't passed to fn and fail with the error of the context.
+//
+// Example:
+//
+//	errs := common.ForEach(ctx, len(ids), 4, func(i int) error {
+//		return client.Delete(ctx, ids[i])
+//	})
+func ForEach(ctx context.Context, n, workers int, fn func(i int) error) []error {
+	if workers <= 0 {
+		workers = defaultFetchWorkers
+	}
+	errs := make([]error, n)
+	indexCh := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexCh {
+				errs[index] = fn(index)
+			}
+		}()
+	}
+	dispatched := 0
+dispatch:
+	for ; dispatched < n; dispatched++ {
+		select {
+		case indexCh <- dispatched:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexCh)
+	wg.Wait()
+
+	for i := dispatched; i < n; i++ {
+		errs[i] = ctx.Err()
+	}
+	return errs
+}
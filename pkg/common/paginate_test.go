@@ -0,0 +1,195 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pagesFetcher returns a fetcher of totalPages pages holding their page number, where
+// lower pages take longer to fetch so that they complete out of order.
+func pagesFetcher(totalPages int, fetched *atomic.Int32) PageFetcher[int] {
+	return func(ctx context.Context, page int) ([]int, ListMetadata, error) {
+		fetched.Add(1)
+		if page > 1 {
+			select {
+			case <-time.After(time.Duration(totalPages-page) * 5 * time.Millisecond):
+			case <-ctx.Done():
+				return nil, ListMetadata{}, ctx.Err()
+			}
+		}
+		return []int{page}, ListMetadata{Page: page, TotalPages: totalPages}, nil
+	}
+}
+
+func TestFetchAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ordered results", func(t *testing.T) {
+		var fetched atomic.Int32
+		items, err := FetchAll(ctx, pagesFetcher(6, &fetched), WithFetchWorkers(3), WithOrderedResults())
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2, 3, 4, 5, 6}, items)
+		require.EqualValues(t, 6, fetched.Load())
+	})
+
+	t.Run("unordered results", func(t *testing.T) {
+		var fetched atomic.Int32
+		items, err := FetchAll(ctx, pagesFetcher(6, &fetched), WithFetchWorkers(5))
+		require.NoError(t, err)
+		require.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6}, items)
+		require.Equal(t, 1, items[0])
+	})
+
+	t.Run("single page", func(t *testing.T) {
+		var fetched atomic.Int32
+		items, err := FetchAll(ctx, pagesFetcher(1, &fetched))
+		require.NoError(t, err)
+		require.Equal(t, []int{1}, items)
+		require.EqualValues(t, 1, fetched.Load())
+	})
+
+	t.Run("max pages", func(t *testing.T) {
+		var fetched atomic.Int32
+		items, err := FetchAll(ctx, pagesFetcher(10, &fetched), WithMaxPages(3), WithOrderedResults())
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2, 3}, items)
+		require.EqualValues(t, 3, fetched.Load())
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		fetchErr := errors.New("fetch failed")
+		items, err := FetchAll(ctx, func(_ context.Context, page int) ([]int, ListMetadata, error) {
+			if page == 3 {
+				return nil, ListMetadata{}, fetchErr
+			}
+			return []int{page}, ListMetadata{Page: page, TotalPages: 5}, nil
+		})
+		require.ErrorIs(t, err, fetchErr)
+		require.Nil(t, items)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		var fetched atomic.Int32
+		fetch := pagesFetcher(100, &fetched)
+		err := FetchPages(ctx, fetch, func(page int, _ []int) error {
+			cancel()
+			return nil
+		}, WithFetchWorkers(2))
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestFetchPages_Progress(t *testing.T) {
+	fetch := func(ctx context.Context, page int) ([]int, ListMetadata, error) {
+		return []int{page * 2, page*2 + 1}, ListMetadata{Page: page, Limit: 2, TotalItems: 10, TotalPages: 5}, nil
+	}
+
+	var processed, total int
+	_, err := FetchAll(context.Background(), fetch, WithFetchProgress(func(p, t int) {
+		processed, total = p, t
+	}))
+	require.NoError(t, err)
+	require.Equal(t, 10, processed)
+	require.Equal(t, 10, total)
+
+	_, err = FetchAll(context.Background(), fetch, WithMaxPages(2), WithFetchProgress(func(p, t int) {
+		processed, total = p, t
+	}))
+	require.NoError(t, err)
+	require.Equal(t, 4, processed)
+	require.Equal(t, 4, total)
+}
+
+func TestFetchPages_Workers(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	fetch := func(_ context.Context, page int) ([]int, ListMetadata, error) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			observed := maxRunning.Load()
+			if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return []int{page}, ListMetadata{Page: page, TotalPages: 20}, nil
+	}
+
+	var handled []int
+	err := FetchPages(context.Background(), fetch, func(page int, items []int) error {
+		handled = append(handled, page)
+		return nil
+	}, WithFetchWorkers(2))
+	require.NoError(t, err)
+	require.Len(t, handled, 20)
+	require.LessOrEqual(t, maxRunning.Load(), int32(2))
+}
+
+func TestFetchPages_HandleError(t *testing.T) {
+	handleErr := errors.New("handle failed")
+	var fetched atomic.Int32
+	err := FetchPages(context.Background(), pagesFetcher(5, &fetched), func(page int, _ []int) error {
+		if page == 2 {
+			return handleErr
+		}
+		return nil
+	}, WithOrderedResults())
+	require.ErrorIs(t, err, handleErr)
+}
+
+func TestForEach(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	failErr := errors.New("odd index")
+	errs := ForEach(context.Background(), 20, 3, func(i int) error {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			observed := maxRunning.Load()
+			if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		if i%2 == 1 {
+			return failErr
+		}
+		return nil
+	})
+	require.Len(t, errs, 20)
+	for i, err := range errs {
+		if i%2 == 1 {
+			require.ErrorIs(t, err, failErr)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+	require.LessOrEqual(t, maxRunning.Load(), int32(3))
+}
+
+func TestForEach_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var called atomic.Int32
+	errs := ForEach(ctx, 10, 1, func(i int) error {
+		called.Add(1)
+		if i == 2 {
+			cancel()
+		}
+		return nil
+	})
+	// The indexes which weren't dispatched before the cancellation fail with its error
+	require.Less(t, int(called.Load()), 10)
+	for i, err := range errs {
+		if i < int(called.Load()) {
+			require.NoError(t, err)
+		} else {
+			require.ErrorIs(t, err, context.Canceled)
+		}
+	}
+	require.Empty(t, ForEach(ctx, 0, 1, func(int) error { return nil }))
+}
@@ -0,0 +1,72 @@
+package common
+
+// Paginated is implemented by the list params of every list API, whose page and limit
+// can be set explicitly, see WithPage and WithLimit.
+type Paginated interface {
+	SetPage(page int)
+	SetLimit(limit int)
+}
+
+// ListOption configures the list params of type P. Options mark the fields they set as
+// explicit, so zero values are sent to the API as well.
+type ListOption[P any] func(*P)
+
+// NewListParams creates list params of type P from the provided options.
+//
+// Example:
+//
+//	params := common.NewListParams(common.WithPage[annotations.QueueListParams](0))
+func NewListParams[P any](options ...ListOption[P]) P {
+	var params P
+	for _, option := range options {
+		option(&params)
+	}
+	return params
+}
+
+// WithPage sets the page number of the list params, even if it is 0.
+func WithPage[P any, PP interface {
+	*P
+	Paginated
+}](page int) ListOption[P] {
+	return func(params *P) {
+		PP(params).SetPage(page)
+	}
+}
+
+// WithLimit sets the page size of the list params, even if it is 0.
+func WithLimit[P any, PP interface {
+	*P
+	Paginated
+}](limit int) ListOption[P] {
+	return func(params *P) {
+		PP(params).SetLimit(limit)
+	}
+}
+
+// Pagination records whether the page and limit of list params were set explicitly. The list
+// params keep it in an unexported field, and only send a zero page or limit once it's marked.
+type Pagination struct {
+	pageSet  bool
+	limitSet bool
+}
+
+// MarkPage marks the page as set explicitly.
+func (p *Pagination) MarkPage() {
+	p.pageSet = true
+}
+
+// MarkLimit marks the limit as set explicitly.
+func (p *Pagination) MarkLimit() {
+	p.limitSet = true
+}
+
+// HasPage reports whether the page must be sent to the API.
+func (p Pagination) HasPage(page int) bool {
+	return page != 0 || p.pageSet
+}
+
+// HasLimit reports whether the limit must be sent to the API.
+func (p Pagination) HasLimit(limit int) bool {
+	return limit != 0 || p.limitSet
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testListParams struct {
+	Page  int
+	Limit int
+
+	pagination Pagination
+}
+
+func (p *testListParams) SetPage(page int) {
+	p.Page = page
+	p.pagination.MarkPage()
+}
+
+func (p *testListParams) SetLimit(limit int) {
+	p.Limit = limit
+	p.pagination.MarkLimit()
+}
+
+func (p *testListParams) query() string {
+	parts := make([]string, 0)
+	if p.pagination.HasPage(p.Page) {
+		parts = append(parts, "page="+strconv.Itoa(p.Page))
+	}
+	if p.pagination.HasLimit(p.Limit) {
+		parts = append(parts, "limit="+strconv.Itoa(p.Limit))
+	}
+	return strings.Join(parts, "&")
+}
+
+func TestNewListParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params testListParams
+		want   string
+	}{
+		{"zero values are omitted", testListParams{}, ""},
+		{"fields", testListParams{Page: 2, Limit: 10}, "page=2&limit=10"},
+		{"explicit zero page", NewListParams(WithPage[testListParams](0), WithLimit[testListParams](10)), "page=0&limit=10"},
+		{"explicit zero limit", NewListParams(WithLimit[testListParams](0)), "limit=0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.params.query())
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package common
+
+// ProgressFunc is called while a long-running operation makes progress, with the number
+// of items processed so far and the total number of items, or 0 if it's unknown.
+type ProgressFunc func(processed, total int)
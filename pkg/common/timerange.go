@@ -0,0 +1,52 @@
+package common
+
+import "time"
+
+// TimeRange is a time window to filter the results of list APIs, from From to To.
+// A zero From or To leaves that side of the window open. It's accepted by the
+// WithTimeRange options of the scores, sessions, traces and comments list params, and
+// by annotations.TraceFilter.SetTimeRange.
+//
+// Example:
+//
+//	params := scores.NewListParams(scores.WithTimeRange(common.LastHours(24)))
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// LastHours returns the time window of the last n hours, up to now.
+func LastHours(n int) TimeRange {
+	now := time.Now()
+	return TimeRange{From: now.Add(-time.Duration(n) * time.Hour), To: now}
+}
+
+// LastDays returns the time window of the last n days, up to now.
+func LastDays(n int) TimeRange {
+	now := time.Now()
+	return TimeRange{From: now.AddDate(0, 0, -n), To: now}
+}
+
+// Today returns the time window from the start of the current day, in local time, up to now.
+func Today() TimeRange {
+	return today(time.Now())
+}
+
+// ThisWeek returns the time window from the start of the current week, on Monday in local
+// time, up to now.
+func ThisWeek() TimeRange {
+	return thisWeek(time.Now())
+}
+
+func today(now time.Time) TimeRange {
+	year, month, day := now.Date()
+	return TimeRange{From: time.Date(year, month, day, 0, 0, 0, 0, now.Location()), To: now}
+}
+
+func thisWeek(now time.Time) TimeRange {
+	r := today(now)
+	// time.Sunday is 0, so Sunday is the 7th day of the week
+	weekday := (int(now.Weekday()) + 6) % 7
+	r.From = r.From.AddDate(0, 0, -weekday)
+	return r
+}
@@ -0,0 +1,30 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeRange(t *testing.T) {
+	r := LastHours(24)
+	require.Equal(t, 24*time.Hour, r.To.Sub(r.From))
+	require.WithinDuration(t, time.Now(), r.To, time.Second)
+
+	r = LastDays(7)
+	require.Equal(t, r.To.AddDate(0, 0, -7), r.From)
+
+	// Wednesday
+	now := time.Date(2025, 5, 14, 15, 30, 0, 0, time.UTC)
+	require.Equal(t, TimeRange{From: time.Date(2025, 5, 14, 0, 0, 0, 0, time.UTC), To: now}, today(now))
+	require.Equal(t, TimeRange{From: time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC), To: now}, thisWeek(now))
+
+	// Sunday belongs to the week which started on Monday
+	now = time.Date(2025, 5, 18, 8, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC), thisWeek(now).From)
+
+	// Monday starts a new week
+	now = time.Date(2025, 5, 19, 8, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2025, 5, 19, 0, 0, 0, 0, time.UTC), thisWeek(now).From)
+}
@@ -0,0 +1,80 @@
+package common
+
+// UnitType is the unit in which the usage of an observation is measured.
+type UnitType string
+
+const (
+	UnitCharacters   UnitType = "CHARACTERS"
+	UnitTokens       UnitType = "TOKENS"
+	UnitMilliseconds UnitType = "MILLISECONDS"
+	UnitSeconds      UnitType = "SECONDS"
+	UnitImages       UnitType = "IMAGES"
+	UnitRequests     UnitType = "REQUESTS"
+)
+
+// Keys of the usage details map as used by Langfuse.
+const (
+	UsageKeyInput           = "input"
+	UsageKeyOutput          = "output"
+	UsageKeyTotal           = "total"
+	UsageKeyInputCached     = "input_cached_tokens"
+	UsageKeyOutputReasoning = "output_reasoning_tokens"
+)
+
+// Usage is the token or unit usage of a generation.
+//
+// It's shared by the ingestion, query and metrics APIs so that callers don't need to
+// deal with the different representations used by Langfuse. Only Input, Output, Total
+// and Unit are part of the legacy usage object, the cached input and reasoning output
+// are reported through the usage details, see Details.
+type Usage struct {
+	Input           int      `json:"input,omitempty"`
+	Output          int      `json:"output,omitempty"`
+	Total           int      `json:"total,omitempty"`
+	InputCached     int      `json:"-"`
+	OutputReasoning int      `json:"-"`
+	Unit            UnitType `json:"unit,omitempty"`
+}
+
+// IsZero reports whether no usage was recorded.
+func (u Usage) IsZero() bool {
+	return u.Input == 0 && u.Output == 0 && u.Total == 0 && u.InputCached == 0 && u.OutputReasoning == 0
+}
+
+// Details returns the usage as a Langfuse usage details map, or nil if no usage was recorded.
+func (u Usage) Details() map[string]int {
+	if u.IsZero() {
+		return nil
+	}
+	details := make(map[string]int)
+	for key, value := range map[string]int{
+		UsageKeyInput:           u.Input,
+		UsageKeyOutput:          u.Output,
+		UsageKeyTotal:           u.Total,
+		UsageKeyInputCached:     u.InputCached,
+		UsageKeyOutputReasoning: u.OutputReasoning,
+	} {
+		if value != 0 {
+			details[key] = value
+		}
+	}
+	return details
+}
+
+// UsageFromDetails converts a Langfuse usage details map into a Usage.
+//
+// Unknown keys are ignored, and the total is the sum of the input and output
+// if it's not present in the details.
+func UsageFromDetails(details map[string]int) Usage {
+	usage := Usage{
+		Input:           details[UsageKeyInput],
+		Output:          details[UsageKeyOutput],
+		Total:           details[UsageKeyTotal],
+		InputCached:     details[UsageKeyInputCached],
+		OutputReasoning: details[UsageKeyOutputReasoning],
+	}
+	if _, ok := details[UsageKeyTotal]; !ok {
+		usage.Total = usage.Input + usage.Output
+	}
+	return usage
+}
@@ -0,0 +1,27 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsage_Details(t *testing.T) {
+	require.Nil(t, Usage{Unit: UnitTokens}.Details())
+
+	usage := Usage{Input: 100, Output: 50, Total: 150, InputCached: 80, OutputReasoning: 20}
+	require.Equal(t, map[string]int{
+		"input":                   100,
+		"output":                  50,
+		"total":                   150,
+		"input_cached_tokens":     80,
+		"output_reasoning_tokens": 20,
+	}, usage.Details())
+	require.Equal(t, usage, UsageFromDetails(usage.Details()))
+}
+
+func TestUsageFromDetails(t *testing.T) {
+	usage := UsageFromDetails(map[string]int{"input": 10, "output": 5, "input_audio_tokens": 3})
+	require.Equal(t, Usage{Input: 10, Output: 5, Total: 15}, usage)
+	require.True(t, UsageFromDetails(nil).IsZero())
+}
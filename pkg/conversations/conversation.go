@@ -0,0 +1,167 @@
+// Package conversations models multi-turn chats on top of Langfuse sessions.
+//
+// Each turn of a conversation is a trace in the session of the conversation, with the
+// message history as input, the latest reply as output and the turn index as metadata,
+// so chat applications don't have to repeat this bookkeeping for every message.
+//
+// Example:
+//
+//	conversation := conversations.New(client.StartSession(chatID),
+//		conversations.WithSystemPrompt("You are a helpful assistant."))
+//
+//	turn := conversation.StartTurn(ctx, userMessage)
+//	reply, err := llm.Chat(ctx, turn.Messages())
+//	if err != nil {
+//		turn.EndWithError(err)
+//		return err
+//	}
+//	turn.End(reply)
+package conversations
+
+import (
+	"context"
+	"sync"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+
+	// MetadataTurnKey is the metadata key of the turn index, starting at 1.
+	MetadataTurnKey = "turn"
+
+	defaultTraceName = "conversation-turn"
+)
+
+// Message is a chat message of a conversation.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Option configures a Conversation.
+type Option func(*Conversation)
+
+// WithTraceName sets the name of the turn traces. Default is "conversation-turn".
+func WithTraceName(name string) Option {
+	return func(c *Conversation) {
+		c.traceName = name
+	}
+}
+
+// WithSystemPrompt starts the message history with a system message.
+func WithSystemPrompt(content string) Option {
+	return func(c *Conversation) {
+		c.history = append(c.history, Message{Role: RoleSystem, Content: content})
+	}
+}
+
+// WithHistory starts the message history with earlier messages, e.g. to resume a
+// conversation after a restart. The turn index continues after the user messages.
+func WithHistory(messages ...Message) Option {
+	return func(c *Conversation) {
+		c.history = append(c.history, messages...)
+		for _, message := range messages {
+			if message.Role == RoleUser {
+				c.turns++
+			}
+		}
+	}
+}
+
+// Conversation is a multi-turn chat whose turns are traced in a session.
+//
+// It's safe for concurrent use, but the turns are meant to be sequential: a turn
+// started before the previous one ended doesn't see its reply in the history.
+type Conversation struct {
+	session   *traces.Session
+	traceName string
+
+	mu      sync.Mutex
+	history []Message
+	turns   int
+}
+
+// New creates a conversation whose turns are traced in the given session.
+func New(session *traces.Session, options ...Option) *Conversation {
+	conversation := &Conversation{session: session, traceName: defaultTraceName}
+	for _, option := range options {
+		option(conversation)
+	}
+	return conversation
+}
+
+// SessionID returns the ID of the session of the conversation.
+func (c *Conversation) SessionID() string {
+	return c.session.ID
+}
+
+// History returns a copy of the messages of the conversation.
+func (c *Conversation) History() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Message(nil), c.history...)
+}
+
+// StartTurn adds the user message to the history and starts the trace of the turn,
+// with the message history as input. Additional trace options are applied after the
+// defaults of the conversation.
+func (c *Conversation) StartTurn(ctx context.Context, userMessage string, options ...traces.TraceOption) *Turn {
+	c.mu.Lock()
+	c.turns++
+	index := c.turns
+	c.history = append(c.history, Message{Role: RoleUser, Content: userMessage})
+	messages := append([]Message(nil), c.history...)
+	c.mu.Unlock()
+
+	defaults := []traces.TraceOption{
+		traces.WithInput(messages),
+		traces.WithMetadata(map[string]any{MetadataTurnKey: index}),
+	}
+	trace := c.session.StartTrace(ctx, c.traceName, append(defaults, options...)...)
+	return &Turn{Index: index, Trace: trace, messages: messages, conversation: c}
+}
+
+// Turn is a single user message and reply of a conversation.
+type Turn struct {
+	// Index is the position of the turn in the conversation, starting at 1.
+	Index int
+	// Trace is the trace of the turn, use it to add observations like the LLM generation.
+	Trace *traces.Trace
+
+	messages     []Message
+	conversation *Conversation
+	once         sync.Once
+}
+
+// Messages returns the message history up to and including the user message of the turn,
+// which is usually the input of the LLM call.
+func (t *Turn) Messages() []Message {
+	return append([]Message(nil), t.messages...)
+}
+
+// End adds the reply to the history, records it as the output and ends the trace of the turn.
+// Calling End or EndWithError more than once has no effect.
+func (t *Turn) End(reply string) {
+	t.once.Do(func() {
+		message := Message{Role: RoleAssistant, Content: reply}
+		t.conversation.mu.Lock()
+		t.conversation.history = append(t.conversation.history, message)
+		t.conversation.mu.Unlock()
+
+		t.Trace.Output = message
+		t.Trace.EndAll()
+	})
+}
+
+// EndWithError ends the trace of a turn which failed to produce a reply, recording
+// the error in the output. The user message stays in the history.
+func (t *Turn) EndWithError(err error) {
+	t.once.Do(func() {
+		t.Trace.Output = map[string]any{"error": err.Error()}
+		t.Trace.EndAll()
+	})
+}
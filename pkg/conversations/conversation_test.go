@@ -0,0 +1,67 @@
+package conversations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+func newSession(t *testing.T, id string) *traces.Session {
+	ingestor := traces.NewIngestor(resty.New())
+	t.Cleanup(func() { _ = ingestor.Close() })
+	return ingestor.StartSession(id)
+}
+
+func TestConversation_Turns(t *testing.T) {
+	ctx := context.Background()
+	session := newSession(t, "chat-1")
+	session.SetMetadata("channel", "web")
+	conversation := New(session, WithSystemPrompt("Be brief."), WithTraceName("chat"))
+	require.Equal(t, "chat-1", conversation.SessionID())
+
+	first := conversation.StartTurn(ctx, "Hi")
+	require.Equal(t, 1, first.Index)
+	require.Equal(t, "chat", first.Trace.Name)
+	require.Equal(t, "chat-1", first.Trace.SessionID)
+	require.Equal(t, map[string]any{"channel": "web", MetadataTurnKey: 1}, first.Trace.Metadata)
+	require.Equal(t, []Message{
+		{Role: RoleSystem, Content: "Be brief."},
+		{Role: RoleUser, Content: "Hi"},
+	}, first.Trace.Input)
+	first.End("Hello!")
+	first.End("ignored")
+	require.Equal(t, Message{Role: RoleAssistant, Content: "Hello!"}, first.Trace.Output)
+
+	second := conversation.StartTurn(ctx, "How are you?", traces.WithTags("smalltalk"))
+	require.Equal(t, 2, second.Index)
+	require.Equal(t, []string{"smalltalk"}, second.Trace.Tags)
+	require.Equal(t, []Message{
+		{Role: RoleSystem, Content: "Be brief."},
+		{Role: RoleUser, Content: "Hi"},
+		{Role: RoleAssistant, Content: "Hello!"},
+		{Role: RoleUser, Content: "How are you?"},
+	}, second.Messages())
+	second.EndWithError(errors.New("model overloaded"))
+	require.Equal(t, map[string]any{"error": "model overloaded"}, second.Trace.Output)
+
+	// The failed turn keeps the user message, but has no reply
+	require.Len(t, conversation.History(), 4)
+}
+
+func TestConversation_WithHistory(t *testing.T) {
+	conversation := New(newSession(t, "chat-2"), WithHistory(
+		Message{Role: RoleUser, Content: "Hi"},
+		Message{Role: RoleAssistant, Content: "Hello!"},
+	))
+
+	turn := conversation.StartTurn(context.Background(), "Bye")
+	require.Equal(t, 2, turn.Index)
+	require.Len(t, turn.Messages(), 3)
+	turn.End("Goodbye!")
+	require.Len(t, conversation.History(), 4)
+}
@@ -0,0 +1,31 @@
+package conversations_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/git-hulk/langfuse-go/pkg/conversations"
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+func ExampleConversation() {
+	ingestor := traces.NewIngestor(resty.New(), traces.WithDryRun(io.Discard))
+	defer ingestor.Close()
+
+	conversation := conversations.New(ingestor.StartSession("session-id"),
+		conversations.WithSystemPrompt("You are a helpful assistant."),
+	)
+	turn := conversation.StartTurn(context.Background(), "What is Langfuse?")
+	turn.End("An open source LLM engineering platform.")
+
+	for _, message := range conversation.History() {
+		fmt.Println(message.Role+":", message.Content)
+	}
+	// Output:
+	// system: You are a helpful assistant.
+	// user: What is Langfuse?
+	// assistant: An open source LLM engineering platform.
+}
@@ -10,6 +10,7 @@ package datasets
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -33,6 +34,23 @@ type Dataset struct {
 	ProjectID   string    `json:"projectId"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
+	// Extra holds the response fields which are not known to this SDK version.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements custom JSON unmarshalling for Dataset.
+// Fields which are not known to the SDK are preserved in Extra.
+func (d *Dataset) UnmarshalJSON(data []byte) error {
+	type Alias Dataset
+	if err := json.Unmarshal(data, (*Alias)(d)); err != nil {
+		return err
+	}
+	extra, err := common.UnknownFields(data, Alias{})
+	if err != nil {
+		return err
+	}
+	d.Extra = extra
+	return nil
 }
 
 // CreateDatasetRequest represents the parameters for creating a new dataset.
@@ -59,20 +77,52 @@ func (r *CreateDatasetRequest) validate() error {
 type ListParams struct {
 	Page  int
 	Limit int
+
+	pagination common.Pagination
 }
 
 // ToQueryString converts the ListParams to a URL query string.
 func (query *ListParams) ToQueryString() string {
 	parts := make([]string, 0)
-	if query.Page != 0 {
+	if query.pagination.HasPage(query.Page) {
 		parts = append(parts, "page="+strconv.Itoa(query.Page))
 	}
-	if query.Limit != 0 {
+	if query.pagination.HasLimit(query.Limit) {
 		parts = append(parts, "limit="+strconv.Itoa(query.Limit))
 	}
 	return strings.Join(parts, "&")
 }
 
+// SetPage sets the page number, even if it is 0.
+func (query *ListParams) SetPage(page int) {
+	query.Page = page
+	query.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (query *ListParams) SetLimit(limit int) {
+	query.Limit = limit
+	query.pagination.MarkLimit()
+}
+
+// ListOption configures ListParams, see common.ListOption.
+type ListOption = common.ListOption[ListParams]
+
+// NewListParams creates ListParams for listing datasets from the provided options.
+func NewListParams(options ...ListOption) ListParams {
+	return common.NewListParams(options...)
+}
+
+// WithPage sets the page number, even if it is 0.
+func WithPage(page int) ListOption {
+	return common.WithPage[ListParams](page)
+}
+
+// WithLimit sets the page size, even if it is 0.
+func WithLimit(limit int) ListOption {
+	return common.WithLimit[ListParams](limit)
+}
+
 // ListDatasets represents the paginated response from the list datasets API.
 //
 // It contains pagination metadata and an array of datasets matching the query criteria.
@@ -86,14 +136,19 @@ type ListDatasets struct {
 // The client handles HTTP communication for dataset management operations
 // including creating, retrieving, listing datasets, and managing dataset items and runs.
 type Client struct {
-	restyCli *resty.Client
+	restyCli       *resty.Client
+	itemValidators map[string][]ItemValidator
 }
 
 // NewClient creates a new datasets client with the provided HTTP client.
 //
 // The resty client should be pre-configured with authentication and base URL.
-func NewClient(cli *resty.Client) *Client {
-	return &Client{restyCli: cli}
+func NewClient(cli *resty.Client, options ...ClientOption) *Client {
+	client := &Client{restyCli: cli}
+	for _, option := range options {
+		option(client)
+	}
+	return client
 }
 
 // V2 Datasets API methods
@@ -0,0 +1,41 @@
+package datasets_test
+
+import (
+	"context"
+	"log"
+
+	langfuse "github.com/git-hulk/langfuse-go"
+	"github.com/git-hulk/langfuse-go/pkg/datasets"
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+// This example runs every item of a dataset through the application, and links the
+// resulting traces to a dataset run to compare the runs in the Langfuse UI.
+func ExampleClient_CreateDatasetRunItems() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...")
+	defer client.Close()
+	ctx := context.Background()
+
+	items, err := client.Datasets().ListAllDatasetItems(ctx, datasets.ListDatasetItemParams{DatasetName: "qa"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, item := range items {
+		trace := client.StartTrace(ctx, "qa-eval", traces.WithInput(item.Input))
+		trace.Output = answer(item.Input)
+		trace.End()
+
+		_, err := client.Datasets().CreateDatasetRunItems(ctx, datasets.CreateDatasetRunItemRequest{
+			RunName:       "gpt-4o-baseline",
+			DatasetItemID: item.ID,
+			TraceID:       trace.ID,
+		})
+		if err != nil {
+			log.Printf("failed to link item %s: %v", item.ID, err)
+		}
+	}
+}
+
+func answer(input any) any {
+	return input
+}
@@ -0,0 +1,266 @@
+package datasets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+)
+
+const (
+	defaultExperimentConcurrency = 4
+	defaultExperimentRetryWait   = 500 * time.Millisecond
+	// itemStatusArchived is the status of dataset items which are excluded from new runs.
+	itemStatusArchived = "ARCHIVED"
+)
+
+// ExperimentTask runs the application on a dataset item and returns the ID of the trace
+// it produced, which is linked to the item in the dataset run.
+type ExperimentTask func(ctx context.Context, item DatasetItem) (traceID string, err error)
+
+type experimentConfig struct {
+	concurrency int
+	itemTimeout time.Duration
+	maxRetries  int
+	retryWait   time.Duration
+	description string
+	metadata    any
+}
+
+// ExperimentOption configures RunExperiment.
+type ExperimentOption func(*experimentConfig)
+
+// WithConcurrency sets the number of dataset items processed in parallel, 4 by default.
+func WithConcurrency(concurrency int) ExperimentOption {
+	return func(c *experimentConfig) {
+		c.concurrency = concurrency
+	}
+}
+
+// WithItemTimeout limits the time of each attempt of the task on a dataset item.
+func WithItemTimeout(timeout time.Duration) ExperimentOption {
+	return func(c *experimentConfig) {
+		c.itemTimeout = timeout
+	}
+}
+
+// WithRetries retries a failed task up to maxRetries times, waiting for wait before the
+// first retry and doubling the wait for every further retry. If wait is not positive,
+// it defaults to 500 milliseconds.
+func WithRetries(maxRetries int, wait time.Duration) ExperimentOption {
+	return func(c *experimentConfig) {
+		if wait <= 0 {
+			wait = defaultExperimentRetryWait
+		}
+		c.maxRetries = maxRetries
+		c.retryWait = wait
+	}
+}
+
+// WithRunDescription sets the description of the dataset run.
+func WithRunDescription(description string) ExperimentOption {
+	return func(c *experimentConfig) {
+		c.description = description
+	}
+}
+
+// WithRunMetadata sets the metadata of the dataset run.
+func WithRunMetadata(metadata any) ExperimentOption {
+	return func(c *experimentConfig) {
+		c.metadata = metadata
+	}
+}
+
+// ExperimentResult reports the outcome of an experiment.
+type ExperimentResult struct {
+	RunName string
+	// RunItems holds the run items of the processed dataset items, in the order of the dataset.
+	RunItems []DatasetRunItem
+	// Failed holds the error of each dataset item which couldn't be processed.
+	Failed map[string]error
+	// Canceled holds the IDs of the dataset items which weren't processed because the
+	// context was done before.
+	Canceled []string
+}
+
+// Summary describes the failures of the experiment, grouping the items by error message.
+func (r *ExperimentResult) Summary() string {
+	total := len(r.RunItems) + len(r.Failed) + len(r.Canceled)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d of %d items succeeded, %d failed, %d canceled", len(r.RunItems), total, len(r.Failed), len(r.Canceled))
+
+	itemsByError := make(map[string][]string)
+	for itemID, err := range r.Failed {
+		itemsByError[err.Error()] = append(itemsByError[err.Error()], itemID)
+	}
+	messages := make([]string, 0, len(itemsByError))
+	for message := range itemsByError {
+		messages = append(messages, message)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		if len(itemsByError[messages[i]]) != len(itemsByError[messages[j]]) {
+			return len(itemsByError[messages[i]]) > len(itemsByError[messages[j]])
+		}
+		return messages[i] < messages[j]
+	})
+	for _, message := range messages {
+		itemIDs := itemsByError[message]
+		sort.Strings(itemIDs)
+		fmt.Fprintf(&sb, "\n%d x %s (items: %s)", len(itemIDs), message, strings.Join(itemIDs, ", "))
+	}
+	return sb.String()
+}
+
+func (r *ExperimentResult) err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	itemIDs := make([]string, 0, len(r.Failed))
+	for itemID := range r.Failed {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Strings(itemIDs)
+	errs := make([]error, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		errs = append(errs, fmt.Errorf("dataset item %s: %w", itemID, r.Failed[itemID]))
+	}
+	return errors.Join(errs...)
+}
+
+// RunExperiment runs the task on every active item of the dataset and links the produced
+// traces to the items in the dataset run.
+//
+// The items are processed in parallel, and each attempt of the task can be limited by
+// WithItemTimeout and retried with WithRetries. Failures don't stop the experiment,
+// they're reported in the result and joined in the returned error. When the context
+// is done, the pending items are reported as canceled.
+//
+// Example:
+//
+//	result, err := client.Datasets().RunExperiment(ctx, "qa-dataset", "gpt-4o-2024-06-01",
+//		func(ctx context.Context, item datasets.DatasetItem) (string, error) {
+//			trace := ingestor.StartTrace(ctx, "qa")
+//			defer trace.End()
+//			output, err := answer(ctx, item.Input)
+//			trace.Output = output
+//			return trace.ID, err
+//		},
+//		datasets.WithConcurrency(8),
+//		datasets.WithItemTimeout(time.Minute),
+//		datasets.WithRetries(2, time.Second),
+//	)
+//	if err != nil {
+//		log.Println(result.Summary())
+//	}
+func (c *Client) RunExperiment(ctx context.Context, datasetName, runName string, task ExperimentTask, options ...ExperimentOption) (*ExperimentResult, error) {
+	if datasetName == "" {
+		return nil, errors.New("'datasetName' is required")
+	}
+	if runName == "" {
+		return nil, errors.New("'runName' is required")
+	}
+	if task == nil {
+		return nil, errors.New("'task' is required")
+	}
+	config := &experimentConfig{concurrency: defaultExperimentConcurrency}
+	for _, option := range options {
+		option(config)
+	}
+	if config.concurrency <= 0 {
+		config.concurrency = defaultExperimentConcurrency
+	}
+
+	allItems, err := c.ListAllDatasetItems(ctx, ListDatasetItemParams{DatasetName: datasetName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dataset items: %w", err)
+	}
+	items := make([]DatasetItem, 0, len(allItems))
+	for _, item := range allItems {
+		if item.Status != itemStatusArchived {
+			items = append(items, item)
+		}
+	}
+
+	result := &ExperimentResult{
+		RunName:  runName,
+		RunItems: make([]DatasetRunItem, 0, len(items)),
+		Failed:   make(map[string]error),
+		Canceled: make([]string, 0),
+	}
+	runItems := make([]*DatasetRunItem, len(items))
+	errs := common.ForEach(ctx, len(items), config.concurrency, func(i int) error {
+		runItem, err := c.runExperimentItem(ctx, runName, items[i], task, config)
+		if err != nil && ctx.Err() != nil {
+			// The item was interrupted rather than failed
+			return ctx.Err()
+		}
+		runItems[i] = runItem
+		return err
+	})
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			result.RunItems = append(result.RunItems, *runItems[i])
+		case err == ctx.Err():
+			result.Canceled = append(result.Canceled, items[i].ID)
+		default:
+			result.Failed[items[i].ID] = err
+		}
+	}
+	sort.Strings(result.Canceled)
+	err = result.err()
+	if len(result.Canceled) > 0 {
+		err = errors.Join(err, ctx.Err())
+	}
+	return result, err
+}
+
+// runExperimentItem runs the task on the dataset item, retrying failed attempts, and
+// links the produced trace to the item in the dataset run.
+func (c *Client) runExperimentItem(ctx context.Context, runName string, item DatasetItem, task ExperimentTask, config *experimentConfig) (*DatasetRunItem, error) {
+	wait := config.retryWait
+	var traceID string
+	for attempt := 0; ; attempt++ {
+		var err error
+		traceID, err = runExperimentTask(ctx, item, task, config.itemTimeout)
+		if err == nil {
+			break
+		}
+		if attempt >= config.maxRetries || ctx.Err() != nil {
+			return nil, err
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, err
+		}
+		wait *= 2
+	}
+	return c.CreateDatasetRunItems(ctx, CreateDatasetRunItemRequest{
+		RunName:        runName,
+		RunDescription: config.description,
+		Metadata:       config.metadata,
+		DatasetItemID:  item.ID,
+		TraceID:        traceID,
+	})
+}
+
+func runExperimentTask(ctx context.Context, item DatasetItem, task ExperimentTask, timeout time.Duration) (string, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	traceID, err := task(ctx, item)
+	if err != nil {
+		return "", err
+	}
+	if traceID == "" {
+		return "", errors.New("task returned no trace ID")
+	}
+	return traceID, nil
+}
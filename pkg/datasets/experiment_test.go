@@ -0,0 +1,148 @@
+package datasets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+)
+
+func newExperimentServer(t *testing.T, items []DatasetItem) (*httptest.Server, *sync.Map) {
+	var runItems sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/dataset-items":
+			require.NoError(t, json.NewEncoder(w).Encode(ListDatasetItems{
+				Metadata: common.ListMetadata{Page: 1, TotalPages: 1},
+				Data:     items,
+			}))
+		case "/dataset-run-items":
+			var req CreateDatasetRunItemRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			runItems.Store(req.DatasetItemID, req)
+			require.NoError(t, json.NewEncoder(w).Encode(DatasetRunItem{
+				ID:             "run-item-" + req.DatasetItemID,
+				DatasetRunName: req.RunName,
+				DatasetItemID:  req.DatasetItemID,
+				TraceID:        req.TraceID,
+			}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, &runItems
+}
+
+func TestClient_RunExperiment(t *testing.T) {
+	items := []DatasetItem{
+		{ID: "item-1", Input: "1"},
+		{ID: "item-2", Input: "2"},
+		{ID: "item-3", Input: "3"},
+		{ID: "item-4", Input: "4"},
+		{ID: "item-5", Input: "5", Status: "ARCHIVED"},
+	}
+	server, runItems := newExperimentServer(t, items)
+	defer server.Close()
+
+	var attempts sync.Map
+	var running, maxRunning atomic.Int32
+	task := func(ctx context.Context, item DatasetItem) (string, error) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			seen := maxRunning.Load()
+			if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		count, _ := attempts.LoadOrStore(item.ID, new(atomic.Int32))
+		attempt := count.(*atomic.Int32).Add(1)
+		switch item.ID {
+		case "item-2":
+			// Fails once, then succeeds on retry
+			if attempt == 1 {
+				return "", errors.New("rate limited")
+			}
+		case "item-3":
+			<-ctx.Done()
+			return "", ctx.Err()
+		case "item-4":
+			return "", errors.New("bad input")
+		}
+		time.Sleep(10 * time.Millisecond)
+		return "trace-" + item.ID, nil
+	}
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	result, err := client.RunExperiment(context.Background(), "qa", "nightly", task,
+		WithConcurrency(2),
+		WithItemTimeout(20*time.Millisecond),
+		WithRetries(1, time.Millisecond),
+		WithRunDescription("Nightly eval"),
+	)
+	require.Error(t, err)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.LessOrEqual(t, maxRunning.Load(), int32(2))
+
+	require.Len(t, result.RunItems, 2)
+	require.Equal(t, "item-1", result.RunItems[0].DatasetItemID)
+	require.Equal(t, "trace-item-2", result.RunItems[1].TraceID)
+	require.Len(t, result.Failed, 2)
+	require.EqualError(t, result.Failed["item-4"], "bad input")
+	require.Empty(t, result.Canceled)
+
+	count, _ := attempts.Load("item-4")
+	require.Equal(t, int32(2), count.(*atomic.Int32).Load())
+	_, archived := attempts.Load("item-5")
+	require.False(t, archived)
+
+	req, ok := runItems.Load("item-1")
+	require.True(t, ok)
+	require.Equal(t, "Nightly eval", req.(CreateDatasetRunItemRequest).RunDescription)
+
+	summary := result.Summary()
+	require.Contains(t, summary, "2 of 4 items succeeded, 2 failed, 0 canceled")
+	require.Contains(t, summary, "1 x bad input (items: item-4)")
+}
+
+func TestClient_RunExperiment_Canceled(t *testing.T) {
+	items := []DatasetItem{{ID: "item-1"}, {ID: "item-2"}, {ID: "item-3"}}
+	server, _ := newExperimentServer(t, items)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	task := func(ctx context.Context, item DatasetItem) (string, error) {
+		cancel()
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	result, err := client.RunExperiment(ctx, "qa", "nightly", task, WithConcurrency(1))
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, result.RunItems)
+	require.Empty(t, result.Failed)
+	require.Equal(t, []string{"item-1", "item-2", "item-3"}, result.Canceled)
+}
+
+func TestClient_RunExperiment_Validation(t *testing.T) {
+	client := NewClient(resty.New())
+	task := func(context.Context, DatasetItem) (string, error) { return "", nil }
+
+	_, err := client.RunExperiment(context.Background(), "", "nightly", task)
+	require.EqualError(t, err, "'datasetName' is required")
+	_, err = client.RunExperiment(context.Background(), "qa", "", task)
+	require.EqualError(t, err, "'runName' is required")
+	_, err = client.RunExperiment(context.Background(), "qa", "nightly", nil)
+	require.EqualError(t, err, "'task' is required")
+}
@@ -77,6 +77,8 @@ type ListDatasetItemParams struct {
 	SourceObservationID string
 	Page                int
 	Limit               int
+
+	pagination common.Pagination
 }
 
 // ToQueryString converts the ListDatasetItemParams to a URL query string.
@@ -91,15 +93,27 @@ func (query *ListDatasetItemParams) ToQueryString() string {
 	if query.SourceObservationID != "" {
 		parts = append(parts, "sourceObservationId="+query.SourceObservationID)
 	}
-	if query.Page != 0 {
+	if query.pagination.HasPage(query.Page) {
 		parts = append(parts, "page="+strconv.Itoa(query.Page))
 	}
-	if query.Limit != 0 {
+	if query.pagination.HasLimit(query.Limit) {
 		parts = append(parts, "limit="+strconv.Itoa(query.Limit))
 	}
 	return strings.Join(parts, "&")
 }
 
+// SetPage sets the page number, even if it is 0.
+func (query *ListDatasetItemParams) SetPage(page int) {
+	query.Page = page
+	query.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (query *ListDatasetItemParams) SetLimit(limit int) {
+	query.Limit = limit
+	query.pagination.MarkLimit()
+}
+
 // ListDatasetItems represents the paginated response from the list dataset items API.
 //
 // It contains pagination metadata and an array of dataset items matching the query criteria.
@@ -130,6 +144,39 @@ func (c *Client) GetDatasetItem(ctx context.Context, id string) (*DatasetItem, e
 	return &datasetItem, nil
 }
 
+// itemLookupPageSize is the number of dataset items listed per request by GetDatasetItemByName.
+const itemLookupPageSize = 100
+
+// GetDatasetItemByName retrieves the item with the given ID from the dataset with the given name.
+//
+// The API has no endpoint to get an item scoped to its dataset, so the items of the dataset
+// are listed page by page until the item is found, and an error is returned if the dataset
+// has no such item.
+func (c *Client) GetDatasetItemByName(ctx context.Context, datasetName, itemID string) (*DatasetItem, error) {
+	if datasetName == "" {
+		return nil, errors.New("'datasetName' is required")
+	}
+	if itemID == "" {
+		return nil, errors.New("'itemID' is required")
+	}
+	params := ListDatasetItemParams{DatasetName: datasetName, Limit: itemLookupPageSize}
+	for page := 1; ; page++ {
+		params.Page = page
+		listItems, err := c.ListDatasetItems(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for i := range listItems.Data {
+			if listItems.Data[i].ID == itemID {
+				return &listItems.Data[i], nil
+			}
+		}
+		if len(listItems.Data) == 0 || page >= listItems.Metadata.TotalPages {
+			return nil, fmt.Errorf("dataset item %s not found in dataset %s", itemID, datasetName)
+		}
+	}
+}
+
 // ListDatasetItems retrieves a list of dataset items based on the provided parameters.
 func (c *Client) ListDatasetItems(ctx context.Context, params ListDatasetItemParams) (*ListDatasetItems, error) {
 	var listResponse ListDatasetItems
@@ -148,11 +195,51 @@ func (c *Client) ListDatasetItems(ctx context.Context, params ListDatasetItemPar
 	return &listResponse, nil
 }
 
+// ListAllDatasetItems retrieves the dataset items of all pages matching the parameters,
+// fetching the pages in parallel.
+//
+// The Page of the parameters is ignored, and Limit sets the page size. Use the common.FetchOption
+// values to configure the number of workers, to keep the results in page order, or to report
+// the progress of an export with common.WithFetchProgress.
+func (c *Client) ListAllDatasetItems(ctx context.Context, params ListDatasetItemParams, options ...common.FetchOption) ([]DatasetItem, error) {
+	return common.FetchAll(ctx, func(ctx context.Context, page int) ([]DatasetItem, common.ListMetadata, error) {
+		pageParams := params
+		pageParams.Page = page
+		listItems, err := c.ListDatasetItems(ctx, pageParams)
+		if err != nil {
+			return nil, common.ListMetadata{}, err
+		}
+		return listItems.Data, listItems.Metadata, nil
+	}, options...)
+}
+
+// SyncDatasetItemsSince retrieves the dataset items matching the parameters which were
+// updated after since, and returns them together with the high-watermark to pass as
+// since of the next sync.
+//
+// The API has no time filter for dataset items, so this is a full scan: all the pages matching
+// the parameters are fetched in parallel on every call and filtered by UpdatedAt, and the
+// page of the parameters is ignored. Narrow the parameters, e.g. with SourceTraceID, to
+// reduce the number of fetched pages.
+func (c *Client) SyncDatasetItemsSince(ctx context.Context, since time.Time, params ListDatasetItemParams, options ...common.FetchOption) ([]DatasetItem, time.Time, error) {
+	items, err := c.ListAllDatasetItems(ctx, params, options...)
+	if err != nil {
+		return nil, since, err
+	}
+	updated, watermark := common.UpdatedSince(items, since, func(item DatasetItem) time.Time {
+		return item.UpdatedAt
+	})
+	return updated, watermark, nil
+}
+
 // CreateDatasetItem creates a new dataset item.
 func (c *Client) CreateDatasetItem(ctx context.Context, createDatasetItem *CreateDatasetItemRequest) (*DatasetItem, error) {
 	if err := createDatasetItem.validate(); err != nil {
 		return nil, err
 	}
+	if err := c.validateItem(createDatasetItem); err != nil {
+		return nil, err
+	}
 
 	var createdDatasetItem DatasetItem
 	rsp, err := c.restyCli.R().
@@ -171,6 +258,48 @@ func (c *Client) CreateDatasetItem(ctx context.Context, createDatasetItem *Creat
 	return &createdDatasetItem, nil
 }
 
+// ImportDatasetItems creates the dataset items one after the other, and reports the number
+// of created items to the optional progress function after each item.
+//
+// The import stops at the first failure or when the context is done, and the returned items
+// are the ones created so far, so the import can be resumed with the remaining requests.
+// All the items are validated before the first one is created, see WithItemValidator.
+//
+// Example:
+//
+//	created, err := client.ImportDatasetItems(ctx, requests, func(processed, total int) {
+//		log.Printf("imported %d/%d items", processed, total)
+//	})
+//	if err != nil {
+//		requests = requests[len(created):]
+//	}
+func (c *Client) ImportDatasetItems(ctx context.Context, requests []*CreateDatasetItemRequest, progress common.ProgressFunc) ([]DatasetItem, error) {
+	for i, request := range requests {
+		if err := request.validate(); err != nil {
+			return nil, fmt.Errorf("invalid dataset item %d: %w", i, err)
+		}
+		if err := c.validateItem(request); err != nil {
+			return nil, fmt.Errorf("dataset item %d: %w", i, err)
+		}
+	}
+
+	created := make([]DatasetItem, 0, len(requests))
+	for i, request := range requests {
+		if err := ctx.Err(); err != nil {
+			return created, err
+		}
+		item, err := c.CreateDatasetItem(ctx, request)
+		if err != nil {
+			return created, fmt.Errorf("failed to import dataset item %d: %w", i, err)
+		}
+		created = append(created, *item)
+		if progress != nil {
+			progress(len(created), len(requests))
+		}
+	}
+	return created, nil
+}
+
 // DeleteDatasetItem deletes a dataset item by ID.
 func (c *Client) DeleteDatasetItem(ctx context.Context, id string) error {
 	if id == "" {
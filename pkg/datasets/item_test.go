@@ -3,8 +3,10 @@ package datasets
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/go-resty/resty/v2"
@@ -399,6 +401,68 @@ func TestDatasetItemClient_List(t *testing.T) {
 	})
 }
 
+func TestDatasetItemClient_ImportDatasetItems(t *testing.T) {
+	ctx := context.Background()
+	requests := []*CreateDatasetItemRequest{
+		{DatasetName: "qa", Input: "q1"},
+		{DatasetName: "qa", Input: "q2"},
+		{DatasetName: "qa", Input: "q3"},
+	}
+
+	t.Run("successful import", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/dataset-items", r.URL.Path)
+			var req CreateDatasetItemRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			w.Header().Set("Content-Type", "application/json")
+			require.NoError(t, json.NewEncoder(w).Encode(DatasetItem{ID: req.Input.(string), DatasetName: req.DatasetName}))
+		}))
+		defer server.Close()
+
+		reported := make([]int, 0)
+		created, err := NewClient(resty.New().SetBaseURL(server.URL)).ImportDatasetItems(ctx, requests, func(processed, total int) {
+			require.Equal(t, 3, total)
+			reported = append(reported, processed)
+		})
+		require.NoError(t, err)
+		require.Len(t, created, 3)
+		require.Equal(t, "q3", created[2].ID)
+		require.Equal(t, []int{1, 2, 3}, reported)
+	})
+
+	t.Run("partial import", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req CreateDatasetItemRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Input == "q2" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			require.NoError(t, json.NewEncoder(w).Encode(DatasetItem{ID: req.Input.(string)}))
+		}))
+		defer server.Close()
+
+		created, err := NewClient(resty.New().SetBaseURL(server.URL)).ImportDatasetItems(ctx, requests, nil)
+		require.ErrorContains(t, err, "failed to import dataset item 1")
+		require.Len(t, created, 1)
+		require.Equal(t, "q1", created[0].ID)
+	})
+
+	t.Run("canceled import", func(t *testing.T) {
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		created, err := NewClient(resty.New()).ImportDatasetItems(canceledCtx, requests, nil)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Empty(t, created)
+	})
+
+	t.Run("invalid item", func(t *testing.T) {
+		_, err := NewClient(resty.New()).ImportDatasetItems(ctx, []*CreateDatasetItemRequest{{}}, nil)
+		require.EqualError(t, err, "invalid dataset item 0: 'datasetName' is required")
+	})
+}
+
 func TestDatasetItemClient_Delete(t *testing.T) {
 	ctx := context.Background()
 
@@ -499,3 +563,35 @@ func TestDatasetItemClient_Delete(t *testing.T) {
 		require.Contains(t, err.Error(), "400")
 	})
 }
+
+func TestDatasetItemClient_GetDatasetItemByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/dataset-items", r.URL.Path)
+		require.Equal(t, "test-dataset", r.URL.Query().Get("datasetName"))
+		require.Equal(t, "100", r.URL.Query().Get("limit"))
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		require.NoError(t, err)
+		itemID := fmt.Sprintf("item-%d", page)
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(ListDatasetItems{
+			Metadata: common.ListMetadata{Page: page, Limit: 100, TotalItems: 2, TotalPages: 2},
+			Data:     []DatasetItem{{ID: itemID, DatasetName: "test-dataset"}},
+		}))
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	item, err := client.GetDatasetItemByName(context.Background(), "test-dataset", "item-2")
+	require.NoError(t, err)
+	require.Equal(t, "item-2", item.ID)
+
+	_, err = client.GetDatasetItemByName(context.Background(), "test-dataset", "item-3")
+	require.EqualError(t, err, "dataset item item-3 not found in dataset test-dataset")
+
+	_, err = client.GetDatasetItemByName(context.Background(), "", "item-1")
+	require.EqualError(t, err, "'datasetName' is required")
+
+	_, err = client.GetDatasetItemByName(context.Background(), "test-dataset", "")
+	require.EqualError(t, err, "'itemID' is required")
+}
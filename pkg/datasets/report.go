@@ -0,0 +1,314 @@
+package datasets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/git-hulk/langfuse-go/pkg/scores"
+)
+
+// reportValueLength is the maximum length of an input or output shown in a report.
+const reportValueLength = 80
+
+// RunReport summarizes the results of a dataset run, e.g. to publish eval results as a CI artifact.
+type RunReport struct {
+	DatasetName string
+	RunName     string
+	Description string
+	CreatedAt   time.Time
+	Items       []RunReportItem
+	Scores      []RunReportScore
+	Latency     LatencySummary
+	TotalCost   float64
+	// Errors is the number of items whose trace couldn't be retrieved.
+	Errors int
+}
+
+// RunReportItem is a dataset item of a run together with the trace it produced.
+type RunReportItem struct {
+	DatasetItemID  string
+	TraceID        string
+	Input          any
+	ExpectedOutput any
+	Output         any
+	Latency        time.Duration
+	Cost           float64
+	Scores         []scores.Score
+	// Error is set when the trace of the item couldn't be retrieved.
+	Error string
+}
+
+// RunReportScore holds the statistics of a score across the items of a run.
+type RunReportScore struct {
+	Name  string
+	Stats *scores.ScoreStats
+}
+
+// LatencySummary summarizes the latencies of the traces of a run.
+type LatencySummary struct {
+	Mean time.Duration
+	P50  time.Duration
+	P90  time.Duration
+	P99  time.Duration
+	Max  time.Duration
+}
+
+// reportTrace holds the trace fields used by the report.
+type reportTrace struct {
+	Output    any     `json:"output"`
+	Latency   float64 `json:"latency"` // in seconds
+	TotalCost float64 `json:"totalCost"`
+}
+
+// RunReport assembles a report of a dataset run from its items, traces and scores.
+//
+// The trace of every run item is retrieved to read its output, latency and cost. Items whose
+// trace can't be retrieved are kept in the report with their Error set.
+//
+// Example:
+//
+//	report, err := client.Datasets().RunReport(ctx, "qa-dataset", "nightly-2024-06-01")
+//	if err != nil {
+//		return err
+//	}
+//	return report.WriteMarkdown(os.Stdout)
+func (c *Client) RunReport(ctx context.Context, datasetName, runName string) (*RunReport, error) {
+	// The run items whose scores can't be fetched are reported with the error of their trace
+	run, _, err := c.getRunItemsWithScores(ctx, datasetName, runName)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	datasetItems, err := c.ListAllDatasetItems(ctx, ListDatasetItemParams{DatasetName: datasetName})
+	if err != nil {
+		return nil, err
+	}
+	itemsByID := make(map[string]*DatasetItem, len(datasetItems))
+	for i := range datasetItems {
+		itemsByID[datasetItems[i].ID] = &datasetItems[i]
+	}
+
+	report := &RunReport{
+		DatasetName: datasetName,
+		RunName:     run.Name,
+		Description: run.Description,
+		CreatedAt:   run.CreatedAt,
+		Items:       make([]RunReportItem, 0, len(run.Items)),
+	}
+	var allScores []scores.Score
+	var latencies []time.Duration
+	for _, runItem := range run.Items {
+		item := RunReportItem{
+			DatasetItemID: runItem.DatasetItemID,
+			TraceID:       runItem.TraceID,
+			Scores:        runItem.Scores,
+		}
+		if datasetItem, ok := itemsByID[runItem.DatasetItemID]; ok {
+			item.Input = datasetItem.Input
+			item.ExpectedOutput = datasetItem.ExpectedOutput
+		}
+		trace, err := c.getReportTrace(ctx, runItem.TraceID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			item.Error = err.Error()
+			report.Errors++
+		} else {
+			item.Output = trace.Output
+			item.Latency = time.Duration(trace.Latency * float64(time.Second))
+			item.Cost = trace.TotalCost
+			report.TotalCost += trace.TotalCost
+			latencies = append(latencies, item.Latency)
+		}
+		allScores = append(allScores, runItem.Scores...)
+		report.Items = append(report.Items, item)
+	}
+
+	stats := scores.Aggregate(allScores, scores.GroupByName)
+	for _, name := range scores.SortedKeys(stats) {
+		report.Scores = append(report.Scores, RunReportScore{Name: name, Stats: stats[name]})
+	}
+	report.Latency = summarizeLatencies(latencies)
+	return report, nil
+}
+
+func (c *Client) getReportTrace(ctx context.Context, traceID string) (*reportTrace, error) {
+	if traceID == "" {
+		return nil, errors.New("run item has no trace")
+	}
+	var trace reportTrace
+	rsp, err := c.restyCli.R().
+		SetContext(ctx).
+		SetResult(&trace).
+		SetPathParam("traceID", traceID).
+		Get("/traces/{traceID}")
+	if err != nil {
+		return nil, err
+	}
+	if rsp.IsError() {
+		return nil, fmt.Errorf("get trace failed: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	}
+	return &trace, nil
+}
+
+func summarizeLatencies(latencies []time.Duration) LatencySummary {
+	if len(latencies) == 0 {
+		return LatencySummary{}
+	}
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+	var sum time.Duration
+	for _, latency := range sorted {
+		sum += latency
+	}
+	percentile := func(p float64) time.Duration {
+		// Nearest-rank percentile
+		rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		return sorted[max(rank, 0)]
+	}
+	return LatencySummary{
+		Mean: sum / time.Duration(len(sorted)),
+		P50:  percentile(50),
+		P90:  percentile(90),
+		P99:  percentile(99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// WriteMarkdown renders the report as Markdown, e.g. for a pull request comment.
+func (r *RunReport) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Dataset run report: %s / %s\n\n", r.DatasetName, r.RunName)
+	if r.Description != "" {
+		fmt.Fprintf(&sb, "%s\n\n", r.Description)
+	}
+	fmt.Fprintf(&sb, "- Items: %d (%d errors)\n", len(r.Items), r.Errors)
+	fmt.Fprintf(&sb, "- Total cost: $%.4f\n", r.TotalCost)
+	fmt.Fprintf(&sb, "- Latency: mean %s, p50 %s, p90 %s, p99 %s, max %s\n",
+		r.Latency.Mean, r.Latency.P50, r.Latency.P90, r.Latency.P99, r.Latency.Max)
+
+	if len(r.Scores) > 0 {
+		sb.WriteString("\n## Scores\n\n| Score | Count | Summary |\n| --- | --- | --- |\n")
+		for _, score := range r.Scores {
+			fmt.Fprintf(&sb, "| %s | %d | %s |\n", escapeMarkdown(score.Name), score.Stats.Count, escapeMarkdown(summarizeStats(score.Stats)))
+		}
+	}
+
+	sb.WriteString("\n## Items\n\n| Item | Trace | Input | Expected | Output | Latency | Cost | Scores |\n| --- | --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, item := range r.Items {
+		output := formatReportValue(item.Output)
+		if item.Error != "" {
+			output = "error: " + truncate(item.Error)
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s | $%.4f | %s |\n",
+			escapeMarkdown(item.DatasetItemID), escapeMarkdown(item.TraceID),
+			escapeMarkdown(formatReportValue(item.Input)), escapeMarkdown(formatReportValue(item.ExpectedOutput)),
+			escapeMarkdown(output), item.Latency, item.Cost, escapeMarkdown(formatItemScores(item.Scores)))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"value":   formatReportValue,
+	"scores":  formatItemScores,
+	"summary": summarizeStats,
+	"cost":    func(cost float64) string { return fmt.Sprintf("$%.4f", cost) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Dataset run report: {{.DatasetName}} / {{.RunName}}</title></head>
+<body>
+<h1>Dataset run report: {{.DatasetName}} / {{.RunName}}</h1>
+{{if .Description}}<p>{{.Description}}</p>
+{{end}}<ul>
+<li>Items: {{len .Items}} ({{.Errors}} errors)</li>
+<li>Total cost: {{cost .TotalCost}}</li>
+<li>Latency: mean {{.Latency.Mean}}, p50 {{.Latency.P50}}, p90 {{.Latency.P90}}, p99 {{.Latency.P99}}, max {{.Latency.Max}}</li>
+</ul>
+{{if .Scores}}<h2>Scores</h2>
+<table>
+<tr><th>Score</th><th>Count</th><th>Summary</th></tr>
+{{range .Scores}}<tr><td>{{.Name}}</td><td>{{.Stats.Count}}</td><td>{{summary .Stats}}</td></tr>
+{{end}}</table>
+{{end}}<h2>Items</h2>
+<table>
+<tr><th>Item</th><th>Trace</th><th>Input</th><th>Expected</th><th>Output</th><th>Latency</th><th>Cost</th><th>Scores</th></tr>
+{{range .Items}}<tr><td>{{.DatasetItemID}}</td><td>{{.TraceID}}</td><td>{{value .Input}}</td><td>{{value .ExpectedOutput}}</td><td>{{if .Error}}error: {{.Error}}{{else}}{{value .Output}}{{end}}</td><td>{{.Latency}}</td><td>{{cost .Cost}}</td><td>{{scores .Scores}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// WriteHTML renders the report as a standalone HTML page.
+func (r *RunReport) WriteHTML(w io.Writer) error {
+	return reportTemplate.Execute(w, r)
+}
+
+func summarizeStats(stats *scores.ScoreStats) string {
+	var parts []string
+	if stats.NumericCount > 0 {
+		parts = append(parts, fmt.Sprintf("mean %.3f, median %.3f, p90 %.3f, min %.3f, max %.3f",
+			stats.Mean, stats.Median, stats.Percentile(90), stats.Min, stats.Max))
+	}
+	if stats.BooleanCount > 0 {
+		parts = append(parts, fmt.Sprintf("pass rate %.1f%% (%d/%d)", stats.PassRate*100, stats.PassCount, stats.BooleanCount))
+	}
+	if len(stats.Distribution) > 0 {
+		categories := make([]string, 0, len(stats.Distribution))
+		for category := range stats.Distribution {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+		for i, category := range categories {
+			categories[i] = fmt.Sprintf("%s: %d", category, stats.Distribution[category])
+		}
+		parts = append(parts, strings.Join(categories, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatItemScores(itemScores []scores.Score) string {
+	parts := make([]string, 0, len(itemScores))
+	for _, score := range itemScores {
+		parts = append(parts, fmt.Sprintf("%s=%s", score.Name, formatReportValue(score.Value)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatReportValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return truncate(v)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return truncate(fmt.Sprint(value))
+	}
+	return truncate(string(data))
+}
+
+func truncate(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if runes := []rune(s); len(runes) > reportValueLength {
+		return string(runes[:reportValueLength-3]) + "..."
+	}
+	return s
+}
+
+func escapeMarkdown(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,97 @@
+package datasets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+	"github.com/git-hulk/langfuse-go/pkg/scores"
+)
+
+func TestClient_RunReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var body any
+		switch r.URL.Path {
+		case "/datasets/qa/runs/nightly":
+			body = DatasetRunWithItems{
+				DatasetRun: DatasetRun{Name: "nightly", Description: "Nightly eval", CreatedAt: mustParseTime("2024-06-01T00:00:00Z")},
+				DatasetRunItems: []DatasetRunItem{
+					{DatasetItemID: "item-1", TraceID: "trace-1"},
+					{DatasetItemID: "item-2", TraceID: "trace-2"},
+					{DatasetItemID: "item-3", TraceID: "trace-missing"},
+				},
+			}
+		case "/dataset-items":
+			require.Equal(t, "qa", r.URL.Query().Get("datasetName"))
+			body = ListDatasetItems{
+				Metadata: common.ListMetadata{Page: 1, TotalPages: 1},
+				Data: []DatasetItem{
+					{ID: "item-1", Input: "What is 1+1?", ExpectedOutput: "2"},
+					{ID: "item-2", Input: map[string]any{"question": "a|b"}, ExpectedOutput: "3"},
+				},
+			}
+		case "/traces/trace-1":
+			body = map[string]any{"output": "2", "latency": 1.5, "totalCost": 0.01, "scores": []scores.Score{
+				{Name: "accuracy", DataType: scores.ScoreDataTypeNumeric, Value: 1.0, TraceID: "trace-1"},
+			}}
+		case "/traces/trace-2":
+			body = map[string]any{"output": "4", "latency": 0.5, "totalCost": 0.02, "scores": []scores.Score{
+				{Name: "accuracy", DataType: scores.ScoreDataTypeNumeric, Value: 0.5, TraceID: "trace-2"},
+			}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			body = map[string]any{"message": "not found"}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	report, err := client.RunReport(context.Background(), "qa", "nightly")
+	require.NoError(t, err)
+	require.Equal(t, "nightly", report.RunName)
+	require.Len(t, report.Items, 3)
+	require.Equal(t, 1, report.Errors)
+	require.InDelta(t, 0.03, report.TotalCost, 1e-9)
+	require.Equal(t, time.Second, report.Latency.Mean)
+	require.Equal(t, 1500*time.Millisecond, report.Latency.Max)
+
+	item := report.Items[0]
+	require.Equal(t, "What is 1+1?", item.Input)
+	require.Equal(t, "2", item.ExpectedOutput)
+	require.Equal(t, "2", item.Output)
+	require.Equal(t, 1500*time.Millisecond, item.Latency)
+	require.Len(t, item.Scores, 1)
+	require.NotEmpty(t, report.Items[2].Error)
+
+	require.Len(t, report.Scores, 1)
+	require.Equal(t, "accuracy", report.Scores[0].Name)
+	require.InDelta(t, 0.75, report.Scores[0].Stats.Mean, 1e-9)
+
+	var markdown bytes.Buffer
+	require.NoError(t, report.WriteMarkdown(&markdown))
+	require.Contains(t, markdown.String(), "# Dataset run report: qa / nightly")
+	require.Contains(t, markdown.String(), "| accuracy | 2 | mean 0.750")
+	require.Contains(t, markdown.String(), `{"question":"a\|b"}`)
+	require.Contains(t, markdown.String(), "| item-3 | trace-missing |")
+
+	var html bytes.Buffer
+	require.NoError(t, report.WriteHTML(&html))
+	require.Contains(t, html.String(), "<h1>Dataset run report: qa / nightly</h1>")
+	require.Contains(t, html.String(), "<td>What is 1&#43;1?</td>")
+}
+
+func TestClient_RunReport_Validation(t *testing.T) {
+	client := NewClient(resty.New())
+	_, err := client.RunReport(context.Background(), "", "nightly")
+	require.EqualError(t, err, "'datasetName' is required")
+}
@@ -9,8 +9,12 @@ import (
 	"time"
 
 	"github.com/git-hulk/langfuse-go/pkg/common"
+	"github.com/git-hulk/langfuse-go/pkg/scores"
 )
 
+// defaultRunScoresWorkers is the number of traces whose scores are fetched in parallel.
+const defaultRunScoresWorkers = 8
+
 // DatasetRun represents an execution run against a dataset.
 //
 // A dataset run tracks the evaluation or processing of dataset items
@@ -51,6 +55,18 @@ type DatasetRunWithItems struct {
 	DatasetRunItems []DatasetRunItem `json:"datasetRunItems"`
 }
 
+// DatasetRunItemWithScores represents a dataset run item together with the scores of its trace.
+type DatasetRunItemWithScores struct {
+	DatasetRunItem
+	Scores []scores.Score `json:"scores"`
+}
+
+// DatasetRunWithScores represents a dataset run whose items are joined with the scores of their traces.
+type DatasetRunWithScores struct {
+	DatasetRun
+	Items []DatasetRunItemWithScores `json:"items"`
+}
+
 // ListDatasetRuns represents the paginated response from the list dataset runs API.
 //
 // It contains pagination metadata and an array of dataset runs matching the query criteria.
@@ -93,15 +109,17 @@ type ListDatasetRunItemsParams struct {
 	RunName   string `json:"runName"`
 	Page      int    `json:"page"`
 	Limit     int    `json:"limit"`
+
+	pagination common.Pagination
 }
 
 // ToQueryString converts the ListDatasetRunItemsParams to a URL query string.
 func (p *ListDatasetRunItemsParams) ToQueryString() string {
 	parts := url.Values{}
-	if p.Page != 0 {
+	if p.pagination.HasPage(p.Page) {
 		parts.Add("page", strconv.Itoa(p.Page))
 	}
-	if p.Limit != 0 {
+	if p.pagination.HasLimit(p.Limit) {
 		parts.Add("limit", strconv.Itoa(p.Limit))
 	}
 	if p.RunName != "" {
@@ -113,6 +131,18 @@ func (p *ListDatasetRunItemsParams) ToQueryString() string {
 	return parts.Encode()
 }
 
+// SetPage sets the page number, even if it is 0.
+func (p *ListDatasetRunItemsParams) SetPage(page int) {
+	p.Page = page
+	p.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (p *ListDatasetRunItemsParams) SetLimit(limit int) {
+	p.Limit = limit
+	p.pagination.MarkLimit()
+}
+
 // ListDatasetRunItems represents the paginated response from the list dataset runs API.
 //
 // It contains pagination metadata and an array of datasets matching the query criteria.
@@ -245,3 +275,72 @@ func (c *Client) ListDatasetRunItems(ctx context.Context, params ListDatasetRunI
 	}
 	return &listResponse, nil
 }
+
+// GetRunItemsWithScores retrieves a dataset run and joins each run item with the scores of its trace.
+//
+// The scores are fetched trace by trace, with several traces in parallel, so that the
+// number of requests follows the size of the run rather than the number of scores of the project.
+func (c *Client) GetRunItemsWithScores(ctx context.Context, datasetName, runName string) (*DatasetRunWithScores, error) {
+	result, traceErrs, err := c.getRunItemsWithScores(ctx, datasetName, runName)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(traceErrs) > 0 {
+		return nil, errors.Join(traceErrs...)
+	}
+	return result, nil
+}
+
+// getRunItemsWithScores retrieves a dataset run with the scores of its traces, and the errors of
+// the traces whose scores couldn't be fetched, whose run items are left without scores.
+func (c *Client) getRunItemsWithScores(ctx context.Context, datasetName, runName string) (*DatasetRunWithScores, []error, error) {
+	datasetRun, err := c.GetDatasetRun(ctx, datasetName, runName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := &DatasetRunWithScores{
+		DatasetRun: datasetRun.DatasetRun,
+		Items:      make([]DatasetRunItemWithScores, 0, len(datasetRun.DatasetRunItems)),
+	}
+	traceIDs := make([]string, 0, len(datasetRun.DatasetRunItems))
+	itemIndexes := make(map[string][]int, len(datasetRun.DatasetRunItems))
+	for i, item := range datasetRun.DatasetRunItems {
+		result.Items = append(result.Items, DatasetRunItemWithScores{
+			DatasetRunItem: item,
+			Scores:         make([]scores.Score, 0),
+		})
+		if item.TraceID == "" {
+			continue
+		}
+		if _, ok := itemIndexes[item.TraceID]; !ok {
+			traceIDs = append(traceIDs, item.TraceID)
+		}
+		itemIndexes[item.TraceID] = append(itemIndexes[item.TraceID], i)
+	}
+
+	scoresCli := scores.NewClient(c.restyCli)
+	traceScores := make([][]scores.Score, len(traceIDs))
+	errs := common.ForEach(ctx, len(traceIDs), defaultRunScoresWorkers, func(i int) error {
+		listScores, err := scoresCli.ListByTrace(ctx, traceIDs[i])
+		if err != nil {
+			return fmt.Errorf("failed to list the scores of trace %s: %w", traceIDs[i], err)
+		}
+		traceScores[i] = listScores
+		return nil
+	})
+	var traceErrs []error
+	for i, traceID := range traceIDs {
+		if errs[i] != nil {
+			traceErrs = append(traceErrs, errs[i])
+			continue
+		}
+		for _, index := range itemIndexes[traceID] {
+			result.Items[index].Scores = append(result.Items[index].Scores, traceScores[i]...)
+		}
+	}
+	return result, traceErrs, nil
+}
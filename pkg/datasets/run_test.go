@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"sync/atomic"
 	"testing"
 
 	"github.com/go-resty/resty/v2"
@@ -731,3 +732,70 @@ func TestListDatasetRunItemsParams_ToQueryString(t *testing.T) {
 		require.Contains(t, queryStr, "runName=run+with+spaces+%26+symbols")
 	})
 }
+
+func TestClient_GetRunItemsWithScores(t *testing.T) {
+	var scoresRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/datasets/test-dataset/runs/test-run":
+			require.NoError(t, json.NewEncoder(w).Encode(DatasetRunWithItems{
+				DatasetRun: DatasetRun{
+					ID:        "run-123",
+					Name:      "test-run",
+					CreatedAt: mustParseTime("2023-01-01T10:00:00Z"),
+				},
+				DatasetRunItems: []DatasetRunItem{
+					{ID: "item-1", TraceID: "trace-1", CreatedAt: mustParseTime("2023-01-01T09:00:00Z")},
+					{ID: "item-2", TraceID: "trace-2", CreatedAt: mustParseTime("2023-01-01T10:00:00Z")},
+					{ID: "item-3", TraceID: "trace-1", CreatedAt: mustParseTime("2023-01-01T10:00:00Z")},
+				},
+			}))
+		case "/traces/trace-1":
+			scoresRequests.Add(1)
+			_, _ = w.Write([]byte(`{"id":"trace-1","scores":[
+				{"id":"score-1","traceId":"trace-1","name":"accuracy","value":0.9},
+				{"id":"score-3","traceId":"trace-1","name":"latency","value":1.5}]}`))
+		case "/traces/trace-2":
+			scoresRequests.Add(1)
+			_, _ = w.Write([]byte(`{"id":"trace-2","scores":[]}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	result, err := client.GetRunItemsWithScores(context.Background(), "test-dataset", "test-run")
+	require.NoError(t, err)
+	require.Equal(t, "run-123", result.ID)
+	require.Len(t, result.Items, 3)
+	require.Len(t, result.Items[0].Scores, 2)
+	require.Equal(t, "score-1", result.Items[0].Scores[0].ID)
+	require.Equal(t, "score-3", result.Items[0].Scores[1].ID)
+	require.Empty(t, result.Items[1].Scores)
+	require.Equal(t, result.Items[0].Scores, result.Items[2].Scores)
+	// The scores of each trace are fetched once
+	require.EqualValues(t, 2, scoresRequests.Load())
+}
+
+func TestClient_GetRunItemsWithScores_TraceError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/datasets/test-dataset/runs/test-run":
+			require.NoError(t, json.NewEncoder(w).Encode(DatasetRunWithItems{
+				DatasetRun:      DatasetRun{ID: "run-123", Name: "test-run"},
+				DatasetRunItems: []DatasetRunItem{{ID: "item-1", TraceID: "trace-missing"}},
+			}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	_, err := client.GetRunItemsWithScores(context.Background(), "test-dataset", "test-run")
+	require.ErrorContains(t, err, "failed to list the scores of trace trace-missing")
+}
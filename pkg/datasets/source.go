@@ -0,0 +1,123 @@
+package datasets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// sourceItemConfig holds the options of AddItemFromTrace.
+type sourceItemConfig struct {
+	observationID     string
+	expectedOutput    any
+	hasExpectedOutput bool
+	metadata          any
+	status            string
+}
+
+// SourceItemOption configures AddItemFromTrace.
+type SourceItemOption func(*sourceItemConfig)
+
+// WithSourceObservation creates the item from the input and output of an observation of
+// the trace instead of the trace itself, and links the item to the observation.
+func WithSourceObservation(observationID string) SourceItemOption {
+	return func(config *sourceItemConfig) {
+		config.observationID = observationID
+	}
+}
+
+// WithExpectedOutput sets the expected output of the item instead of the recorded output,
+// e.g. a corrected answer. Pass nil to create an item without expected output.
+func WithExpectedOutput(expectedOutput any) SourceItemOption {
+	return func(config *sourceItemConfig) {
+		config.expectedOutput = expectedOutput
+		config.hasExpectedOutput = true
+	}
+}
+
+// WithItemMetadata sets the metadata of the item.
+func WithItemMetadata(metadata any) SourceItemOption {
+	return func(config *sourceItemConfig) {
+		config.metadata = metadata
+	}
+}
+
+// WithItemStatus sets the status of the item, e.g. ARCHIVED. Default is ACTIVE.
+func WithItemStatus(status string) SourceItemOption {
+	return func(config *sourceItemConfig) {
+		config.status = status
+	}
+}
+
+// sourceRecord is the input and output of a trace or an observation.
+type sourceRecord struct {
+	TraceID string `json:"traceId"`
+	Input   any    `json:"input"`
+	Output  any    `json:"output"`
+}
+
+// AddItemFromTrace creates a dataset item from the input and output of a trace, linked to
+// the trace by its sourceTraceId, e.g. to turn an interesting production case into an
+// evaluation fixture. The recorded output becomes the expected output unless it's
+// replaced with WithExpectedOutput.
+//
+// Example:
+//
+//	item, err := client.AddItemFromTrace(ctx, "regressions", traceID,
+//		datasets.WithExpectedOutput("The refund was issued on May 2nd."))
+func (c *Client) AddItemFromTrace(ctx context.Context, datasetName, traceID string, options ...SourceItemOption) (*DatasetItem, error) {
+	if datasetName == "" {
+		return nil, errors.New("'datasetName' is required")
+	}
+	if traceID == "" {
+		return nil, errors.New("'traceID' is required")
+	}
+	config := &sourceItemConfig{}
+	for _, option := range options {
+		option(config)
+	}
+
+	var source *sourceRecord
+	var err error
+	if config.observationID != "" {
+		source, err = c.getSource(ctx, "/observations/{id}", config.observationID, "observation")
+		if err == nil && source.TraceID != traceID {
+			err = fmt.Errorf("observation %s does not belong to trace %s", config.observationID, traceID)
+		}
+	} else {
+		source, err = c.getSource(ctx, "/traces/{id}", traceID, "trace")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	expectedOutput := source.Output
+	if config.hasExpectedOutput {
+		expectedOutput = config.expectedOutput
+	}
+	return c.CreateDatasetItem(ctx, &CreateDatasetItemRequest{
+		DatasetName:         datasetName,
+		Input:               source.Input,
+		ExpectedOutput:      expectedOutput,
+		Metadata:            config.metadata,
+		SourceTraceID:       traceID,
+		SourceObservationID: config.observationID,
+		Status:              config.status,
+	})
+}
+
+func (c *Client) getSource(ctx context.Context, path, id, kind string) (*sourceRecord, error) {
+	var source sourceRecord
+	rsp, err := c.restyCli.R().
+		SetContext(ctx).
+		SetResult(&source).
+		SetPathParam("id", id).
+		Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.IsError() {
+		return nil, fmt.Errorf("get %s failed: %s, got status code: %d", kind, rsp.String(), rsp.StatusCode())
+	}
+	return &source, nil
+}
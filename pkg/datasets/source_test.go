@@ -0,0 +1,66 @@
+package datasets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_AddItemFromTrace(t *testing.T) {
+	var created []CreateDatasetItemRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/traces/trace-1":
+			_, _ = w.Write([]byte(`{"id": "trace-1", "input": {"question": "refund?"}, "output": "issued"}`))
+		case "/observations/gen-1":
+			_, _ = w.Write([]byte(`{"id": "gen-1", "traceId": "trace-1", "input": "prompt", "output": "completion"}`))
+		case "/dataset-items":
+			var request CreateDatasetItemRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			created = append(created, request)
+			_, _ = w.Write([]byte(`{"id": "item-1", "datasetName": "regressions"}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	ctx := context.Background()
+
+	item, err := client.AddItemFromTrace(ctx, "regressions", "trace-1")
+	require.NoError(t, err)
+	require.Equal(t, "item-1", item.ID)
+
+	_, err = client.AddItemFromTrace(ctx, "regressions", "trace-1",
+		WithSourceObservation("gen-1"), WithExpectedOutput("corrected"), WithItemMetadata(map[string]any{"reason": "bug"}))
+	require.NoError(t, err)
+
+	require.Equal(t, []CreateDatasetItemRequest{
+		{
+			DatasetName:    "regressions",
+			Input:          map[string]any{"question": "refund?"},
+			ExpectedOutput: "issued",
+			SourceTraceID:  "trace-1",
+		},
+		{
+			DatasetName:         "regressions",
+			Input:               "prompt",
+			ExpectedOutput:      "corrected",
+			Metadata:            map[string]any{"reason": "bug"},
+			SourceTraceID:       "trace-1",
+			SourceObservationID: "gen-1",
+		},
+	}, created)
+
+	_, err = client.AddItemFromTrace(ctx, "regressions", "trace-2", WithSourceObservation("gen-1"))
+	require.EqualError(t, err, "observation gen-1 does not belong to trace trace-2")
+	_, err = client.AddItemFromTrace(ctx, "", "trace-1")
+	require.EqualError(t, err, "'datasetName' is required")
+}
@@ -0,0 +1,85 @@
+package datasets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidDatasetItem is returned when a dataset item is rejected by the validators
+// registered with WithItemValidator.
+var ErrInvalidDatasetItem = errors.New("invalid dataset item")
+
+// ClientOption configures the datasets API client.
+type ClientOption func(*Client)
+
+// ItemValidator validates a dataset item before it's created, returning an error to reject it.
+type ItemValidator func(item *CreateDatasetItemRequest) error
+
+// WithItemValidator registers a validator for the items of the given dataset, so that
+// CreateDatasetItem and ImportDatasetItems reject malformed items locally instead of
+// sending them. Multiple validators can be registered for the same dataset and are run
+// in order. The rejections wrap ErrInvalidDatasetItem.
+//
+// Example:
+//
+//	client := datasets.NewClient(restyCli, datasets.WithItemValidator("qa-eval",
+//		datasets.RequireInputKeys("question", "context")))
+func WithItemValidator(datasetName string, validator ItemValidator) ClientOption {
+	return func(c *Client) {
+		if validator == nil {
+			return
+		}
+		if c.itemValidators == nil {
+			c.itemValidators = make(map[string][]ItemValidator)
+		}
+		c.itemValidators[datasetName] = append(c.itemValidators[datasetName], validator)
+	}
+}
+
+// RequireInputKeys returns a validator which requires the input of the items to be a JSON
+// object with the given keys, e.g. a struct or a map.
+func RequireInputKeys(keys ...string) ItemValidator {
+	return func(item *CreateDatasetItemRequest) error {
+		return requireKeys("input", item.Input, keys)
+	}
+}
+
+// RequireExpectedOutputKeys returns a validator which requires the expected output of the
+// items to be a JSON object with the given keys, e.g. a struct or a map.
+func RequireExpectedOutputKeys(keys ...string) ItemValidator {
+	return func(item *CreateDatasetItemRequest) error {
+		return requireKeys("expectedOutput", item.ExpectedOutput, keys)
+	}
+}
+
+// requireKeys checks that the value is encoded as a JSON object with the given keys.
+func requireKeys(field string, value any, keys []string) error {
+	if value == nil {
+		return fmt.Errorf("'%s' is required", field)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode '%s': %w", field, err)
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return fmt.Errorf("'%s' must be an object", field)
+	}
+	for _, key := range keys {
+		if _, ok := object[key]; !ok {
+			return fmt.Errorf("'%s.%s' is required", field, key)
+		}
+	}
+	return nil
+}
+
+// validateItem runs the validators registered for the dataset of the item.
+func (c *Client) validateItem(item *CreateDatasetItemRequest) error {
+	for _, validator := range c.itemValidators[item.DatasetName] {
+		if err := validator(item); err != nil {
+			return fmt.Errorf("%w for dataset %q: %w", ErrInvalidDatasetItem, item.DatasetName, err)
+		}
+	}
+	return nil
+}
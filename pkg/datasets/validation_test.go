@@ -0,0 +1,88 @@
+package datasets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireInputKeys(t *testing.T) {
+	type input struct {
+		Question string `json:"question"`
+		Context  string `json:"context,omitempty"`
+	}
+	validator := RequireInputKeys("question", "context")
+
+	require.NoError(t, validator(&CreateDatasetItemRequest{
+		Input: map[string]any{"question": "q", "context": "c"},
+	}))
+	require.NoError(t, validator(&CreateDatasetItemRequest{
+		Input: input{Question: "q", Context: "c"},
+	}))
+	require.EqualError(t, validator(&CreateDatasetItemRequest{
+		Input: input{Question: "q"},
+	}), "'input.context' is required")
+	require.EqualError(t, validator(&CreateDatasetItemRequest{Input: "q"}), "'input' must be an object")
+	require.EqualError(t, validator(&CreateDatasetItemRequest{}), "'input' is required")
+
+	require.EqualError(t, RequireExpectedOutputKeys("answer")(&CreateDatasetItemRequest{
+		ExpectedOutput: map[string]any{},
+	}), "'expectedOutput.answer' is required")
+}
+
+func TestClient_ItemValidators(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": "item-1", "datasetName": "qa-eval"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL),
+		WithItemValidator("qa-eval", RequireInputKeys("question")),
+		WithItemValidator("qa-eval", func(item *CreateDatasetItemRequest) error {
+			if item.ExpectedOutput == nil {
+				return errors.New("'expectedOutput' is required")
+			}
+			return nil
+		}))
+	ctx := context.Background()
+
+	_, err := client.CreateDatasetItem(ctx, &CreateDatasetItemRequest{
+		DatasetName: "qa-eval",
+		Input:       map[string]any{"question": "q"},
+	})
+	require.ErrorIs(t, err, ErrInvalidDatasetItem)
+	require.EqualError(t, err, `invalid dataset item for dataset "qa-eval": 'expectedOutput' is required`)
+
+	// The items of other datasets aren't validated
+	_, err = client.CreateDatasetItem(ctx, &CreateDatasetItemRequest{DatasetName: "other", Input: "q"})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, requests.Load())
+
+	valid := &CreateDatasetItemRequest{
+		DatasetName:    "qa-eval",
+		Input:          map[string]any{"question": "q"},
+		ExpectedOutput: "a",
+	}
+	created, err := client.ImportDatasetItems(ctx, []*CreateDatasetItemRequest{
+		valid, {DatasetName: "qa-eval", Input: map[string]any{}, ExpectedOutput: "a"},
+	}, nil)
+	require.ErrorIs(t, err, ErrInvalidDatasetItem)
+	require.EqualError(t, err, `dataset item 1: invalid dataset item for dataset "qa-eval": 'input.question' is required`)
+	require.Empty(t, created)
+	require.EqualValues(t, 1, requests.Load())
+
+	created, err = client.ImportDatasetItems(ctx, []*CreateDatasetItemRequest{valid}, nil)
+	require.NoError(t, err)
+	require.Len(t, created, 1)
+	require.EqualValues(t, 2, requests.Load())
+}
@@ -0,0 +1,21 @@
+package health_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/git-hulk/langfuse-go/pkg/health"
+)
+
+func ExampleClient_Check() {
+	client := health.NewClient(resty.New().SetBaseURL("https://cloud.langfuse.com/api/public"))
+
+	response, err := client.Check(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(response.Status, response.Version)
+}
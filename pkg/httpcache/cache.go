@@ -0,0 +1,111 @@
+// Package httpcache provides an HTTP caching layer for the read-heavy Langfuse API endpoints.
+//
+// Responses of the cached endpoints which carry an ETag or Last-Modified header are stored,
+// and later requests for the same URL are sent as conditional requests with If-None-Match
+// and If-Modified-Since. When the server answers 304 Not Modified, the stored response is
+// returned instead, which saves the transfer and decoding of unchanged prompts, score
+// configs and models. Responses without validators are never cached, so the cache can't
+// return stale content.
+package httpcache
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultMaxEntries = 1000
+
+// Entry is a cached response.
+type Entry struct {
+	StatusCode   int
+	Header       http.Header
+	Body         []byte
+	ETag         string
+	LastModified string
+	StoredAt     time.Time
+}
+
+// Cache stores the cached responses. Implementations must be safe for concurrent use,
+// and may be backed by a shared store like Redis to share the cache between processes.
+type Cache interface {
+	Get(key string) (*Entry, bool)
+	Set(key string, entry *Entry)
+	Delete(key string)
+}
+
+// MemoryCache is an in-memory Cache which evicts the least recently used entries.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type memoryItem struct {
+	key   string
+	entry *Entry
+}
+
+// NewMemoryCache creates an in-memory cache holding at most maxEntries responses.
+// If maxEntries is not positive, it defaults to 1000.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultMaxEntries
+	}
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get returns the entry stored for the key.
+func (c *MemoryCache) Get(key string) (*Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*memoryItem).entry, true
+}
+
+// Set stores the entry for the key, evicting the least recently used entry if the cache is full.
+func (c *MemoryCache) Set(key string, entry *Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*memoryItem).entry = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&memoryItem{key: key, entry: entry})
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryItem).key)
+	}
+}
+
+// Delete removes the entry stored for the key.
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of cached entries.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
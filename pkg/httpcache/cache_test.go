@@ -0,0 +1,32 @@
+package httpcache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", &Entry{ETag: "a"})
+	cache.Set("b", &Entry{ETag: "b"})
+
+	// Reading "a" makes "b" the least recently used entry
+	entry, ok := cache.Get("a")
+	require.True(t, ok)
+	require.Equal(t, "a", entry.ETag)
+	cache.Set("c", &Entry{ETag: "c"})
+	require.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	require.False(t, ok)
+
+	cache.Set("a", &Entry{ETag: "a2"})
+	entry, ok = cache.Get("a")
+	require.True(t, ok)
+	require.Equal(t, "a2", entry.ETag)
+
+	cache.Delete("a")
+	_, ok = cache.Get("a")
+	require.False(t, ok)
+	require.Equal(t, 1, cache.Len())
+}
@@ -0,0 +1,203 @@
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// DefaultBasePath is the path of the public API, which the cached paths are relative to.
+const DefaultBasePath = "/api/public"
+
+// DefaultPaths are the read-heavy endpoints which are cached by default.
+var DefaultPaths = []string{"/v2/prompts", "/score-configs", "/models"}
+
+// Config holds the configuration of a Transport.
+type Config struct {
+	// Cache stores the responses. Default is an in-memory cache of 1000 entries.
+	Cache Cache
+	// Paths are the prefixes of the API paths which are cached, relative to the BasePath.
+	// Default is DefaultPaths.
+	Paths []string
+	// BasePath is the path of the public API. Default is DefaultBasePath.
+	BasePath string
+}
+
+// Transport is an http.RoundTripper which caches the GET responses of the configured
+// paths and revalidates them with conditional requests.
+type Transport struct {
+	base     http.RoundTripper
+	cache    Cache
+	paths    []string
+	basePath string
+	now      func() time.Time
+}
+
+// New creates a Transport which sends the requests through base, or http.DefaultTransport if nil.
+//
+// Example:
+//
+//	transport := httpcache.New(nil, httpcache.Config{Cache: httpcache.NewMemoryCache(500)})
+//	transport.Install(restyCli)
+func New(base http.RoundTripper, config Config) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if config.Cache == nil {
+		config.Cache = NewMemoryCache(defaultMaxEntries)
+	}
+	if len(config.Paths) == 0 {
+		config.Paths = DefaultPaths
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	return &Transport{
+		base:     base,
+		cache:    config.Cache,
+		paths:    config.Paths,
+		basePath: config.BasePath,
+		now:      time.Now,
+	}
+}
+
+// Install makes the resty client send its requests through the cache, wrapping the
+// transport the client currently uses. It replaces the transport of the *http.Client of
+// the resty client, so don't install it on a resty client created from a shared
+// *http.Client, e.g. http.DefaultClient.
+func (t *Transport) Install(cli *resty.Client) {
+	if base := cli.GetClient().Transport; base != nil {
+		t.base = base
+	}
+	cli.SetTransport(t)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || !t.cacheable(req.URL.Path) {
+		return t.base.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	entry, cached := t.cache.Get(key)
+	if cached {
+		req = req.Clone(req.Context())
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	rsp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case rsp.StatusCode == http.StatusNotModified && cached:
+		_, _ = io.Copy(io.Discard, rsp.Body)
+		_ = rsp.Body.Close()
+		entry = entry.revalidated(rsp.Header, t.now())
+		t.cache.Set(key, entry)
+		return entry.response(req, rsp), nil
+	case rsp.StatusCode == http.StatusOK:
+		etag, lastModified := rsp.Header.Get("ETag"), rsp.Header.Get("Last-Modified")
+		if etag == "" && lastModified == "" {
+			t.cache.Delete(key)
+			return rsp, nil
+		}
+		body, err := io.ReadAll(rsp.Body)
+		_ = rsp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		t.cache.Set(key, &Entry{
+			StatusCode:   rsp.StatusCode,
+			Header:       rsp.Header.Clone(),
+			Body:         body,
+			ETag:         etag,
+			LastModified: lastModified,
+			StoredAt:     t.now(),
+		})
+		rsp.Body = io.NopCloser(bytes.NewReader(body))
+		return rsp, nil
+	case rsp.StatusCode == http.StatusNotFound:
+		t.cache.Delete(key)
+	}
+	return rsp, nil
+}
+
+func (t *Transport) cacheable(path string) bool {
+	if _, apiPath, ok := strings.Cut(path, t.basePath); ok {
+		path = apiPath
+	}
+	for _, prefix := range t.paths {
+		if path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// notModifiedExcluded are the header fields of a 304 response which describe its own
+// framing rather than the cached response, so they don't update the cached headers.
+var notModifiedExcluded = map[string]bool{
+	"Content-Length":    true,
+	"Content-Encoding":  true,
+	"Content-Range":     true,
+	"Transfer-Encoding": true,
+}
+
+// revalidated returns a copy of the entry whose headers are updated with the ones of the
+// 304 response, as in RFC 9111 section 4.3.4, so that e.g. the Date header is the one of
+// the revalidation instead of the one of the cached response.
+func (e *Entry) revalidated(header http.Header, now time.Time) *Entry {
+	updated := *e
+	updated.Header = e.Header.Clone()
+	if updated.Header == nil {
+		updated.Header = make(http.Header)
+	}
+	for name, values := range header {
+		if !notModifiedExcluded[name] {
+			updated.Header[name] = append([]string(nil), values...)
+		}
+	}
+	if etag := header.Get("ETag"); etag != "" {
+		updated.ETag = etag
+	}
+	if lastModified := header.Get("Last-Modified"); lastModified != "" {
+		updated.LastModified = lastModified
+	}
+	updated.StoredAt = now
+	return &updated
+}
+
+// response builds the response to a revalidated request from the cached entry.
+func (e *Entry) response(req *http.Request, notModified *http.Response) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// cacheKey identifies the response by URL and credentials, so that clients of different
+// projects sharing a cache don't see each other's responses.
+func cacheKey(req *http.Request) string {
+	credentials := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return hex.EncodeToString(credentials[:8]) + " " + req.URL.String()
+}
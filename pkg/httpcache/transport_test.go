@@ -0,0 +1,147 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport_ETag(t *testing.T) {
+	var requests, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"greeting"}`))
+	}))
+	defer server.Close()
+
+	cli := resty.New().SetBaseURL(server.URL + "/api/public")
+	New(nil, Config{}).Install(cli)
+
+	for i := 0; i < 3; i++ {
+		rsp, err := cli.R().Get("/v2/prompts/greeting")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rsp.StatusCode())
+		require.JSONEq(t, `{"name":"greeting"}`, rsp.String())
+		require.Equal(t, "application/json", rsp.Header().Get("Content-Type"))
+	}
+	require.EqualValues(t, 3, requests.Load())
+	require.EqualValues(t, 2, notModified.Load())
+}
+
+func TestTransport_LastModified(t *testing.T) {
+	const lastModified = "Wed, 01 Jan 2025 10:00:00 GMT"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte(`{"id":"model-1"}`))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(0)
+	cli := resty.New().SetBaseURL(server.URL + "/api/public")
+	New(nil, Config{Cache: cache}).Install(cli)
+
+	for i := 0; i < 2; i++ {
+		rsp, err := cli.R().Get("/models/model-1")
+		require.NoError(t, err)
+		require.Equal(t, `{"id":"model-1"}`, rsp.String())
+	}
+	require.Equal(t, 1, cache.Len())
+}
+
+func TestTransport_NotCached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get("If-None-Match"))
+		if r.URL.Path != "/api/public/v2/prompts/no-validator" {
+			w.Header().Set("ETag", `"v1"`)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(0)
+	cli := resty.New().SetBaseURL(server.URL + "/api/public")
+	New(nil, Config{Cache: cache}).Install(cli)
+
+	for i := 0; i < 2; i++ {
+		// Not a cached path
+		_, err := cli.R().Get("/traces/trace-1")
+		require.NoError(t, err)
+		// Not a GET request
+		_, err = cli.R().SetBody(`{}`).Post("/v2/prompts")
+		require.NoError(t, err)
+		// No validators in the response
+		_, err = cli.R().Get("/v2/prompts/no-validator")
+		require.NoError(t, err)
+	}
+	require.Equal(t, 0, cache.Len())
+}
+
+func TestTransport_CacheKeyIncludesCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(0)
+	for _, publicKey := range []string{"pk-1", "pk-2"} {
+		cli := resty.New().SetBaseURL(server.URL+"/api/public").SetBasicAuth(publicKey, "sk")
+		New(nil, Config{Cache: cache}).Install(cli)
+		_, err := cli.R().Get("/score-configs")
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, cache.Len())
+}
+
+func TestTransport_NotModifiedHeaders(t *testing.T) {
+	const stale, fresh = "Wed, 01 Jan 2025 10:00:00 GMT", "Wed, 01 Jan 2025 11:00:00 GMT"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.Header().Set("Date", fresh)
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Date", stale)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"greeting"}`))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(0)
+	cli := resty.New().SetBaseURL(server.URL + "/api/public")
+	New(nil, Config{Cache: cache}).Install(cli)
+
+	rsp, err := cli.R().Get("/v2/prompts/greeting")
+	require.NoError(t, err)
+	require.Equal(t, stale, rsp.Header().Get("Date"))
+
+	for i := 0; i < 2; i++ {
+		rsp, err = cli.R().Get("/v2/prompts/greeting")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rsp.StatusCode())
+		require.JSONEq(t, `{"name":"greeting"}`, rsp.String())
+		require.Equal(t, fresh, rsp.Header().Get("Date"))
+		require.Equal(t, "max-age=60", rsp.Header().Get("Cache-Control"))
+		require.Equal(t, `"v1"`, rsp.Header().Get("ETag"))
+		require.Equal(t, "application/json", rsp.Header().Get("Content-Type"))
+	}
+}
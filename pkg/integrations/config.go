@@ -0,0 +1,95 @@
+// Package integrations holds the configuration shared by the integrations which create
+// observations on behalf of the application, like HTTP middlewares or LLM client wrappers.
+//
+// Integrations take a Config and start their observations with Config.StartObservation,
+// instead of hard-coding the observation type of each operation, so that applications
+// decide how the operations are rendered in Langfuse, e.g. HTTP calls to an LLM gateway
+// as generations instead of spans.
+//
+// Example:
+//
+//	config := integrations.Config{
+//		ObservationTypes: map[integrations.Operation]traces.ObservationType{
+//			integrations.OperationHTTPRequest: traces.ObservationTypeGeneration,
+//		},
+//	}
+//	observation := config.StartObservation(trace, integrations.OperationHTTPRequest, "POST /v1/chat")
+package integrations
+
+import (
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+// Operation is a kind of operation instrumented by an integration.
+type Operation string
+
+const (
+	// OperationHTTPRequest is an HTTP request, handled or sent.
+	OperationHTTPRequest Operation = "http_request"
+	// OperationLLMCall is a completion or chat call to an LLM.
+	OperationLLMCall Operation = "llm_call"
+	// OperationEmbedding is a call to an embedding model.
+	OperationEmbedding Operation = "embedding"
+	// OperationToolCall is a call of a tool requested by an LLM.
+	OperationToolCall Operation = "tool_call"
+	// OperationRetrieval is a retrieval of documents, e.g. from a vector store.
+	OperationRetrieval Operation = "retrieval"
+	// OperationLog is a point-in-time record without a duration, e.g. a streamed chunk.
+	OperationLog Operation = "log"
+)
+
+// defaultObservationTypes are the observation types of the operations unless configured.
+var defaultObservationTypes = map[Operation]traces.ObservationType{
+	OperationHTTPRequest: traces.ObservationTypeSpan,
+	OperationLLMCall:     traces.ObservationTypeGeneration,
+	OperationEmbedding:   traces.ObservationTypeEmbedding,
+	OperationToolCall:    traces.ObservationTypeTool,
+	OperationRetrieval:   traces.ObservationTypeRetriever,
+	OperationLog:         traces.ObservationTypeEvent,
+}
+
+// DefaultObservationType returns the observation type of the operation unless configured
+// otherwise, SPAN for unknown operations.
+func DefaultObservationType(operation Operation) traces.ObservationType {
+	if typ, ok := defaultObservationTypes[operation]; ok {
+		return typ
+	}
+	return traces.ObservationTypeSpan
+}
+
+// Config is the configuration shared by the integrations.
+type Config struct {
+	// ObservationTypes overrides the observation types of the operations, see
+	// DefaultObservationType for the defaults.
+	ObservationTypes map[Operation]traces.ObservationType
+	// Disabled lists the operations which aren't recorded at all.
+	Disabled []Operation
+}
+
+// ObservationType returns the observation type to create for the operation.
+func (c *Config) ObservationType(operation Operation) traces.ObservationType {
+	if typ, ok := c.ObservationTypes[operation]; ok && typ != "" {
+		return typ
+	}
+	return DefaultObservationType(operation)
+}
+
+// Enabled reports whether the operation is recorded.
+func (c *Config) Enabled(operation Operation) bool {
+	for _, disabled := range c.Disabled {
+		if disabled == operation {
+			return false
+		}
+	}
+	return true
+}
+
+// StartObservation starts an observation of the configured type for the operation within
+// the trace, or returns nil if the operation is disabled. Integrations must handle the nil
+// observation, e.g. by skipping the recording.
+func (c *Config) StartObservation(trace *traces.Trace, operation Operation, name string) *traces.Observation {
+	if trace == nil || !c.Enabled(operation) {
+		return nil
+	}
+	return trace.StartObservation(name, c.ObservationType(operation))
+}
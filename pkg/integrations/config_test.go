@@ -0,0 +1,42 @@
+package integrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+func TestConfig_ObservationType(t *testing.T) {
+	config := Config{
+		ObservationTypes: map[Operation]traces.ObservationType{
+			OperationHTTPRequest: traces.ObservationTypeGeneration,
+		},
+	}
+	require.Equal(t, traces.ObservationTypeGeneration, config.ObservationType(OperationHTTPRequest))
+	require.Equal(t, traces.ObservationTypeGeneration, config.ObservationType(OperationLLMCall))
+	require.Equal(t, traces.ObservationTypeEvent, config.ObservationType(OperationLog))
+	require.Equal(t, traces.ObservationTypeSpan, config.ObservationType("custom"))
+
+	var empty Config
+	require.Equal(t, traces.ObservationTypeSpan, empty.ObservationType(OperationHTTPRequest))
+}
+
+func TestConfig_StartObservation(t *testing.T) {
+	ingestor := traces.NewIngestor(resty.New())
+	defer ingestor.Close()
+	trace := ingestor.StartTrace(context.Background(), "handler")
+
+	config := Config{
+		ObservationTypes: map[Operation]traces.ObservationType{OperationToolCall: traces.ObservationTypeSpan},
+		Disabled:         []Operation{OperationLog},
+	}
+	observation := config.StartObservation(trace, OperationToolCall, "search")
+	require.Equal(t, traces.ObservationTypeSpan, observation.Type)
+	require.Equal(t, "search", observation.Name)
+	require.Nil(t, config.StartObservation(trace, OperationLog, "chunk"))
+	require.Nil(t, config.StartObservation(nil, OperationToolCall, "search"))
+}
@@ -0,0 +1,26 @@
+package llmconnections_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	langfuse "github.com/git-hulk/langfuse-go"
+	"github.com/git-hulk/langfuse-go/pkg/llmconnections"
+)
+
+func ExampleClient_Upsert() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...")
+	defer client.Close()
+
+	connection, err := client.LLMConnections().Upsert(context.Background(), &llmconnections.UpsertLLMConnectionRequest{
+		Provider:          "openai",
+		Adapter:           llmconnections.AdapterOpenAI,
+		SecretKey:         "sk-...",
+		WithDefaultModels: true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(connection.Provider)
+}
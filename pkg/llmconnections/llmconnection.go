@@ -94,20 +94,52 @@ func (r *UpsertLLMConnectionRequest) validate() error {
 type ListParams struct {
 	Page  int
 	Limit int
+
+	pagination common.Pagination
 }
 
 // ToQueryString converts the ListParams to a URL query string.
 func (query *ListParams) ToQueryString() string {
 	parts := make([]string, 0)
-	if query.Page != 0 {
+	if query.pagination.HasPage(query.Page) {
 		parts = append(parts, "page="+strconv.Itoa(query.Page))
 	}
-	if query.Limit != 0 {
+	if query.pagination.HasLimit(query.Limit) {
 		parts = append(parts, "limit="+strconv.Itoa(query.Limit))
 	}
 	return strings.Join(parts, "&")
 }
 
+// SetPage sets the page number, even if it is 0.
+func (query *ListParams) SetPage(page int) {
+	query.Page = page
+	query.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (query *ListParams) SetLimit(limit int) {
+	query.Limit = limit
+	query.pagination.MarkLimit()
+}
+
+// ListOption configures ListParams, see common.ListOption.
+type ListOption = common.ListOption[ListParams]
+
+// NewListParams creates ListParams for listing LLM connections from the provided options.
+func NewListParams(options ...ListOption) ListParams {
+	return common.NewListParams(options...)
+}
+
+// WithPage sets the page number, even if it is 0.
+func WithPage(page int) ListOption {
+	return common.WithPage[ListParams](page)
+}
+
+// WithLimit sets the page size, even if it is 0.
+func WithLimit(limit int) ListOption {
+	return common.WithLimit[ListParams](limit)
+}
+
 // ListLLMConnections represents the paginated response from the list LLM connections API.
 //
 // It contains pagination metadata and an array of LLM connections matching the query criteria.
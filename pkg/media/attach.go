@@ -0,0 +1,32 @@
+package media
+
+import (
+	"context"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+// NewMediaUploader returns a traces.MediaUploader which uploads the media attached to
+// observations with the client, to use with traces.WithMediaUploader.
+//
+// Example:
+//
+//	mediaCli := media.NewClient(restyCli)
+//	ingestor := traces.NewIngestor(restyCli, traces.WithMediaUploader(media.NewMediaUploader(mediaCli)))
+func NewMediaUploader(client *Client) traces.MediaUploader {
+	return traces.MediaUploaderFunc(func(ctx context.Context, attachment traces.MediaAttachment) (string, error) {
+		contentType := ContentType(attachment.ContentType)
+		uploaded, err := client.UploadFromBytes(ctx, &UploadFromBytesRequest{
+			TraceID:       attachment.TraceID,
+			ObservationID: attachment.ObservationID,
+			ContentType:   contentType,
+			Field:         attachment.Field,
+			Data:          attachment.Data,
+		})
+		if err != nil {
+			return "", err
+		}
+		reference := Reference{MediaID: uploaded.MediaID, ContentType: contentType, Source: "bytes"}
+		return reference.String(), nil
+	})
+}
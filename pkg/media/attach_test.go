@@ -0,0 +1,37 @@
+package media
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+func TestNewMediaUploader(t *testing.T) {
+	var uploaded []byte
+	var patched PatchMediaRequest
+	apiServer, uploadServer := newUploadTestServers(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, string(ContentTypeImagePNG), r.Header.Get("Content-Type"))
+		uploaded, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}, &patched)
+	defer apiServer.Close()
+	defer uploadServer.Close()
+
+	uploader := NewMediaUploader(NewClient(resty.New().SetBaseURL(apiServer.URL)))
+	reference, err := uploader.UploadMedia(context.Background(), traces.MediaAttachment{
+		TraceID:       "trace-1",
+		ObservationID: "observation-1",
+		Field:         "input",
+		ContentType:   string(ContentTypeImagePNG),
+		Data:          []byte("png"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "png", string(uploaded))
+	require.Equal(t, "@@@langfuseMedia:type=image/png|id=media-123|source=bytes@@@", reference)
+}
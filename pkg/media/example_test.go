@@ -0,0 +1,41 @@
+package media_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	langfuse "github.com/git-hulk/langfuse-go"
+	"github.com/git-hulk/langfuse-go/pkg/media"
+)
+
+func ExampleClient_UploadFile() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...")
+	defer client.Close()
+
+	uploaded, err := client.Media().UploadFile(context.Background(), &media.UploadFileRequest{
+		TraceID:     "trace-id",
+		ContentType: media.ContentTypeImagePNG,
+		Field:       "input",
+		FilePath:    "screenshot.png",
+		Progress: func(sent, total int64) {
+			log.Printf("uploaded %d/%d bytes", sent, total)
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Reference the uploaded media in the input of the trace
+	fmt.Println(media.Reference{MediaID: uploaded.MediaID, ContentType: media.ContentTypeImagePNG, Source: "file"})
+}
+
+func ExampleParseReferences() {
+	input := map[string]any{
+		"text":  "Describe this image",
+		"image": "@@@langfuseMedia:type=image/png|id=media-1|source=file@@@",
+	}
+	for _, reference := range media.ParseReferences(input) {
+		fmt.Println(reference.MediaID, reference.ContentType)
+	}
+	// Output: media-1 image/png
+}
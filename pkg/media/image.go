@@ -0,0 +1,98 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+)
+
+// ImageFormat represents the encoding of an uploaded image.
+type ImageFormat string
+
+const (
+	ImageFormatPNG  ImageFormat = "png"
+	ImageFormatJPEG ImageFormat = "jpeg"
+)
+
+// UploadImageRequest represents the request for uploading a decoded image.
+type UploadImageRequest struct {
+	TraceID       string      `json:"traceId"`
+	ObservationID string      `json:"observationId,omitempty"`
+	Field         string      `json:"field"`
+	Image         image.Image `json:"-"` // Not serialized to JSON
+	// Format is the encoding of the uploaded image. Default is PNG.
+	Format ImageFormat `json:"-"`
+	// JPEGQuality is the quality of JPEG images, ranging from 1 to 100. Default is 75.
+	JPEGQuality int `json:"-"`
+	// Progress is called while the encoded image is uploaded. Optional.
+	Progress ProgressFunc `json:"-"`
+}
+
+func (r *UploadImageRequest) validate() error {
+	if r.Image == nil {
+		return errors.New("'image' is required")
+	}
+	switch r.Format {
+	case "", ImageFormatPNG, ImageFormatJPEG:
+	default:
+		return fmt.Errorf("'format' must be one of: %s, %s", ImageFormatPNG, ImageFormatJPEG)
+	}
+	if r.JPEGQuality < 0 || r.JPEGQuality > 100 {
+		return errors.New("'jpegQuality' must be between 1 and 100")
+	}
+	return nil
+}
+
+// encode returns the encoded image and its content type.
+func (r *UploadImageRequest) encode() ([]byte, ContentType, error) {
+	var buf bytes.Buffer
+	if r.Format == ImageFormatJPEG {
+		quality := r.JPEGQuality
+		if quality == 0 {
+			quality = jpeg.DefaultQuality
+		}
+		if err := jpeg.Encode(&buf, r.Image, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, "", fmt.Errorf("failed to encode JPEG image: %w", err)
+		}
+		return buf.Bytes(), ContentTypeImageJPEG, nil
+	}
+	if err := png.Encode(&buf, r.Image); err != nil {
+		return nil, "", fmt.Errorf("failed to encode PNG image: %w", err)
+	}
+	return buf.Bytes(), ContentTypeImagePNG, nil
+}
+
+// UploadImage encodes an in-memory image and uploads it.
+//
+// This is convenient for vision-model pipelines which hold decoded images, the image
+// is encoded as PNG or JPEG and uploaded using UploadFromBytes.
+//
+// Example:
+//
+//	uploaded, err := client.UploadImage(ctx, &media.UploadImageRequest{
+//		TraceID: trace.ID,
+//		Field:   "input",
+//		Image:   img,
+//		Format:  media.ImageFormatJPEG,
+//	})
+func (c *Client) UploadImage(ctx context.Context, request *UploadImageRequest) (*UploadResponse, error) {
+	if err := request.validate(); err != nil {
+		return nil, err
+	}
+	data, contentType, err := request.encode()
+	if err != nil {
+		return nil, err
+	}
+	return c.UploadFromBytes(ctx, &UploadFromBytesRequest{
+		TraceID:       request.TraceID,
+		ObservationID: request.ObservationID,
+		ContentType:   contentType,
+		Field:         request.Field,
+		Data:          data,
+		Progress:      request.Progress,
+	})
+}
@@ -0,0 +1,90 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadImageRequest_validate(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	tests := []struct {
+		name    string
+		request UploadImageRequest
+		errMsg  string
+	}{
+		{"missing image", UploadImageRequest{}, "'image' is required"},
+		{"invalid format", UploadImageRequest{Image: img, Format: "gif"}, "'format' must be one of: png, jpeg"},
+		{"invalid quality", UploadImageRequest{Image: img, Format: ImageFormatJPEG, JPEGQuality: 101}, "'jpegQuality' must be between 1 and 100"},
+		{"valid", UploadImageRequest{Image: img}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.validate()
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestClient_UploadImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for x := 0; x < 4; x++ {
+		img.Set(x, x, color.RGBA{R: 255, A: 255})
+	}
+
+	for _, format := range []ImageFormat{ImageFormatPNG, ImageFormatJPEG} {
+		t.Run(string(format), func(t *testing.T) {
+			var decodedFormat string
+			uploadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var buf bytes.Buffer
+				_, err := buf.ReadFrom(r.Body)
+				require.NoError(t, err)
+				decoded, name, err := image.Decode(&buf)
+				require.NoError(t, err)
+				require.Equal(t, img.Bounds(), decoded.Bounds())
+				decodedFormat = name
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer uploadServer.Close()
+
+			apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case "POST":
+					var req GetUploadURLRequest
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+					require.Equal(t, ContentType("image/"+string(format)), req.ContentType)
+					require.Equal(t, "observation-1", req.ObservationID)
+					w.Header().Set("Content-Type", "application/json")
+					json.NewEncoder(w).Encode(GetUploadURLResponse{UploadURL: uploadServer.URL, MediaID: "media-123"})
+				case "PATCH":
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer apiServer.Close()
+
+			client := NewClient(resty.New().SetBaseURL(apiServer.URL))
+			response, err := client.UploadImage(context.Background(), &UploadImageRequest{
+				TraceID:       "trace-123",
+				ObservationID: "observation-1",
+				Field:         "input",
+				Image:         img,
+				Format:        format,
+			})
+			require.NoError(t, err)
+			require.Equal(t, "media-123", response.MediaID)
+			require.Equal(t, string(format), decodedFormat)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"mime"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -126,12 +127,44 @@ func (r *PatchMediaRequest) validate() error {
 
 // Client represents the media API client.
 type Client struct {
-	restyCli *resty.Client
+	restyCli        *resty.Client
+	uploadClient    *http.Client
+	uploadTimeout   time.Duration
+	uploadRetries   int
+	uploadRetryWait time.Duration
+}
+
+// ClientOption configures the media API client.
+type ClientOption func(*Client)
+
+// WithUploadTimeout bounds each attempt to upload the media content to the storage provider.
+//
+// Uploads don't go through the API client, so they're not bounded by its timeouts.
+// By default, an upload is only bounded by the context.
+func WithUploadTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.uploadTimeout = timeout
+	}
+}
+
+// WithUploadHTTPClient sets the HTTP client uploading the media content to the presigned
+// URLs of the storage provider, e.g. to use the proxy and TLS settings of the API client.
+// Default is a client with the default transport.
+func WithUploadHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.uploadClient = httpClient
+		}
+	}
 }
 
 // NewClient creates a new media API client.
-func NewClient(cli *resty.Client) *Client {
-	return &Client{restyCli: cli}
+func NewClient(cli *resty.Client, options ...ClientOption) *Client {
+	client := &Client{restyCli: cli, uploadClient: &http.Client{}, uploadRetryWait: defaultUploadRetryWait}
+	for _, option := range options {
+		option(client)
+	}
+	return client
 }
 
 // GetUploadURL retrieves a presigned upload URL for uploading media.
@@ -219,6 +252,8 @@ type UploadFromBytesRequest struct {
 	ContentType   ContentType `json:"contentType"`
 	Field         string      `json:"field"`
 	Data          []byte      `json:"-"` // Not serialized to JSON
+	// Progress is called while the content is uploaded. Optional.
+	Progress ProgressFunc `json:"-"`
 }
 
 func (r *UploadFromBytesRequest) validate() error {
@@ -247,6 +282,8 @@ type UploadFileRequest struct {
 	ContentType   ContentType `json:"contentType"`
 	Field         string      `json:"field"`
 	FilePath      string      `json:"-"` // Not serialized to JSON
+	// Progress is called while the content is uploaded. Optional.
+	Progress ProgressFunc `json:"-"`
 }
 
 func (r *UploadFileRequest) validate() error {
@@ -304,12 +341,7 @@ func (c *Client) UploadFromBytes(ctx context.Context, request *UploadFromBytesRe
 	}
 
 	startTime := time.Now()
-	uploadRsp, err := resty.New().R().
-		SetContext(ctx).
-		SetHeader("Content-Type", string(request.ContentType)).
-		SetHeader("x-amz-checksum-sha256", sha256Hash).
-		SetBody(request.Data).
-		Put(uploadURLRsp.UploadURL)
+	uploadRsp, err := c.putContent(ctx, uploadURLRsp.UploadURL, request.ContentType, sha256Hash, request.Data, request.Progress)
 
 	uploadTimeMs := int(time.Since(startTime).Milliseconds())
 
@@ -323,9 +355,9 @@ func (c *Client) UploadFromBytes(ctx context.Context, request *UploadFromBytesRe
 		patchReq.UploadHTTPStatus = 0 // Use 0 for network errors
 		patchReq.UploadHTTPError = err.Error()
 	} else {
-		patchReq.UploadHTTPStatus = uploadRsp.StatusCode()
-		if uploadRsp.IsError() {
-			patchReq.UploadHTTPError = fmt.Sprintf("HTTP %d: %s", uploadRsp.StatusCode(), uploadRsp.String())
+		patchReq.UploadHTTPStatus = uploadRsp.statusCode
+		if uploadRsp.isError() {
+			patchReq.UploadHTTPError = fmt.Sprintf("HTTP %d: %s", uploadRsp.statusCode, uploadRsp.body)
 		}
 	}
 
@@ -336,8 +368,8 @@ func (c *Client) UploadFromBytes(ctx context.Context, request *UploadFromBytesRe
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload media: %w", err)
 	}
-	if uploadRsp.IsError() {
-		return nil, fmt.Errorf("upload failed with status %d: %s", uploadRsp.StatusCode(), uploadRsp.String())
+	if uploadRsp.isError() {
+		return nil, fmt.Errorf("upload failed with status %d: %s", uploadRsp.statusCode, uploadRsp.body)
 	}
 
 	return &UploadResponse{MediaID: uploadURLRsp.MediaID}, nil
@@ -385,5 +417,6 @@ func (c *Client) UploadFile(ctx context.Context, request *UploadFileRequest) (*U
 		ContentType:   contentType,
 		Field:         request.Field,
 		Data:          data,
+		Progress:      request.Progress,
 	})
 }
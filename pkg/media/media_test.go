@@ -555,6 +555,40 @@ func TestClient_UploadFromBytes(t *testing.T) {
 	require.Equal(t, mockMediaID, response.MediaID)
 }
 
+func TestClient_UploadFromBytes_UploadTimeout(t *testing.T) {
+	uploadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer uploadServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(GetUploadURLResponse{UploadURL: uploadServer.URL, MediaID: "media-123"})
+		case "PATCH":
+			var req PatchMediaRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, 0, req.UploadHTTPStatus)
+			require.Contains(t, req.UploadHTTPError, "context deadline exceeded")
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer apiServer.Close()
+
+	client := NewClient(resty.New().SetBaseURL(apiServer.URL), WithUploadTimeout(50*time.Millisecond))
+	_, err := client.UploadFromBytes(context.Background(), &UploadFromBytesRequest{
+		TraceID:     "trace-123",
+		ContentType: ContentTypeImagePNG,
+		Field:       "input",
+		Data:        []byte("test file content"),
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestClient_UploadFromBytes_ValidationError(t *testing.T) {
 	client := NewClient(resty.New())
 
@@ -0,0 +1,37 @@
+package media
+
+import (
+	"context"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+// NewLargeValueOffloader returns a handler for traces.WithLargeValues which uploads a large
+// value as text media and replaces it by a preview of its first previewBytes bytes followed
+// by the media reference, so the Langfuse UI shows the preview and links the full value.
+// If previewBytes is not positive, it defaults to traces.DefaultPreviewBytes.
+//
+// Example:
+//
+//	mediaCli := media.NewClient(restyCli)
+//	ingestor := traces.NewIngestor(restyCli,
+//		traces.WithLargeValues(256*1024, media.NewLargeValueOffloader(mediaCli, 0)))
+func NewLargeValueOffloader(client *Client, previewBytes int) traces.LargeValueHandler {
+	if previewBytes <= 0 {
+		previewBytes = traces.DefaultPreviewBytes
+	}
+	return traces.LargeValueHandlerFunc(func(ctx context.Context, value traces.LargeValue) (string, error) {
+		uploaded, err := client.UploadFromBytes(ctx, &UploadFromBytesRequest{
+			TraceID:       value.TraceID,
+			ObservationID: value.ObservationID,
+			ContentType:   ContentTypeTextPlain,
+			Field:         value.Field,
+			Data:          []byte(value.Value),
+		})
+		if err != nil {
+			return "", err
+		}
+		reference := Reference{MediaID: uploaded.MediaID, ContentType: ContentTypeTextPlain, Source: "bytes"}
+		return traces.Preview(value.Value, previewBytes) + "\n\n" + reference.String(), nil
+	})
+}
@@ -0,0 +1,38 @@
+package media
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+func TestNewLargeValueOffloader(t *testing.T) {
+	value := strings.Repeat("a", 100)
+	var uploaded []byte
+	var patched PatchMediaRequest
+	apiServer, uploadServer := newUploadTestServers(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, string(ContentTypeTextPlain), r.Header.Get("Content-Type"))
+		uploaded, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}, &patched)
+	defer apiServer.Close()
+	defer uploadServer.Close()
+
+	offloader := NewLargeValueOffloader(NewClient(resty.New().SetBaseURL(apiServer.URL)), 10)
+	replaced, err := offloader.HandleLargeValue(context.Background(), traces.LargeValue{
+		TraceID: "trace-1",
+		Field:   "output",
+		Value:   value,
+	})
+	require.NoError(t, err)
+	require.Equal(t, value, string(uploaded))
+	require.Equal(t, "aaaaaaaaaa… [10 of 100 bytes]\n\n@@@langfuseMedia:type=text/plain|id=media-123|source=bytes@@@", replaced)
+	require.Equal(t, []Reference{{MediaID: "media-123", ContentType: ContentTypeTextPlain, Source: "bytes"}}, ParseReferences(replaced))
+}
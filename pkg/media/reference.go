@@ -0,0 +1,103 @@
+package media
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// referencePattern matches the media reference strings which Langfuse stores in the
+// input, output and metadata fields in place of the uploaded media content.
+var referencePattern = regexp.MustCompile(`@@@langfuseMedia:([^@]+)@@@`)
+
+// Reference is a media reference in a trace or observation field.
+type Reference struct {
+	MediaID     string
+	ContentType ContentType
+	Source      string
+}
+
+// String returns the reference string to put into a trace or observation field, e.g.
+// "@@@langfuseMedia:type=image/png|id=cc48838a-3da8-4ca4-a007-2cf8df930e69|source=bytes@@@".
+func (r Reference) String() string {
+	return fmt.Sprintf("@@@langfuseMedia:type=%s|id=%s|source=%s@@@", r.ContentType, r.MediaID, r.Source)
+}
+
+// ParseReferences returns the media references found in a trace or observation field.
+//
+// The value is searched recursively, so it may be a string, a JSON document, or any
+// value which is encoded to JSON like a map of messages.
+func ParseReferences(value any) []Reference {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	case json.RawMessage:
+		text = string(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil
+		}
+		text = string(data)
+	}
+
+	references := make([]Reference, 0)
+	for _, match := range referencePattern.FindAllStringSubmatch(text, -1) {
+		var reference Reference
+		for _, part := range strings.Split(match[1], "|") {
+			key, val, _ := strings.Cut(part, "=")
+			switch key {
+			case "type":
+				reference.ContentType = ContentType(val)
+			case "id":
+				reference.MediaID = val
+			case "source":
+				reference.Source = val
+			}
+		}
+		if reference.MediaID != "" {
+			references = append(references, reference)
+		}
+	}
+	return references
+}
+
+// FindOrphans returns the IDs of the media records which are not referenced by any of
+// the given trace or observation fields, in lexical order.
+//
+// The public API can neither list nor delete media records, so the candidate IDs must
+// come from your own records, e.g. the UploadResponse of each upload, and the orphans
+// have to be removed from the storage bucket directly.
+//
+// Example:
+//
+//	orphans := media.FindOrphans(uploadedIDs, observation.Input, observation.Output, observation.Metadata)
+func FindOrphans(mediaIDs []string, fields ...any) []string {
+	referenced := make(map[string]struct{})
+	for _, field := range fields {
+		for _, reference := range ParseReferences(field) {
+			referenced[reference.MediaID] = struct{}{}
+		}
+	}
+
+	orphans := make([]string, 0)
+	seen := make(map[string]struct{}, len(mediaIDs))
+	for _, mediaID := range mediaIDs {
+		if _, ok := seen[mediaID]; ok {
+			continue
+		}
+		seen[mediaID] = struct{}{}
+		if _, ok := referenced[mediaID]; !ok {
+			orphans = append(orphans, mediaID)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
@@ -0,0 +1,41 @@
+package media
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReference_String(t *testing.T) {
+	reference := Reference{MediaID: "media-1", ContentType: ContentTypeImagePNG, Source: "bytes"}
+	require.Equal(t, "@@@langfuseMedia:type=image/png|id=media-1|source=bytes@@@", reference.String())
+	require.Equal(t, []Reference{reference}, ParseReferences(reference.String()))
+}
+
+func TestParseReferences(t *testing.T) {
+	image := Reference{MediaID: "media-1", ContentType: ContentTypeImageJPEG, Source: "base64_data_uri"}
+	audio := Reference{MediaID: "media-2", ContentType: ContentTypeAudioWAV, Source: "bytes"}
+
+	input := map[string]any{
+		"messages": []any{
+			map[string]any{"role": "user", "content": []any{
+				map[string]any{"type": "image_url", "image_url": map[string]any{"url": image.String()}},
+			}},
+		},
+		"audio": audio.String(),
+	}
+	require.ElementsMatch(t, []Reference{image, audio}, ParseReferences(input))
+	require.Equal(t, []Reference{audio}, ParseReferences(json.RawMessage(`{"audio":"`+audio.String()+`"}`)))
+	require.Empty(t, ParseReferences("no media here"))
+	require.Empty(t, ParseReferences(nil))
+}
+
+func TestFindOrphans(t *testing.T) {
+	input := map[string]any{"image": Reference{MediaID: "media-1", ContentType: ContentTypeImagePNG, Source: "bytes"}.String()}
+	output := "See " + Reference{MediaID: "media-3", ContentType: ContentTypeApplicationPDF, Source: "file"}.String()
+
+	orphans := FindOrphans([]string{"media-4", "media-1", "media-2", "media-3", "media-2"}, input, output, nil)
+	require.Equal(t, []string{"media-2", "media-4"}, orphans)
+	require.Empty(t, FindOrphans(nil, input))
+}
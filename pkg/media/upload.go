@@ -0,0 +1,127 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const defaultUploadRetryWait = 500 * time.Millisecond
+
+// ProgressFunc is called while media content is uploaded, with the number of bytes sent
+// so far and the total number of bytes. When an upload is retried, the progress starts
+// again from 0.
+type ProgressFunc func(sent, total int64)
+
+// WithUploadRetries retries failed uploads of media content up to maxRetries times.
+//
+// Network errors and the status codes 408, 429 and 5xx are retried, waiting for wait
+// before the first retry and doubling the wait for every further retry. If wait is not
+// positive, it defaults to 500 milliseconds.
+//
+// The presigned upload URLs of Langfuse accept the whole content in a single PUT, so
+// neither multipart uploads nor ranged resumes are possible, and a retry sends the
+// whole content again.
+func WithUploadRetries(maxRetries int, wait time.Duration) ClientOption {
+	return func(c *Client) {
+		if wait <= 0 {
+			wait = defaultUploadRetryWait
+		}
+		c.uploadRetries = maxRetries
+		c.uploadRetryWait = wait
+	}
+}
+
+// uploadResult is the outcome of the upload of media content to the presigned URL.
+type uploadResult struct {
+	statusCode int
+	body       string
+}
+
+func (r *uploadResult) isError() bool {
+	return r.statusCode < 200 || r.statusCode > 299
+}
+
+// putContent uploads the content to the presigned URL, retrying failed attempts.
+func (c *Client) putContent(ctx context.Context, uploadURL string, contentType ContentType, sha256Hash string, data []byte, progress ProgressFunc) (*uploadResult, error) {
+	wait := c.uploadRetryWait
+	for attempt := 0; ; attempt++ {
+		result, err := c.putContentOnce(ctx, uploadURL, contentType, sha256Hash, data, progress)
+		if attempt >= c.uploadRetries || !isRetryableUpload(ctx, result, err) {
+			return result, err
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			if err == nil {
+				err = ctx.Err()
+			}
+			return result, err
+		}
+		wait *= 2
+	}
+}
+
+func (c *Client) putContentOnce(ctx context.Context, uploadURL string, contentType ContentType, sha256Hash string, data []byte, progress ProgressFunc) (*uploadResult, error) {
+	if c.uploadTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.uploadTimeout)
+		defer cancel()
+	}
+
+	var body io.Reader = bytes.NewReader(data)
+	if progress != nil {
+		body = &progressReader{reader: body, total: int64(len(data)), progress: progress}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, body)
+	if err != nil {
+		return nil, err
+	}
+	// The progress reader hides the length, which the presigned URL requires
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Type", string(contentType))
+	req.Header.Set("x-amz-checksum-sha256", sha256Hash)
+
+	rsp, err := c.uploadClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	rspBody, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload response: %w", err)
+	}
+	return &uploadResult{statusCode: rsp.StatusCode, body: string(rspBody)}, nil
+}
+
+func isRetryableUpload(ctx context.Context, result *uploadResult, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return result.statusCode == http.StatusRequestTimeout ||
+		result.statusCode == http.StatusTooManyRequests ||
+		result.statusCode >= http.StatusInternalServerError
+}
+
+type progressReader struct {
+	reader   io.Reader
+	sent     atomic.Int64
+	total    int64
+	progress ProgressFunc
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.progress(r.sent.Add(int64(n)), r.total)
+	}
+	return n, err
+}
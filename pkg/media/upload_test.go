@@ -0,0 +1,163 @@
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func newUploadTestServers(t *testing.T, upload http.HandlerFunc, patched *PatchMediaRequest) (*httptest.Server, *httptest.Server) {
+	uploadServer := httptest.NewServer(upload)
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(GetUploadURLResponse{UploadURL: uploadServer.URL, MediaID: "media-123"})
+		case "PATCH":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(patched))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	return apiServer, uploadServer
+}
+
+func TestClient_UploadFromBytes_Retries(t *testing.T) {
+	testData := []byte("test file content")
+	var attempts atomic.Int32
+	var patched PatchMediaRequest
+	apiServer, uploadServer := newUploadTestServers(t, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, testData, body)
+		require.Equal(t, int64(len(testData)), r.ContentLength)
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}, &patched)
+	defer apiServer.Close()
+	defer uploadServer.Close()
+
+	client := NewClient(resty.New().SetBaseURL(apiServer.URL), WithUploadRetries(2, time.Millisecond))
+	response, err := client.UploadFromBytes(context.Background(), &UploadFromBytesRequest{
+		TraceID:     "trace-123",
+		ContentType: ContentTypeImagePNG,
+		Field:       "input",
+		Data:        testData,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "media-123", response.MediaID)
+	require.EqualValues(t, 3, attempts.Load())
+	require.Equal(t, http.StatusOK, patched.UploadHTTPStatus)
+	require.Empty(t, patched.UploadHTTPError)
+}
+
+func TestClient_UploadFromBytes_RetriesExhausted(t *testing.T) {
+	var attempts atomic.Int32
+	var patched PatchMediaRequest
+	apiServer, uploadServer := newUploadTestServers(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}, &patched)
+	defer apiServer.Close()
+	defer uploadServer.Close()
+
+	client := NewClient(resty.New().SetBaseURL(apiServer.URL), WithUploadRetries(1, time.Millisecond))
+	_, err := client.UploadFromBytes(context.Background(), &UploadFromBytesRequest{
+		TraceID:     "trace-123",
+		ContentType: ContentTypeImagePNG,
+		Field:       "input",
+		Data:        []byte("test file content"),
+	})
+	require.ErrorContains(t, err, "upload failed with status 502")
+	require.EqualValues(t, 2, attempts.Load())
+	require.Equal(t, http.StatusBadGateway, patched.UploadHTTPStatus)
+}
+
+func TestClient_UploadFromBytes_NoRetryOnClientError(t *testing.T) {
+	var attempts atomic.Int32
+	var patched PatchMediaRequest
+	apiServer, uploadServer := newUploadTestServers(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	}, &patched)
+	defer apiServer.Close()
+	defer uploadServer.Close()
+
+	client := NewClient(resty.New().SetBaseURL(apiServer.URL), WithUploadRetries(3, time.Millisecond))
+	_, err := client.UploadFromBytes(context.Background(), &UploadFromBytesRequest{
+		TraceID:     "trace-123",
+		ContentType: ContentTypeImagePNG,
+		Field:       "input",
+		Data:        []byte("test file content"),
+	})
+	require.Error(t, err)
+	require.EqualValues(t, 1, attempts.Load())
+}
+
+func TestClient_UploadFromBytes_Progress(t *testing.T) {
+	testData := make([]byte, 256*1024)
+	var patched PatchMediaRequest
+	apiServer, uploadServer := newUploadTestServers(t, func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.Copy(io.Discard, r.Body)
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+	}, &patched)
+	defer apiServer.Close()
+	defer uploadServer.Close()
+
+	var lastSent, lastTotal int64
+	client := NewClient(resty.New().SetBaseURL(apiServer.URL))
+	_, err := client.UploadFromBytes(context.Background(), &UploadFromBytesRequest{
+		TraceID:     "trace-123",
+		ContentType: ContentTypeImagePNG,
+		Field:       "input",
+		Data:        testData,
+		Progress: func(sent, total int64) {
+			require.GreaterOrEqual(t, sent, lastSent)
+			lastSent, lastTotal = sent, total
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(len(testData)), lastSent)
+	require.Equal(t, int64(len(testData)), lastTotal)
+}
+
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClient_UploadFromBytes_UploadHTTPClient(t *testing.T) {
+	var patched PatchMediaRequest
+	apiServer, uploadServer := newUploadTestServers(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, &patched)
+	defer apiServer.Close()
+	defer uploadServer.Close()
+
+	transport := &countingTransport{}
+	client := NewClient(resty.New().SetBaseURL(apiServer.URL), WithUploadHTTPClient(&http.Client{Transport: transport}))
+	_, err := client.UploadFromBytes(context.Background(), &UploadFromBytesRequest{
+		TraceID:     "trace-123",
+		ContentType: ContentTypeImagePNG,
+		Field:       "input",
+		Data:        []byte("test file content"),
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, transport.requests.Load())
+	require.Equal(t, http.StatusOK, patched.UploadHTTPStatus)
+}
@@ -0,0 +1,27 @@
+package models_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	langfuse "github.com/git-hulk/langfuse-go"
+	"github.com/git-hulk/langfuse-go/pkg/models"
+)
+
+func ExampleClient_Create() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...")
+	defer client.Close()
+
+	model, err := client.Models().Create(context.Background(), &models.ModelEntry{
+		ModelName:    "internal-llm",
+		MatchPattern: "(?i)^(internal-llm)$",
+		Unit:         "TOKENS",
+		InputPrice:   0.000001,
+		OutputPrice:  0.000002,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(model.ID)
+}
@@ -27,22 +27,29 @@ type TokenizerConfig struct {
 	TokensPerMessage int `json:"tokensPerMessage,omitempty"`
 }
 
+// ModelPrice represents the price (USD) per unit of a specific usage type.
+type ModelPrice struct {
+	Price float64 `json:"price"`
+}
+
 // ModelEntry represents a model configuration with pricing and metadata.
 //
 // A model entry defines how a model is identified (via name and match pattern),
 // its pricing structure for input/output tokens, and tokenization configuration.
 // Models are used for cost tracking and analytics in Langfuse.
 type ModelEntry struct {
-	ID              string          `json:"id,omitempty"`
-	ModelName       string          `json:"modelName"`
-	MatchPattern    string          `json:"matchPattern,omitempty"`
-	StartDate       time.Time       `json:"startDate,omitempty"`
-	InputPrice      float64         `json:"inputPrice,omitempty"`
-	OutputPrice     float64         `json:"outputPrice,omitempty"`
-	TotalPrice      float64         `json:"totalPrice,omitempty"`
-	Unit            string          `json:"unit"`
-	TokenizerId     string          `json:"tokenizerId,omitempty"`
-	TokenizerConfig TokenizerConfig `json:"tokenizerConfig,omitempty"`
+	ID                string                `json:"id,omitempty"`
+	ModelName         string                `json:"modelName"`
+	MatchPattern      string                `json:"matchPattern,omitempty"`
+	StartDate         time.Time             `json:"startDate,omitempty"`
+	InputPrice        float64               `json:"inputPrice,omitempty"`
+	OutputPrice       float64               `json:"outputPrice,omitempty"`
+	TotalPrice        float64               `json:"totalPrice,omitempty"`
+	Unit              string                `json:"unit"`
+	TokenizerId       string                `json:"tokenizerId,omitempty"`
+	TokenizerConfig   TokenizerConfig       `json:"tokenizerConfig,omitempty"`
+	IsLangfuseManaged bool                  `json:"isLangfuseManaged,omitempty"`
+	Prices            map[string]ModelPrice `json:"prices,omitempty"`
 }
 
 func (m *ModelEntry) validate() error {
@@ -62,20 +69,52 @@ func (m *ModelEntry) validate() error {
 type ListParams struct {
 	Page  int
 	Limit int
+
+	pagination common.Pagination
 }
 
 // ToQueryString converts the ListParams to a URL query string.
 func (query *ListParams) ToQueryString() string {
 	parts := make([]string, 0)
-	if query.Page != 0 {
+	if query.pagination.HasPage(query.Page) {
 		parts = append(parts, "page="+strconv.Itoa(query.Page))
 	}
-	if query.Limit != 0 {
+	if query.pagination.HasLimit(query.Limit) {
 		parts = append(parts, "limit="+strconv.Itoa(query.Limit))
 	}
 	return strings.Join(parts, "&")
 }
 
+// SetPage sets the page number, even if it is 0.
+func (query *ListParams) SetPage(page int) {
+	query.Page = page
+	query.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (query *ListParams) SetLimit(limit int) {
+	query.Limit = limit
+	query.pagination.MarkLimit()
+}
+
+// ListOption configures ListParams, see common.ListOption.
+type ListOption = common.ListOption[ListParams]
+
+// NewListParams creates ListParams for listing models from the provided options.
+func NewListParams(options ...ListOption) ListParams {
+	return common.NewListParams(options...)
+}
+
+// WithPage sets the page number, even if it is 0.
+func WithPage(page int) ListOption {
+	return common.WithPage[ListParams](page)
+}
+
+// WithLimit sets the page size, even if it is 0.
+func WithLimit(limit int) ListOption {
+	return common.WithLimit[ListParams](limit)
+}
+
 // ListModels represents the response from listing models.
 type ListModels struct {
 	Metadata common.ListMetadata `json:"meta"`
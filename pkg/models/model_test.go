@@ -21,6 +21,7 @@ func TestListParams_ToQueryString(t *testing.T) {
 		{"with page only", ListParams{Page: 1}, "page=1"},
 		{"with limit only", ListParams{Limit: 10}, "limit=10"},
 		{"no params", ListParams{}, ""},
+		{"with explicit zero page", NewListParams(WithPage(0), WithLimit(10)), "page=0&limit=10"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
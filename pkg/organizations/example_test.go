@@ -0,0 +1,24 @@
+package organizations_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	langfuse "github.com/git-hulk/langfuse-go"
+	"github.com/git-hulk/langfuse-go/pkg/organizations"
+)
+
+func ExampleClient_UpdateMembership() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...")
+	defer client.Close()
+
+	membership, err := client.Organizations().UpdateMembership(context.Background(), &organizations.MembershipRequest{
+		UserID: "user-id",
+		Role:   organizations.MembershipRoleViewer,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(membership.Role)
+}
@@ -24,6 +24,9 @@ const (
 	MembershipRoleAdmin  MembershipRole = "ADMIN"
 	MembershipRoleMember MembershipRole = "MEMBER"
 	MembershipRoleViewer MembershipRole = "VIEWER"
+	// MembershipRoleNone denies the access to a project for a member of its organization,
+	// it's only valid as a project role.
+	MembershipRoleNone MembershipRole = "NONE"
 )
 
 // MembershipRequest represents the parameters for creating or updating a user's membership.
@@ -63,16 +66,22 @@ type MembershipsResponse struct {
 // Client provides methods for interacting with the Langfuse organizations API.
 //
 // The client handles HTTP communication for membership management operations
-// including listing, creating, and updating user memberships in organizations and projects.
+// including listing, creating, and updating user memberships in organizations and projects,
+// and for the management of the projects of organizations.
 type Client struct {
 	restyCli *resty.Client
+	orgKeys  map[string]organizationKey
 }
 
 // NewClient creates a new organizations client with the provided HTTP client.
 //
 // The resty client should be pre-configured with authentication and base URL.
-func NewClient(cli *resty.Client) *Client {
-	return &Client{restyCli: cli}
+func NewClient(cli *resty.Client, options ...ClientOption) *Client {
+	client := &Client{restyCli: cli}
+	for _, option := range options {
+		option(client)
+	}
+	return client
 }
 
 // ListMemberships retrieves all memberships for the organization associated with the API key.
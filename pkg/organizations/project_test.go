@@ -0,0 +1,69 @@
+package organizations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/projects"
+)
+
+func TestClient_ListProjects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/organizations/projects", r.URL.Path)
+		publicKey, _, ok := r.BasicAuth()
+		require.True(t, ok)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"projects": [{"id": "project-` + publicKey + `", "name": "chat",
+			"createdAt": "2025-01-01T00:00:00Z", "updatedAt": "2025-01-02T00:00:00Z"}]}`))
+	}))
+	defer server.Close()
+
+	cli := resty.New().SetBaseURL(server.URL).SetBasicAuth("pk-default", "sk-default")
+	client := NewClient(cli, WithOrganizationKey("org-1", "pk-org-1", "sk-org-1"))
+
+	rsp, err := client.ListProjects(context.Background(), "org-1")
+	require.NoError(t, err)
+	require.Len(t, rsp.Projects, 1)
+	require.Equal(t, "project-pk-org-1", rsp.Projects[0].ID)
+	require.Equal(t, 2025, rsp.Projects[0].UpdatedAt.Year())
+
+	rsp, err = client.ListProjects(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, "project-pk-default", rsp.Projects[0].ID)
+
+	_, err = client.ListProjects(context.Background(), "org-2")
+	require.EqualError(t, err, "no API key registered for organization: org-2")
+}
+
+func TestClient_CreateProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/projects", r.URL.Path)
+		publicKey, secretKey, _ := r.BasicAuth()
+		require.Equal(t, "pk-org-1", publicKey)
+		require.Equal(t, "sk-org-1", secretKey)
+
+		var req projects.CreateProjectRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(projects.Project{ID: "project-1", Name: req.Name, RetentionDays: req.Retention})
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL), WithOrganizationKey("org-1", "pk-org-1", "sk-org-1"))
+	project, err := client.CreateProject(context.Background(), "org-1", &projects.CreateProjectRequest{Name: "chat", Retention: 30})
+	require.NoError(t, err)
+	require.Equal(t, "project-1", project.ID)
+	require.Equal(t, 30, project.RetentionDays)
+
+	_, err = client.CreateProject(context.Background(), "org-1", &projects.CreateProjectRequest{})
+	require.EqualError(t, err, "'name' is required")
+}
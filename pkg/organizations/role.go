@@ -0,0 +1,82 @@
+package organizations
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotMember is returned by EffectiveRole if the user isn't a member of the organization.
+var ErrNotMember = errors.New("user is not a member of the organization")
+
+var roleRanks = map[MembershipRole]int{
+	MembershipRoleNone:   0,
+	MembershipRoleViewer: 1,
+	MembershipRoleMember: 2,
+	MembershipRoleAdmin:  3,
+	MembershipRoleOwner:  4,
+}
+
+// AtLeast reports whether the role grants at least the permissions of the other role,
+// e.g. ADMIN is at least MEMBER. Unknown roles grant nothing.
+func (r MembershipRole) AtLeast(other MembershipRole) bool {
+	rank, ok := roleRanks[r]
+	if !ok {
+		return false
+	}
+	return rank >= roleRanks[other]
+}
+
+// ResolveRole returns the effective role on a project from the role in the organization
+// and the role in the project, which is empty if the user has no project membership.
+//
+// A project role takes precedence over the organization role, so it can both raise
+// and lower the permissions of the user on the project.
+func ResolveRole(orgRole, projectRole MembershipRole) MembershipRole {
+	if projectRole != "" {
+		return projectRole
+	}
+	return orgRole
+}
+
+// EffectiveRole returns the role of the user on the project, resolved from the memberships
+// of the organization and the project, see ResolveRole. It returns ErrNotMember if the user
+// isn't a member of the organization. Requires organization-scoped API key.
+//
+// Example:
+//
+//	role, err := orgClient.EffectiveRole(ctx, "user-id", "project-id")
+//	if err == nil && role.AtLeast(organizations.MembershipRoleAdmin) {
+//		// allow to manage the project
+//	}
+func (c *Client) EffectiveRole(ctx context.Context, userID, projectID string) (MembershipRole, error) {
+	if userID == "" {
+		return "", errors.New("'userId' is required")
+	}
+	if projectID == "" {
+		return "", errors.New("'projectId' is required")
+	}
+
+	orgMemberships, err := c.ListMemberships(ctx)
+	if err != nil {
+		return "", err
+	}
+	orgRole, ok := findRole(orgMemberships, userID)
+	if !ok {
+		return "", ErrNotMember
+	}
+	projectMemberships, err := c.ListProjectMemberships(ctx, projectID)
+	if err != nil {
+		return "", err
+	}
+	projectRole, _ := findRole(projectMemberships, userID)
+	return ResolveRole(orgRole, projectRole), nil
+}
+
+func findRole(memberships *MembershipsResponse, userID string) (MembershipRole, bool) {
+	for _, membership := range memberships.Memberships {
+		if membership.UserID == userID {
+			return membership.Role, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,57 @@
+package organizations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMembershipRole_AtLeast(t *testing.T) {
+	require.True(t, MembershipRoleOwner.AtLeast(MembershipRoleAdmin))
+	require.True(t, MembershipRoleMember.AtLeast(MembershipRoleMember))
+	require.False(t, MembershipRoleViewer.AtLeast(MembershipRoleMember))
+	require.False(t, MembershipRoleNone.AtLeast(MembershipRoleViewer))
+	require.False(t, MembershipRole("UNKNOWN").AtLeast(MembershipRoleNone))
+}
+
+func TestClient_EffectiveRole(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/organizations/memberships":
+			_, _ = w.Write([]byte(`{"memberships": [
+				{"userId": "user-1", "role": "MEMBER"},
+				{"userId": "user-2", "role": "ADMIN"},
+				{"userId": "user-3", "role": "VIEWER"}]}`))
+		case "/projects/project-1/memberships":
+			_, _ = w.Write([]byte(`{"memberships": [
+				{"userId": "user-1", "role": "OWNER"},
+				{"userId": "user-2", "role": "NONE"}]}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	ctx := context.Background()
+
+	role, err := client.EffectiveRole(ctx, "user-1", "project-1")
+	require.NoError(t, err)
+	require.Equal(t, MembershipRoleOwner, role)
+
+	role, err = client.EffectiveRole(ctx, "user-2", "project-1")
+	require.NoError(t, err)
+	require.Equal(t, MembershipRoleNone, role)
+
+	role, err = client.EffectiveRole(ctx, "user-3", "project-1")
+	require.NoError(t, err)
+	require.Equal(t, MembershipRoleViewer, role)
+
+	_, err = client.EffectiveRole(ctx, "user-4", "project-1")
+	require.ErrorIs(t, err, ErrNotMember)
+}
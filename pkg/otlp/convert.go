@@ -0,0 +1,255 @@
+package otlp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+// Attributes of the converted spans which have no counterpart in the OpenTelemetry
+// semantic conventions, named after the ones understood by the Langfuse OTLP endpoint.
+const (
+	AttributeServiceName           = "service.name"
+	AttributeLangfuseTraceName     = "langfuse.trace.name"
+	AttributeLangfuseTraceInput    = "langfuse.trace.input"
+	AttributeLangfuseTraceOutput   = "langfuse.trace.output"
+	AttributeLangfuseTraceMetadata = "langfuse.trace.metadata"
+	AttributeLangfuseTraceTags     = "langfuse.trace.tags"
+	AttributeLangfuseUserID        = "langfuse.user.id"
+	AttributeLangfuseSessionID     = "langfuse.session.id"
+	AttributeLangfuseRelease       = "langfuse.release"
+	AttributeLangfuseVersion       = "langfuse.version"
+	AttributeLangfuseType          = "langfuse.observation.type"
+	AttributeLangfuseInput         = "langfuse.observation.input"
+	AttributeLangfuseOutput        = "langfuse.observation.output"
+	AttributeLangfuseMetadata      = "langfuse.observation.metadata"
+	AttributeLangfuseCost          = "langfuse.observation.cost"
+)
+
+// scopeName is the instrumentation scope of the converted spans.
+const scopeName = "github.com/git-hulk/langfuse-go"
+
+// ConvertTraces converts the traces to an OTLP export request of the given service.
+//
+// Each trace becomes a root span holding the trace attributes, and each observation becomes
+// a child span of its parent observation, or of the root span if it has no parent. Trace and
+// observation IDs generated by this SDK are valid OTLP IDs and are kept, other IDs are hashed
+// so that they stay stable across exports.
+func ConvertTraces(serviceName string, traceList ...*traces.Trace) *ExportTraceServiceRequest {
+	spans := make([]Span, 0)
+	for _, trace := range traceList {
+		spans = append(spans, ConvertTrace(trace)...)
+	}
+	return &ExportTraceServiceRequest{
+		ResourceSpans: []ResourceSpans{{
+			Resource: Resource{Attributes: []KeyValue{
+				{Key: AttributeServiceName, Value: StringValue(serviceName)},
+			}},
+			ScopeSpans: []ScopeSpans{{
+				Scope: Scope{Name: scopeName},
+				Spans: spans,
+			}},
+		}},
+	}
+}
+
+// ConvertTrace converts a trace and its observations to OTLP spans, see ConvertTraces.
+func ConvertTrace(trace *traces.Trace) []Span {
+	traceID := toTraceID(trace.ID)
+	rootSpanID := hashID(8, "trace:"+trace.ID)
+	observations := trace.Observations()
+
+	spanIDs := make(map[string]string, len(observations))
+	for _, observation := range observations {
+		spanIDs[observation.ID] = toSpanID(observation.ID)
+	}
+
+	spans := make([]Span, 0, len(observations)+1)
+	spans = append(spans, Span{
+		TraceID:           traceID,
+		SpanID:            rootSpanID,
+		Name:              trace.Name,
+		Kind:              SpanKindInternal,
+		StartTimeUnixNano: unixNano(trace.Timestamp),
+		EndTimeUnixNano:   unixNano(traceEndTime(trace, observations)),
+		Attributes:        traceAttributes(&trace.TraceEntry),
+	})
+	for _, observation := range observations {
+		parentSpanID, ok := spanIDs[observation.ParentObservationID]
+		if !ok || observation.ParentObservationID == observation.ID {
+			parentSpanID = rootSpanID
+		}
+		spans = append(spans, convertObservation(traceID, spanIDs[observation.ID], parentSpanID, observation))
+	}
+	return spans
+}
+
+func convertObservation(traceID, spanID, parentSpanID string, observation *traces.Observation) Span {
+	endTime := observation.StartTime
+	if observation.EndTime != nil {
+		endTime = *observation.EndTime
+	}
+	kind := SpanKindInternal
+	if observation.Type == traces.ObservationTypeGeneration || observation.Type == traces.ObservationTypeEmbedding {
+		kind = SpanKindClient
+	}
+	status := Status{Code: StatusCodeUnset}
+	if observation.Level == traces.ObservationLevelError {
+		status = Status{Code: StatusCodeError, Message: observation.StatusMessage}
+	}
+	return Span{
+		TraceID:           traceID,
+		SpanID:            spanID,
+		ParentSpanID:      parentSpanID,
+		Name:              observation.Name,
+		Kind:              kind,
+		StartTimeUnixNano: unixNano(observation.StartTime),
+		EndTimeUnixNano:   unixNano(endTime),
+		Attributes:        observationAttributes(observation),
+		Status:            status,
+	}
+}
+
+func traceAttributes(trace *traces.TraceEntry) []KeyValue {
+	attributes := make([]KeyValue, 0)
+	attributes = appendString(attributes, AttributeLangfuseTraceName, trace.Name)
+	attributes = appendString(attributes, AttributeLangfuseUserID, trace.UserID)
+	attributes = appendString(attributes, AttributeLangfuseSessionID, trace.SessionID)
+	attributes = appendString(attributes, AttributeLangfuseRelease, trace.Release)
+	attributes = appendString(attributes, AttributeLangfuseVersion, trace.Version)
+	attributes = appendString(attributes, traces.AttributeLangfuseEnvironment, trace.Environment)
+	attributes = appendJSON(attributes, AttributeLangfuseTraceInput, trace.Input)
+	attributes = appendJSON(attributes, AttributeLangfuseTraceOutput, trace.Output)
+	attributes = appendJSON(attributes, AttributeLangfuseTraceMetadata, trace.Metadata)
+	if len(trace.Tags) > 0 {
+		attributes = append(attributes, KeyValue{Key: AttributeLangfuseTraceTags, Value: StringArrayValue(trace.Tags)})
+	}
+	return attributes
+}
+
+func observationAttributes(observation *traces.Observation) []KeyValue {
+	attributes := []KeyValue{{Key: AttributeLangfuseType, Value: StringValue(string(observation.Type))}}
+	attributes = appendString(attributes, traces.AttributeRequestModel, observation.Model)
+	attributes = appendString(attributes, traces.AttributeLangfuseLevel, string(observation.Level))
+	attributes = appendString(attributes, traces.AttributeLangfuseStatusMessage, observation.StatusMessage)
+	attributes = appendString(attributes, traces.AttributeLangfusePromptName, observation.PromptName)
+	if observation.PromptVersion != 0 {
+		attributes = append(attributes, KeyValue{
+			Key:   traces.AttributeLangfusePromptVersion,
+			Value: IntValue(int64(observation.PromptVersion)),
+		})
+	}
+	attributes = appendString(attributes, traces.AttributeLangfuseEnvironment, observation.Environment)
+	attributes = appendInt(attributes, traces.AttributeUsageInputTokens, observation.Usage.Input)
+	attributes = appendInt(attributes, traces.AttributeUsageOutputTokens, observation.Usage.Output)
+	attributes = appendInt(attributes, traces.AttributeUsageTotalTokens, observation.Usage.Total)
+	if cost, ok := observation.CostDetails["total"]; ok {
+		attributes = append(attributes, KeyValue{Key: AttributeLangfuseCost, Value: DoubleValue(cost)})
+	}
+
+	keys := make([]string, 0, len(observation.ModelParameters))
+	for key := range observation.ModelParameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		attributes = append(attributes, KeyValue{Key: "gen_ai.request." + key, Value: toValue(observation.ModelParameters[key])})
+	}
+
+	attributes = appendJSON(attributes, AttributeLangfuseInput, observation.Input)
+	attributes = appendJSON(attributes, AttributeLangfuseOutput, observation.Output)
+	attributes = appendJSON(attributes, AttributeLangfuseMetadata, observation.Metadata)
+	return attributes
+}
+
+func appendString(attributes []KeyValue, key, value string) []KeyValue {
+	if value == "" {
+		return attributes
+	}
+	return append(attributes, KeyValue{Key: key, Value: StringValue(value)})
+}
+
+func appendInt(attributes []KeyValue, key string, value int) []KeyValue {
+	if value == 0 {
+		return attributes
+	}
+	return append(attributes, KeyValue{Key: key, Value: IntValue(int64(value))})
+}
+
+// appendJSON adds the value encoded as JSON, since OTLP attributes can't hold structured values.
+func appendJSON(attributes []KeyValue, key string, value any) []KeyValue {
+	if value == nil {
+		return attributes
+	}
+	if s, ok := value.(string); ok {
+		return appendString(attributes, key, s)
+	}
+	data, err := json.Marshal(value)
+	if err != nil || string(data) == "null" {
+		return attributes
+	}
+	return append(attributes, KeyValue{Key: key, Value: StringValue(string(data))})
+}
+
+func toValue(value any) AnyValue {
+	switch v := value.(type) {
+	case string:
+		return StringValue(v)
+	case bool:
+		return BoolValue(v)
+	case int:
+		return IntValue(int64(v))
+	case int64:
+		return IntValue(v)
+	case float64:
+		return DoubleValue(v)
+	case float32:
+		return DoubleValue(float64(v))
+	}
+	data, _ := json.Marshal(value)
+	return StringValue(string(data))
+}
+
+func traceEndTime(trace *traces.Trace, observations []*traces.Observation) time.Time {
+	if trace.Latency > 0 {
+		return trace.Timestamp.Add(time.Duration(trace.Latency) * time.Millisecond)
+	}
+	endTime := trace.Timestamp
+	for _, observation := range observations {
+		if observation.EndTime != nil && observation.EndTime.After(endTime) {
+			endTime = *observation.EndTime
+		}
+	}
+	return endTime
+}
+
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
+
+func toTraceID(id string) string {
+	if traceID, err := traces.FromTraceID(id); err == nil {
+		return traceID.String()
+	}
+	return hashID(16, id)
+}
+
+func toSpanID(id string) string {
+	if spanID, err := traces.FromSpanID(id); err == nil {
+		return spanID.String()
+	}
+	return hashID(8, id)
+}
+
+// hashID derives an ID of the given number of bytes from an arbitrary string.
+func hashID(size int, id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:size])
+}
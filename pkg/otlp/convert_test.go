@@ -0,0 +1,104 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+func attributeMap(attributes []KeyValue) map[string]AnyValue {
+	m := make(map[string]AnyValue, len(attributes))
+	for _, attribute := range attributes {
+		m[attribute.Key] = attribute.Value
+	}
+	return m
+}
+
+func newTestTrace(t *testing.T) *traces.Trace {
+	ingestor := traces.NewIngestor(resty.New())
+	t.Cleanup(func() { _ = ingestor.Close() })
+
+	trace := ingestor.StartTrace(context.Background(), "chat", traces.WithUser("user-1"), traces.WithTags("prod"))
+	trace.Input = map[string]any{"question": "hi"}
+	trace.Timestamp = time.Unix(100, 0)
+	trace.Latency = 2000
+
+	span := trace.StartSpan("retrieve")
+	span.StartTime = time.Unix(100, 0)
+	spanEnd := time.Unix(101, 0)
+	span.EndTime = &spanEnd
+
+	generation := trace.StartGeneration("llm")
+	generation.ParentObservationID = span.ID
+	generation.StartTime = time.Unix(100, 500)
+	generation.Model = "gpt-4o"
+	generation.ModelParameters = map[string]any{"temperature": 0.2}
+	generation.Usage = traces.Usage{Input: 10, Output: 5, Total: 15}
+	generation.Level = traces.ObservationLevelError
+	generation.StatusMessage = "rate limited"
+	return trace
+}
+
+func TestConvertTrace(t *testing.T) {
+	trace := newTestTrace(t)
+	observations := trace.Observations()
+	spans := ConvertTrace(trace)
+	require.Len(t, spans, 3)
+
+	root := spans[0]
+	require.Equal(t, trace.ID, root.TraceID)
+	require.Empty(t, root.ParentSpanID)
+	require.Equal(t, "chat", root.Name)
+	require.Equal(t, uint64(100*time.Second), root.StartTimeUnixNano)
+	require.Equal(t, uint64(102*time.Second), root.EndTimeUnixNano)
+	rootAttributes := attributeMap(root.Attributes)
+	require.Equal(t, "user-1", *rootAttributes[AttributeLangfuseUserID].StringValue)
+	require.Equal(t, `{"question":"hi"}`, *rootAttributes[AttributeLangfuseTraceInput].StringValue)
+	require.Len(t, rootAttributes[AttributeLangfuseTraceTags].ArrayValue.Values, 1)
+
+	span := spans[1]
+	require.Equal(t, observations[0].ID, span.SpanID)
+	require.Equal(t, root.SpanID, span.ParentSpanID)
+	require.Equal(t, SpanKindInternal, span.Kind)
+	require.Equal(t, uint64(101*time.Second), span.EndTimeUnixNano)
+
+	generation := spans[2]
+	require.Equal(t, span.SpanID, generation.ParentSpanID)
+	require.Equal(t, SpanKindClient, generation.Kind)
+	require.Equal(t, generation.StartTimeUnixNano, generation.EndTimeUnixNano)
+	require.Equal(t, Status{Code: StatusCodeError, Message: "rate limited"}, generation.Status)
+	attributes := attributeMap(generation.Attributes)
+	require.Equal(t, "GENERATION", *attributes[AttributeLangfuseType].StringValue)
+	require.Equal(t, "gpt-4o", *attributes[traces.AttributeRequestModel].StringValue)
+	require.Equal(t, "10", *attributes[traces.AttributeUsageInputTokens].IntValue)
+	require.Equal(t, 0.2, *attributes["gen_ai.request.temperature"].DoubleValue)
+}
+
+func TestConvertTrace_CustomIDs(t *testing.T) {
+	trace := newTestTrace(t)
+	trace.ID = "custom-trace"
+	spans := ConvertTrace(trace)
+	require.Len(t, spans[0].TraceID, 32)
+	require.Equal(t, spans[0].TraceID, ConvertTrace(trace)[0].TraceID)
+}
+
+func TestConvertTraces_JSON(t *testing.T) {
+	request := ConvertTraces("chat-api", newTestTrace(t))
+	data, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	resourceSpans := decoded["resourceSpans"].([]any)[0].(map[string]any)
+	resource := resourceSpans["resource"].(map[string]any)
+	require.Equal(t, map[string]any{"key": "service.name", "value": map[string]any{"stringValue": "chat-api"}},
+		resource["attributes"].([]any)[0])
+	span := resourceSpans["scopeSpans"].([]any)[0].(map[string]any)["spans"].([]any)[0].(map[string]any)
+	require.Equal(t, "100000000000", span["startTimeUnixNano"])
+}
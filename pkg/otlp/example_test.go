@@ -0,0 +1,24 @@
+package otlp_test
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/git-hulk/langfuse-go/pkg/otlp"
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+func ExampleNewExporter() {
+	exporter := otlp.NewExporter("https://otel-collector:4318/v1/traces",
+		otlp.WithServiceName("support-bot"),
+		otlp.WithHeaders(map[string]string{"Authorization": "Bearer token"}),
+	)
+	ingestor := traces.NewIngestor(resty.New().SetBaseURL("https://cloud.langfuse.com/api/public"),
+		traces.WithTraceExporter(exporter),
+	)
+	defer ingestor.Close()
+
+	trace := ingestor.StartTrace(context.Background(), "question")
+	trace.End()
+}
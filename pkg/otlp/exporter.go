@@ -0,0 +1,96 @@
+package otlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+const (
+	defaultServiceName = "langfuse-go"
+	// defaultTimeout bounds the export requests of the default resty client.
+	defaultTimeout = 10 * time.Second
+)
+
+// Exporter sends traces to an OTLP/HTTP endpoint with the JSON encoding.
+//
+// It implements traces.TraceExporter, so it can be registered with the ingestor to
+// double-write every trace sent to Langfuse.
+type Exporter struct {
+	restyCli    *resty.Client
+	endpoint    string
+	serviceName string
+	headers     map[string]string
+}
+
+// ExporterOption configures an Exporter.
+type ExporterOption func(*Exporter)
+
+// WithServiceName sets the service.name resource attribute of the exported spans.
+// Default is "langfuse-go".
+func WithServiceName(serviceName string) ExporterOption {
+	return func(e *Exporter) {
+		e.serviceName = serviceName
+	}
+}
+
+// WithHeaders sets headers sent with every export request, e.g. for authentication.
+func WithHeaders(headers map[string]string) ExporterOption {
+	return func(e *Exporter) {
+		for key, value := range headers {
+			e.headers[key] = value
+		}
+	}
+}
+
+// WithRestyClient sets the resty client used to send the export requests. The default
+// client times out the requests after 10 seconds.
+func WithRestyClient(cli *resty.Client) ExporterOption {
+	return func(e *Exporter) {
+		e.restyCli = cli
+	}
+}
+
+// NewExporter creates an exporter sending the spans to the given URL of the traces
+// endpoint, e.g. "http://localhost:4318/v1/traces".
+func NewExporter(endpoint string, options ...ExporterOption) *Exporter {
+	exporter := &Exporter{
+		restyCli:    resty.New().SetTimeout(defaultTimeout),
+		endpoint:    endpoint,
+		serviceName: defaultServiceName,
+		headers:     make(map[string]string),
+	}
+	for _, option := range options {
+		option(exporter)
+	}
+	return exporter
+}
+
+// Export converts the traces to OTLP spans and sends them in a single request.
+func (e *Exporter) Export(ctx context.Context, traceList []*traces.Trace) error {
+	if e.endpoint == "" {
+		return errors.New("'endpoint' is required")
+	}
+	if len(traceList) == 0 {
+		return nil
+	}
+
+	rsp, err := e.restyCli.R().
+		SetContext(ctx).
+		SetHeaders(e.headers).
+		SetHeader("Content-Type", "application/json").
+		SetBody(ConvertTraces(e.serviceName, traceList...)).
+		Post(e.endpoint)
+	if err != nil {
+		return err
+	}
+	if rsp.IsError() {
+		return fmt.Errorf("export traces failed: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+func TestExporter_Export(t *testing.T) {
+	var received ExportTraceServiceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/v1/traces", r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The headers are kept with a custom resty client set afterwards
+	exporter := NewExporter(server.URL+"/v1/traces",
+		WithServiceName("chat-api"),
+		WithHeaders(map[string]string{"X-Api-Key": "secret"}),
+		WithRestyClient(resty.New()))
+	trace := newTestTrace(t)
+	require.NoError(t, exporter.Export(context.Background(), []*traces.Trace{trace}))
+	require.Len(t, received.ResourceSpans, 1)
+	require.Len(t, received.ResourceSpans[0].ScopeSpans[0].Spans, 3)
+	require.Equal(t, trace.ID, received.ResourceSpans[0].ScopeSpans[0].Spans[0].TraceID)
+}
+
+func TestExporter_ExportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("bad spans"))
+	}))
+	defer server.Close()
+
+	err := NewExporter(server.URL).Export(context.Background(), []*traces.Trace{newTestTrace(t)})
+	require.EqualError(t, err, "export traces failed: bad spans, got status code: 400")
+
+	err = NewExporter("").Export(context.Background(), nil)
+	require.EqualError(t, err, "'endpoint' is required")
+}
@@ -0,0 +1,122 @@
+// Package otlp converts Langfuse traces to OpenTelemetry spans in the OTLP format.
+//
+// This allows double-writing the traces to an existing observability backend like Tempo
+// or Jaeger while migrating to Langfuse. The spans are encoded with the JSON mapping of the
+// OTLP protobuf messages, which every OTLP/HTTP receiver accepts at /v1/traces.
+//
+// Example:
+//
+//	exporter := otlp.NewExporter("http://tempo:4318/v1/traces", otlp.WithServiceName("chat-api"))
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithTraceExporter(exporter))
+package otlp
+
+import "strconv"
+
+// Span kinds of the OTLP protocol.
+const (
+	SpanKindInternal = 1
+	SpanKindClient   = 3
+)
+
+// Status codes of the OTLP protocol.
+const (
+	StatusCodeUnset = 0
+	StatusCodeError = 2
+)
+
+// ExportTraceServiceRequest is the body of an OTLP trace export request.
+type ExportTraceServiceRequest struct {
+	ResourceSpans []ResourceSpans `json:"resourceSpans"`
+}
+
+// ResourceSpans holds the spans produced by a resource, e.g. a service.
+type ResourceSpans struct {
+	Resource   Resource     `json:"resource"`
+	ScopeSpans []ScopeSpans `json:"scopeSpans"`
+}
+
+// Resource describes the entity producing the spans.
+type Resource struct {
+	Attributes []KeyValue `json:"attributes,omitempty"`
+}
+
+// ScopeSpans holds the spans produced by an instrumentation scope.
+type ScopeSpans struct {
+	Scope Scope  `json:"scope"`
+	Spans []Span `json:"spans"`
+}
+
+// Scope is the instrumentation scope which produced the spans.
+type Scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// Span is a single operation within a trace.
+type Span struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano uint64     `json:"startTimeUnixNano,string"`
+	EndTimeUnixNano   uint64     `json:"endTimeUnixNano,string"`
+	Attributes        []KeyValue `json:"attributes,omitempty"`
+	Status            Status     `json:"status"`
+}
+
+// Status is the status of a span.
+type Status struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// KeyValue is an attribute of a span or resource.
+type KeyValue struct {
+	Key   string   `json:"key"`
+	Value AnyValue `json:"value"`
+}
+
+// AnyValue is the value of an attribute, exactly one of the fields is set.
+type AnyValue struct {
+	StringValue *string     `json:"stringValue,omitempty"`
+	BoolValue   *bool       `json:"boolValue,omitempty"`
+	IntValue    *string     `json:"intValue,omitempty"` // int64 encoded as a string
+	DoubleValue *float64    `json:"doubleValue,omitempty"`
+	ArrayValue  *ArrayValue `json:"arrayValue,omitempty"`
+}
+
+// ArrayValue is a list of attribute values.
+type ArrayValue struct {
+	Values []AnyValue `json:"values"`
+}
+
+// StringValue creates a string attribute value.
+func StringValue(v string) AnyValue {
+	return AnyValue{StringValue: &v}
+}
+
+// BoolValue creates a boolean attribute value.
+func BoolValue(v bool) AnyValue {
+	return AnyValue{BoolValue: &v}
+}
+
+// IntValue creates an integer attribute value.
+func IntValue(v int64) AnyValue {
+	s := strconv.FormatInt(v, 10)
+	return AnyValue{IntValue: &s}
+}
+
+// DoubleValue creates a floating point attribute value.
+func DoubleValue(v float64) AnyValue {
+	return AnyValue{DoubleValue: &v}
+}
+
+// StringArrayValue creates an attribute value holding a list of strings.
+func StringArrayValue(values []string) AnyValue {
+	array := &ArrayValue{Values: make([]AnyValue, 0, len(values))}
+	for _, v := range values {
+		array.Values = append(array.Values, StringValue(v))
+	}
+	return AnyValue{ArrayValue: array}
+}
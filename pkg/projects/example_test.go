@@ -0,0 +1,24 @@
+package projects_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	langfuse "github.com/git-hulk/langfuse-go"
+	"github.com/git-hulk/langfuse-go/pkg/projects"
+)
+
+func ExampleClient_Create() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...")
+	defer client.Close()
+
+	project, err := client.Projects().Create(context.Background(), &projects.CreateProjectRequest{
+		Name:      "support-bot",
+		Retention: 30,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(project.ID)
+}
@@ -7,11 +7,14 @@ package projects
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
 )
 
 // Project represents a Langfuse project with its configuration and metadata.
@@ -23,6 +26,23 @@ type Project struct {
 	Name          string         `json:"name"`
 	Metadata      map[string]any `json:"metadata,omitempty"`
 	RetentionDays int            `json:"retentionDays,omitempty"`
+	// Extra holds the response fields which are not known to this SDK version.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements custom JSON unmarshalling for Project.
+// Fields which are not known to the SDK are preserved in Extra.
+func (p *Project) UnmarshalJSON(data []byte) error {
+	type Alias Project
+	if err := json.Unmarshal(data, (*Alias)(p)); err != nil {
+		return err
+	}
+	extra, err := common.UnknownFields(data, Alias{})
+	if err != nil {
+		return err
+	}
+	p.Extra = extra
+	return nil
 }
 
 // CreateProjectRequest represents the parameters for creating a new project.
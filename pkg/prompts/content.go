@@ -0,0 +1,117 @@
+package prompts
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	ContentPartTypeText     = "text"
+	ContentPartTypeImageURL = "image_url"
+)
+
+// ImageURL references an image of a multi-modal chat message.
+//
+// URL can be either a http(s) URL or a data URL with the base64 encoded image.
+// Detail is an optional hint for the image resolution, e.g. "low", "high" or "auto".
+type ImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ContentPart is a single part of a multi-modal chat message content.
+//
+// Type determines which field is set: Text for "text" and ImageURL for "image_url".
+type ContentPart struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	ImageURL *ImageURL `json:"image_url,omitempty"`
+}
+
+// TextPart creates a text content part.
+func TextPart(text string) ContentPart {
+	return ContentPart{Type: ContentPartTypeText, Text: text}
+}
+
+// ImagePart creates an image content part which references the image by URL.
+func ImagePart(url string) ContentPart {
+	return ContentPart{Type: ContentPartTypeImageURL, ImageURL: &ImageURL{URL: url}}
+}
+
+func (p *ContentPart) validate() error {
+	switch p.Type {
+	case ContentPartTypeText:
+		if p.Text == "" {
+			return errors.New("'text' is required when content part type is 'text'")
+		}
+	case ContentPartTypeImageURL:
+		if p.ImageURL == nil || p.ImageURL.URL == "" {
+			return errors.New("'image_url.url' is required when content part type is 'image_url'")
+		}
+	default:
+		return fmt.Errorf("invalid content part type: '%s'", p.Type)
+	}
+	return nil
+}
+
+// compile renders the variables in the text and the image URL of the content part.
+func (p ContentPart) compile(variables map[string]any) ContentPart {
+	p.Text = newTemplateCompiler(p.Text).compile(variables)
+	if p.ImageURL != nil {
+		imageURL := *p.ImageURL
+		imageURL.URL = newTemplateCompiler(imageURL.URL).compile(variables)
+		p.ImageURL = &imageURL
+	}
+	return p
+}
+
+// MarshalJSON implements custom JSON marshalling for ChatMessageWithPlaceHolder.
+// The content is encoded as an array of content parts if ContentParts is set,
+// otherwise as a plain string.
+func (c ChatMessageWithPlaceHolder) MarshalJSON() ([]byte, error) {
+	type Alias ChatMessageWithPlaceHolder
+	if len(c.ContentParts) == 0 {
+		return json.Marshal(Alias(c))
+	}
+	return json.Marshal(&struct {
+		Alias
+		Content []ContentPart `json:"content"`
+	}{
+		Alias:   Alias(c),
+		Content: c.ContentParts,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshalling for ChatMessageWithPlaceHolder.
+// It decodes the content into Content if it's a string, or into ContentParts
+// if it's an array of content parts.
+func (c *ChatMessageWithPlaceHolder) UnmarshalJSON(data []byte) error {
+	type Alias ChatMessageWithPlaceHolder
+	temp := &struct {
+		*Alias
+		Content json.RawMessage `json:"content,omitempty"`
+	}{
+		Alias: (*Alias)(c),
+	}
+	if err := json.Unmarshal(data, temp); err != nil {
+		return err
+	}
+
+	c.Content = ""
+	c.ContentParts = nil
+	content := bytes.TrimSpace(temp.Content)
+	switch {
+	case len(content) == 0 || bytes.Equal(content, []byte("null")):
+	case content[0] == '[':
+		if err := json.Unmarshal(content, &c.ContentParts); err != nil {
+			return fmt.Errorf("failed to unmarshal message content as []ContentPart: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(content, &c.Content); err != nil {
+			return fmt.Errorf("failed to unmarshal message content as string: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package prompts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatMessageWithPlaceHolder_JSON(t *testing.T) {
+	var entry PromptEntry
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"vision","type":"chat","prompt":[
+		{"role":"system","type":"chatmessage","content":"You describe images."},
+		{"role":"user","type":"chatmessage","content":[
+			{"type":"text","text":"Describe {{subject}}"},
+			{"type":"image_url","image_url":{"url":"https://example.com/{{image}}.png","detail":"low"}}
+		]}
+	]}`), &entry))
+
+	messages, ok := entry.Prompt.([]ChatMessageWithPlaceHolder)
+	require.True(t, ok)
+	require.Equal(t, "You describe images.", messages[0].Content)
+	require.Nil(t, messages[0].ContentParts)
+	require.Empty(t, messages[1].Content)
+	require.Equal(t, []ContentPart{
+		TextPart("Describe {{subject}}"),
+		{Type: ContentPartTypeImageURL, ImageURL: &ImageURL{URL: "https://example.com/{{image}}.png", Detail: "low"}},
+	}, messages[1].ContentParts)
+	require.NoError(t, entry.validate())
+
+	data, err := json.Marshal(messages)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"role":"system","type":"chatmessage","content":"You describe images."},
+		{"role":"user","type":"chatmessage","content":[
+			{"type":"text","text":"Describe {{subject}}"},
+			{"type":"image_url","image_url":{"url":"https://example.com/{{image}}.png","detail":"low"}}
+		]}
+	]`, string(data))
+
+	compiled, err := entry.Compile(map[string]any{"subject": "the cat", "image": "cat"})
+	require.NoError(t, err)
+	compiledMessages := compiled.([]ChatMessageWithPlaceHolder)
+	require.Equal(t, "Describe the cat", compiledMessages[1].ContentParts[0].Text)
+	require.Equal(t, "https://example.com/cat.png", compiledMessages[1].ContentParts[1].ImageURL.URL)
+	// The original prompt is left untouched
+	require.Equal(t, "https://example.com/{{image}}.png", messages[1].ContentParts[1].ImageURL.URL)
+}
+
+func TestChatMessageWithPlaceHolder_validateContentParts(t *testing.T) {
+	tests := []struct {
+		name    string
+		message ChatMessageWithPlaceHolder
+		wantErr string
+	}{
+		{"valid parts", ChatMessageWithPlaceHolder{Role: "user", ContentParts: []ContentPart{TextPart("hi"), ImagePart("https://example.com/a.png")}}, ""},
+		{"content and parts", ChatMessageWithPlaceHolder{Role: "user", Content: "hi", ContentParts: []ContentPart{TextPart("hi")}},
+			"only one of Content and ContentParts can be set"},
+		{"empty text", ChatMessageWithPlaceHolder{Role: "user", ContentParts: []ContentPart{{Type: ContentPartTypeText}}},
+			"invalid content part 0: 'text' is required when content part type is 'text'"},
+		{"missing image url", ChatMessageWithPlaceHolder{Role: "user", ContentParts: []ContentPart{TextPart("hi"), {Type: ContentPartTypeImageURL}}},
+			"invalid content part 1: 'image_url.url' is required when content part type is 'image_url'"},
+		{"unknown type", ChatMessageWithPlaceHolder{Role: "user", ContentParts: []ContentPart{{Type: "audio"}}},
+			"invalid content part 0: invalid content part type: 'audio'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.message.validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
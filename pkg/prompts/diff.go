@@ -0,0 +1,273 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ChangeKind is the kind of a change between two prompt versions.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// MessageChange is a change of a chat message, matched by its position in the prompt.
+type MessageChange struct {
+	Index int                         `json:"index"`
+	Kind  ChangeKind                  `json:"kind"`
+	From  *ChatMessageWithPlaceHolder `json:"from,omitempty"`
+	To    *ChatMessageWithPlaceHolder `json:"to,omitempty"`
+}
+
+// ConfigChange is a change of a config value. Keys of nested objects are joined with dots,
+// e.g. "response_format.type".
+type ConfigChange struct {
+	Key  string     `json:"key"`
+	Kind ChangeKind `json:"kind"`
+	From any        `json:"from,omitempty"`
+	To   any        `json:"to,omitempty"`
+}
+
+// TextChange is a change of a value which is compared as a whole, like a text prompt.
+type TextChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// PromptDiff is the structured difference between two versions of a prompt.
+type PromptDiff struct {
+	Name          string          `json:"name"`
+	FromVersion   int             `json:"fromVersion"`
+	ToVersion     int             `json:"toVersion"`
+	Type          *TextChange     `json:"type,omitempty"`
+	Text          *TextChange     `json:"text,omitempty"`
+	Messages      []MessageChange `json:"messages,omitempty"`
+	Config        []ConfigChange  `json:"config,omitempty"`
+	AddedLabels   []string        `json:"addedLabels,omitempty"`
+	RemovedLabels []string        `json:"removedLabels,omitempty"`
+	AddedTags     []string        `json:"addedTags,omitempty"`
+	RemovedTags   []string        `json:"removedTags,omitempty"`
+}
+
+// Diff retrieves two versions of a prompt and returns their structured difference,
+// e.g. to post a summary of the prompt changes to a pull request.
+//
+// Example:
+//
+//	diff, err := client.Prompts().Diff(ctx, "support-agent", 3, 4)
+//	if err != nil {
+//		return err
+//	}
+//	if diff.HasChanges() {
+//		fmt.Println(diff)
+//	}
+func (c *Client) Diff(ctx context.Context, name string, fromVersion, toVersion int) (*PromptDiff, error) {
+	if fromVersion <= 0 || toVersion <= 0 {
+		return nil, errors.New("'fromVersion' and 'toVersion' are required")
+	}
+	from, err := c.Get(ctx, GetParams{Name: name, Version: fromVersion})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version %d: %w", fromVersion, err)
+	}
+	to, err := c.Get(ctx, GetParams{Name: name, Version: toVersion})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version %d: %w", toVersion, err)
+	}
+	return DiffPrompts(from, to), nil
+}
+
+// DiffPrompts returns the structured difference between two prompts.
+//
+// Chat messages are compared by position, so inserting a message reports the following
+// messages as modified. Text prompts are reported as a whole.
+func DiffPrompts(from, to *PromptEntry) *PromptDiff {
+	diff := &PromptDiff{Name: to.Name, FromVersion: from.Version, ToVersion: to.Version}
+	if !strings.EqualFold(from.Type, to.Type) {
+		diff.Type = &TextChange{From: from.Type, To: to.Type}
+	}
+
+	fromText, fromIsText := from.Prompt.(string)
+	toText, toIsText := to.Prompt.(string)
+	switch {
+	case fromIsText && toIsText:
+		if fromText != toText {
+			diff.Text = &TextChange{From: fromText, To: toText}
+		}
+	case fromIsText || toIsText:
+		diff.Text = &TextChange{From: promptText(from.Prompt), To: promptText(to.Prompt)}
+	default:
+		diff.Messages = diffMessages(promptMessages(from.Prompt), promptMessages(to.Prompt))
+	}
+
+	diff.Config = diffConfig("", configMap(from.Config), configMap(to.Config))
+	diff.AddedLabels, diff.RemovedLabels = diffStrings(from.Labels, to.Labels)
+	diff.AddedTags, diff.RemovedTags = diffStrings(from.Tags, to.Tags)
+	return diff
+}
+
+// HasChanges reports whether the prompts differ.
+func (d *PromptDiff) HasChanges() bool {
+	return d.Type != nil || d.Text != nil || len(d.Messages) > 0 || len(d.Config) > 0 ||
+		len(d.AddedLabels) > 0 || len(d.RemovedLabels) > 0 || len(d.AddedTags) > 0 || len(d.RemovedTags) > 0
+}
+
+// String summarizes the changes in a human-readable form.
+func (d *PromptDiff) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Prompt %q: version %d -> %d\n", d.Name, d.FromVersion, d.ToVersion)
+	if !d.HasChanges() {
+		sb.WriteString("no changes\n")
+		return sb.String()
+	}
+	if d.Type != nil {
+		fmt.Fprintf(&sb, "type: %s -> %s\n", d.Type.From, d.Type.To)
+	}
+	if d.Text != nil {
+		fmt.Fprintf(&sb, "text:\n- %s\n+ %s\n", d.Text.From, d.Text.To)
+	}
+	for _, change := range d.Messages {
+		switch change.Kind {
+		case ChangeAdded:
+			fmt.Fprintf(&sb, "message %d added:\n+ %s\n", change.Index, formatMessage(change.To))
+		case ChangeRemoved:
+			fmt.Fprintf(&sb, "message %d removed:\n- %s\n", change.Index, formatMessage(change.From))
+		default:
+			fmt.Fprintf(&sb, "message %d modified:\n- %s\n+ %s\n", change.Index, formatMessage(change.From), formatMessage(change.To))
+		}
+	}
+	for _, change := range d.Config {
+		switch change.Kind {
+		case ChangeAdded:
+			fmt.Fprintf(&sb, "config %s added: %v\n", change.Key, change.To)
+		case ChangeRemoved:
+			fmt.Fprintf(&sb, "config %s removed: %v\n", change.Key, change.From)
+		default:
+			fmt.Fprintf(&sb, "config %s: %v -> %v\n", change.Key, change.From, change.To)
+		}
+	}
+	writeStrings(&sb, "labels added", d.AddedLabels)
+	writeStrings(&sb, "labels removed", d.RemovedLabels)
+	writeStrings(&sb, "tags added", d.AddedTags)
+	writeStrings(&sb, "tags removed", d.RemovedTags)
+	return sb.String()
+}
+
+func diffMessages(from, to []ChatMessageWithPlaceHolder) []MessageChange {
+	changes := make([]MessageChange, 0)
+	for i := 0; i < max(len(from), len(to)); i++ {
+		switch {
+		case i >= len(from):
+			changes = append(changes, MessageChange{Index: i, Kind: ChangeAdded, To: &to[i]})
+		case i >= len(to):
+			changes = append(changes, MessageChange{Index: i, Kind: ChangeRemoved, From: &from[i]})
+		case !sameJSON(normalizePrompt([]ChatMessageWithPlaceHolder{from[i]}), normalizePrompt([]ChatMessageWithPlaceHolder{to[i]})):
+			changes = append(changes, MessageChange{Index: i, Kind: ChangeModified, From: &from[i], To: &to[i]})
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+func diffConfig(prefix string, from, to map[string]any) []ConfigChange {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []ConfigChange
+	for _, key := range keys {
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+		switch {
+		case !inFrom:
+			changes = append(changes, ConfigChange{Key: prefix + key, Kind: ChangeAdded, To: toValue})
+		case !inTo:
+			changes = append(changes, ConfigChange{Key: prefix + key, Kind: ChangeRemoved, From: fromValue})
+		default:
+			fromMap, fromIsMap := fromValue.(map[string]any)
+			toMap, toIsMap := toValue.(map[string]any)
+			if fromIsMap && toIsMap {
+				changes = append(changes, diffConfig(prefix+key+".", fromMap, toMap)...)
+			} else if !sameJSON(fromValue, toValue) {
+				changes = append(changes, ConfigChange{Key: prefix + key, Kind: ChangeModified, From: fromValue, To: toValue})
+			}
+		}
+	}
+	return changes
+}
+
+// diffStrings returns the values which were added to and removed from the list.
+func diffStrings(from, to []string) (added, removed []string) {
+	for _, v := range to {
+		if !slices.Contains(from, v) {
+			added = append(added, v)
+		}
+	}
+	for _, v := range from {
+		if !slices.Contains(to, v) {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
+
+func sameJSON(a, b any) bool {
+	aJSON, aErr := canonicalJSON(a)
+	bJSON, bErr := canonicalJSON(b)
+	return aErr == nil && bErr == nil && string(aJSON) == string(bJSON)
+}
+
+func configMap(config any) map[string]any {
+	if m, ok := config.(map[string]any); ok {
+		return m
+	}
+	if config == nil {
+		return nil
+	}
+	// Compare configs of other types as a whole
+	return map[string]any{"": config}
+}
+
+func promptMessages(prompt any) []ChatMessageWithPlaceHolder {
+	messages, _ := prompt.([]ChatMessageWithPlaceHolder)
+	return messages
+}
+
+func promptText(prompt any) string {
+	if text, ok := prompt.(string); ok {
+		return text
+	}
+	data, err := canonicalJSON(normalizePrompt(prompt))
+	if err != nil {
+		return fmt.Sprint(prompt)
+	}
+	return string(data)
+}
+
+func formatMessage(message *ChatMessageWithPlaceHolder) string {
+	if message.Type == ChatMessageTypePlaceHolder {
+		return fmt.Sprintf("[placeholder] %s", message.Name)
+	}
+	return fmt.Sprintf("[%s] %s", message.Role, message.Content)
+}
+
+func writeStrings(sb *strings.Builder, title string, values []string) {
+	if len(values) > 0 {
+		fmt.Fprintf(sb, "%s: %s\n", title, strings.Join(values, ", "))
+	}
+}
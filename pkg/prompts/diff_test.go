@@ -0,0 +1,119 @@
+package prompts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffPrompts_Chat(t *testing.T) {
+	from := &PromptEntry{
+		Name:    "support",
+		Type:    "chat",
+		Version: 1,
+		Prompt: []ChatMessageWithPlaceHolder{
+			{Role: "system", Content: "You are helpful."},
+			{Role: "user", Content: "{{question}}"},
+		},
+		Config: map[string]any{
+			"model":           "gpt-4o",
+			"temperature":     0.2,
+			"response_format": map[string]any{"type": "text"},
+		},
+		Labels: []string{"production"},
+	}
+	to := &PromptEntry{
+		Name:    "support",
+		Type:    "chat",
+		Version: 2,
+		Prompt: []ChatMessageWithPlaceHolder{
+			{Role: "system", Content: "You are concise.", Type: ChatMessageTypeMessage},
+			{Role: "user", Content: "{{question}}", Type: ChatMessageTypeMessage},
+			{Type: ChatMessageTypePlaceHolder, Name: "history"},
+		},
+		Config: map[string]any{
+			"model":           "gpt-4o",
+			"max_tokens":      512,
+			"response_format": map[string]any{"type": "json_object"},
+		},
+		Labels: []string{"latest"},
+	}
+
+	diff := DiffPrompts(from, to)
+	require.True(t, diff.HasChanges())
+	require.Nil(t, diff.Type)
+	require.Nil(t, diff.Text)
+
+	require.Len(t, diff.Messages, 2)
+	require.Equal(t, 0, diff.Messages[0].Index)
+	require.Equal(t, ChangeModified, diff.Messages[0].Kind)
+	require.Equal(t, "You are concise.", diff.Messages[0].To.Content)
+	require.Equal(t, 2, diff.Messages[1].Index)
+	require.Equal(t, ChangeAdded, diff.Messages[1].Kind)
+	require.Nil(t, diff.Messages[1].From)
+
+	require.Equal(t, []ConfigChange{
+		{Key: "max_tokens", Kind: ChangeAdded, To: 512},
+		{Key: "response_format.type", Kind: ChangeModified, From: "text", To: "json_object"},
+		{Key: "temperature", Kind: ChangeRemoved, From: 0.2},
+	}, diff.Config)
+	require.Equal(t, []string{"latest"}, diff.AddedLabels)
+	require.Equal(t, []string{"production"}, diff.RemovedLabels)
+
+	summary := diff.String()
+	require.Contains(t, summary, `Prompt "support": version 1 -> 2`)
+	require.Contains(t, summary, "message 2 added:\n+ [placeholder] history")
+	require.Contains(t, summary, "config response_format.type: text -> json_object")
+}
+
+func TestDiffPrompts_Text(t *testing.T) {
+	from := &PromptEntry{Name: "greeting", Type: "text", Version: 1, Prompt: "Hello {{name}}"}
+	to := &PromptEntry{Name: "greeting", Type: "text", Version: 2, Prompt: "Hello {{name}}", Config: map[string]any{}}
+
+	diff := DiffPrompts(from, to)
+	require.False(t, diff.HasChanges())
+	require.Contains(t, diff.String(), "no changes")
+
+	to.Prompt = "Hi {{name}}"
+	diff = DiffPrompts(from, to)
+	require.Equal(t, &TextChange{From: "Hello {{name}}", To: "Hi {{name}}"}, diff.Text)
+	require.Empty(t, diff.Messages)
+}
+
+func TestClient_Diff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/prompts/greeting", r.URL.Path)
+		prompt := PromptEntry{Name: "greeting", Type: "text", Prompt: "Hello"}
+		switch r.URL.Query().Get("version") {
+		case "1":
+			prompt.Version = 1
+		case "2":
+			prompt.Version = 2
+			prompt.Prompt = "Hello there"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(prompt))
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	diff, err := client.Diff(context.Background(), "greeting", 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, diff.FromVersion)
+	require.Equal(t, 2, diff.ToVersion)
+	require.Equal(t, &TextChange{From: "Hello", To: "Hello there"}, diff.Text)
+
+	_, err = client.Diff(context.Background(), "greeting", 1, 3)
+	require.Error(t, err)
+
+	_, err = client.Diff(context.Background(), "greeting", 0, 2)
+	require.Error(t, err)
+}
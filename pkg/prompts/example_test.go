@@ -0,0 +1,63 @@
+package prompts_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	langfuse "github.com/git-hulk/langfuse-go"
+	"github.com/git-hulk/langfuse-go/pkg/prompts"
+)
+
+func ExamplePromptEntry_Compile() {
+	prompt := &prompts.PromptEntry{
+		Name:   "greeting",
+		Type:   "text",
+		Prompt: "Hello {{name}}, welcome to {{product}}!",
+	}
+	compiled, err := prompt.Compile(map[string]any{"name": "Alice", "product": "Langfuse"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(compiled)
+	// Output: Hello Alice, welcome to Langfuse!
+}
+
+func ExamplePromptEntry_Compile_chat() {
+	prompt := &prompts.PromptEntry{
+		Name: "assistant",
+		Type: "chat",
+		Prompt: []prompts.ChatMessageWithPlaceHolder{
+			{Role: "system", Content: "You are a {{tone}} assistant."},
+			{Role: "user", Content: "{{question}}"},
+		},
+	}
+	compiled, err := prompt.Compile(map[string]any{"tone": "friendly", "question": "What is tracing?"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, message := range compiled.([]prompts.ChatMessageWithPlaceHolder) {
+		fmt.Printf("%s: %s\n", message.Role, message.Content)
+	}
+	// Output:
+	// system: You are a friendly assistant.
+	// user: What is tracing?
+}
+
+func ExampleClient_Get() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...")
+	defer client.Close()
+
+	prompt, err := client.Prompts().Get(context.Background(), prompts.GetParams{
+		Name:  "greeting",
+		Label: "production",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	compiled, err := prompt.Compile(map[string]any{"name": "Alice"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(compiled)
+}
@@ -0,0 +1,170 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+const (
+	VariantA = "A"
+	VariantB = "B"
+)
+
+// Metadata keys used to record an experiment assignment on a trace.
+const (
+	MetadataKeyExperiment    = "prompt_experiment"
+	MetadataKeyVariant       = "prompt_variant"
+	MetadataKeyPromptName    = "prompt_name"
+	MetadataKeyPromptVersion = "prompt_version"
+)
+
+// ExperimentVariant identifies one side of a prompt experiment by either a label or a version.
+//
+// If Version is greater than 0 it takes precedence over Label.
+type ExperimentVariant struct {
+	Label   string
+	Version int
+}
+
+// Experiment describes an A/B test between two labels or versions of the same prompt.
+//
+// Subjects (e.g. users or sessions) are assigned deterministically to a variant by
+// hashing the experiment name together with the subject key, so the same subject
+// always sees the same variant. WeightB is the fraction of subjects assigned to B,
+// between 0 and 1.
+type Experiment struct {
+	Name       string
+	PromptName string
+	A          ExperimentVariant
+	B          ExperimentVariant
+	WeightB    float64
+}
+
+func (e *Experiment) validate() error {
+	if e.Name == "" {
+		return errors.New("'name' is required")
+	}
+	if e.PromptName == "" {
+		return errors.New("'promptName' is required")
+	}
+	if e.A.Label == "" && e.A.Version <= 0 {
+		return errors.New("either 'label' or 'version' is required for variant A")
+	}
+	if e.B.Label == "" && e.B.Version <= 0 {
+		return errors.New("either 'label' or 'version' is required for variant B")
+	}
+	if e.WeightB < 0 || e.WeightB > 1 {
+		return errors.New("'weightB' must be between 0 and 1")
+	}
+	return nil
+}
+
+// Choose deterministically assigns the subject identified by key to a variant.
+//
+// It returns VariantA or VariantB.
+func (e *Experiment) Choose(key string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(e.Name))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(key))
+	bucket := float64(h.Sum64()%10000) / 10000
+	if bucket < e.WeightB {
+		return VariantB
+	}
+	return VariantA
+}
+
+// ExperimentAssignment is the result of assigning a subject to a prompt experiment.
+type ExperimentAssignment struct {
+	Experiment string
+	Variant    string
+	Prompt     *PromptEntry
+}
+
+// Metadata returns the assignment as trace metadata, so that traces can be
+// filtered and grouped by experiment variant and prompt version in Langfuse.
+func (a *ExperimentAssignment) Metadata() map[string]any {
+	return map[string]any{
+		MetadataKeyExperiment:    a.Experiment,
+		MetadataKeyVariant:       a.Variant,
+		MetadataKeyPromptName:    a.Prompt.Name,
+		MetadataKeyPromptVersion: a.Prompt.Version,
+	}
+}
+
+// ApplyToTrace records the assignment in the trace metadata.
+//
+// The trace metadata must be either nil or a map[string]any, the assignment
+// keys are merged into the existing map.
+func (a *ExperimentAssignment) ApplyToTrace(trace *traces.Trace) error {
+	var metadata map[string]any
+	switch m := trace.Metadata.(type) {
+	case nil:
+		metadata = make(map[string]any)
+	case map[string]any:
+		metadata = m
+	default:
+		return fmt.Errorf("trace metadata must be map[string]any, got %T", trace.Metadata)
+	}
+	for key, value := range a.Metadata() {
+		metadata[key] = value
+	}
+	trace.Metadata = metadata
+	return nil
+}
+
+// ApplyToObservation links the observation (usually a generation) to the chosen prompt version.
+func (a *ExperimentAssignment) ApplyToObservation(observation *traces.Observation) {
+	observation.PromptName = a.Prompt.Name
+	observation.PromptVersion = a.Prompt.Version
+}
+
+// GetForExperiment assigns the subject identified by key to a variant of the experiment
+// and retrieves the prompt of the chosen variant.
+//
+// Example:
+//
+//	experiment := prompts.Experiment{
+//		Name:       "summarizer-v2-rollout",
+//		PromptName: "summarizer",
+//		A:          prompts.ExperimentVariant{Label: "production"},
+//		B:          prompts.ExperimentVariant{Label: "candidate"},
+//		WeightB:    0.2,
+//	}
+//	assignment, err := client.Prompts().GetForExperiment(ctx, experiment, userID)
+//	if err != nil {
+//		return err
+//	}
+//	_ = assignment.ApplyToTrace(trace)
+//	assignment.ApplyToObservation(generation)
+func (c *Client) GetForExperiment(ctx context.Context, experiment Experiment, key string) (*ExperimentAssignment, error) {
+	if err := experiment.validate(); err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, errors.New("'key' is required")
+	}
+
+	variant := experiment.Choose(key)
+	chosen := experiment.A
+	if variant == VariantB {
+		chosen = experiment.B
+	}
+	params := GetParams{Name: experiment.PromptName, Version: chosen.Version}
+	if chosen.Version <= 0 {
+		params.Label = chosen.Label
+	}
+	prompt, err := c.Get(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &ExperimentAssignment{
+		Experiment: experiment.Name,
+		Variant:    variant,
+		Prompt:     prompt,
+	}, nil
+}
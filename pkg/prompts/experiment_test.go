@@ -0,0 +1,127 @@
+package prompts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+func TestExperiment_Choose(t *testing.T) {
+	experiment := Experiment{Name: "exp", WeightB: 0.3}
+
+	countB := 0
+	for i := 0; i < 10000; i++ {
+		key := fmt.Sprintf("user-%d", i)
+		variant := experiment.Choose(key)
+		require.Equal(t, variant, experiment.Choose(key))
+		if variant == VariantB {
+			countB++
+		}
+	}
+	require.InDelta(t, 3000, countB, 300)
+
+	experiment.WeightB = 0
+	require.Equal(t, VariantA, experiment.Choose("user-1"))
+	experiment.WeightB = 1
+	require.Equal(t, VariantB, experiment.Choose("user-1"))
+}
+
+func TestExperiment_Validate(t *testing.T) {
+	valid := Experiment{
+		Name:       "exp",
+		PromptName: "prompt",
+		A:          ExperimentVariant{Label: "production"},
+		B:          ExperimentVariant{Version: 2},
+		WeightB:    0.5,
+	}
+	require.NoError(t, valid.validate())
+
+	invalid := valid
+	invalid.B = ExperimentVariant{}
+	require.Error(t, invalid.validate())
+
+	invalid = valid
+	invalid.WeightB = 1.5
+	require.Error(t, invalid.validate())
+
+	invalid = valid
+	invalid.PromptName = ""
+	require.Error(t, invalid.validate())
+}
+
+func TestPromptClient_GetForExperiment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/prompts/summarizer", r.URL.Path)
+		version := 1
+		if r.URL.Query().Get("version") == "2" {
+			version = 2
+		} else {
+			require.Equal(t, "production", r.URL.Query().Get("label"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(PromptEntry{
+			Name:    "summarizer",
+			Type:    "text",
+			Prompt:  "Summarize {{text}}",
+			Version: version,
+		}))
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	experiment := Experiment{
+		Name:       "exp",
+		PromptName: "summarizer",
+		A:          ExperimentVariant{Label: "production"},
+		B:          ExperimentVariant{Version: 2},
+		WeightB:    1,
+	}
+	assignment, err := client.GetForExperiment(context.Background(), experiment, "user-1")
+	require.NoError(t, err)
+	require.Equal(t, VariantB, assignment.Variant)
+	require.Equal(t, 2, assignment.Prompt.Version)
+
+	experiment.WeightB = 0
+	assignment, err = client.GetForExperiment(context.Background(), experiment, "user-1")
+	require.NoError(t, err)
+	require.Equal(t, VariantA, assignment.Variant)
+	require.Equal(t, 1, assignment.Prompt.Version)
+
+	_, err = client.GetForExperiment(context.Background(), experiment, "")
+	require.Error(t, err)
+}
+
+func TestExperimentAssignment_Apply(t *testing.T) {
+	assignment := &ExperimentAssignment{
+		Experiment: "exp",
+		Variant:    VariantB,
+		Prompt:     &PromptEntry{Name: "summarizer", Version: 2},
+	}
+
+	trace := &traces.Trace{}
+	trace.Metadata = map[string]any{"existing": "value"}
+	require.NoError(t, assignment.ApplyToTrace(trace))
+	require.Equal(t, map[string]any{
+		"existing":               "value",
+		MetadataKeyExperiment:    "exp",
+		MetadataKeyVariant:       VariantB,
+		MetadataKeyPromptName:    "summarizer",
+		MetadataKeyPromptVersion: 2,
+	}, trace.Metadata)
+
+	trace.Metadata = "not a map"
+	require.Error(t, assignment.ApplyToTrace(trace))
+
+	observation := &traces.Observation{}
+	assignment.ApplyToObservation(observation)
+	require.Equal(t, "summarizer", observation.PromptName)
+	require.Equal(t, 2, observation.PromptVersion)
+}
@@ -7,9 +7,12 @@ package prompts
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -19,6 +22,17 @@ import (
 	"github.com/go-resty/resty/v2"
 )
 
+// latestLabel is the label Langfuse assigns to the most recently created version of a prompt.
+const latestLabel = "latest"
+
+// ErrVersionConflict is returned by Create when the server rejects the prompt because
+// another version was created concurrently, e.g. by a parallel deployment.
+var ErrVersionConflict = errors.New("prompt version conflict")
+
+// ErrProtectedLabel is returned by Create and UpdateLabels when the server rejects a
+// protected label, e.g. production, because the API key isn't allowed to set it.
+var ErrProtectedLabel = errors.New("prompt label is protected")
+
 const (
 	ChatMessageTypePlaceHolder = "placeholder"
 	ChatMessageTypeMessage     = "chatmessage"
@@ -29,11 +43,14 @@ const (
 // Placeholders in the content can be replaced with actual values when using the prompt.
 // The Role field specifies the message role (e.g., "system", "user", "assistant"),
 // Type specifies the content type, and Content contains the message text with optional placeholders.
+// Multi-modal messages set ContentParts instead of Content, which is encoded as an array
+// of content parts (e.g. text and image references) in the content field.
 type ChatMessageWithPlaceHolder struct {
-	Role    string `json:"role,omitempty"`
-	Type    string `json:"type,omitempty"`
-	Content string `json:"content,omitempty"`
-	Name    string `json:"name,omitempty"`
+	Role         string        `json:"role,omitempty"`
+	Type         string        `json:"type,omitempty"`
+	Content      string        `json:"content,omitempty"`
+	ContentParts []ContentPart `json:"-"`
+	Name         string        `json:"name,omitempty"`
 }
 
 func (c *ChatMessageWithPlaceHolder) validate() error {
@@ -46,13 +63,34 @@ func (c *ChatMessageWithPlaceHolder) validate() error {
 		if c.Role == "" {
 			return errors.New("'role' is required when type is 'chatmessage'")
 		}
-		if c.Content == "" {
+		if c.Content != "" && len(c.ContentParts) > 0 {
+			return errors.New("only one of Content and ContentParts can be set")
+		}
+		if c.Content == "" && len(c.ContentParts) == 0 {
 			return errors.New("'content' is required when type is 'chatmessage'")
 		}
+		for i := range c.ContentParts {
+			if err := c.ContentParts[i].validate(); err != nil {
+				return fmt.Errorf("invalid content part %d: %w", i, err)
+			}
+		}
 	}
 	return nil
 }
 
+// compile renders the variables in the content of the message.
+func (c ChatMessageWithPlaceHolder) compile(variables map[string]any) ChatMessageWithPlaceHolder {
+	c.Content = newTemplateCompiler(c.Content).compile(variables)
+	if len(c.ContentParts) > 0 {
+		parts := make([]ContentPart, 0, len(c.ContentParts))
+		for _, part := range c.ContentParts {
+			parts = append(parts, part.compile(variables))
+		}
+		c.ContentParts = parts
+	}
+	return c
+}
+
 // PromptEntry represents a complete prompt template with its configuration and messages.
 //
 // A prompt entry contains the prompt name, which can be either a string (when Type is "text")
@@ -67,11 +105,14 @@ type PromptEntry struct {
 	Tags    []string `json:"tags,omitempty"`
 	Labels  []string `json:"labels,omitempty"`
 	Config  any      `json:"config,omitempty"`
+	// Extra holds the response fields which are not known to this SDK version.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // UnmarshalJSON implements custom JSON unmarshalling for PromptEntry.
 // It correctly unmarshal the Prompt field as either a string (for "text" type)
 // or []ChatMessageWithPlaceHolder (for other types) based on the Type field.
+// Fields which are not known to the SDK are preserved in Extra.
 func (p *PromptEntry) UnmarshalJSON(data []byte) error {
 	type Alias PromptEntry
 
@@ -100,6 +141,11 @@ func (p *PromptEntry) UnmarshalJSON(data []byte) error {
 		p.Prompt = promptMessages
 	}
 
+	extra, err := common.UnknownFields(data, PromptEntry{})
+	if err != nil {
+		return err
+	}
+	p.Extra = extra
 	return nil
 }
 
@@ -162,8 +208,7 @@ func (p *PromptEntry) Compile(variables map[string]any) (any, error) {
 	compiledMessages := make([]ChatMessageWithPlaceHolder, 0, len(messages))
 	for _, message := range messages {
 		if message.Type != ChatMessageTypePlaceHolder {
-			message.Content = newTemplateCompiler(message.Content).compile(variables)
-			compiledMessages = append(compiledMessages, message)
+			compiledMessages = append(compiledMessages, message.compile(variables))
 		} else {
 			variable, exists := variables[message.Name]
 			if !exists {
@@ -182,9 +227,7 @@ func (p *PromptEntry) Compile(variables map[string]any) (any, error) {
 				if chatMessage.Type == ChatMessageTypePlaceHolder {
 					return nil, fmt.Errorf("nested placeholders are not allowed, found in placeholder '%s'", message.Name)
 				}
-				compiledContent := newTemplateCompiler(chatMessage.Content).compile(variables)
-				chatMessage.Content = compiledContent
-				compiledMessages = append(compiledMessages, chatMessage)
+				compiledMessages = append(compiledMessages, chatMessage.compile(variables))
 			}
 		}
 	}
@@ -204,6 +247,8 @@ type ListParams struct {
 	Limit         int
 	FromUpdatedAt time.Time
 	ToUpdatedAt   time.Time
+
+	pagination common.Pagination
 }
 
 // ToQueryString converts the ListParams to a URL query string.
@@ -218,10 +263,10 @@ func (query *ListParams) ToQueryString() string {
 	if query.Tag != "" {
 		parts = append(parts, "tag="+query.Tag)
 	}
-	if query.Page != 0 {
+	if query.pagination.HasPage(query.Page) {
 		parts = append(parts, "page="+strconv.Itoa(query.Page))
 	}
-	if query.Limit != 0 {
+	if query.pagination.HasLimit(query.Limit) {
 		parts = append(parts, "limit="+strconv.Itoa(query.Limit))
 	}
 	if !query.FromUpdatedAt.IsZero() {
@@ -233,6 +278,36 @@ func (query *ListParams) ToQueryString() string {
 	return strings.Join(parts, "&")
 }
 
+// SetPage sets the page number, even if it is 0.
+func (query *ListParams) SetPage(page int) {
+	query.Page = page
+	query.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (query *ListParams) SetLimit(limit int) {
+	query.Limit = limit
+	query.pagination.MarkLimit()
+}
+
+// ListOption configures ListParams, see common.ListOption.
+type ListOption = common.ListOption[ListParams]
+
+// NewListParams creates ListParams for listing prompts from the provided options.
+func NewListParams(options ...ListOption) ListParams {
+	return common.NewListParams(options...)
+}
+
+// WithPage sets the page number, even if it is 0.
+func WithPage(page int) ListOption {
+	return common.WithPage[ListParams](page)
+}
+
+// WithLimit sets the page size, even if it is 0.
+func WithLimit(limit int) ListOption {
+	return common.WithLimit[ListParams](limit)
+}
+
 // GetParams defines the parameters for retrieving a specific prompt.
 //
 // Use Name to specify the prompt name, Label for a specific label,
@@ -323,6 +398,32 @@ func (c Client) List(ctx context.Context, params ListParams) (*ListPrompts, erro
 	return &listResponse, nil
 }
 
+// SyncSince retrieves the prompts matching the parameters which were updated after since,
+// and returns them together with the high-watermark to pass as since of the next sync.
+//
+// The pages are fetched in parallel with FromUpdatedAt set to since, and the page of the
+// parameters is ignored. Use Get to retrieve the content of the returned prompts.
+func (c *Client) SyncSince(ctx context.Context, since time.Time, params ListParams, options ...common.FetchOption) ([]PromptMeta, time.Time, error) {
+	params.FromUpdatedAt = since
+	prompts, err := common.FetchAll(ctx, func(ctx context.Context, page int) ([]PromptMeta, common.ListMetadata, error) {
+		pageParams := params
+		pageParams.Page = page
+		listPrompts, err := c.List(ctx, pageParams)
+		if err != nil {
+			return nil, common.ListMetadata{}, err
+		}
+		return listPrompts.Data, listPrompts.Metadata, nil
+	}, options...)
+	if err != nil {
+		return nil, since, err
+	}
+	// FromUpdatedAt is sent with a precision of seconds and includes since itself
+	updated, watermark := common.UpdatedSince(prompts, since, func(prompt PromptMeta) time.Time {
+		return prompt.LastUpdatedAt
+	})
+	return updated, watermark, nil
+}
+
 // Create creates a new prompt.
 func (c *Client) Create(ctx context.Context, createPrompt *PromptEntry) (*PromptEntry, error) {
 	if err := createPrompt.validate(); err != nil {
@@ -341,8 +442,188 @@ func (c *Client) Create(ctx context.Context, createPrompt *PromptEntry) (*Prompt
 		return nil, err
 	}
 
+	if rsp.StatusCode() == http.StatusConflict {
+		return nil, fmt.Errorf("%w: %s", ErrVersionConflict, rsp.String())
+	}
+	if err := protectedLabelError(rsp); err != nil {
+		return nil, err
+	}
 	if rsp.IsError() {
 		return nil, fmt.Errorf("failed to create prompt: %s, got status code: %d", rsp.String(), rsp.StatusCode())
 	}
 	return &createdPrompt, nil
 }
+
+// UpdateLabels sets the labels of a prompt version, e.g. to promote it to production.
+//
+// The labels are moved from other versions of the prompt to this version. Setting a
+// protected label without the permission fails with ErrProtectedLabel.
+func (c *Client) UpdateLabels(ctx context.Context, name string, version int, labels []string) (*PromptEntry, error) {
+	if name == "" {
+		return nil, errors.New("'name' is required")
+	}
+	if version <= 0 {
+		return nil, errors.New("'version' must be positive")
+	}
+	if labels == nil {
+		labels = []string{}
+	}
+
+	var updatedPrompt PromptEntry
+	rsp, err := c.restyCli.R().
+		SetContext(ctx).
+		SetBody(map[string]any{"newLabels": labels}).
+		SetResult(&updatedPrompt).
+		SetPathParam("name", name).
+		SetPathParam("version", strconv.Itoa(version)).
+		Patch("/v2/prompts/{name}/versions/{version}")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := protectedLabelError(rsp); err != nil {
+		return nil, err
+	}
+	if rsp.IsError() {
+		return nil, fmt.Errorf("failed to update prompt labels: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	}
+	return &updatedPrompt, nil
+}
+
+// protectedLabelError returns ErrProtectedLabel with the message of the server if the
+// response rejects a protected label, or nil otherwise.
+func protectedLabelError(rsp *resty.Response) error {
+	if rsp.StatusCode() != http.StatusForbidden || !strings.Contains(strings.ToLower(rsp.String()), "protected") {
+		return nil
+	}
+	message := rsp.String()
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(rsp.Body(), &body); err == nil && body.Message != "" {
+		message = body.Message
+	}
+	return fmt.Errorf("%w: %s", ErrProtectedLabel, message)
+}
+
+// CreateOrGet creates a new prompt version unless the latest version already has the same content.
+//
+// The content is the type, the prompt and the config. If the latest version is identical, it is
+// returned instead of creating a new version, which makes deployments idempotent. If the creation
+// fails with ErrVersionConflict because the prompt was created concurrently, the latest version is
+// fetched again and returned if it's identical, otherwise the conflict error is returned.
+func (c *Client) CreateOrGet(ctx context.Context, createPrompt *PromptEntry) (*PromptEntry, error) {
+	if err := createPrompt.validate(); err != nil {
+		return nil, err
+	}
+
+	latest, err := c.getLatest(ctx, createPrompt.Name)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && samePromptContent(latest, createPrompt) {
+		return latest, nil
+	}
+
+	createdPrompt, err := c.Create(ctx, createPrompt)
+	if err == nil || !errors.Is(err, ErrVersionConflict) {
+		return createdPrompt, err
+	}
+	latest, getErr := c.getLatest(ctx, createPrompt.Name)
+	if getErr != nil {
+		return nil, errors.Join(err, getErr)
+	}
+	if latest != nil && samePromptContent(latest, createPrompt) {
+		return latest, nil
+	}
+	return nil, err
+}
+
+// getLatest retrieves the latest version of the prompt, or nil if the prompt doesn't exist.
+func (c *Client) getLatest(ctx context.Context, name string) (*PromptEntry, error) {
+	var prompt PromptEntry
+	rsp, err := c.restyCli.R().
+		SetContext(ctx).
+		SetResult(&prompt).
+		SetPathParam("name", name).
+		SetQueryParam("label", latestLabel).
+		Get("/v2/prompts/{name}")
+	if err != nil {
+		return nil, err
+	}
+	if rsp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if rsp.IsError() {
+		return nil, fmt.Errorf("get prompt failed: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	}
+	return &prompt, nil
+}
+
+// samePromptContent reports whether both prompts have the same type, prompt and config.
+func samePromptContent(a, b *PromptEntry) bool {
+	return a.Hash() == b.Hash()
+}
+
+// Hash returns a hex encoded SHA-256 hash of the prompt content.
+//
+// The content consists of the type, the prompt and the config, while metadata like the
+// version, labels and tags is ignored. Prompts with the same content have the same hash
+// regardless of whether they were created locally or retrieved from the server, which
+// allows detecting whether a new version needs to be created.
+func (p *PromptEntry) Hash() string {
+	content := struct {
+		Type   string `json:"type"`
+		Prompt any    `json:"prompt"`
+		Config any    `json:"config"`
+	}{
+		Type:   strings.ToLower(p.Type),
+		Prompt: normalizePrompt(p.Prompt),
+		Config: normalizeConfig(p.Config),
+	}
+	data, err := canonicalJSON(content)
+	if err != nil {
+		// Fall back to the Go representation, which is still stable for the same content
+		data = []byte(fmt.Sprintf("%#v", content))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizePrompt fills in the default message type, which the server always returns.
+func normalizePrompt(prompt any) any {
+	messages, ok := prompt.([]ChatMessageWithPlaceHolder)
+	if !ok {
+		return prompt
+	}
+	normalized := make([]ChatMessageWithPlaceHolder, 0, len(messages))
+	for _, message := range messages {
+		if message.Type == "" {
+			message.Type = ChatMessageTypeMessage
+		}
+		normalized = append(normalized, message)
+	}
+	return normalized
+}
+
+// normalizeConfig treats a missing config like an empty one, which the server returns by default.
+func normalizeConfig(config any) any {
+	if config == nil {
+		return map[string]any{}
+	}
+	return config
+}
+
+// canonicalJSON encodes v as JSON with the object keys sorted at every level,
+// so that structs and maps with the same content produce the same bytes.
+func canonicalJSON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
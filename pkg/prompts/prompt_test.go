@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/stretchr/testify/require"
@@ -71,6 +72,30 @@ func TestPromptClient_List(t *testing.T) {
 	require.Equal(t, 1, promptList.Metadata.TotalPages)
 }
 
+func TestPromptClient_SyncSince(t *testing.T) {
+	since := time.Date(2025, 1, 1, 10, 0, 0, 500, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/v2/prompts", r.URL.Path)
+			require.Equal(t, "2025-01-01T10:00:00Z", r.URL.Query().Get("fromUpdatedAt"))
+			w.Header().Set("Content-Type", "application/json")
+			_, err := w.Write([]byte(`{"meta":{"page":1,"limit":10,"totalItems":3,"totalPages":1},"data":[
+				{"name":"unchanged","lastUpdatedAt":"2025-01-01T10:00:00Z"},
+				{"name":"updated","lastUpdatedAt":"2025-01-02T10:00:00Z"},
+				{"name":"created","lastUpdatedAt":"2025-01-01T12:00:00Z"}]}`))
+			require.NoError(t, err)
+		}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	prompts, watermark, err := client.SyncSince(context.Background(), since, ListParams{})
+	require.NoError(t, err)
+	require.Len(t, prompts, 2)
+	require.Equal(t, "updated", prompts[0].Name)
+	require.Equal(t, "created", prompts[1].Name)
+	require.Equal(t, time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), watermark.UTC())
+}
+
 func TestPromptClient_Create(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
@@ -92,6 +117,98 @@ func TestPromptClient_Create(t *testing.T) {
 	require.Equal(t, "test-prompt", prompt.Name)
 }
 
+func TestPromptClient_Create_VersionConflict(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"conflict"}`))
+		}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	_, err := client.Create(context.Background(), &PromptEntry{Name: "test-prompt", Type: "text", Prompt: "hello"})
+	require.ErrorIs(t, err, ErrVersionConflict)
+}
+
+func TestPromptClient_UpdateLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPatch, r.Method)
+			require.Equal(t, "/v2/prompts/test-prompt/versions/3", r.URL.Path)
+			var body struct {
+				NewLabels []string `json:"newLabels"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if body.NewLabels[0] == "production" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message":"Label 'production' is protected and can only be set by admins"}`))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"test-prompt","version":3,"type":"text","prompt":"hello","labels":["staging"]}`))
+		}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	prompt, err := client.UpdateLabels(context.Background(), "test-prompt", 3, []string{"staging"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"staging"}, prompt.Labels)
+
+	_, err = client.UpdateLabels(context.Background(), "test-prompt", 3, []string{"production"})
+	require.ErrorIs(t, err, ErrProtectedLabel)
+	require.EqualError(t, err, "prompt label is protected: Label 'production' is protected and can only be set by admins")
+}
+
+func TestPromptClient_CreateOrGet(t *testing.T) {
+	var created int
+	latest := `{"name":"test-prompt","type":"chat","version":3,"config":{},
+		"prompt":[{"role":"user","type":"chatmessage","content":"hello"}]}`
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/v2/prompts/test-prompt":
+				require.Equal(t, "latest", r.URL.Query().Get("label"))
+				_, _ = w.Write([]byte(latest))
+			case r.Method == http.MethodGet:
+				w.WriteHeader(http.StatusNotFound)
+			case r.Method == http.MethodPost:
+				created++
+				var prompt PromptEntry
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&prompt))
+				if prompt.Name == "conflicting-prompt" {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+				prompt.Version = 1
+				require.NoError(t, json.NewEncoder(w).Encode(prompt))
+			}
+		}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+
+	// The latest version has the same content, so no new version is created
+	prompt, err := client.CreateOrGet(context.Background(), &PromptEntry{
+		Name:   "test-prompt",
+		Type:   "chat",
+		Prompt: []ChatMessageWithPlaceHolder{{Role: "user", Content: "hello"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, prompt.Version)
+	require.Equal(t, 0, created)
+
+	// The prompt doesn't exist yet
+	prompt, err = client.CreateOrGet(context.Background(), &PromptEntry{Name: "new-prompt", Type: "text", Prompt: "hello"})
+	require.NoError(t, err)
+	require.Equal(t, 1, prompt.Version)
+	require.Equal(t, 1, created)
+
+	// The creation conflicts and there's no identical version to fall back to
+	_, err = client.CreateOrGet(context.Background(), &PromptEntry{Name: "conflicting-prompt", Type: "text", Prompt: "hello"})
+	require.ErrorIs(t, err, ErrVersionConflict)
+}
+
 func TestPromptEntryCompile_Text(t *testing.T) {
 	entry := &PromptEntry{
 		Name:   "text",
@@ -192,3 +309,12 @@ func TestPromptEntryCompile_NilPromptEntry(t *testing.T) {
 	_, err := entry.Compile(map[string]any{})
 	require.EqualError(t, err, "prompt entry is empty")
 }
+
+func TestPromptEntry_UnmarshalJSON_Extra(t *testing.T) {
+	var prompt PromptEntry
+	err := json.Unmarshal([]byte(`{"name":"test","type":"text","prompt":"hello","version":2,"commitMessage":"init"}`), &prompt)
+	require.NoError(t, err)
+	require.Equal(t, "hello", prompt.Prompt)
+	require.Equal(t, 2, prompt.Version)
+	require.Equal(t, map[string]json.RawMessage{"commitMessage": json.RawMessage(`"init"`)}, prompt.Extra)
+}
@@ -0,0 +1,159 @@
+package prompts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SyncedPrompt reports the outcome of syncing a single prompt file.
+//
+// Changed is true if a new version was created because the content of the file
+// differs from the latest version on the server.
+type SyncedPrompt struct {
+	File    string
+	Name    string
+	Version int
+	Hash    string
+	Changed bool
+}
+
+// SyncFromFS creates new prompt versions from the prompt files in dir, but only
+// for prompts whose content changed.
+//
+// Every .json, .yaml or .yml file in dir and its subdirectories holds a single prompt
+// with the same fields as PromptEntry, i.e. name, type, prompt, config, labels and tags.
+// If the name is missing, the file name without extension is used, and if the type is
+// missing, it's "text" for a string prompt and "chat" otherwise. The hash of each
+// file is compared with the hash of the latest version on the server, and a new version
+// is created only if they differ, which enables GitOps-style prompt management.
+//
+// Files which fail to sync don't stop the sync of the others, their errors are joined
+// and returned together with the results of the other files.
+//
+// Example prompt file:
+//
+//	name: summarizer
+//	type: chat
+//	labels: [production]
+//	prompt:
+//	  - role: system
+//	    content: Summarize the text in {{max_words}} words.
+//	config:
+//	  model: gpt-4o
+func (c *Client) SyncFromFS(ctx context.Context, dir string) ([]SyncedPrompt, error) {
+	if dir == "" {
+		return nil, errors.New("'dir' is required")
+	}
+	fsys := os.DirFS(dir)
+	files, err := promptFiles(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	synced := make([]SyncedPrompt, 0, len(files))
+	var errs []error
+	for _, file := range files {
+		result, err := c.syncFile(ctx, fsys, file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("sync prompt file %s: %w", file, err))
+			continue
+		}
+		synced = append(synced, *result)
+	}
+	return synced, errors.Join(errs...)
+}
+
+func (c *Client) syncFile(ctx context.Context, fsys fs.FS, file string) (*SyncedPrompt, error) {
+	prompt, err := readPromptFile(fsys, file)
+	if err != nil {
+		return nil, err
+	}
+	if err := prompt.validate(); err != nil {
+		return nil, err
+	}
+
+	hash := prompt.Hash()
+	latest, err := c.getLatest(ctx, prompt.Name)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.Hash() == hash {
+		return &SyncedPrompt{File: file, Name: prompt.Name, Version: latest.Version, Hash: hash}, nil
+	}
+
+	created, err := c.Create(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return &SyncedPrompt{File: file, Name: prompt.Name, Version: created.Version, Hash: hash, Changed: true}, nil
+}
+
+// promptFiles returns the paths of the prompt files in fsys in lexical order.
+func promptFiles(fsys fs.FS) ([]string, error) {
+	files := make([]string, 0)
+	err := fs.WalkDir(fsys, ".", func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(path.Ext(file)) {
+		case ".json", ".yaml", ".yml":
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// readPromptFile decodes a JSON or YAML prompt file into a PromptEntry.
+func readPromptFile(fsys fs.FS, file string) (*PromptEntry, error) {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return nil, err
+	}
+
+	var content map[string]any
+	if strings.ToLower(path.Ext(file)) == ".json" {
+		err = json.Unmarshal(data, &content)
+	} else {
+		err = yaml.Unmarshal(data, &content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt file: %w", err)
+	}
+	if content == nil {
+		return nil, errors.New("prompt file is empty")
+	}
+	if _, ok := content["type"]; !ok {
+		content["type"] = "chat"
+		if _, isText := content["prompt"].(string); isText {
+			content["type"] = "text"
+		}
+	}
+
+	// Re-encode the content to reuse the decoding of the prompt by type
+	if data, err = json.Marshal(content); err != nil {
+		return nil, fmt.Errorf("failed to encode prompt: %w", err)
+	}
+	var prompt PromptEntry
+	if err := json.Unmarshal(data, &prompt); err != nil {
+		return nil, fmt.Errorf("failed to parse prompt: %w", err)
+	}
+	if prompt.Name == "" {
+		prompt.Name = strings.TrimSuffix(path.Base(file), path.Ext(file))
+	}
+	return &prompt, nil
+}
@@ -0,0 +1,92 @@
+package prompts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptEntry_Hash(t *testing.T) {
+	local := &PromptEntry{
+		Name:   "summarizer",
+		Type:   "chat",
+		Prompt: []ChatMessageWithPlaceHolder{{Role: "system", Content: "Summarize"}},
+		Config: map[string]any{"temperature": 0.2, "model": "gpt-4o"},
+		Labels: []string{"production"},
+	}
+	var remote PromptEntry
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"summarizer","type":"chat","version":7,
+		"prompt":[{"role":"system","type":"chatmessage","content":"Summarize"}],
+		"config":{"model":"gpt-4o","temperature":0.2}}`), &remote))
+	require.Equal(t, local.Hash(), remote.Hash())
+	require.Len(t, local.Hash(), 64)
+
+	changed := *local
+	changed.Config = map[string]any{"temperature": 0.3, "model": "gpt-4o"}
+	require.NotEqual(t, local.Hash(), changed.Hash())
+
+	require.Equal(t, (&PromptEntry{Type: "text", Prompt: "hi"}).Hash(), (&PromptEntry{Type: "text", Prompt: "hi", Config: map[string]any{}}).Hash())
+}
+
+func TestPromptClient_SyncFromFS(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "summarizer.yaml"), []byte(`
+type: chat
+prompt:
+  - role: system
+    content: Summarize the text.
+config:
+  model: gpt-4o
+`), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "greeting.json"),
+		[]byte(`{"name":"greeting","prompt":"Hello {{name}}","labels":["production"]}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yml"), []byte("prompt: [\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o600))
+
+	var created []PromptEntry
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/prompts/summarizer":
+			_, _ = w.Write([]byte(`{"name":"summarizer","type":"chat","version":2,"config":{"model":"gpt-4o"},
+				"prompt":[{"role":"system","type":"chatmessage","content":"Summarize the text."}]}`))
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost:
+			var prompt PromptEntry
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&prompt))
+			created = append(created, prompt)
+			prompt.Version = 1
+			require.NoError(t, json.NewEncoder(w).Encode(prompt))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	synced, err := client.SyncFromFS(context.Background(), dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "sync prompt file broken.yml")
+	require.Len(t, synced, 2)
+
+	require.Equal(t, "nested/greeting.json", synced[0].File)
+	require.Equal(t, "greeting", synced[0].Name)
+	require.True(t, synced[0].Changed)
+	require.Equal(t, 1, synced[0].Version)
+
+	require.Equal(t, "summarizer", synced[1].Name)
+	require.False(t, synced[1].Changed)
+	require.Equal(t, 2, synced[1].Version)
+
+	require.Len(t, created, 1)
+	require.Equal(t, "text", created[0].Type)
+	require.Equal(t, "Hello {{name}}", created[0].Prompt)
+	require.Equal(t, []string{"production"}, created[0].Labels)
+}
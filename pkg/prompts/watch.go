@@ -0,0 +1,155 @@
+package prompts
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/git-hulk/langfuse-go/pkg/logger"
+)
+
+const defaultWatchInterval = 30 * time.Second
+
+type watchConfig struct {
+	interval time.Duration
+	label    string
+}
+
+// WatchOption configures Watch.
+type WatchOption func(*watchConfig)
+
+// WithWatchInterval sets how often the prompt is polled. Default is 30 seconds,
+// and a negative interval disables polling, so the prompt is only refreshed by
+// Watcher.Trigger.
+func WithWatchInterval(interval time.Duration) WatchOption {
+	return func(c *watchConfig) {
+		c.interval = interval
+	}
+}
+
+// WithWatchLabel watches the version of the prompt with the given label, e.g. "production".
+// By default, the latest version is watched.
+func WithWatchLabel(label string) WatchOption {
+	return func(c *watchConfig) {
+		c.label = label
+	}
+}
+
+// Watcher keeps a prompt up to date by polling it, see Client.Watch.
+type Watcher struct {
+	client   *Client
+	params   GetParams
+	onChange func(*PromptEntry)
+	trigger  chan struct{}
+	done     chan struct{}
+
+	mu      sync.RWMutex
+	current *PromptEntry
+}
+
+// Watch polls the prompt with the given name in the background and calls onChange with
+// the first retrieved prompt and then whenever its version or content changes, so services
+// can hot-reload prompts without a restart.
+//
+// Failed polls are logged and the previous prompt is kept. The watcher stops when the
+// context is done. Instead of waiting for the next poll, Trigger refreshes the prompt
+// right away, e.g. when a prompt webhook is received. Combined with a response cache,
+// polls of an unchanged prompt are answered with 304 Not Modified.
+//
+// Example:
+//
+//	watcher := client.Prompts().Watch(ctx, "system-prompt", func(prompt *prompts.PromptEntry) {
+//		systemPrompt.Store(prompt)
+//	}, prompts.WithWatchLabel("production"))
+//
+//	webhookHandler.OnPromptVersion(func(ctx context.Context, event *webhooks.Event) error {
+//		watcher.Trigger()
+//		return nil
+//	})
+func (c *Client) Watch(ctx context.Context, name string, onChange func(*PromptEntry), options ...WatchOption) *Watcher {
+	config := &watchConfig{interval: defaultWatchInterval}
+	for _, option := range options {
+		option(config)
+	}
+	if config.interval == 0 {
+		config.interval = defaultWatchInterval
+	}
+
+	w := &Watcher{
+		client:   c,
+		params:   GetParams{Name: name, Label: config.label},
+		onChange: onChange,
+		trigger:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	go w.run(ctx, config.interval)
+	return w
+}
+
+// Current returns the latest retrieved prompt, or nil if it hasn't been retrieved yet.
+func (w *Watcher) Current() *PromptEntry {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// Trigger refreshes the prompt without waiting for the next poll.
+func (w *Watcher) Trigger() {
+	select {
+	case w.trigger <- struct{}{}:
+	default:
+		// A refresh is already pending
+	}
+}
+
+// Done returns a channel which is closed once the watcher has stopped.
+func (w *Watcher) Done() <-chan struct{} {
+	return w.done
+}
+
+func (w *Watcher) run(ctx context.Context, interval time.Duration) {
+	defer close(w.done)
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		w.refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case <-w.trigger:
+		}
+	}
+}
+
+func (w *Watcher) refresh(ctx context.Context) {
+	prompt, err := w.client.Get(ctx, w.params)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Get().With(
+				zap.Error(err),
+				zap.String("prompt_name", w.params.Name),
+			).Warn("Failed to refresh watched prompt")
+		}
+		return
+	}
+
+	w.mu.Lock()
+	previous := w.current
+	changed := previous == nil || previous.Version != prompt.Version || !samePromptContent(previous, prompt)
+	if changed {
+		w.current = prompt
+	}
+	w.mu.Unlock()
+
+	if changed && w.onChange != nil {
+		w.onChange(prompt)
+	}
+}
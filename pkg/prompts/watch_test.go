@@ -0,0 +1,90 @@
+package prompts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Watch(t *testing.T) {
+	var version atomic.Int32
+	version.Store(1)
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls.Add(1)
+		require.Equal(t, "/v2/prompts/greeting", r.URL.Path)
+		require.Equal(t, "production", r.URL.Query().Get("label"))
+		w.Header().Set("Content-Type", "application/json")
+		v := int(version.Load())
+		require.NoError(t, json.NewEncoder(w).Encode(PromptEntry{
+			Name:    "greeting",
+			Type:    "text",
+			Prompt:  "Hello v" + strconv.Itoa(v),
+			Version: v,
+		}))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan *PromptEntry, 10)
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	watcher := client.Watch(ctx, "greeting", func(prompt *PromptEntry) {
+		changes <- prompt
+	}, WithWatchLabel("production"), WithWatchInterval(-1))
+
+	first := <-changes
+	require.Equal(t, 1, first.Version)
+	require.Equal(t, first, watcher.Current())
+
+	// An unchanged prompt doesn't notify
+	watcher.Trigger()
+	require.Eventually(t, func() bool { return polls.Load() >= 2 }, time.Second, time.Millisecond)
+	require.Empty(t, changes)
+
+	version.Store(2)
+	watcher.Trigger()
+	second := <-changes
+	require.Equal(t, 2, second.Version)
+	require.Equal(t, "Hello v2", second.Prompt)
+
+	cancel()
+	select {
+	case <-watcher.Done():
+	case <-time.After(time.Second):
+		t.Fatal("watcher didn't stop")
+	}
+}
+
+func TestClient_Watch_Polling(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(PromptEntry{Name: "greeting", Type: "text", Prompt: "Hello", Version: 1}))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan *PromptEntry, 10)
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	watcher := client.Watch(ctx, "greeting", func(prompt *PromptEntry) {
+		changes <- prompt
+	}, WithWatchInterval(5*time.Millisecond))
+
+	// The first poll fails, and the prompt is retrieved by the next one
+	prompt := <-changes
+	require.Equal(t, 1, prompt.Version)
+	require.Equal(t, prompt, watcher.Current())
+}
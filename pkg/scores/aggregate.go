@@ -0,0 +1,264 @@
+package scores
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GroupFunc returns the group keys of a score. A score is counted in every returned group
+// and skipped if no key is returned.
+type GroupFunc func(score *Score) []string
+
+// GroupByName groups scores by their name.
+func GroupByName(score *Score) []string {
+	return []string{score.Name}
+}
+
+// GroupByConfigID groups scores by their score config, scores without a config are skipped.
+func GroupByConfigID(score *Score) []string {
+	if score.ConfigID == "" {
+		return nil
+	}
+	return []string{score.ConfigID}
+}
+
+// GroupByTraceTag groups scores by the tags of their trace, so a score is counted once per tag.
+//
+// The trace is only populated by the list endpoints, scores from Get are skipped.
+func GroupByTraceTag(score *Score) []string {
+	return score.Trace.Tags
+}
+
+// GroupByNameAndTraceTag groups scores by "<name>/<tag>" for every tag of their trace.
+func GroupByNameAndTraceTag(score *Score) []string {
+	keys := make([]string, 0, len(score.Trace.Tags))
+	for _, tag := range score.Trace.Tags {
+		keys = append(keys, score.Name+"/"+tag)
+	}
+	return keys
+}
+
+// ScoreStats holds the aggregated values of a group of scores.
+//
+// Numeric scores fill Mean, Median, Min and Max, boolean scores fill the pass rate
+// and categorical scores fill the Distribution. Scores whose value can't be read
+// are only counted in Skipped.
+type ScoreStats struct {
+	Key     string
+	Count   int
+	Skipped int
+
+	// Numeric scores
+	NumericCount int
+	Mean         float64
+	Median       float64
+	Min          float64
+	Max          float64
+
+	// Boolean scores
+	BooleanCount int
+	PassCount    int
+	PassRate     float64
+
+	// Categorical scores
+	Distribution map[string]int
+
+	values []float64
+}
+
+// Percentile returns the p-th percentile (0-100) of the numeric values, using linear
+// interpolation between the closest ranks. It returns NaN if there are no numeric values.
+func (s *ScoreStats) Percentile(p float64) float64 {
+	if len(s.values) == 0 {
+		return math.NaN()
+	}
+	p = min(max(p, 0), 100)
+	rank := p / 100 * float64(len(s.values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return s.values[lower] + (s.values[upper]-s.values[lower])*(rank-float64(lower))
+}
+
+// Share returns the share (0-1) of categorical scores with the given category.
+func (s *ScoreStats) Share(category string) float64 {
+	total := 0
+	for _, count := range s.Distribution {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Distribution[category]) / float64(total)
+}
+
+// Aggregate groups the scores with the group function and computes the statistics of each group.
+//
+// Example:
+//
+//	list, err := client.Scores().ListAll(ctx, scores.ListParams{Name: "correctness"})
+//	if err != nil {
+//		return err
+//	}
+//	for key, stats := range scores.Aggregate(list, scores.GroupByTraceTag) {
+//		fmt.Printf("%s: mean=%.2f p90=%.2f\n", key, stats.Mean, stats.Percentile(90))
+//	}
+func Aggregate(scores []Score, group GroupFunc) map[string]*ScoreStats {
+	if group == nil {
+		group = GroupByName
+	}
+	result := make(map[string]*ScoreStats)
+	for i := range scores {
+		for _, key := range group(&scores[i]) {
+			stats, ok := result[key]
+			if !ok {
+				stats = &ScoreStats{Key: key}
+				result[key] = stats
+			}
+			stats.add(&scores[i])
+		}
+	}
+	for _, stats := range result {
+		stats.finish()
+	}
+	return result
+}
+
+// AggregateAll computes the statistics of all scores as a single group.
+func AggregateAll(scores []Score) *ScoreStats {
+	return Aggregate(scores, func(*Score) []string { return []string{""} })[""]
+}
+
+func (s *ScoreStats) add(score *Score) {
+	s.Count++
+	switch scoreDataType(score) {
+	case ScoreDataTypeBoolean:
+		passed, ok := booleanValue(score)
+		if !ok {
+			s.Skipped++
+			return
+		}
+		s.BooleanCount++
+		if passed {
+			s.PassCount++
+		}
+	case ScoreDataTypeCategorical:
+		category, ok := categoricalValue(score)
+		if !ok {
+			s.Skipped++
+			return
+		}
+		if s.Distribution == nil {
+			s.Distribution = make(map[string]int)
+		}
+		s.Distribution[category]++
+	default:
+		value, ok := numericValue(score.Value)
+		if !ok {
+			s.Skipped++
+			return
+		}
+		s.values = append(s.values, value)
+	}
+}
+
+func (s *ScoreStats) finish() {
+	if s.BooleanCount > 0 {
+		s.PassRate = float64(s.PassCount) / float64(s.BooleanCount)
+	}
+	s.NumericCount = len(s.values)
+	if s.NumericCount == 0 {
+		return
+	}
+	sort.Float64s(s.values)
+	sum := 0.0
+	for _, v := range s.values {
+		sum += v
+	}
+	s.Mean = sum / float64(s.NumericCount)
+	s.Median = s.Percentile(50)
+	s.Min = s.values[0]
+	s.Max = s.values[s.NumericCount-1]
+}
+
+func scoreDataType(score *Score) ScoreDataType {
+	if score.DataType.IsValid() {
+		return score.DataType
+	}
+	switch score.Value.(type) {
+	case bool:
+		return ScoreDataTypeBoolean
+	case string:
+		return ScoreDataTypeCategorical
+	default:
+		return ScoreDataTypeNumeric
+	}
+}
+
+// booleanValue reads a boolean score, which the API returns as 1 or 0 with
+// a "True" or "False" stringValue.
+func booleanValue(score *Score) (bool, bool) {
+	if v, ok := score.Value.(bool); ok {
+		return v, true
+	}
+	if v, ok := numericValue(score.Value); ok {
+		return v != 0, true
+	}
+	if v, ok := stringValue(score); ok {
+		passed, err := strconv.ParseBool(strings.ToLower(v))
+		return passed, err == nil
+	}
+	return false, false
+}
+
+// categoricalValue reads a categorical score, which the API returns in stringValue.
+func categoricalValue(score *Score) (string, bool) {
+	if v, ok := stringValue(score); ok {
+		return v, true
+	}
+	switch v := score.Value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+func stringValue(score *Score) (string, bool) {
+	raw, ok := score.Extra["stringValue"]
+	if !ok {
+		return "", false
+	}
+	var v string
+	if err := json.Unmarshal(raw, &v); err != nil || v == "" {
+		return "", false
+	}
+	return v, true
+}
+
+func numericValue(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, !math.IsNaN(v)
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// SortedKeys returns the group keys of the aggregation in a stable order, e.g. to print a report.
+func SortedKeys(stats map[string]*ScoreStats) []string {
+	return slices.Sorted(maps.Keys(stats))
+}
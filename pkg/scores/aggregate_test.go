@@ -0,0 +1,70 @@
+package scores
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	var list []Score
+	for i, value := range []float64{0.1, 0.4, 0.5, 0.8, 1.0} {
+		tags := []string{"prod"}
+		if i%2 == 0 {
+			tags = append(tags, "beta")
+		}
+		list = append(list, Score{
+			Name:     "relevance",
+			DataType: ScoreDataTypeNumeric,
+			Value:    value,
+			Trace:    traces.TraceEntry{Tags: tags},
+		})
+	}
+	list = append(list,
+		Score{Name: "correct", DataType: ScoreDataTypeBoolean, Value: 1.0},
+		Score{Name: "correct", DataType: ScoreDataTypeBoolean, Value: 0.0},
+		Score{Name: "correct", DataType: ScoreDataTypeBoolean, Value: 1.0},
+		Score{Name: "correct", DataType: ScoreDataTypeBoolean, Value: 1.0},
+		Score{Name: "tone", DataType: ScoreDataTypeCategorical, Extra: map[string]json.RawMessage{"stringValue": json.RawMessage(`"friendly"`)}},
+		Score{Name: "tone", DataType: ScoreDataTypeCategorical, Value: "neutral"},
+		Score{Name: "tone", DataType: ScoreDataTypeCategorical, Extra: map[string]json.RawMessage{"stringValue": json.RawMessage(`"friendly"`)}},
+		Score{Name: "tone", DataType: ScoreDataTypeCategorical},
+	)
+
+	stats := Aggregate(list, GroupByName)
+	require.Equal(t, []string{"correct", "relevance", "tone"}, SortedKeys(stats))
+
+	relevance := stats["relevance"]
+	require.Equal(t, 5, relevance.NumericCount)
+	require.InDelta(t, 0.56, relevance.Mean, 1e-9)
+	require.InDelta(t, 0.5, relevance.Median, 1e-9)
+	require.InDelta(t, 0.1, relevance.Min, 1e-9)
+	require.InDelta(t, 1.0, relevance.Max, 1e-9)
+	require.InDelta(t, 0.92, relevance.Percentile(90), 1e-9)
+	require.InDelta(t, 1.0, relevance.Percentile(100), 1e-9)
+
+	correct := stats["correct"]
+	require.Equal(t, 4, correct.BooleanCount)
+	require.Equal(t, 3, correct.PassCount)
+	require.InDelta(t, 0.75, correct.PassRate, 1e-9)
+	require.True(t, math.IsNaN(correct.Percentile(50)))
+
+	tone := stats["tone"]
+	require.Equal(t, 4, tone.Count)
+	require.Equal(t, 1, tone.Skipped)
+	require.Equal(t, map[string]int{"friendly": 2, "neutral": 1}, tone.Distribution)
+	require.InDelta(t, 2.0/3, tone.Share("friendly"), 1e-9)
+
+	byTag := Aggregate(list, GroupByTraceTag)
+	require.Equal(t, []string{"beta", "prod"}, SortedKeys(byTag))
+	require.Equal(t, 5, byTag["prod"].NumericCount)
+	require.Equal(t, 3, byTag["beta"].NumericCount)
+	require.InDelta(t, 0.5, byTag["beta"].Median, 1e-9)
+
+	all := AggregateAll(list)
+	require.Equal(t, len(list), all.Count)
+}
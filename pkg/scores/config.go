@@ -60,15 +60,7 @@ func (r *CreateScoreConfigRequest) validate() error {
 		return errors.New("'dataType' is required")
 	}
 	// Validate that dataType is a valid value
-	validDataTypes := []ScoreDataType{ScoreDataTypeNumeric, ScoreDataTypeBoolean, ScoreDataTypeCategorical}
-	isValid := false
-	for _, dt := range validDataTypes {
-		if r.DataType == dt {
-			isValid = true
-			break
-		}
-	}
-	if !isValid {
+	if !r.DataType.IsValid() {
 		return fmt.Errorf("invalid 'dataType': %s, must be one of NUMERIC, BOOLEAN, CATEGORICAL", r.DataType)
 	}
 
@@ -101,20 +93,34 @@ func (r *CreateScoreConfigRequest) validate() error {
 type ConfigListParams struct {
 	Page  int
 	Limit int
+
+	pagination common.Pagination
 }
 
 // ToQueryString converts the ConfigListParams to a URL query string.
 func (p *ConfigListParams) ToQueryString() string {
 	parts := make([]string, 0)
-	if p.Page != 0 {
+	if p.pagination.HasPage(p.Page) {
 		parts = append(parts, "page="+strconv.Itoa(p.Page))
 	}
-	if p.Limit != 0 {
+	if p.pagination.HasLimit(p.Limit) {
 		parts = append(parts, "limit="+strconv.Itoa(p.Limit))
 	}
 	return strings.Join(parts, "&")
 }
 
+// SetPage sets the page number, even if it is 0.
+func (p *ConfigListParams) SetPage(page int) {
+	p.Page = page
+	p.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (p *ConfigListParams) SetLimit(limit int) {
+	p.Limit = limit
+	p.pagination.MarkLimit()
+}
+
 // ListScoreConfigs represents the paginated response from the list score configs API.
 //
 // It contains pagination metadata and an array of score configurations matching the query criteria.
@@ -185,3 +191,80 @@ func (c *Client) GetConfig(ctx context.Context, configID string) (*ScoreConfig,
 	}
 	return &config, nil
 }
+
+// ValueFor returns the numeric value of the category with the given label, and whether
+// the config has such a category.
+func (c *ScoreConfig) ValueFor(label string) (float64, bool) {
+	for _, category := range c.Categories {
+		if category.Label == label {
+			return category.Value, true
+		}
+	}
+	return 0, false
+}
+
+// LabelFor returns the label of the category with the given numeric value, and whether
+// the config has such a category.
+func (c *ScoreConfig) LabelFor(value float64) (string, bool) {
+	for _, category := range c.Categories {
+		if category.Value == value {
+			return category.Label, true
+		}
+	}
+	return "", false
+}
+
+// labels returns the labels of the categories, to list them in errors.
+func (c *ScoreConfig) labels() string {
+	labels := make([]string, len(c.Categories))
+	for i, category := range c.Categories {
+		labels[i] = strconv.Quote(category.Label)
+	}
+	return strings.Join(labels, ", ")
+}
+
+// CreateWithConfig creates a score following the score config: the config ID and data type
+// are set from the config, and so is the name if it's empty.
+//
+// For categorical configs, the value is either the label of a category, e.g. "Good", or the
+// numeric value of a category, which is sent as its label. Values which don't match any
+// category are rejected without sending a request.
+//
+// Example:
+//
+//	config, err := client.GetConfig(ctx, configID)
+//	_, err = client.CreateWithConfig(ctx, config, &scores.CreateScoreRequest{
+//		TraceID: traceID,
+//		Value:   "Good",
+//	})
+func (c *Client) CreateWithConfig(ctx context.Context, config *ScoreConfig, createScore *CreateScoreRequest) (*CreateScoreResponse, error) {
+	if config == nil {
+		return nil, errors.New("'config' is required")
+	}
+	request := *createScore
+	request.ConfigID = config.ID
+	request.DataType = config.DataType
+	if request.Name == "" {
+		request.Name = config.Name
+	}
+	if config.DataType == ScoreDataTypeCategorical {
+		switch value := request.Value.(type) {
+		case nil:
+		case string:
+			if _, ok := config.ValueFor(value); !ok {
+				return nil, fmt.Errorf("value %q is not a category of score config %q, expected one of: %s", value, config.Name, config.labels())
+			}
+		default:
+			number, ok := numericValue(value)
+			if !ok {
+				return nil, fmt.Errorf("value must be a label or a category value for score config %q", config.Name)
+			}
+			label, ok := config.LabelFor(number)
+			if !ok {
+				return nil, fmt.Errorf("value %v is not a category of score config %q, expected one of: %s", value, config.Name, config.labels())
+			}
+			request.Value = label
+		}
+	}
+	return c.Create(ctx, &request)
+}
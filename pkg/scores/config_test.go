@@ -341,3 +341,67 @@ func TestClient_GetConfig(t *testing.T) {
 		require.Equal(t, "'configID' is required", err.Error())
 	})
 }
+
+func TestScoreConfig_ValueFor(t *testing.T) {
+	config := ScoreConfig{
+		Name:     "quality",
+		DataType: ScoreDataTypeCategorical,
+		Categories: []ConfigCategory{
+			{Value: 0, Label: "Bad"},
+			{Value: 1, Label: "Good"},
+		},
+	}
+	value, ok := config.ValueFor("Good")
+	require.True(t, ok)
+	require.Equal(t, float64(1), value)
+	_, ok = config.ValueFor("good")
+	require.False(t, ok)
+
+	label, ok := config.LabelFor(0)
+	require.True(t, ok)
+	require.Equal(t, "Bad", label)
+	_, ok = config.LabelFor(0.5)
+	require.False(t, ok)
+}
+
+func TestClient_CreateWithConfig(t *testing.T) {
+	var created []CreateScoreRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/scores", r.URL.Path)
+		var request CreateScoreRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		created = append(created, request)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "score-1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	config := &ScoreConfig{
+		ID:       "config-1",
+		Name:     "quality",
+		DataType: ScoreDataTypeCategorical,
+		Categories: []ConfigCategory{
+			{Value: 0, Label: "Bad"},
+			{Value: 1, Label: "Good"},
+		},
+	}
+	ctx := context.Background()
+
+	_, err := client.CreateWithConfig(ctx, config, &CreateScoreRequest{TraceID: "trace-1", Value: "Good"})
+	require.NoError(t, err)
+	_, err = client.CreateWithConfig(ctx, config, &CreateScoreRequest{TraceID: "trace-1", Name: "quality-v2", Value: 0})
+	require.NoError(t, err)
+	require.Equal(t, []CreateScoreRequest{
+		{TraceID: "trace-1", Name: "quality", DataType: ScoreDataTypeCategorical, ConfigID: "config-1", Value: "Good"},
+		{TraceID: "trace-1", Name: "quality-v2", DataType: ScoreDataTypeCategorical, ConfigID: "config-1", Value: "Bad"},
+	}, created)
+
+	_, err = client.CreateWithConfig(ctx, config, &CreateScoreRequest{TraceID: "trace-1", Value: "Great"})
+	require.EqualError(t, err, `value "Great" is not a category of score config "quality", expected one of: "Bad", "Good"`)
+	_, err = client.CreateWithConfig(ctx, config, &CreateScoreRequest{TraceID: "trace-1", Value: 2})
+	require.EqualError(t, err, `value 2 is not a category of score config "quality", expected one of: "Bad", "Good"`)
+	_, err = client.CreateWithConfig(ctx, nil, &CreateScoreRequest{TraceID: "trace-1", Value: "Good"})
+	require.EqualError(t, err, "'config' is required")
+	require.Len(t, created, 2)
+}
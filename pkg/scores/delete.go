@@ -0,0 +1,146 @@
+package scores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+)
+
+const defaultDeleteWorkers = 4
+
+// DeleteResult reports the outcome of a bulk deletion.
+type DeleteResult struct {
+	// Deleted holds the IDs of the deleted scores.
+	Deleted []string
+	// Failed holds the error of each score which couldn't be deleted.
+	Failed map[string]error
+	// Canceled holds the IDs of the scores which weren't deleted because the context
+	// was done before.
+	Canceled []string
+}
+
+type deleteConfig struct {
+	progress common.ProgressFunc
+}
+
+// DeleteOption configures DeleteMany and DeleteByFilter.
+type DeleteOption func(*deleteConfig)
+
+// WithDeleteProgress reports the number of scores processed, either deleted or failed,
+// after each deletion.
+func WithDeleteProgress(progress common.ProgressFunc) DeleteOption {
+	return func(c *deleteConfig) {
+		c.progress = progress
+	}
+}
+
+// DeleteMany deletes the scores with the given IDs.
+//
+// The API deletes one score per request, so the scores are deleted in parallel by a
+// few workers. Failures don't stop the deletion of the other scores, they're reported
+// in the result and joined in the returned error.
+//
+// When the context is done, the pending deletions are stopped and reported as canceled,
+// and the result holds the scores deleted so far.
+func (c *Client) DeleteMany(ctx context.Context, scoreIDs []string, options ...DeleteOption) (*DeleteResult, error) {
+	if len(scoreIDs) == 0 {
+		return nil, errors.New("'scoreIDs' is required")
+	}
+	config := &deleteConfig{}
+	for _, option := range options {
+		option(config)
+	}
+
+	result := &DeleteResult{
+		Deleted:  make([]string, 0, len(scoreIDs)),
+		Failed:   make(map[string]error),
+		Canceled: make([]string, 0),
+	}
+	var mu sync.Mutex
+	processed := 0
+	errs := common.ForEach(ctx, len(scoreIDs), defaultDeleteWorkers, func(i int) error {
+		err := c.Delete(ctx, scoreIDs[i])
+		if err != nil && ctx.Err() != nil {
+			// The deletion was interrupted rather than failed
+			return ctx.Err()
+		}
+		if config.progress != nil {
+			mu.Lock()
+			processed++
+			config.progress(processed, len(scoreIDs))
+			mu.Unlock()
+		}
+		return err
+	})
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			result.Deleted = append(result.Deleted, scoreIDs[i])
+		case err == ctx.Err():
+			result.Canceled = append(result.Canceled, scoreIDs[i])
+		default:
+			result.Failed[scoreIDs[i]] = err
+		}
+	}
+	sort.Strings(result.Deleted)
+	sort.Strings(result.Canceled)
+	err := result.err()
+	if len(result.Canceled) > 0 {
+		err = errors.Join(err, ctx.Err())
+	}
+	return result, err
+}
+
+// DeleteByFilter deletes all scores with the given name matching the other filters of
+// the parameters, e.g. a time range, and reports which ones were deleted.
+//
+// This is meant to clean up the scores produced by a buggy evaluator. The name is
+// required to avoid deleting unrelated scores by accident, and the pagination of the
+// parameters is ignored. The options only apply to the deletion, not to the listing.
+//
+// Example:
+//
+//	result, err := client.DeleteByFilter(ctx, scores.ListParams{
+//		Name:          "faithfulness",
+//		FromTimestamp: deployedAt,
+//		ToTimestamp:   fixedAt,
+//	})
+//	log.Printf("deleted %d scores", len(result.Deleted))
+func (c *Client) DeleteByFilter(ctx context.Context, params ListParams, options ...DeleteOption) (*DeleteResult, error) {
+	if params.Name == "" {
+		return nil, errors.New("'name' is required")
+	}
+	scores, err := c.ListAll(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scores: %w", err)
+	}
+	if len(scores) == 0 {
+		return &DeleteResult{Deleted: []string{}, Failed: map[string]error{}, Canceled: []string{}}, nil
+	}
+
+	scoreIDs := make([]string, 0, len(scores))
+	for _, score := range scores {
+		scoreIDs = append(scoreIDs, score.ID)
+	}
+	return c.DeleteMany(ctx, scoreIDs, options...)
+}
+
+func (r *DeleteResult) err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	scoreIDs := make([]string, 0, len(r.Failed))
+	for scoreID := range r.Failed {
+		scoreIDs = append(scoreIDs, scoreID)
+	}
+	sort.Strings(scoreIDs)
+	errs := make([]error, 0, len(scoreIDs))
+	for _, scoreID := range scoreIDs {
+		errs = append(errs, fmt.Errorf("delete score %s: %w", scoreID, r.Failed[scoreID]))
+	}
+	return errors.Join(errs...)
+}
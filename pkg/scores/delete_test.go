@@ -0,0 +1,105 @@
+package scores
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+)
+
+func newDeleteServer(t *testing.T, deleted *sync.Map) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			require.Equal(t, "/v2/scores", r.URL.Path)
+			require.Equal(t, "faithfulness", r.URL.Query().Get("name"))
+			w.Header().Set("Content-Type", "application/json")
+			require.NoError(t, json.NewEncoder(w).Encode(ListScores{
+				Metadata: common.ListMetadata{Page: 1, TotalItems: 3, TotalPages: 1},
+				Data:     []Score{{ID: "score-1"}, {ID: "score-2"}, {ID: "score-3"}},
+			}))
+		case http.MethodDelete:
+			scoreID := strings.TrimPrefix(r.URL.Path, "/scores/")
+			if scoreID == "score-2" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			deleted.Store(scoreID, true)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func TestClient_DeleteMany(t *testing.T) {
+	var deleted sync.Map
+	server := newDeleteServer(t, &deleted)
+	defer server.Close()
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+
+	result, err := client.DeleteMany(context.Background(), []string{"score-3", "score-1", "score-2"})
+	require.ErrorContains(t, err, "delete score score-2")
+	require.Equal(t, []string{"score-1", "score-3"}, result.Deleted)
+	require.Len(t, result.Failed, 1)
+	require.Contains(t, result.Failed, "score-2")
+
+	_, err = client.DeleteMany(context.Background(), nil)
+	require.EqualError(t, err, "'scoreIDs' is required")
+}
+
+func TestClient_DeleteMany_Progress(t *testing.T) {
+	var deleted sync.Map
+	server := newDeleteServer(t, &deleted)
+	defer server.Close()
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+
+	var mu sync.Mutex
+	reported := make([]int, 0)
+	_, err := client.DeleteMany(context.Background(), []string{"score-1", "score-2", "score-3"},
+		WithDeleteProgress(func(processed, total int) {
+			mu.Lock()
+			defer mu.Unlock()
+			require.Equal(t, 3, total)
+			reported = append(reported, processed)
+		}))
+	require.Error(t, err)
+	require.Equal(t, []int{1, 2, 3}, reported)
+}
+
+func TestClient_DeleteMany_Canceled(t *testing.T) {
+	var deleted sync.Map
+	server := newDeleteServer(t, &deleted)
+	defer server.Close()
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := client.DeleteMany(ctx, []string{"score-1", "score-3"})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, result.Deleted)
+	require.Empty(t, result.Failed)
+	require.Equal(t, []string{"score-1", "score-3"}, result.Canceled)
+}
+
+func TestClient_DeleteByFilter(t *testing.T) {
+	var deleted sync.Map
+	server := newDeleteServer(t, &deleted)
+	defer server.Close()
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+
+	result, err := client.DeleteByFilter(context.Background(), ListParams{Name: "faithfulness"})
+	require.Error(t, err)
+	require.Equal(t, []string{"score-1", "score-3"}, result.Deleted)
+	_, ok := deleted.Load("score-3")
+	require.True(t, ok)
+
+	_, err = client.DeleteByFilter(context.Background(), ListParams{})
+	require.EqualError(t, err, "'name' is required")
+}
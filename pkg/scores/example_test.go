@@ -0,0 +1,27 @@
+package scores_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	langfuse "github.com/git-hulk/langfuse-go"
+	"github.com/git-hulk/langfuse-go/pkg/scores"
+)
+
+func ExampleClient_Create() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...")
+	defer client.Close()
+
+	score, err := client.Scores().Create(context.Background(), &scores.CreateScoreRequest{
+		TraceID:  "trace-id",
+		Name:     "faithfulness",
+		DataType: scores.ScoreDataTypeNumeric,
+		Value:    0.92,
+		Comment:  "grounded in the retrieved documents",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(score.ID)
+}
@@ -0,0 +1,61 @@
+package scores
+
+import (
+	"strconv"
+)
+
+// WithValuePrecision rounds the numeric values of created scores to the given number of
+// decimal places, e.g. 0.1+0.2 is sent as 0.3 instead of 0.30000000000000004 with a
+// precision of 2. Negative precisions are ignored.
+func WithValuePrecision(decimals int) ClientOption {
+	return func(c *Client) {
+		if decimals >= 0 {
+			c.valuePrecision = &decimals
+		}
+	}
+}
+
+// WithDecimalStrings allows numeric values for CATEGORICAL scores, which are sent as exact
+// decimal strings, e.g. 0.5 as "0.5", so the categories match the ones of the score config.
+// The value is rounded to the precision of WithValuePrecision if set, otherwise the
+// shortest decimal representation is used.
+func WithDecimalStrings() ClientOption {
+	return func(c *Client) {
+		c.decimalStrings = true
+	}
+}
+
+// RoundValue rounds the value to the given number of decimal places, returning the float64
+// closest to the rounded decimal, e.g. RoundValue(0.1+0.2, 2) is 0.3.
+func RoundValue(value float64, decimals int) float64 {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(value, 'f', decimals, 64), 64)
+	if err != nil {
+		return value
+	}
+	return rounded
+}
+
+// FormatValue formats the value as a decimal string with the given number of decimal
+// places, or the shortest representation if decimals is negative, e.g. FormatValue(0.5, -1)
+// is "0.5".
+func FormatValue(value float64, decimals int) string {
+	return strconv.FormatFloat(value, 'f', decimals, 64)
+}
+
+// normalizeValue applies the precision options to the value of the request.
+func (c *Client) normalizeValue(request *CreateScoreRequest) {
+	value, ok := numericValue(request.Value)
+	if !ok {
+		return
+	}
+	decimals := -1
+	if c.valuePrecision != nil {
+		decimals = *c.valuePrecision
+	}
+	switch {
+	case c.decimalStrings && request.DataType == ScoreDataTypeCategorical:
+		request.Value = FormatValue(value, decimals)
+	case decimals >= 0 && request.DataType != ScoreDataTypeBoolean:
+		request.Value = RoundValue(value, decimals)
+	}
+}
@@ -0,0 +1,59 @@
+package scores
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundValue(t *testing.T) {
+	require.Equal(t, 0.3, RoundValue(0.1+0.2, 2))
+	require.Equal(t, 1.01, RoundValue(1.0078, 2))
+	require.Equal(t, 42.0, RoundValue(41.6, 0))
+	require.Equal(t, "0.3", FormatValue(RoundValue(0.1+0.2, 6), -1))
+	require.Equal(t, "0.50", FormatValue(0.5, 2))
+}
+
+func TestClient_Create_ValuePrecision(t *testing.T) {
+	var values []json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Value json.RawMessage `json:"value"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		values = append(values, request.Value)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "score-1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL), WithValuePrecision(2), WithDecimalStrings())
+	ctx := context.Background()
+	requests := []*CreateScoreRequest{
+		{Name: "accuracy", TraceID: "trace-1", Value: 0.1 + 0.2},
+		{Name: "accuracy", TraceID: "trace-1", Value: float32(0.7), DataType: ScoreDataTypeNumeric},
+		{Name: "grade", TraceID: "trace-1", Value: 0.5, DataType: ScoreDataTypeCategorical},
+		{Name: "grade", TraceID: "trace-1", Value: "good", DataType: ScoreDataTypeCategorical},
+		{Name: "passed", TraceID: "trace-1", Value: true, DataType: ScoreDataTypeBoolean},
+	}
+	for _, request := range requests {
+		_, err := client.Create(ctx, request)
+		require.NoError(t, err)
+	}
+	require.Equal(t, []json.RawMessage{
+		json.RawMessage(`0.3`), json.RawMessage(`0.7`), json.RawMessage(`"0.50"`),
+		json.RawMessage(`"good"`), json.RawMessage(`1`),
+	}, values)
+	// The requests aren't modified
+	require.Equal(t, 0.1+0.2, requests[0].Value)
+
+	_, err := NewClient(resty.New()).Create(ctx, &CreateScoreRequest{
+		Name: "grade", TraceID: "trace-1", Value: 0.5, DataType: ScoreDataTypeCategorical,
+	})
+	require.EqualError(t, err, "value must be a string for CATEGORICAL data type")
+}
@@ -0,0 +1,127 @@
+package scores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+)
+
+const configsPageSize = 100
+
+// ClientOption configures the scores API client.
+type ClientOption func(*Client)
+
+// ErrUnknownScoreName is returned by Create in strict mode when the name of the score
+// has no score config, see StrictScoreNames.
+var ErrUnknownScoreName = errors.New("unknown score name")
+
+// NameRegistry is a set of allowed score names, e.g. the names of the score configs of
+// a project. It's safe for concurrent use.
+type NameRegistry struct {
+	mu    sync.RWMutex
+	names map[string]struct{}
+}
+
+// NewNameRegistry creates a registry allowing the given score names.
+func NewNameRegistry(names ...string) *NameRegistry {
+	registry := &NameRegistry{names: make(map[string]struct{}, len(names))}
+	registry.Add(names...)
+	return registry
+}
+
+// Add allows the given score names.
+func (r *NameRegistry) Add(names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range names {
+		if name != "" {
+			r.names[name] = struct{}{}
+		}
+	}
+}
+
+// Contains reports whether the score name is allowed.
+func (r *NameRegistry) Contains(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.names[name]
+	return ok
+}
+
+// Names returns the allowed score names in alphabetical order.
+func (r *NameRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.names))
+	for name := range r.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StrictScoreNames makes Create reject the scores whose name isn't in the registry with
+// ErrUnknownScoreName, so that a typo doesn't silently create a new metric.
+//
+// If registry is nil, it's seeded with the names of the score configs which aren't archived,
+// see LoadNameRegistry, on the first Create. A failed load is retried on the next Create.
+//
+// Example:
+//
+//	client := scores.NewClient(restyCli, scores.StrictScoreNames(nil))
+func StrictScoreNames(registry *NameRegistry) ClientOption {
+	return func(c *Client) {
+		c.strictNames = true
+		c.nameRegistry = registry
+	}
+}
+
+// LoadNameRegistry creates a registry with the names of all the score configs which
+// aren't archived.
+func (c *Client) LoadNameRegistry(ctx context.Context) (*NameRegistry, error) {
+	configs, err := common.FetchAll(ctx, func(ctx context.Context, page int) ([]ScoreConfig, common.ListMetadata, error) {
+		listConfigs, err := c.ListConfigs(ctx, ConfigListParams{Page: page, Limit: configsPageSize})
+		if err != nil {
+			return nil, common.ListMetadata{}, err
+		}
+		return listConfigs.Data, listConfigs.Metadata, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	registry := NewNameRegistry()
+	for _, config := range configs {
+		if !config.IsArchived {
+			registry.Add(config.Name)
+		}
+	}
+	return registry, nil
+}
+
+// checkScoreName rejects the score name in strict mode if it's not in the registry.
+func (c *Client) checkScoreName(ctx context.Context, name string) error {
+	if !c.strictNames {
+		return nil
+	}
+	c.nameRegistryMu.Lock()
+	registry := c.nameRegistry
+	if registry == nil {
+		var err error
+		registry, err = c.LoadNameRegistry(ctx)
+		if err != nil {
+			c.nameRegistryMu.Unlock()
+			return fmt.Errorf("failed to load the score names: %w", err)
+		}
+		c.nameRegistry = registry
+	}
+	c.nameRegistryMu.Unlock()
+
+	if !registry.Contains(name) {
+		return fmt.Errorf("%w: %q has no score config", ErrUnknownScoreName, name)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package scores
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+)
+
+func TestNameRegistry(t *testing.T) {
+	registry := NewNameRegistry("accuracy", "")
+	registry.Add("helpfulness")
+	require.True(t, registry.Contains("accuracy"))
+	require.False(t, registry.Contains("acuracy"))
+	require.False(t, registry.Contains(""))
+	require.Equal(t, []string{"accuracy", "helpfulness"}, registry.Names())
+}
+
+func TestClient_StrictScoreNames(t *testing.T) {
+	var configRequests, createRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/score-configs":
+			configRequests.Add(1)
+			_ = json.NewEncoder(w).Encode(ListScoreConfigs{
+				Metadata: common.ListMetadata{Page: 1, Limit: configsPageSize, TotalItems: 2, TotalPages: 1},
+				Data: []ScoreConfig{
+					{ID: "config-1", Name: "accuracy"},
+					{ID: "config-2", Name: "legacy", IsArchived: true},
+				},
+			})
+		case "/scores":
+			createRequests.Add(1)
+			_ = json.NewEncoder(w).Encode(CreateScoreResponse{ID: "score-1"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL), StrictScoreNames(nil))
+	ctx := context.Background()
+
+	created, err := client.Create(ctx, &CreateScoreRequest{TraceID: "trace-1", Name: "accuracy", Value: 0.9})
+	require.NoError(t, err)
+	require.Equal(t, "score-1", created.ID)
+
+	for _, name := range []string{"acuracy", "legacy"} {
+		_, err = client.Create(ctx, &CreateScoreRequest{TraceID: "trace-1", Name: name, Value: 0.9})
+		require.ErrorIs(t, err, ErrUnknownScoreName)
+	}
+	require.EqualError(t, err, `unknown score name: "legacy" has no score config`)
+
+	// The names are loaded once and the rejected scores aren't sent
+	require.EqualValues(t, 1, configRequests.Load())
+	require.EqualValues(t, 1, createRequests.Load())
+
+	// An explicit registry is used as is
+	client = NewClient(resty.New().SetBaseURL(server.URL), StrictScoreNames(NewNameRegistry("latency")))
+	_, err = client.Create(ctx, &CreateScoreRequest{TraceID: "trace-1", Name: "latency", Value: 1})
+	require.NoError(t, err)
+	_, err = client.Create(ctx, &CreateScoreRequest{TraceID: "trace-1", Name: "accuracy", Value: 1})
+	require.ErrorIs(t, err, ErrUnknownScoreName)
+	require.EqualValues(t, 1, configRequests.Load())
+
+	// Without strict mode, any name is accepted
+	client = NewClient(resty.New().SetBaseURL(server.URL))
+	_, err = client.Create(ctx, &CreateScoreRequest{TraceID: "trace-1", Name: "acuracy", Value: 1})
+	require.NoError(t, err)
+}
+
+func TestClient_StrictScoreNamesLoadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL), StrictScoreNames(nil))
+	_, err := client.Create(context.Background(), &CreateScoreRequest{TraceID: "trace-1", Name: "accuracy", Value: 1})
+	require.ErrorContains(t, err, "failed to load the score names")
+	require.Nil(t, client.nameRegistry)
+}
@@ -8,11 +8,13 @@ package scores
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/git-hulk/langfuse-go/pkg/common"
@@ -69,6 +71,23 @@ type Score struct {
 	QueueID       string            `json:"queueId,omitempty"`
 	Metadata      any               `json:"metadata,omitempty"`
 	Trace         traces.TraceEntry `json:"trace,omitempty"`
+	// Extra holds the response fields which are not known to this SDK version.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements custom JSON unmarshalling for Score.
+// Fields which are not known to the SDK are preserved in Extra.
+func (s *Score) UnmarshalJSON(data []byte) error {
+	type Alias Score
+	if err := json.Unmarshal(data, (*Alias)(s)); err != nil {
+		return err
+	}
+	extra, err := common.UnknownFields(data, Alias{})
+	if err != nil {
+		return err
+	}
+	s.Extra = extra
+	return nil
 }
 
 // CreateScoreRequest represents the parameters for creating a new score.
@@ -137,16 +156,19 @@ type ListParams struct {
 	QueueID       string
 	DataType      ScoreDataType
 	TraceTags     []string
+
+	pagination common.Pagination
+	valueSet   bool
 }
 
 // ToQueryString converts the ListParams to a URL query string.
 func (p *ListParams) ToQueryString() string {
 	parts := make([]string, 0)
 
-	if p.Page != 0 {
+	if p.pagination.HasPage(p.Page) {
 		parts = append(parts, "page="+strconv.Itoa(p.Page))
 	}
-	if p.Limit != 0 {
+	if p.pagination.HasLimit(p.Limit) {
 		parts = append(parts, "limit="+strconv.Itoa(p.Limit))
 	}
 	if p.UserID != "" {
@@ -174,7 +196,7 @@ func (p *ListParams) ToQueryString() string {
 	if p.Operator != "" {
 		parts = append(parts, "operator="+url.QueryEscape(p.Operator))
 	}
-	if p.Value != 0 {
+	if p.Value != 0 || p.valueSet {
 		parts = append(parts, "value="+strconv.FormatFloat(p.Value, 'f', -1, 64))
 	}
 	if len(p.ScoreIDs) > 0 {
@@ -200,6 +222,55 @@ func (p *ListParams) ToQueryString() string {
 	return strings.Join(parts, "&")
 }
 
+// SetPage sets the page number, even if it is 0.
+func (p *ListParams) SetPage(page int) {
+	p.Page = page
+	p.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (p *ListParams) SetLimit(limit int) {
+	p.Limit = limit
+	p.pagination.MarkLimit()
+}
+
+// ListOption configures ListParams, see common.ListOption.
+type ListOption = common.ListOption[ListParams]
+
+// NewListParams creates ListParams for listing scores from the provided options.
+func NewListParams(options ...ListOption) ListParams {
+	return common.NewListParams(options...)
+}
+
+// WithPage sets the page number, even if it is 0.
+func WithPage(page int) ListOption {
+	return common.WithPage[ListParams](page)
+}
+
+// WithTimeRange sets FromTimestamp and ToTimestamp from the time window, e.g.
+// common.LastHours(24) or common.Today().
+func WithTimeRange(r common.TimeRange) ListOption {
+	return func(p *ListParams) {
+		p.FromTimestamp = r.From
+		p.ToTimestamp = r.To
+	}
+}
+
+// WithLimit sets the page size, even if it is 0.
+func WithLimit(limit int) ListOption {
+	return common.WithLimit[ListParams](limit)
+}
+
+// WithValue sets the value to compare the scores with, even if it is 0.
+//
+// Use it together with Operator, e.g. to list all scores with a value of exactly 0.
+func WithValue(value float64) ListOption {
+	return func(p *ListParams) {
+		p.Value = value
+		p.valueSet = true
+	}
+}
+
 // ListScores represents the paginated response from the list scores API.
 //
 // It contains pagination metadata and an array of scores matching the query criteria.
@@ -214,14 +285,23 @@ type ListScores struct {
 // including creating, retrieving, listing, and deleting scores, as well as
 // managing score configurations.
 type Client struct {
-	restyCli *resty.Client
+	restyCli       *resty.Client
+	valuePrecision *int
+	decimalStrings bool
+	strictNames    bool
+	nameRegistry   *NameRegistry
+	nameRegistryMu sync.Mutex
 }
 
 // NewClient creates a new scores client with the provided HTTP client.
 //
 // The resty client should be pre-configured with authentication and base URL.
-func NewClient(cli *resty.Client) *Client {
-	return &Client{restyCli: cli}
+func NewClient(cli *resty.Client, options ...ClientOption) *Client {
+	client := &Client{restyCli: cli}
+	for _, option := range options {
+		option(client)
+	}
+	return client
 }
 
 // List retrieves a list of scores based on the provided parameters (v2 API).
@@ -242,6 +322,44 @@ func (c *Client) List(ctx context.Context, params ListParams) (*ListScores, erro
 	return &listResponse, nil
 }
 
+// ListAll retrieves the scores of all pages matching the parameters, fetching the pages in parallel.
+//
+// The Page of the parameters is ignored, and Limit sets the page size. Use the common.FetchOption
+// values to configure the number of workers, or to keep the results in page order.
+func (c *Client) ListAll(ctx context.Context, params ListParams, options ...common.FetchOption) ([]Score, error) {
+	return common.FetchAll(ctx, func(ctx context.Context, page int) ([]Score, common.ListMetadata, error) {
+		pageParams := params
+		pageParams.Page = page
+		listScores, err := c.List(ctx, pageParams)
+		if err != nil {
+			return nil, common.ListMetadata{}, err
+		}
+		return listScores.Data, listScores.Metadata, nil
+	}, options...)
+}
+
+// SyncSince retrieves the scores matching the parameters which were updated after since,
+// and returns them together with the high-watermark to pass as since of the next sync.
+//
+// The API can only filter scores by creation time, so FromTimestamp is raised to since and
+// the fetched scores are filtered by UpdatedAt. A score which was created before since and
+// updated afterwards isn't returned, use ListAll with common.UpdatedSince to scan all the
+// scores for those. The pages are fetched in parallel and the page of the parameters is ignored.
+func (c *Client) SyncSince(ctx context.Context, since time.Time, params ListParams, options ...common.FetchOption) ([]Score, time.Time, error) {
+	if since.After(params.FromTimestamp) {
+		params.FromTimestamp = since
+	}
+	scores, err := c.ListAll(ctx, params, options...)
+	if err != nil {
+		return nil, since, err
+	}
+	// FromTimestamp includes since itself
+	updated, watermark := common.UpdatedSince(scores, since, func(score Score) time.Time {
+		return score.UpdatedAt
+	})
+	return updated, watermark, nil
+}
+
 // Get retrieves a specific score by ID (v2 API).
 func (c *Client) Get(ctx context.Context, scoreID string) (*Score, error) {
 	if scoreID == "" {
@@ -266,15 +384,24 @@ func (c *Client) Get(ctx context.Context, scoreID string) (*Score, error) {
 }
 
 // Create creates a new score (v1 API).
+//
+// Numeric values are rounded according to WithValuePrecision and WithDecimalStrings
+// before they're sent, the request itself isn't modified. With StrictScoreNames, the
+// scores whose name has no score config are rejected.
 func (c *Client) Create(ctx context.Context, createScore *CreateScoreRequest) (*CreateScoreResponse, error) {
-	if err := createScore.validate(); err != nil {
+	request := *createScore
+	c.normalizeValue(&request)
+	if err := request.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.checkScoreName(ctx, request.Name); err != nil {
 		return nil, err
 	}
 
 	var createdScore CreateScoreResponse
 	rsp, err := c.restyCli.R().
 		SetContext(ctx).
-		SetBody(createScore).
+		SetBody(&request).
 		SetResult(&createdScore).
 		Post("/scores")
 	if err != nil {
@@ -339,3 +466,112 @@ func (c *Client) Delete(ctx context.Context, scoreID string) error {
 	}
 	return nil
 }
+
+// ListBySession retrieves the scores of a session matching the parameters.
+//
+// The v2 scores API has no session filter, so all the scores matching the parameters are
+// fetched with ListAll and filtered by their SessionID. Narrow the parameters, e.g. by
+// name or time range, to limit the number of fetched pages.
+func (c *Client) ListBySession(ctx context.Context, sessionID string, params ListParams, options ...common.FetchOption) ([]Score, error) {
+	if sessionID == "" {
+		return nil, errors.New("'sessionID' is required")
+	}
+	return c.listWhere(ctx, params, options, func(score *Score) bool {
+		return score.SessionID == sessionID
+	})
+}
+
+// ListByDatasetRun retrieves the scores of a dataset run matching the parameters.
+//
+// Like ListBySession, the scores are filtered by their DatasetRunID on the client side.
+func (c *Client) ListByDatasetRun(ctx context.Context, datasetRunID string, params ListParams, options ...common.FetchOption) ([]Score, error) {
+	if datasetRunID == "" {
+		return nil, errors.New("'datasetRunID' is required")
+	}
+	return c.listWhere(ctx, params, options, func(score *Score) bool {
+		return score.DatasetRunID == datasetRunID
+	})
+}
+
+// listWhere retrieves the scores matching the parameters for which keep returns true.
+func (c *Client) listWhere(ctx context.Context, params ListParams, options []common.FetchOption, keep func(*Score) bool) ([]Score, error) {
+	allScores, err := c.ListAll(ctx, params, options...)
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]Score, 0)
+	for i := range allScores {
+		if keep(&allScores[i]) {
+			matched = append(matched, allScores[i])
+		}
+	}
+	return matched, nil
+}
+
+// ListByTrace retrieves all scores attached to a trace, including the scores of its observations.
+//
+// The scores are read from the trace details, so no pagination is needed.
+func (c *Client) ListByTrace(ctx context.Context, traceID string) ([]Score, error) {
+	if traceID == "" {
+		return nil, errors.New("'traceID' is required")
+	}
+
+	var trace struct {
+		Scores []Score `json:"scores"`
+	}
+	rsp, err := c.restyCli.R().
+		SetContext(ctx).
+		SetResult(&trace).
+		SetPathParam("traceID", traceID).
+		Get("/traces/{traceID}")
+	if err != nil {
+		return nil, err
+	}
+	if rsp.IsError() {
+		return nil, fmt.Errorf("get trace scores failed: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	}
+	if trace.Scores == nil {
+		return []Score{}, nil
+	}
+	return trace.Scores, nil
+}
+
+// ListByObservation retrieves all scores attached to an observation.
+//
+// The observation is looked up first to find its trace, then the scores of the
+// trace are filtered by the observation ID.
+func (c *Client) ListByObservation(ctx context.Context, observationID string) ([]Score, error) {
+	if observationID == "" {
+		return nil, errors.New("'observationID' is required")
+	}
+
+	var observation struct {
+		TraceID string `json:"traceId"`
+	}
+	rsp, err := c.restyCli.R().
+		SetContext(ctx).
+		SetResult(&observation).
+		SetPathParam("observationID", observationID).
+		Get("/observations/{observationID}")
+	if err != nil {
+		return nil, err
+	}
+	if rsp.IsError() {
+		return nil, fmt.Errorf("get observation failed: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	}
+	if observation.TraceID == "" {
+		return []Score{}, nil
+	}
+
+	traceScores, err := c.ListByTrace(ctx, observation.TraceID)
+	if err != nil {
+		return nil, err
+	}
+	observationScores := make([]Score, 0)
+	for _, score := range traceScores {
+		if score.ObservationID == observationID {
+			observationScores = append(observationScores, score)
+		}
+	}
+	return observationScores, nil
+}
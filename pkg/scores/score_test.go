@@ -146,6 +146,22 @@ func TestListParams_ToQueryString(t *testing.T) {
 			},
 			want: "operator=%3E%3D&value=0.8",
 		},
+		{
+			name: "with explicit zero value",
+			params: func() ListParams {
+				params := NewListParams(WithPage(1), WithValue(0))
+				params.Operator = "="
+				return params
+			}(),
+			want: "page=1&operator=%3D&value=0",
+		},
+		{
+			name: "with time range",
+			params: NewListParams(WithTimeRange(common.TimeRange{
+				From: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC),
+			})),
+			want: "fromTimestamp=2023-01-01T10%3A00%3A00Z",
+		},
 		{
 			name: "with score IDs",
 			params: ListParams{
@@ -501,3 +517,138 @@ func mustParseTime(s string) time.Time {
 	}
 	return t
 }
+
+func TestClient_ListByTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/traces/trace-123", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"trace-123","scores":[
+			{"id":"score-1","traceId":"trace-123","name":"accuracy","value":0.9,"dataType":"NUMERIC"},
+			{"id":"score-2","traceId":"trace-123","observationId":"obs-1","name":"relevance","value":1,"dataType":"NUMERIC"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	scores, err := client.ListByTrace(context.Background(), "trace-123")
+	require.NoError(t, err)
+	require.Len(t, scores, 2)
+	require.Equal(t, "score-1", scores[0].ID)
+
+	_, err = client.ListByTrace(context.Background(), "")
+	require.EqualError(t, err, "'traceID' is required")
+}
+
+func TestClient_ListByObservation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/observations/obs-1":
+			_, _ = w.Write([]byte(`{"id":"obs-1","traceId":"trace-123"}`))
+		case "/traces/trace-123":
+			_, _ = w.Write([]byte(`{"id":"trace-123","scores":[
+				{"id":"score-1","traceId":"trace-123","name":"accuracy","value":0.9},
+				{"id":"score-2","traceId":"trace-123","observationId":"obs-1","name":"relevance","value":1},
+				{"id":"score-3","traceId":"trace-123","observationId":"obs-2","name":"relevance","value":0}]}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	scores, err := client.ListByObservation(context.Background(), "obs-1")
+	require.NoError(t, err)
+	require.Len(t, scores, 1)
+	require.Equal(t, "score-2", scores[0].ID)
+
+	_, err = client.ListByObservation(context.Background(), "")
+	require.EqualError(t, err, "'observationID' is required")
+}
+
+func TestClient_ListAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/scores", r.URL.Path)
+		query := r.URL.Query()
+		require.Equal(t, "accuracy", query.Get("name"))
+		require.Equal(t, "2", query.Get("limit"))
+
+		page := query.Get("page")
+		scores := ListScores{
+			Metadata: common.ListMetadata{Limit: 2, TotalItems: 5, TotalPages: 3},
+			Data:     []Score{{ID: "score-" + page + "-1"}, {ID: "score-" + page + "-2"}},
+		}
+		if page == "3" {
+			scores.Data = scores.Data[:1]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(scores))
+	}))
+	defer server.Close()
+
+	scoreClient := NewClient(resty.New().SetBaseURL(server.URL))
+	result, err := scoreClient.ListAll(context.Background(), ListParams{Name: "accuracy", Limit: 2}, common.WithOrderedResults())
+	require.NoError(t, err)
+	ids := make([]string, 0, len(result))
+	for _, score := range result {
+		ids = append(ids, score.ID)
+	}
+	require.Equal(t, []string{"score-1-1", "score-1-2", "score-2-1", "score-2-2", "score-3-1"}, ids)
+}
+
+func TestClient_ListBySession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/scores", r.URL.Path)
+		require.Equal(t, "accuracy", r.URL.Query().Get("name"))
+		require.Empty(t, r.URL.Query().Get("sessionId"))
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"meta":{"page":1,"limit":10,"totalItems":3,"totalPages":1},"data":[
+			{"id":"score-1","sessionId":"session-1"},
+			{"id":"score-2","sessionId":"session-2","datasetRunId":"run-1"},
+			{"id":"score-3","sessionId":"session-1","datasetRunId":"run-1"}]}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	scoreIDs := func(scores []Score) []string {
+		ids := make([]string, 0, len(scores))
+		for _, score := range scores {
+			ids = append(ids, score.ID)
+		}
+		return ids
+	}
+
+	sessionScores, err := client.ListBySession(context.Background(), "session-1", ListParams{Name: "accuracy"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"score-1", "score-3"}, scoreIDs(sessionScores))
+
+	runScores, err := client.ListByDatasetRun(context.Background(), "run-1", ListParams{Name: "accuracy"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"score-2", "score-3"}, scoreIDs(runScores))
+
+	_, err = client.ListBySession(context.Background(), "", ListParams{})
+	require.EqualError(t, err, "'sessionID' is required")
+	_, err = client.ListByDatasetRun(context.Background(), "", ListParams{})
+	require.EqualError(t, err, "'datasetRunID' is required")
+}
+
+func TestClient_SyncSince(t *testing.T) {
+	since := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/scores", r.URL.Path)
+		require.Equal(t, "2025-01-01T10:00:00Z", r.URL.Query().Get("fromTimestamp"))
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"meta":{"page":1,"limit":10,"totalItems":2,"totalPages":1},"data":[
+			{"id":"unchanged","updatedAt":"2025-01-01T10:00:00Z"},
+			{"id":"updated","updatedAt":"2025-01-02T10:00:00Z"}]}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	scores, watermark, err := client.SyncSince(context.Background(), since, ListParams{FromTimestamp: since.AddDate(0, 0, -1)})
+	require.NoError(t, err)
+	require.Len(t, scores, 1)
+	require.Equal(t, "updated", scores[0].ID)
+	require.Equal(t, time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), watermark.UTC())
+}
@@ -0,0 +1,79 @@
+package scores
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var scoreSources = []ScoreSource{ScoreSourceAnnotation, ScoreSourceAPI, ScoreSourceEval}
+
+var scoreDataTypes = []ScoreDataType{ScoreDataTypeNumeric, ScoreDataTypeBoolean, ScoreDataTypeCategorical}
+
+// ParseScoreSource parses a score source case-insensitively, e.g. "eval" or "EVAL".
+func ParseScoreSource(s string) (ScoreSource, error) {
+	for _, source := range scoreSources {
+		if strings.EqualFold(strings.TrimSpace(s), string(source)) {
+			return source, nil
+		}
+	}
+	return "", fmt.Errorf("invalid score source %q, expected one of %s", s, joinValues(scoreSources))
+}
+
+// String implements fmt.Stringer.
+func (s ScoreSource) String() string {
+	return string(s)
+}
+
+// IsValid reports whether the score source is one of the known sources.
+func (s ScoreSource) IsValid() bool {
+	return slices.Contains(scoreSources, s)
+}
+
+// Set parses the score source, which allows using it as a flag.Value.
+func (s *ScoreSource) Set(value string) error {
+	source, err := ParseScoreSource(value)
+	if err != nil {
+		return err
+	}
+	*s = source
+	return nil
+}
+
+// ParseScoreDataType parses a score data type case-insensitively, e.g. "numeric" or "NUMERIC".
+func ParseScoreDataType(s string) (ScoreDataType, error) {
+	for _, dataType := range scoreDataTypes {
+		if strings.EqualFold(strings.TrimSpace(s), string(dataType)) {
+			return dataType, nil
+		}
+	}
+	return "", fmt.Errorf("invalid score data type %q, expected one of %s", s, joinValues(scoreDataTypes))
+}
+
+// String implements fmt.Stringer.
+func (t ScoreDataType) String() string {
+	return string(t)
+}
+
+// IsValid reports whether the score data type is one of the known data types.
+func (t ScoreDataType) IsValid() bool {
+	return slices.Contains(scoreDataTypes, t)
+}
+
+// Set parses the score data type, which allows using it as a flag.Value.
+func (t *ScoreDataType) Set(value string) error {
+	dataType, err := ParseScoreDataType(value)
+	if err != nil {
+		return err
+	}
+	*t = dataType
+	return nil
+}
+
+func joinValues[T ~string](values []T) string {
+	s := make([]string, 0, len(values))
+	for _, v := range values {
+		s = append(s, string(v))
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,60 @@
+package scores
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScoreSource(t *testing.T) {
+	for input, want := range map[string]ScoreSource{
+		"ANNOTATION": ScoreSourceAnnotation,
+		"api":        ScoreSourceAPI,
+		" Eval ":     ScoreSourceEval,
+	} {
+		source, err := ParseScoreSource(input)
+		require.NoError(t, err, input)
+		require.Equal(t, want, source)
+		require.True(t, source.IsValid())
+	}
+
+	_, err := ParseScoreSource("manual")
+	require.EqualError(t, err, `invalid score source "manual", expected one of ANNOTATION, API, EVAL`)
+	require.False(t, ScoreSource("api").IsValid())
+	require.Equal(t, "EVAL", ScoreSourceEval.String())
+}
+
+func TestParseScoreDataType(t *testing.T) {
+	for input, want := range map[string]ScoreDataType{
+		"NUMERIC":     ScoreDataTypeNumeric,
+		"boolean":     ScoreDataTypeBoolean,
+		"Categorical": ScoreDataTypeCategorical,
+	} {
+		dataType, err := ParseScoreDataType(input)
+		require.NoError(t, err, input)
+		require.Equal(t, want, dataType)
+	}
+
+	_, err := ParseScoreDataType("text")
+	require.EqualError(t, err, `invalid score data type "text", expected one of NUMERIC, BOOLEAN, CATEGORICAL`)
+	require.False(t, ScoreDataType("").IsValid())
+	require.Equal(t, "BOOLEAN", ScoreDataTypeBoolean.String())
+}
+
+func TestScoreEnums_Flag(t *testing.T) {
+	flags := flag.NewFlagSet("scores", flag.ContinueOnError)
+	source := ScoreSourceAPI
+	dataType := ScoreDataTypeNumeric
+	flags.Var(&source, "source", "score source")
+	flags.Var(&dataType, "data-type", "score data type")
+
+	require.NoError(t, flags.Parse([]string{"-source", "eval", "-data-type", "categorical"}))
+	require.Equal(t, ScoreSourceEval, source)
+	require.Equal(t, ScoreDataTypeCategorical, dataType)
+
+	flags.SetOutput(io.Discard)
+	require.Error(t, flags.Parse([]string{"-source", "unknown"}))
+	require.Equal(t, ScoreSourceEval, source)
+}
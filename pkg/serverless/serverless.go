@@ -0,0 +1,196 @@
+// Package serverless helps to not lose traces in FaaS environments like AWS Lambda or Cloud Run.
+//
+// In these environments the process is frozen between invocations and may be shut down
+// without running deferred functions, so traces which are still buffered by the ingestor
+// are lost. The Lifecycle in this package force-flushes the ingestor after each invocation
+// and when the runtime signals the shutdown of the execution environment.
+//
+// Example with AWS Lambda:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithBatchConfig(serverless.BatchConfig()))
+//	lifecycle := serverless.New(client, serverless.Config{})
+//	lifecycle.Start()
+//	defer lifecycle.Stop()
+//
+//	lambda.Start(serverless.WrapHandler(lifecycle, handler))
+package serverless
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/git-hulk/langfuse-go/pkg/batch"
+	"github.com/git-hulk/langfuse-go/pkg/logger"
+)
+
+const (
+	defaultFlushTimeout = 2 * time.Second
+	// defaultDeadlineMargin is kept free before the invocation deadline, so the
+	// handler still returns in time if the flush is slow.
+	defaultDeadlineMargin = 100 * time.Millisecond
+)
+
+// BatchConfig returns batch settings suited to serverless environments.
+//
+// Batches are smaller and flushed more often than by default, which reduces the
+// amount of buffered traces when the execution environment is frozen.
+func BatchConfig() batch.Config {
+	return batch.Config{
+		MaxBatchSize:    10,
+		FlushInterval:   500 * time.Millisecond,
+		BufferSize:      100,
+		ShutdownTimeout: defaultFlushTimeout,
+	}
+}
+
+// Flusher is implemented by the Langfuse client and the traces ingestor.
+type Flusher interface {
+	FlushContext(ctx context.Context) error
+}
+
+// Config holds the configuration of a Lifecycle.
+type Config struct {
+	// FlushTimeout bounds each flush if the context has no earlier deadline.
+	// Default is 2 seconds.
+	FlushTimeout time.Duration
+	// Signals are the signals which announce the shutdown of the execution environment.
+	// AWS Lambda sends SIGTERM when at least one extension is registered, and Cloud Run
+	// sends SIGTERM before stopping an instance. Default is SIGTERM and os.Interrupt.
+	Signals []os.Signal
+	// OnFreeze is called before the execution environment may be frozen, after the
+	// flush of an invocation or on shutdown. Optional.
+	OnFreeze func()
+	// OnError is called when a flush fails. By default, the error is logged.
+	OnError func(err error)
+}
+
+func (c *Config) normalize() {
+	if c.FlushTimeout <= 0 {
+		c.FlushTimeout = defaultFlushTimeout
+	}
+	if len(c.Signals) == 0 {
+		c.Signals = []os.Signal{syscall.SIGTERM, os.Interrupt}
+	}
+	if c.OnError == nil {
+		c.OnError = func(err error) {
+			logger.Get().Error("Failed to flush traces", zap.Error(err))
+		}
+	}
+}
+
+// Lifecycle flushes a Flusher at the end of each invocation and on shutdown.
+type Lifecycle struct {
+	flusher Flusher
+	config  Config
+
+	mu       sync.Mutex
+	signalCh chan os.Signal
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// New creates a Lifecycle which flushes the given Flusher.
+func New(flusher Flusher, config Config) *Lifecycle {
+	config.normalize()
+	return &Lifecycle{flusher: flusher, config: config}
+}
+
+// Start listens for the shutdown signals and flushes when one is received.
+//
+// The signal is not re-raised, so the process keeps running until the runtime stops it.
+// Calling Start on a started Lifecycle is a no-op.
+func (l *Lifecycle) Start() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.signalCh != nil {
+		return
+	}
+	l.signalCh = make(chan os.Signal, 1)
+	l.stopCh = make(chan struct{})
+	l.doneCh = make(chan struct{})
+	signal.Notify(l.signalCh, l.config.Signals...)
+
+	go func(signalCh chan os.Signal, stopCh, doneCh chan struct{}) {
+		defer close(doneCh)
+		for {
+			select {
+			case <-signalCh:
+				_ = l.Flush(context.Background())
+			case <-stopCh:
+				return
+			}
+		}
+	}(l.signalCh, l.stopCh, l.doneCh)
+}
+
+// Stop stops listening for the shutdown signals.
+func (l *Lifecycle) Stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.signalCh == nil {
+		return
+	}
+	signal.Stop(l.signalCh)
+	close(l.stopCh)
+	<-l.doneCh
+	l.signalCh = nil
+}
+
+// Flush flushes the buffered traces, bounded by the context and the flush timeout.
+//
+// It calls the OnFreeze hook afterward, and reports a failure to the OnError hook
+// besides returning it.
+func (l *Lifecycle) Flush(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, l.config.FlushTimeout)
+	defer cancel()
+
+	err := l.flusher.FlushContext(ctx)
+	if err != nil {
+		l.config.OnError(err)
+	}
+	if l.config.OnFreeze != nil {
+		l.config.OnFreeze()
+	}
+	return err
+}
+
+// OnFreeze flushes the buffered traces before the execution environment is frozen.
+//
+// Call it at the end of every invocation when the handler can't be wrapped with WrapHandler.
+// The flush stops shortly before the deadline of the context, if any.
+func (l *Lifecycle) OnFreeze(ctx context.Context) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline.Add(-defaultDeadlineMargin))
+		defer cancel()
+	}
+	return l.Flush(ctx)
+}
+
+// WrapHandler wraps a handler so that the buffered traces are flushed after each invocation.
+//
+// The signature matches the handlers accepted by the AWS Lambda Go runtime. Flush errors
+// are reported to the OnError hook and don't fail the invocation.
+func WrapHandler[In, Out any](l *Lifecycle, handler func(context.Context, In) (Out, error)) func(context.Context, In) (Out, error) {
+	return func(ctx context.Context, in In) (Out, error) {
+		defer func() {
+			// Keep the invocation deadline, but flush even if the handler canceled the context
+			flushCtx := context.WithoutCancel(ctx)
+			if deadline, ok := ctx.Deadline(); ok {
+				var cancel context.CancelFunc
+				flushCtx, cancel = context.WithDeadline(flushCtx, deadline)
+				defer cancel()
+			}
+			_ = l.OnFreeze(flushCtx)
+		}()
+		return handler(ctx, in)
+	}
+}
@@ -0,0 +1,85 @@
+package serverless
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockFlusher struct {
+	flushes  atomic.Int32
+	err      error
+	deadline time.Time
+}
+
+func (f *mockFlusher) FlushContext(ctx context.Context) error {
+	f.flushes.Add(1)
+	f.deadline, _ = ctx.Deadline()
+	return f.err
+}
+
+func TestWrapHandler(t *testing.T) {
+	flusher := &mockFlusher{}
+	var frozen int
+	lifecycle := New(flusher, Config{OnFreeze: func() { frozen++ }})
+
+	handler := WrapHandler(lifecycle, func(_ context.Context, name string) (string, error) {
+		require.EqualValues(t, 0, flusher.flushes.Load())
+		return "hello " + name, nil
+	})
+
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	out, err := handler(ctx, "lambda")
+	require.NoError(t, err)
+	require.Equal(t, "hello lambda", out)
+	require.EqualValues(t, 1, flusher.flushes.Load())
+	require.Equal(t, 1, frozen)
+	// The flush is bounded by the flush timeout, which is before the invocation deadline
+	require.True(t, flusher.deadline.Before(deadline.Add(-defaultDeadlineMargin)))
+}
+
+func TestLifecycle_OnFreeze_Error(t *testing.T) {
+	flusher := &mockFlusher{err: errors.New("flush failed")}
+	var reported error
+	lifecycle := New(flusher, Config{OnError: func(err error) { reported = err }})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.EqualError(t, lifecycle.OnFreeze(ctx), "flush failed")
+	require.Equal(t, flusher.err, reported)
+	require.True(t, flusher.deadline.Before(time.Now().Add(time.Second-defaultDeadlineMargin)))
+}
+
+func TestLifecycle_Signal(t *testing.T) {
+	flusher := &mockFlusher{}
+	frozen := make(chan struct{}, 1)
+	lifecycle := New(flusher, Config{
+		Signals:  []os.Signal{syscall.SIGUSR1},
+		OnFreeze: func() { frozen <- struct{}{} },
+	})
+	lifecycle.Start()
+	lifecycle.Start()
+	defer lifecycle.Stop()
+
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+	select {
+	case <-frozen:
+	case <-time.After(time.Second):
+		t.Fatal("expected a flush on the shutdown signal")
+	}
+	require.EqualValues(t, 1, flusher.flushes.Load())
+}
+
+func TestBatchConfig(t *testing.T) {
+	config := BatchConfig()
+	require.Equal(t, 10, config.MaxBatchSize)
+	require.Equal(t, 500*time.Millisecond, config.FlushInterval)
+}
@@ -0,0 +1,69 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+// deleteTracesPageSize is the number of traces listed and deleted per request.
+const deleteTracesPageSize = 100
+
+// DeleteSessionResponse reports the deletion of the traces of a session.
+type DeleteSessionResponse struct {
+	SessionID string
+	// TraceIDs are the IDs of the deleted traces.
+	TraceIDs []string
+	// Message is the deletion status returned by the API. The traces are deleted
+	// asynchronously, so they may still be listed for a short time.
+	Message string
+}
+
+// Delete removes the conversation history of a session, e.g. to honor a GDPR erasure
+// request. The API has no endpoint to delete a session itself, which only exists through
+// its traces, so all the traces of the session are deleted, which removes the session.
+//
+// If the session has no traces, Delete returns an empty response without error.
+func (c *Client) Delete(ctx context.Context, sessionID string) (*DeleteSessionResponse, error) {
+	if sessionID == "" {
+		return nil, errors.New("'sessionID' is required")
+	}
+
+	tracesCli := traces.NewClient(c.restyCli)
+	traceIDs, err := c.listTraceIDs(ctx, tracesCli, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	response := &DeleteSessionResponse{SessionID: sessionID}
+	for start := 0; start < len(traceIDs); start += deleteTracesPageSize {
+		chunk := traceIDs[start:min(start+deleteTracesPageSize, len(traceIDs))]
+		deleted, err := tracesCli.DeleteMany(ctx, chunk)
+		if err != nil {
+			return response, fmt.Errorf("delete session failed after %d of %d traces: %w", start, len(traceIDs), err)
+		}
+		response.TraceIDs = append(response.TraceIDs, chunk...)
+		response.Message = deleted.Message
+	}
+	return response, nil
+}
+
+// listTraceIDs returns the IDs of all the traces of the session. All the pages are listed
+// before deleting any trace, so that the deletions don't shift the pages.
+func (c *Client) listTraceIDs(ctx context.Context, tracesCli *traces.Client, sessionID string) ([]string, error) {
+	sessionTraces, err := tracesCli.ListAll(ctx, traces.ListParams{
+		Limit:     deleteTracesPageSize,
+		SessionID: sessionID,
+		Fields:    "core",
+	}, common.WithOrderedResults())
+	if err != nil {
+		return nil, err
+	}
+	traceIDs := make([]string, 0, len(sessionTraces))
+	for _, trace := range sessionTraces {
+		traceIDs = append(traceIDs, trace.ID)
+	}
+	return traceIDs, nil
+}
@@ -0,0 +1,88 @@
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deletes the traces of all pages", func(t *testing.T) {
+		var deleted [][]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method {
+			case http.MethodGet:
+				require.Equal(t, "/traces", r.URL.Path)
+				query := r.URL.Query()
+				require.Equal(t, "session-123", query.Get("sessionId"))
+				require.Equal(t, "core", query.Get("fields"))
+				page, _ := strconv.Atoi(query.Get("page"))
+				data := make([]map[string]string, 0)
+				for i := 0; i < 100 && (page-1)*100+i < 150; i++ {
+					data = append(data, map[string]string{"id": fmt.Sprintf("trace-%d", (page-1)*100+i)})
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"data": data,
+					"meta": map[string]int{"page": page, "limit": 100, "totalItems": 150, "totalPages": 2},
+				})
+			case http.MethodDelete:
+				require.Equal(t, "/traces", r.URL.Path)
+				var body struct {
+					TraceIDs []string `json:"traceIds"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				deleted = append(deleted, body.TraceIDs)
+				_, _ = w.Write([]byte(`{"message": "Traces deleted"}`))
+			}
+		}))
+		defer server.Close()
+
+		response, err := NewClient(resty.New().SetBaseURL(server.URL)).Delete(ctx, "session-123")
+		require.NoError(t, err)
+		require.Equal(t, "session-123", response.SessionID)
+		require.Equal(t, "Traces deleted", response.Message)
+		require.Len(t, response.TraceIDs, 150)
+		require.Len(t, deleted, 2)
+		require.Len(t, deleted[0], 100)
+		require.Equal(t, "trace-149", deleted[1][49])
+	})
+
+	t.Run("session without traces", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data": [], "meta": {"page": 1, "limit": 100, "totalItems": 0, "totalPages": 0}}`))
+		}))
+		defer server.Close()
+
+		response, err := NewClient(resty.New().SetBaseURL(server.URL)).Delete(ctx, "session-123")
+		require.NoError(t, err)
+		require.Empty(t, response.TraceIDs)
+	})
+
+	t.Run("empty session ID", func(t *testing.T) {
+		_, err := NewClient(resty.New()).Delete(ctx, "")
+		require.EqualError(t, err, "'sessionID' is required")
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "unauthorized"}`))
+		}))
+		defer server.Close()
+
+		_, err := NewClient(resty.New().SetBaseURL(server.URL)).Delete(ctx, "session-123")
+		require.EqualError(t, err, `list traces failed: {"message": "unauthorized"}, got status code: 401`)
+	})
+}
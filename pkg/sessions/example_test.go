@@ -0,0 +1,27 @@
+package sessions_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	langfuse "github.com/git-hulk/langfuse-go"
+	"github.com/git-hulk/langfuse-go/pkg/common"
+	"github.com/git-hulk/langfuse-go/pkg/sessions"
+)
+
+func ExampleClient_List() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...")
+	defer client.Close()
+
+	listSessions, err := client.Sessions().List(context.Background(), sessions.NewListParams(
+		sessions.WithTimeRange(common.Today()),
+		sessions.WithLimit(20),
+	))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, session := range listSessions.Data {
+		fmt.Println(session.ID, session.CreatedAt)
+	}
+}
@@ -7,6 +7,7 @@ package sessions
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -30,6 +31,23 @@ type Session struct {
 	CreatedAt   time.Time `json:"createdAt"`
 	ProjectID   string    `json:"projectId"`
 	Environment string    `json:"environment,omitempty"`
+	// Extra holds the response fields which are not known to this SDK version.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements custom JSON unmarshalling for Session.
+// Fields which are not known to the SDK are preserved in Extra.
+func (s *Session) UnmarshalJSON(data []byte) error {
+	type Alias Session
+	if err := json.Unmarshal(data, (*Alias)(s)); err != nil {
+		return err
+	}
+	extra, err := common.UnknownFields(data, Alias{})
+	if err != nil {
+		return err
+	}
+	s.Extra = extra
+	return nil
 }
 
 // SessionWithTraces represents a complete session including all its associated traces.
@@ -41,6 +59,28 @@ type SessionWithTraces struct {
 	Traces []traces.TraceEntry `json:"traces"`
 }
 
+// UnmarshalJSON implements custom JSON unmarshalling for SessionWithTraces.
+// It's required since the promoted Session.UnmarshalJSON would skip the traces.
+func (s *SessionWithTraces) UnmarshalJSON(data []byte) error {
+	var temp struct {
+		Traces []traces.TraceEntry `json:"traces"`
+	}
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	if err := s.Session.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	s.Traces = temp.Traces
+
+	extra, err := common.UnknownFields(data, SessionWithTraces{})
+	if err != nil {
+		return err
+	}
+	s.Extra = extra
+	return nil
+}
+
 // ListParams defines the query parameters for filtering and paginating session listings.
 //
 // Use FromTimestamp and ToTimestamp to filter sessions by creation time.
@@ -52,16 +92,18 @@ type ListParams struct {
 	FromTimestamp time.Time
 	ToTimestamp   time.Time
 	Environment   []string
+
+	pagination common.Pagination
 }
 
 // ToQueryString converts the ListParams to a URL query string.
 func (p *ListParams) ToQueryString() string {
 	parts := make([]string, 0)
 
-	if p.Page != 0 {
+	if p.pagination.HasPage(p.Page) {
 		parts = append(parts, "page="+strconv.Itoa(p.Page))
 	}
-	if p.Limit != 0 {
+	if p.pagination.HasLimit(p.Limit) {
 		parts = append(parts, "limit="+strconv.Itoa(p.Limit))
 	}
 	if !p.FromTimestamp.IsZero() {
@@ -81,6 +123,45 @@ func (p *ListParams) ToQueryString() string {
 	return strings.Join(parts, "&")
 }
 
+// SetPage sets the page number, even if it is 0.
+func (p *ListParams) SetPage(page int) {
+	p.Page = page
+	p.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (p *ListParams) SetLimit(limit int) {
+	p.Limit = limit
+	p.pagination.MarkLimit()
+}
+
+// ListOption configures ListParams, see common.ListOption.
+type ListOption = common.ListOption[ListParams]
+
+// NewListParams creates ListParams for listing sessions from the provided options.
+func NewListParams(options ...ListOption) ListParams {
+	return common.NewListParams(options...)
+}
+
+// WithPage sets the page number, even if it is 0.
+func WithPage(page int) ListOption {
+	return common.WithPage[ListParams](page)
+}
+
+// WithTimeRange sets FromTimestamp and ToTimestamp from the time window, e.g.
+// common.LastHours(24) or common.Today().
+func WithTimeRange(r common.TimeRange) ListOption {
+	return func(p *ListParams) {
+		p.FromTimestamp = r.From
+		p.ToTimestamp = r.To
+	}
+}
+
+// WithLimit sets the page size, even if it is 0.
+func WithLimit(limit int) ListOption {
+	return common.WithLimit[ListParams](limit)
+}
+
 // ListSessions represents the paginated response from the list sessions API.
 //
 // It contains pagination metadata and an array of sessions matching the query criteria.
@@ -122,6 +203,22 @@ func (c *Client) List(ctx context.Context, params ListParams) (*ListSessions, er
 	return &listResponse, nil
 }
 
+// ListAll retrieves the sessions of all pages matching the parameters, fetching the pages in parallel.
+//
+// The Page of the parameters is ignored, and Limit sets the page size. Use the common.FetchOption
+// values to configure the number of workers, or to keep the results in page order.
+func (c *Client) ListAll(ctx context.Context, params ListParams, options ...common.FetchOption) ([]Session, error) {
+	return common.FetchAll(ctx, func(ctx context.Context, page int) ([]Session, common.ListMetadata, error) {
+		pageParams := params
+		pageParams.Page = page
+		listSessions, err := c.List(ctx, pageParams)
+		if err != nil {
+			return nil, common.ListMetadata{}, err
+		}
+		return listSessions.Data, listSessions.Metadata, nil
+	}, options...)
+}
+
 // Get retrieves a specific session by ID with its traces.
 func (c *Client) Get(ctx context.Context, sessionID string) (*SessionWithTraces, error) {
 	if sessionID == "" {
@@ -37,6 +37,11 @@ func TestListParams_ToQueryString(t *testing.T) {
 			params: ListParams{Limit: 25},
 			want:   "limit=25",
 		},
+		{
+			name:   "explicit zero page and limit",
+			params: NewListParams(WithPage(0), WithLimit(0)),
+			want:   "page=0&limit=0",
+		},
 		{
 			name:   "both page and limit",
 			params: ListParams{Page: 3, Limit: 15},
@@ -52,6 +57,14 @@ func TestListParams_ToQueryString(t *testing.T) {
 			},
 			want: "page=1&limit=10&fromTimestamp=2023-01-01T10%3A00%3A00Z&toTimestamp=2023-01-02T10%3A00%3A00Z",
 		},
+		{
+			name: "with time range",
+			params: NewListParams(WithTimeRange(common.TimeRange{
+				From: mustParseTime("2023-01-01T10:00:00Z"),
+				To:   mustParseTime("2023-01-02T10:00:00Z"),
+			})),
+			want: "fromTimestamp=2023-01-01T10%3A00%3A00Z&toTimestamp=2023-01-02T10%3A00%3A00Z",
+		},
 		{
 			name: "with environments",
 			params: ListParams{
@@ -383,3 +396,13 @@ func mustParseTime(s string) time.Time {
 	}
 	return t
 }
+
+func TestSessionWithTraces_UnmarshalJSON_Extra(t *testing.T) {
+	var session SessionWithTraces
+	err := json.Unmarshal([]byte(`{"id":"session-1","projectId":"project-1","traces":[{"id":"trace-1"}],"newField":"value"}`), &session)
+	require.NoError(t, err)
+	require.Equal(t, "session-1", session.ID)
+	require.Len(t, session.Traces, 1)
+	require.Equal(t, "trace-1", session.Traces[0].ID)
+	require.Equal(t, map[string]json.RawMessage{"newField": json.RawMessage(`"value"`)}, session.Extra)
+}
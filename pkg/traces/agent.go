@@ -0,0 +1,167 @@
+package traces
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// ToolCall is the input of a tool observation: the called tool and its arguments.
+//
+// The ID, Result, Duration and Error are only used by Observation.AddToolCall to record
+// a call which has already been made.
+type ToolCall struct {
+	// ID is the ID of the call given by the model, e.g. "call_abc123". Default is the ID
+	// of the tool observation.
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	// Arguments are the arguments of the call, either as a JSON string as returned by
+	// the model, or as a value which is encoded to JSON.
+	Arguments any           `json:"arguments,omitempty"`
+	Result    any           `json:"-"`
+	Duration  time.Duration `json:"-"`
+	Error     error         `json:"-"`
+}
+
+// openAIToolCall is a tool call in the format of the OpenAI function calling, which is
+// rendered by Langfuse.
+type openAIToolCall struct {
+	ID       string             `json:"id"`
+	Type     string             `json:"type"`
+	Function openAIFunctionCall `json:"function"`
+}
+
+type openAIFunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// StartAgent creates a new child observation of type AGENT within this trace.
+//
+// Use StartToolCall on the agent to record the tools it calls.
+//
+// Example:
+//
+//	agent := trace.StartAgent("support-agent")
+//	call := agent.StartToolCall("get_weather", map[string]any{"city": "Berlin"})
+//	weather, err := getWeather(ctx, "Berlin")
+//	call.EndToolCall(weather, err)
+//	agent.End()
+func (t *Trace) StartAgent(name string) *Observation {
+	return t.StartObservation(name, ObservationTypeAgent)
+}
+
+// StartToolCall creates a child observation of type TOOL for a call of the given tool,
+// with the tool name and arguments as its input.
+//
+// The tool call is a child of this observation, regardless of the other observations
+// started in the meantime, so agents may call tools concurrently. End it with EndToolCall
+// to record the result.
+func (o *Observation) StartToolCall(toolName string, arguments any) *Observation {
+	call := o.startChild(toolName, ObservationTypeTool)
+	call.Input = ToolCall{Name: toolName, Arguments: arguments}
+	return call
+}
+
+// EndToolCall records the result of a tool call as the output and ends the observation.
+//
+// If err is not nil, the level is set to ERROR and the error message is recorded as
+// the status message.
+func (o *Observation) EndToolCall(result any, err error) {
+	o.Output = result
+	if err != nil {
+		o.Level = ObservationLevelError
+		o.StatusMessage = err.Error()
+	}
+	o.End()
+}
+
+// AddToolCall records a tool call requested by the model of a generation, which has already
+// been made. The call is appended to the tool_calls of the output, which becomes an assistant
+// message in the format of the OpenAI function calling, with the previous output as content.
+// The result, duration and error of the call are recorded by a child observation of type TOOL,
+// which is returned.
+//
+// Example:
+//
+//	start := time.Now()
+//	weather, err := getWeather(ctx, args)
+//	generation.AddToolCall(traces.ToolCall{
+//		ID:        call.ID,
+//		Name:      "get_weather",
+//		Arguments: call.Function.Arguments, // e.g. `{"city":"Berlin"}`
+//		Result:    weather,
+//		Duration:  time.Since(start),
+//		Error:     err,
+//	})
+func (o *Observation) AddToolCall(tc ToolCall) *Observation {
+	call := o.startChild(tc.Name, ObservationTypeTool)
+	call.StartTime = call.StartTime.Add(-tc.Duration)
+	if tc.ID == "" {
+		tc.ID = call.ID
+	}
+	call.Input = ToolCall{ID: tc.ID, Name: tc.Name, Arguments: tc.Arguments}
+	call.EndToolCall(tc.Result, tc.Error)
+
+	mu := o.metadataLock()
+	mu.Lock()
+	defer mu.Unlock()
+	message, ok := o.Output.(map[string]any)
+	if !ok || message["role"] != "assistant" {
+		message = map[string]any{"role": "assistant", "content": o.Output}
+	}
+	toolCalls, _ := message["tool_calls"].([]any)
+	message["tool_calls"] = append(toolCalls, openAIToolCall{
+		ID:   tc.ID,
+		Type: "function",
+		Function: openAIFunctionCall{
+			Name:      tc.Name,
+			Arguments: toolCallArguments(tc.Arguments),
+		},
+	})
+	o.Output = message
+	return call
+}
+
+// toolCallArguments returns the arguments of a tool call as a JSON string.
+func toolCallArguments(arguments any) string {
+	switch v := arguments.(type) {
+	case nil:
+		return "{}"
+	case string:
+		return v
+	case json.RawMessage:
+		return string(v)
+	case []byte:
+		return string(v)
+	}
+	encoded, err := json.Marshal(arguments)
+	if err != nil {
+		return "{}"
+	}
+	return string(encoded)
+}
+
+// startChild starts an observation whose parent is this observation. Observations
+// which were not started from a trace get a detached child with the same clock.
+func (o *Observation) startChild(name string, typ ObservationType) *Observation {
+	if o.trace != nil {
+		child := o.trace.StartObservation(name, typ)
+		child.ParentObservationID = o.ID
+		return child
+	}
+	child := &Observation{
+		TraceID:             o.TraceID,
+		ID:                  NewIDGenerator().GenerateSpanID().String(),
+		Name:                name,
+		Type:                typ,
+		ParentObservationID: o.ID,
+		clock:               o.clock,
+		metadataMu:          &sync.Mutex{},
+	}
+	child.StartTime = time.Now()
+	if o.clock != nil {
+		child.StartTime = o.clock.Now()
+	}
+	return child
+}
@@ -0,0 +1,91 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservation_StartToolCall(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	defer ingestor.Close()
+	trace := ingestor.StartTrace(context.Background(), "agent-run")
+
+	agent := trace.StartAgent("support-agent")
+	require.Equal(t, ObservationTypeAgent, agent.Type)
+
+	weather := agent.StartToolCall("get_weather", map[string]any{"city": "Berlin"})
+	// Tool calls may overlap, both are children of the agent
+	search := agent.StartToolCall("search", map[string]any{"query": "umbrella"})
+	require.Equal(t, agent.ID, weather.ParentObservationID)
+	require.Equal(t, agent.ID, search.ParentObservationID)
+	require.Equal(t, ObservationTypeTool, weather.Type)
+	require.Equal(t, "get_weather", weather.Name)
+	require.Equal(t, ToolCall{Name: "get_weather", Arguments: map[string]any{"city": "Berlin"}}, weather.Input)
+
+	weather.EndToolCall(map[string]any{"temperature": 21}, nil)
+	require.Equal(t, map[string]any{"temperature": 21}, weather.Output)
+	require.NotNil(t, weather.EndTime)
+	require.Empty(t, weather.Level)
+
+	search.EndToolCall(nil, errors.New("rate limited"))
+	require.Equal(t, ObservationLevelError, search.Level)
+	require.Equal(t, "rate limited", search.StatusMessage)
+	agent.End()
+
+	require.Equal(t, []*Observation{weather, search}, agent.Children())
+}
+
+func TestObservation_StartToolCall_Detached(t *testing.T) {
+	agent := &Observation{ID: "agent-1", TraceID: "trace-1", Type: ObservationTypeAgent}
+	call := agent.StartToolCall("lookup", nil)
+	require.Equal(t, "trace-1", call.TraceID)
+	require.Equal(t, "agent-1", call.ParentObservationID)
+	require.NotEmpty(t, call.ID)
+	require.False(t, call.StartTime.IsZero())
+}
+
+func TestObservation_AddToolCall(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	defer ingestor.Close()
+	trace := ingestor.StartTrace(context.Background(), "agent-run")
+	generation := trace.StartGeneration("chat")
+	generation.Output = "Let me check the weather."
+
+	weather := generation.AddToolCall(ToolCall{
+		ID:        "call_1",
+		Name:      "get_weather",
+		Arguments: `{"city":"Berlin"}`,
+		Result:    map[string]any{"temperature": 21},
+		Duration:  time.Second,
+	})
+	search := generation.AddToolCall(ToolCall{
+		Name:      "search",
+		Arguments: map[string]any{"query": "umbrella"},
+		Error:     errors.New("rate limited"),
+	})
+
+	require.Equal(t, generation.ID, weather.ParentObservationID)
+	require.Equal(t, ObservationTypeTool, weather.Type)
+	require.Equal(t, ToolCall{ID: "call_1", Name: "get_weather", Arguments: `{"city":"Berlin"}`}, weather.Input)
+	require.Equal(t, map[string]any{"temperature": 21}, weather.Output)
+	require.GreaterOrEqual(t, weather.EndTime.Sub(weather.StartTime), time.Second)
+	require.Equal(t, ObservationLevelError, search.Level)
+	require.Equal(t, "rate limited", search.StatusMessage)
+
+	encoded, err := json.Marshal(generation.Output)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"role": "assistant",
+		"content": "Let me check the weather.",
+		"tool_calls": [
+			{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Berlin\"}"}},
+			{"id": "`+search.ID+`", "type": "function", "function": {"name": "search", "arguments": "{\"query\":\"umbrella\"}"}}
+		]
+	}`, string(encoded))
+}
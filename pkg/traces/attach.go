@@ -0,0 +1,129 @@
+package traces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MetadataAttachmentsKey is the key of the media references attached to a map input, output
+// or metadata, see Observation.AttachBytes.
+const MetadataAttachmentsKey = "attachments"
+
+// MediaAttachment is the content of a media attached to an observation.
+type MediaAttachment struct {
+	TraceID       string
+	ObservationID string
+	// Field is one of "input", "output" or "metadata".
+	Field       string
+	ContentType string
+	Data        []byte
+}
+
+// MediaUploader uploads the media attached to observations and returns the media reference
+// to put into the field, see WithMediaUploader.
+type MediaUploader interface {
+	UploadMedia(ctx context.Context, attachment MediaAttachment) (string, error)
+}
+
+// MediaUploaderFunc is an adapter to use a function as a MediaUploader.
+type MediaUploaderFunc func(ctx context.Context, attachment MediaAttachment) (string, error)
+
+// UploadMedia implements MediaUploader.
+func (f MediaUploaderFunc) UploadMedia(ctx context.Context, attachment MediaAttachment) (string, error) {
+	return f(ctx, attachment)
+}
+
+// WithMediaUploader sets the uploader of the media attached with Observation.AttachBytes
+// and Observation.AttachFile, e.g. media.NewMediaUploader.
+func WithMediaUploader(uploader MediaUploader) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.mediaUploader = uploader
+	}
+}
+
+// AttachBytes uploads the data as media of the observation and inserts the media reference
+// into the field, which is one of "input", "output" or "metadata", and returns the reference.
+//
+// If the field is empty, the reference becomes its value. Otherwise it's appended to a string
+// after a blank line, to a []any, or to the MetadataAttachmentsKey list of a map[string]any.
+// Set the field before attaching media to it, since setting it afterward drops the reference.
+//
+// Example:
+//
+//	generation.Input = map[string]any{"prompt": "Describe the image"}
+//	_, err := generation.AttachBytes(ctx, png, "image/png", "input")
+func (o *Observation) AttachBytes(ctx context.Context, data []byte, contentType, field string) (string, error) {
+	if field != "input" && field != "output" && field != "metadata" {
+		return "", fmt.Errorf("'field' must be one of: input, output, metadata")
+	}
+	if o.trace == nil || o.trace.ingestor == nil || o.trace.ingestor.mediaUploader == nil {
+		return "", errors.New("no media uploader is configured, see WithMediaUploader")
+	}
+	reference, err := o.trace.ingestor.mediaUploader.UploadMedia(ctx, MediaAttachment{
+		TraceID:       o.TraceID,
+		ObservationID: o.ID,
+		Field:         field,
+		ContentType:   contentType,
+		Data:          data,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	mu := o.metadataLock()
+	mu.Lock()
+	defer mu.Unlock()
+	target := &o.Metadata
+	switch field {
+	case "input":
+		target = &o.Input
+	case "output":
+		target = &o.Output
+	}
+	value, err := attachReference(*target, reference)
+	if err != nil {
+		return "", fmt.Errorf("attach media to %s: %w", field, err)
+	}
+	*target = value
+	return reference, nil
+}
+
+// AttachFile uploads the file as media of the observation like AttachBytes. The content
+// type is detected from the file extension.
+func (o *Observation) AttachFile(ctx context.Context, path, field string) (string, error) {
+	contentType, _, _ := strings.Cut(mime.TypeByExtension(filepath.Ext(path)), ";")
+	if contentType == "" {
+		return "", fmt.Errorf("could not determine content type for file extension %s", filepath.Ext(path))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	return o.AttachBytes(ctx, data, strings.TrimSpace(contentType), field)
+}
+
+// attachReference inserts the media reference into the value of a field.
+func attachReference(value any, reference string) (any, error) {
+	switch v := value.(type) {
+	case nil:
+		return reference, nil
+	case string:
+		if v == "" {
+			return reference, nil
+		}
+		return v + "\n\n" + reference, nil
+	case []any:
+		return append(v, reference), nil
+	case map[string]any:
+		attachments, _ := v[MetadataAttachmentsKey].([]any)
+		v[MetadataAttachmentsKey] = append(attachments, reference)
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
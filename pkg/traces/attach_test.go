@@ -0,0 +1,94 @@
+package traces
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservation_AttachBytes(t *testing.T) {
+	var attachments []MediaAttachment
+	uploader := MediaUploaderFunc(func(_ context.Context, attachment MediaAttachment) (string, error) {
+		attachments = append(attachments, attachment)
+		return "@@@media-" + attachment.Field + "@@@", nil
+	})
+	ingestor := NewIngestor(resty.New(), WithMediaUploader(uploader))
+	trace := ingestor.StartTrace(context.Background(), "attach")
+	generation := trace.StartGeneration("describe")
+	generation.Input = map[string]any{"prompt": "Describe the image"}
+	generation.Output = "A cat"
+
+	reference, err := generation.AttachBytes(context.Background(), []byte("png"), "image/png", "input")
+	require.NoError(t, err)
+	require.Equal(t, "@@@media-input@@@", reference)
+	require.Equal(t, []MediaAttachment{{
+		TraceID:       trace.ID,
+		ObservationID: generation.ID,
+		Field:         "input",
+		ContentType:   "image/png",
+		Data:          []byte("png"),
+	}}, attachments)
+	require.Equal(t, map[string]any{
+		"prompt":               "Describe the image",
+		MetadataAttachmentsKey: []any{"@@@media-input@@@"},
+	}, generation.Input)
+
+	_, err = generation.AttachBytes(context.Background(), []byte("png"), "image/png", "output")
+	require.NoError(t, err)
+	require.Equal(t, "A cat\n\n@@@media-output@@@", generation.Output)
+
+	_, err = generation.AttachBytes(context.Background(), []byte("png"), "image/png", "metadata")
+	require.NoError(t, err)
+	require.Equal(t, "@@@media-metadata@@@", generation.Metadata)
+
+	_, err = generation.AttachBytes(context.Background(), nil, "image/png", "body")
+	require.EqualError(t, err, "'field' must be one of: input, output, metadata")
+
+	generation.Metadata = 42
+	_, err = generation.AttachBytes(context.Background(), []byte("png"), "image/png", "metadata")
+	require.EqualError(t, err, "attach media to metadata: unsupported value type int")
+	require.Equal(t, 42, generation.Metadata)
+}
+
+func TestObservation_AttachBytes_Errors(t *testing.T) {
+	trace := NewIngestor(resty.New()).StartTrace(context.Background(), "attach")
+	span := trace.StartSpan("span")
+	_, err := span.AttachBytes(context.Background(), []byte("png"), "image/png", "input")
+	require.EqualError(t, err, "no media uploader is configured, see WithMediaUploader")
+
+	uploader := MediaUploaderFunc(func(context.Context, MediaAttachment) (string, error) {
+		return "", errors.New("upload failed")
+	})
+	trace = NewIngestor(resty.New(), WithMediaUploader(uploader)).StartTrace(context.Background(), "attach")
+	span = trace.StartSpan("span")
+	span.Input = "question"
+	_, err = span.AttachBytes(context.Background(), []byte("png"), "image/png", "input")
+	require.EqualError(t, err, "upload failed")
+	require.Equal(t, "question", span.Input)
+}
+
+func TestObservation_AttachFile(t *testing.T) {
+	var attachment MediaAttachment
+	uploader := MediaUploaderFunc(func(_ context.Context, a MediaAttachment) (string, error) {
+		attachment = a
+		return "@@@media@@@", nil
+	})
+	trace := NewIngestor(resty.New(), WithMediaUploader(uploader)).StartTrace(context.Background(), "attach")
+	span := trace.StartSpan("span")
+
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	require.NoError(t, os.WriteFile(path, []byte("%PDF"), 0o600))
+	_, err := span.AttachFile(context.Background(), path, "output")
+	require.NoError(t, err)
+	require.Equal(t, "application/pdf", attachment.ContentType)
+	require.Equal(t, []byte("%PDF"), attachment.Data)
+	require.Equal(t, "@@@media@@@", span.Output)
+
+	_, err = span.AttachFile(context.Background(), filepath.Join(t.TempDir(), "data.unknownext"), "output")
+	require.EqualError(t, err, "could not determine content type for file extension .unknownext")
+}
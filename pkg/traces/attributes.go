@@ -0,0 +1,182 @@
+package traces
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Well-known attribute keys of the OpenTelemetry semantic conventions for generative AI,
+// and the Langfuse specific attributes understood by SetAttributes.
+const (
+	AttributeOperationName         = "gen_ai.operation.name"
+	AttributeRequestModel          = "gen_ai.request.model"
+	AttributeResponseModel         = "gen_ai.response.model"
+	AttributeUsageInputTokens      = "gen_ai.usage.input_tokens"
+	AttributeUsageOutputTokens     = "gen_ai.usage.output_tokens"
+	AttributeUsagePromptTokens     = "gen_ai.usage.prompt_tokens"
+	AttributeUsageCompletionTokens = "gen_ai.usage.completion_tokens"
+	AttributeUsageTotalTokens      = "gen_ai.usage.total_tokens"
+	AttributeInputMessages         = "gen_ai.input.messages"
+	AttributeOutputMessages        = "gen_ai.output.messages"
+	AttributePrompt                = "gen_ai.prompt"
+	AttributeCompletion            = "gen_ai.completion"
+	AttributeErrorType             = "error.type"
+	AttributeDeploymentEnvironment = "deployment.environment"
+	AttributeHTTPStatusCode        = "http.response.status_code"
+
+	AttributeLangfuseLevel         = "langfuse.observation.level"
+	AttributeLangfuseStatusMessage = "langfuse.observation.status_message"
+	AttributeLangfusePromptName    = "langfuse.observation.prompt.name"
+	AttributeLangfusePromptVersion = "langfuse.observation.prompt.version"
+	AttributeLangfuseEnvironment   = "langfuse.environment"
+
+	requestAttributePrefix = "gen_ai.request."
+)
+
+// operationTypes maps the gen_ai.operation.name values to observation types.
+var operationTypes = map[string]ObservationType{
+	"chat":             ObservationTypeGeneration,
+	"text_completion":  ObservationTypeGeneration,
+	"generate_content": ObservationTypeGeneration,
+	"embeddings":       ObservationTypeEmbedding,
+	"execute_tool":     ObservationTypeTool,
+	"create_agent":     ObservationTypeAgent,
+	"invoke_agent":     ObservationTypeAgent,
+}
+
+// SetAttributes sets the fields of the observation from attributes named after the
+// OpenTelemetry semantic conventions for generative AI.
+//
+// The model, usage, input and output attributes are mapped to the corresponding fields,
+// gen_ai.request.* attributes (e.g. gen_ai.request.temperature) become model parameters,
+// and gen_ai.operation.name determines the observation type. If both are present, the
+// response model takes precedence over the request model. Attributes which have no
+// matching field are merged into the metadata, see MergeMetadata.
+//
+// Example:
+//
+//	err := generation.SetAttributes(map[string]any{
+//		"gen_ai.request.model":       "gpt-4o",
+//		"gen_ai.request.temperature": 0.2,
+//		"gen_ai.usage.input_tokens":  120,
+//		"gen_ai.usage.output_tokens": 48,
+//	})
+func (o *Observation) SetAttributes(attributes map[string]any) error {
+	metadata := make(map[string]any)
+	var errs []error
+	for key, value := range attributes {
+		if err := o.setAttribute(key, value, metadata); err != nil {
+			errs = append(errs, fmt.Errorf("attribute %q: %w", key, err))
+		}
+	}
+	if model, ok := attributes[AttributeResponseModel].(string); ok && model != "" {
+		o.Model = model
+	}
+	if len(metadata) > 0 {
+		if err := o.MergeMetadata(metadata); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (o *Observation) setAttribute(key string, value any, metadata map[string]any) error {
+	switch key {
+	case AttributeOperationName:
+		operation, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a string, got %T", value)
+		}
+		if typ, ok := operationTypes[operation]; ok {
+			o.Type = typ
+		}
+		metadata[key] = operation
+	case AttributeRequestModel, AttributeResponseModel:
+		model, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a string, got %T", value)
+		}
+		if o.Model == "" || key == AttributeResponseModel {
+			o.Model = model
+		}
+	case AttributeUsageInputTokens, AttributeUsagePromptTokens:
+		return setTokens(&o.Usage.Input, &o.Usage.Unit, value)
+	case AttributeUsageOutputTokens, AttributeUsageCompletionTokens:
+		return setTokens(&o.Usage.Output, &o.Usage.Unit, value)
+	case AttributeUsageTotalTokens:
+		return setTokens(&o.Usage.Total, &o.Usage.Unit, value)
+	case AttributeInputMessages, AttributePrompt:
+		o.Input = value
+	case AttributeOutputMessages, AttributeCompletion:
+		o.Output = value
+	case AttributeErrorType:
+		o.Level = ObservationLevelError
+		if o.StatusMessage == "" {
+			o.StatusMessage = fmt.Sprint(value)
+		}
+	case AttributeDeploymentEnvironment, AttributeLangfuseEnvironment:
+		o.Environment = fmt.Sprint(value)
+	case AttributeLangfuseLevel:
+		o.Level = ObservationLevel(strings.ToUpper(fmt.Sprint(value)))
+	case AttributeLangfuseStatusMessage:
+		o.StatusMessage = fmt.Sprint(value)
+	case AttributeLangfusePromptName:
+		o.PromptName = fmt.Sprint(value)
+	case AttributeLangfusePromptVersion:
+		version, ok := toInt(value)
+		if !ok {
+			return fmt.Errorf("must be an integer, got %T", value)
+		}
+		o.PromptVersion = version
+	default:
+		if name, ok := strings.CutPrefix(key, requestAttributePrefix); ok && name != "" {
+			if o.ModelParameters == nil {
+				o.ModelParameters = make(map[string]any)
+			}
+			o.ModelParameters[name] = value
+			return nil
+		}
+		metadata[key] = value
+	}
+	return nil
+}
+
+func setTokens(tokens *int, unit *UnitType, value any) error {
+	count, ok := toInt(value)
+	if !ok {
+		return fmt.Errorf("must be an integer, got %T", value)
+	}
+	*tokens = count
+	if *unit == "" {
+		*unit = UnitTokens
+	}
+	return nil
+}
+
+// toInt converts the numeric types which are commonly used for attribute values to int.
+func toInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case uint32:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	case float64:
+		if v != float64(int(v)) {
+			return 0, false
+		}
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err == nil
+	default:
+		return 0, false
+	}
+}
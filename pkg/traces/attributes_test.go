@@ -0,0 +1,56 @@
+package traces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservation_SetAttributes(t *testing.T) {
+	observation := &Observation{Type: ObservationTypeSpan, Metadata: map[string]any{"existing": true}}
+	err := observation.SetAttributes(map[string]any{
+		"gen_ai.operation.name":               "chat",
+		"gen_ai.system":                       "openai",
+		"gen_ai.request.model":                "gpt-4o",
+		"gen_ai.response.model":               "gpt-4o-2024-08-06",
+		"gen_ai.request.temperature":          0.2,
+		"gen_ai.request.max_tokens":           int64(256),
+		"gen_ai.usage.input_tokens":           int64(120),
+		"gen_ai.usage.output_tokens":          float64(48),
+		"gen_ai.input.messages":               []map[string]string{{"role": "user", "content": "hi"}},
+		"gen_ai.output.messages":              "hello",
+		"langfuse.observation.prompt.name":    "chat-prompt",
+		"langfuse.observation.prompt.version": 3,
+		"deployment.environment":              "staging",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, ObservationTypeGeneration, observation.Type)
+	require.Equal(t, "gpt-4o-2024-08-06", observation.Model)
+	require.Equal(t, map[string]any{"temperature": 0.2, "max_tokens": int64(256)}, observation.ModelParameters)
+	require.Equal(t, Usage{Input: 120, Output: 48, Unit: UnitTokens}, observation.Usage)
+	require.Equal(t, []map[string]string{{"role": "user", "content": "hi"}}, observation.Input)
+	require.Equal(t, "hello", observation.Output)
+	require.Equal(t, "chat-prompt", observation.PromptName)
+	require.Equal(t, 3, observation.PromptVersion)
+	require.Equal(t, "staging", observation.Environment)
+	require.Equal(t, map[string]any{
+		"existing":              true,
+		"gen_ai.operation.name": "chat",
+		"gen_ai.system":         "openai",
+	}, observation.Metadata)
+}
+
+func TestObservation_SetAttributes_Errors(t *testing.T) {
+	observation := &Observation{}
+	err := observation.SetAttributes(map[string]any{
+		"gen_ai.usage.input_tokens": "many",
+		"error.type":                "timeout",
+		"langfuse.internal":         1,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `attribute "gen_ai.usage.input_tokens": must be an integer, got string`)
+	require.Contains(t, err.Error(), `metadata key "langfuse.internal" is reserved`)
+	require.Equal(t, ObservationLevelError, observation.Level)
+	require.Equal(t, "timeout", observation.StatusMessage)
+}
@@ -0,0 +1,164 @@
+package traces
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// backfillBatchEvents is the number of events IngestFromReader reads before sending them.
+const backfillBatchEvents = 500
+
+// BackfillRecord is a line of the newline-delimited JSON read by IngestFromReader.
+//
+// Exactly one of Trace and Observation must be set, using the JSON field names of
+// TraceEntry and Observation:
+//
+//	{"trace": {"id": "trace-1", "name": "chat", "timestamp": "2025-01-01T10:00:00Z", "userId": "user-1"}}
+//	{"observation": {"id": "span-1", "traceId": "trace-1", "type": "GENERATION", "name": "llm",
+//		"startTime": "2025-01-01T10:00:01Z", "endTime": "2025-01-01T10:00:03Z", "model": "gpt-4o"}}
+//
+// Traces need an id and a timestamp, observations an id, a traceId and a startTime. The
+// type of an observation defaults to SPAN. Observations may precede their trace and don't
+// need to be in the same batch.
+type BackfillRecord struct {
+	Trace       *TraceEntry  `json:"trace,omitempty"`
+	Observation *Observation `json:"observation,omitempty"`
+}
+
+// BackfillResult summarizes the outcome of IngestFromReader.
+type BackfillResult struct {
+	// Lines is the number of lines which have been sent. If the backfill fails, it can be
+	// resumed by skipping these lines.
+	Lines        int
+	Traces       int
+	Observations int
+}
+
+// IngestFromReader reads traces and observations from newline-delimited JSON, see
+// BackfillRecord, and sends them in batches, e.g. to backfill historical data from logs.
+//
+// The events are sent directly instead of being queued like ended traces, and the
+// ingestion stops at the first invalid line or failed request. Empty lines are skipped.
+//
+// Example:
+//
+//	file, err := os.Open("traces.ndjson")
+//	if err != nil {
+//		return err
+//	}
+//	defer file.Close()
+//	result, err := ingestor.IngestFromReader(ctx, file)
+//	if err != nil {
+//		log.Printf("backfill stopped after %d lines: %v", result.Lines, err)
+//	}
+func (ingestor *Ingestor) IngestFromReader(ctx context.Context, r io.Reader) (*BackfillResult, error) {
+	result := &BackfillResult{}
+	reader := bufio.NewReader(r)
+	events := make([]IngestionEvent, 0, backfillBatchEvents)
+	var pending BackfillResult
+	line := 0
+	flush := func() error {
+		if len(events) == 0 {
+			return nil
+		}
+		for _, chunk := range splitEvents(events, ingestor.maxBatchBytes) {
+			if err := ingestor.sender()(ctx, chunk); err != nil {
+				return fmt.Errorf("send lines %d-%d: %w", result.Lines+1, line, err)
+			}
+		}
+		result.Lines = line
+		result.Traces += pending.Traces
+		result.Observations += pending.Observations
+		pending = BackfillResult{}
+		events = events[:0]
+		return nil
+	}
+
+	for {
+		data, readErr := reader.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return result, readErr
+		}
+		if len(data) > 0 {
+			line++
+		}
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			event, err := backfillEvent(data)
+			if err != nil {
+				return result, fmt.Errorf("line %d: %w", line, err)
+			}
+			if event.Type == IngestionCreateTrace {
+				pending.Traces++
+			} else {
+				pending.Observations++
+			}
+			events = append(events, *event)
+			if len(events) >= backfillBatchEvents {
+				if err := flush(); err != nil {
+					return result, err
+				}
+			}
+		}
+		if readErr != nil {
+			break
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+	result.Lines = line
+	return result, nil
+}
+
+// backfillEvent parses a line of IngestFromReader into an ingestion event.
+func backfillEvent(data []byte) (*IngestionEvent, error) {
+	var record BackfillRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	if (record.Trace == nil) == (record.Observation == nil) {
+		return nil, errors.New("exactly one of 'trace' and 'observation' is required")
+	}
+
+	event := &IngestionEvent{ID: uuid.Must(uuid.NewV4()).String()}
+	if trace := record.Trace; trace != nil {
+		if trace.ID == "" {
+			return nil, errors.New("'trace.id' is required")
+		}
+		if trace.Timestamp.IsZero() {
+			return nil, errors.New("'trace.timestamp' is required")
+		}
+		event.Timestamp = trace.Timestamp
+		event.Type = IngestionCreateTrace
+		event.Body = trace
+		return event, nil
+	}
+
+	observation := record.Observation
+	if observation.ID == "" {
+		return nil, errors.New("'observation.id' is required")
+	}
+	if observation.TraceID == "" {
+		return nil, errors.New("'observation.traceId' is required")
+	}
+	if observation.StartTime.IsZero() {
+		return nil, errors.New("'observation.startTime' is required")
+	}
+	if observation.Type == "" {
+		observation.Type = ObservationTypeSpan
+	}
+	event.Type = toIngestionType(observation.Type)
+	if event.Type == "" {
+		return nil, fmt.Errorf("invalid 'observation.type': %s", observation.Type)
+	}
+	event.Timestamp = observation.StartTime
+	event.Body = observation
+	return event, nil
+}
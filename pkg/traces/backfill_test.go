@@ -0,0 +1,67 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestor_IngestFromReader(t *testing.T) {
+	var types []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Batch []struct {
+				Type string         `json:"type"`
+				Body map[string]any `json:"body"`
+			} `json:"batch"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		for _, event := range request.Batch {
+			types = append(types, event.Type)
+		}
+		require.Equal(t, "2025-01-01T10:00:00Z", request.Batch[0].Body["timestamp"])
+		require.Equal(t, "trace-1", request.Batch[1].Body["traceId"])
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"successes": [], "errors": []}`))
+	}))
+	defer server.Close()
+
+	input := `{"trace": {"id": "trace-1", "name": "chat", "timestamp": "2025-01-01T10:00:00Z"}}
+
+{"observation": {"id": "span-1", "traceId": "trace-1", "startTime": "2025-01-01T10:00:01Z"}}
+{"observation": {"id": "gen-1", "traceId": "trace-1", "type": "GENERATION", "startTime": "2025-01-01T10:00:02Z", "model": "gpt-4o"}}`
+
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL))
+	defer ingestor.Close()
+	result, err := ingestor.IngestFromReader(context.Background(), strings.NewReader(input))
+	require.NoError(t, err)
+	require.Equal(t, &BackfillResult{Lines: 4, Traces: 1, Observations: 2}, result)
+	require.Equal(t, []string{IngestionCreateTrace, IngestionCreateSpan, IngestionCreateGeneration}, types)
+}
+
+func TestIngestor_IngestFromReader_InvalidLine(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	defer ingestor.Close()
+
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{`{"trace": {"id": "trace-1"}}`, "line 1: 'trace.timestamp' is required"},
+		{`{}`, "line 1: exactly one of 'trace' and 'observation' is required"},
+		{`{"observation": {"id": "span-1", "startTime": "2025-01-01T10:00:01Z"}}`, "line 1: 'observation.traceId' is required"},
+		{`{"observation": {"id": "span-1", "traceId": "trace-1", "type": "UNKNOWN", "startTime": "2025-01-01T10:00:01Z"}}`,
+			"line 1: invalid 'observation.type': UNKNOWN"},
+	}
+	for _, tt := range tests {
+		result, err := ingestor.IngestFromReader(context.Background(), strings.NewReader(tt.input))
+		require.EqualError(t, err, tt.wantErr)
+		require.Zero(t, result.Lines)
+	}
+}
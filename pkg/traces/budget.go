@@ -0,0 +1,114 @@
+package traces
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/git-hulk/langfuse-go/pkg/logger"
+)
+
+// MetadataKeySummarizedObservations is the trace metadata key of the number of observations
+// whose input and output were summarized to keep the trace within its size budget.
+const MetadataKeySummarizedObservations = ReservedMetadataPrefix + "summarized_observations"
+
+// summaryPreviewBytes is the size of the preview kept in the summary of a value.
+const summaryPreviewBytes = 256
+
+// WithSizeBudget limits the accumulated size of the input, output and metadata of a trace
+// and its observations, in bytes of JSON, e.g. to keep a runaway agent loop from producing
+// an enormous trace.
+//
+// When a trace exceeds the budget, the input and output of its observations are replaced
+// by a summary with a preview, the size and a SHA-256 hash of the value, starting from the
+// second observation, until the trace fits in the budget. The first and last observations
+// are kept intact, even if the trace still exceeds the budget, and the number of summarized observations is added to the trace metadata
+// under MetadataKeySummarizedObservations.
+//
+// Example:
+//
+//	ingestor := traces.NewIngestor(restyCli, traces.WithSizeBudget(4*1024*1024))
+func WithSizeBudget(maxBytes int) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.sizeBudget = maxBytes
+	}
+}
+
+// applySizeBudget summarizes the observations of the traces exceeding the size budget.
+func (ingestor *Ingestor) applySizeBudget(traces []*Trace) {
+	if ingestor.sizeBudget <= 0 {
+		return
+	}
+	for _, trace := range traces {
+		if summarized := ingestor.summarizeTrace(trace); summarized > 0 {
+			logger.Get().With(
+				zap.String("trace_id", trace.ID),
+				zap.Int("observations", summarized),
+				zap.Int("budget_bytes", ingestor.sizeBudget),
+			).Warn("Summarized observations of a trace exceeding its size budget")
+		}
+	}
+}
+
+// summarizeTrace summarizes the middle observations of the trace until it fits in the
+// budget, and returns the number of summarized observations.
+func (ingestor *Ingestor) summarizeTrace(trace *Trace) int {
+	size := encodedSize(trace.Input) + encodedSize(trace.Output) + encodedSize(trace.Metadata)
+	sizes := make([]int, len(trace.observations))
+	for i, observation := range trace.observations {
+		mu := observation.metadataLock()
+		mu.Lock()
+		sizes[i] = encodedSize(observation.Input) + encodedSize(observation.Output)
+		size += sizes[i] + encodedSize(observation.Metadata)
+		mu.Unlock()
+	}
+	if size <= ingestor.sizeBudget {
+		return 0
+	}
+
+	summarized := 0
+	for i := 1; i < len(trace.observations)-1 && size > ingestor.sizeBudget; i++ {
+		observation := trace.observations[i]
+		mu := observation.metadataLock()
+		mu.Lock()
+		observation.Input = summarizeValue(observation.Input)
+		observation.Output = summarizeValue(observation.Output)
+		newSize := encodedSize(observation.Input) + encodedSize(observation.Output)
+		mu.Unlock()
+		size -= sizes[i] - newSize
+		summarized++
+	}
+	if summarized > 0 {
+		trace.Metadata = withMetadataValue(trace.Metadata, MetadataKeySummarizedObservations, summarized)
+	}
+	return summarized
+}
+
+// summarizeValue replaces the value by a preview of its JSON, followed by its size and
+// the prefix of its SHA-256 hash. Small values are kept.
+func summarizeValue(value any) any {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil || len(data) <= summaryPreviewBytes {
+		return value
+	}
+	hash := sha256.Sum256(data)
+	return fmt.Sprintf("%s sha256:%s", Preview(string(data), summaryPreviewBytes), hex.EncodeToString(hash[:8]))
+}
+
+// encodedSize returns the size of the value encoded as JSON.
+func encodedSize(value any) int {
+	if value == nil {
+		return 0
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
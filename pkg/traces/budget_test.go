@@ -0,0 +1,47 @@
+package traces
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestor_applySizeBudget(t *testing.T) {
+	ingestor := NewIngestor(resty.New(), WithSizeBudget(5000))
+	trace := ingestor.StartTrace(context.Background(), "agent-loop")
+	trace.Metadata = map[string]any{"tenant": "acme"}
+	large := strings.Repeat("x", 1000)
+	steps := make([]*Observation, 6)
+	for i := range steps {
+		steps[i] = trace.StartSpan("step")
+		steps[i].Input = large
+		steps[i].Output = map[string]any{"result": large}
+	}
+	// The first and last steps are kept, even if the trace still exceeds the budget
+	ingestor.applySizeBudget([]*Trace{trace})
+
+	require.Equal(t, large, steps[0].Input)
+	require.Equal(t, large, steps[5].Input)
+	require.Equal(t, map[string]any{"result": large}, steps[5].Output)
+	for _, step := range steps[1:5] {
+		summary, ok := step.Input.(string)
+		require.True(t, ok)
+		require.True(t, strings.HasPrefix(summary, `"xxx`))
+		require.Contains(t, summary, "… [256 of 1002 bytes] sha256:")
+		require.Contains(t, step.Output, "of 1013 bytes] sha256:")
+	}
+	require.Equal(t, map[string]any{"tenant": "acme", MetadataKeySummarizedObservations: 4}, trace.Metadata)
+}
+
+func TestIngestor_applySizeBudget_WithinBudget(t *testing.T) {
+	ingestor := NewIngestor(resty.New(), WithSizeBudget(5000))
+	trace := ingestor.StartTrace(context.Background(), "small")
+	span := trace.StartSpan("step")
+	span.Input = strings.Repeat("x", 1000)
+	ingestor.applySizeBudget([]*Trace{trace})
+	require.Equal(t, strings.Repeat("x", 1000), span.Input)
+	require.Nil(t, trace.Metadata)
+}
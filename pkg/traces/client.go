@@ -0,0 +1,125 @@
+package traces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// TraceDetails is a trace stored in Langfuse, as returned by Client.Get.
+type TraceDetails struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+	Input       any       `json:"input,omitempty"`
+	Output      any       `json:"output,omitempty"`
+	SessionID   string    `json:"sessionId,omitempty"`
+	Release     string    `json:"release,omitempty"`
+	Version     string    `json:"version,omitempty"`
+	UserID      string    `json:"userId,omitempty"`
+	Metadata    any       `json:"metadata,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	Environment string    `json:"environment,omitempty"`
+	HTMLPath    string    `json:"htmlPath,omitempty"`
+	Latency     float64   `json:"latency,omitempty"` // in seconds
+	TotalCost   float64   `json:"totalCost,omitempty"`
+}
+
+// DeleteTraceResponse represents the response from deleting one or more traces.
+type DeleteTraceResponse struct {
+	Message string `json:"message"`
+}
+
+// deleteTracesRequest represents the request body for deleting multiple traces.
+type deleteTracesRequest struct {
+	TraceIDs []string `json:"traceIds"`
+}
+
+// Client provides methods for interacting with the Langfuse traces API.
+//
+// The client handles HTTP communication for trace management operations
+// such as retrieving and deleting traces. Use the Ingestor to create and submit new traces.
+type Client struct {
+	restyCli *resty.Client
+}
+
+// NewClient creates a new traces client with the provided HTTP client.
+//
+// The resty client should be pre-configured with authentication and base URL.
+func NewClient(cli *resty.Client) *Client {
+	return &Client{restyCli: cli}
+}
+
+// Get retrieves a stored trace by ID.
+func (c *Client) Get(ctx context.Context, traceID string) (*TraceDetails, error) {
+	if traceID == "" {
+		return nil, errors.New("'traceID' is required")
+	}
+
+	var trace TraceDetails
+	rsp, err := c.restyCli.R().
+		SetContext(ctx).
+		SetResult(&trace).
+		SetPathParam("traceID", traceID).
+		Get("/traces/{traceID}")
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.IsError() {
+		return nil, fmt.Errorf("get trace failed: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	}
+	return &trace, nil
+}
+
+// Delete deletes a specific trace by ID.
+func (c *Client) Delete(ctx context.Context, traceID string) (*DeleteTraceResponse, error) {
+	if traceID == "" {
+		return nil, errors.New("'traceID' is required")
+	}
+
+	var deleteResponse DeleteTraceResponse
+	rsp, err := c.restyCli.R().
+		SetContext(ctx).
+		SetResult(&deleteResponse).
+		SetPathParam("traceID", traceID).
+		Delete("/traces/{traceID}")
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.IsError() {
+		return nil, fmt.Errorf("delete trace failed: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	}
+	return &deleteResponse, nil
+}
+
+// DeleteMany deletes multiple traces by their IDs in a single request.
+func (c *Client) DeleteMany(ctx context.Context, traceIDs []string) (*DeleteTraceResponse, error) {
+	if len(traceIDs) == 0 {
+		return nil, errors.New("'traceIDs' is required and cannot be empty")
+	}
+	for i, traceID := range traceIDs {
+		if traceID == "" {
+			return nil, fmt.Errorf("traceIDs[%d] cannot be empty", i)
+		}
+	}
+
+	var deleteResponse DeleteTraceResponse
+	rsp, err := c.restyCli.R().
+		SetContext(ctx).
+		SetBody(&deleteTracesRequest{TraceIDs: traceIDs}).
+		SetResult(&deleteResponse).
+		Delete("/traces")
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.IsError() {
+		return nil, fmt.Errorf("delete traces failed: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	}
+	return &deleteResponse, nil
+}
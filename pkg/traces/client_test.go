@@ -0,0 +1,116 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceClient_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/traces/trace-1", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "trace-1", "name": "chat", "timestamp": "2025-01-01T10:00:00Z",
+			"input": {"question": "refund?"}, "tags": ["prod"], "latency": 1.5, "observations": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	trace, err := client.Get(context.Background(), "trace-1")
+	require.NoError(t, err)
+	require.Equal(t, "chat", trace.Name)
+	require.Equal(t, map[string]any{"question": "refund?"}, trace.Input)
+	require.Equal(t, 1.5, trace.Latency)
+
+	_, err = client.Get(context.Background(), "")
+	require.EqualError(t, err, "'traceID' is required")
+}
+
+func TestTraceClient_Delete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/traces/test-trace-id", r.URL.Path)
+			require.Equal(t, "DELETE", r.Method)
+			w.Header().Set("Content-Type", "application/json")
+			_, err := w.Write([]byte(`{"message":"Trace deleted successfully"}`))
+			require.NoError(t, err)
+		}))
+	defer server.Close()
+
+	cli := resty.New().SetBaseURL(server.URL)
+	client := NewClient(cli)
+
+	deleteResponse, err := client.Delete(context.Background(), "test-trace-id")
+	require.NoError(t, err)
+	require.Equal(t, "Trace deleted successfully", deleteResponse.Message)
+}
+
+func TestTraceClient_Delete_MissingTraceID(t *testing.T) {
+	cli := resty.New()
+	client := NewClient(cli)
+	_, err := client.Delete(context.Background(), "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'traceID' is required")
+}
+
+func TestTraceClient_Delete_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Trace not found"}`))
+		}))
+	defer server.Close()
+
+	cli := resty.New().SetBaseURL(server.URL)
+	client := NewClient(cli)
+
+	_, err := client.Delete(context.Background(), "missing-trace-id")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "got status code: 404")
+}
+
+func TestTraceClient_DeleteMany(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/traces", r.URL.Path)
+			require.Equal(t, "DELETE", r.Method)
+
+			var body struct {
+				TraceIDs []string `json:"traceIds"`
+			}
+			err := json.NewDecoder(r.Body).Decode(&body)
+			require.NoError(t, err)
+			require.Equal(t, []string{"trace-1", "trace-2"}, body.TraceIDs)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, err = w.Write([]byte(`{"message":"Traces deleted successfully"}`))
+			require.NoError(t, err)
+		}))
+	defer server.Close()
+
+	cli := resty.New().SetBaseURL(server.URL)
+	client := NewClient(cli)
+
+	deleteResponse, err := client.DeleteMany(context.Background(), []string{"trace-1", "trace-2"})
+	require.NoError(t, err)
+	require.Equal(t, "Traces deleted successfully", deleteResponse.Message)
+}
+
+func TestTraceClient_DeleteMany_Validation(t *testing.T) {
+	cli := resty.New()
+	client := NewClient(cli)
+
+	_, err := client.DeleteMany(context.Background(), nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'traceIDs' is required")
+
+	_, err = client.DeleteMany(context.Background(), []string{"trace-1", ""})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "traceIDs[1] cannot be empty")
+}
@@ -0,0 +1,97 @@
+package traces
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Clock provides the current time for trace and observation timestamps.
+//
+// The default clock uses time.Now. A custom clock can be injected with WithClock,
+// for example to get deterministic timestamps in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock returns the clock backed by time.Now.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// defaultSkewTolerance is the default minimal clock skew that gets corrected.
+// The Date header only has a resolution of one second, so smaller skews can't be detected reliably.
+const defaultSkewTolerance = 2 * time.Second
+
+// SkewCorrectedClock is a Clock that corrects the time of a base clock by the
+// skew to the Langfuse server.
+//
+// The skew is estimated from the Date header of the server responses once the
+// clock is installed on a resty client. This keeps timestamps correct on hosts
+// whose clock drifts from the server clock.
+type SkewCorrectedClock struct {
+	base      Clock
+	tolerance time.Duration
+	skew      atomic.Int64
+}
+
+// NewSkewCorrectedClock creates a clock that corrects the base clock by the detected server time skew.
+//
+// Skews whose absolute value is below the tolerance are ignored. If base is nil, the
+// system clock is used. If tolerance is less than or equal to zero, 2 seconds is used.
+func NewSkewCorrectedClock(base Clock, tolerance time.Duration) *SkewCorrectedClock {
+	if base == nil {
+		base = SystemClock()
+	}
+	if tolerance <= 0 {
+		tolerance = defaultSkewTolerance
+	}
+	return &SkewCorrectedClock{base: base, tolerance: tolerance}
+}
+
+// Now returns the time of the base clock corrected by the detected skew.
+func (c *SkewCorrectedClock) Now() time.Time {
+	return c.base.Now().Add(c.Skew())
+}
+
+// Skew returns the detected skew of the server clock relative to the base clock.
+//
+// A positive skew means the server clock is ahead of the local clock.
+func (c *SkewCorrectedClock) Skew() time.Duration {
+	return time.Duration(c.skew.Load())
+}
+
+// Observe updates the skew from a server time observed at the current base clock time.
+func (c *SkewCorrectedClock) Observe(serverTime time.Time) {
+	skew := serverTime.Sub(c.base.Now())
+	if skew > -c.tolerance && skew < c.tolerance {
+		skew = 0
+	}
+	c.skew.Store(int64(skew))
+}
+
+// Install registers a response hook on the resty client that observes the
+// Date header of every response to keep the skew up to date.
+func (c *SkewCorrectedClock) Install(cli *resty.Client) {
+	cli.OnAfterResponse(func(_ *resty.Client, rsp *resty.Response) error {
+		date := rsp.Header().Get("Date")
+		if date == "" {
+			return nil
+		}
+		serverTime, err := http.ParseTime(date)
+		if err != nil {
+			return nil
+		}
+		// The Date header is truncated to seconds, so use the middle of the second as the estimate.
+		c.Observe(serverTime.Add(500 * time.Millisecond))
+		return nil
+	})
+}
@@ -0,0 +1,74 @@
+package traces
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestIngestor_WithClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: start}
+	ingestor := NewIngestor(resty.New(), WithClock(clock))
+	defer ingestor.Close()
+
+	trace := ingestor.StartTrace(context.Background(), "test-trace")
+	require.Equal(t, start, trace.Timestamp)
+
+	span := trace.StartSpan("test-span")
+	require.Equal(t, start, span.StartTime)
+
+	clock.now = start.Add(1500 * time.Millisecond)
+	span.End()
+	require.Equal(t, start.Add(1500*time.Millisecond), *span.EndTime)
+
+	trace.End()
+	require.Equal(t, int64(1500), trace.Latency)
+}
+
+func TestSkewCorrectedClock_Observe(t *testing.T) {
+	local := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewSkewCorrectedClock(&fakeClock{now: local}, time.Second)
+
+	clock.Observe(local.Add(500 * time.Millisecond))
+	require.Zero(t, clock.Skew())
+	require.Equal(t, local, clock.Now())
+
+	clock.Observe(local.Add(-time.Minute))
+	require.Equal(t, -time.Minute, clock.Skew())
+	require.Equal(t, local.Add(-time.Minute), clock.Now())
+
+	clock.Observe(local)
+	require.Zero(t, clock.Skew())
+}
+
+func TestSkewCorrectedClock_Install(t *testing.T) {
+	serverTime := time.Now().Add(time.Hour).UTC()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cli := resty.New().SetBaseURL(server.URL)
+	clock := NewSkewCorrectedClock(nil, 0)
+	clock.Install(cli)
+
+	_, err := cli.R().Get("/health")
+	require.NoError(t, err)
+	require.InDelta(t, time.Hour.Seconds(), clock.Skew().Seconds(), 2)
+	require.WithinDuration(t, serverTime, clock.Now(), 2*time.Second)
+}
@@ -0,0 +1,68 @@
+package traces
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoTraceInContext is returned when a helper requires a trace in the context but none was found.
+var ErrNoTraceInContext = errors.New("no trace found in context")
+
+type traceContextKey struct{}
+
+// ContextWithTrace returns a copy of ctx that carries the given trace.
+//
+// Use it together with WithSpan and WithGeneration so that observations can be
+// created from any function that receives the context.
+func ContextWithTrace(ctx context.Context, trace *Trace) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, trace)
+}
+
+// TraceFromContext returns the trace stored in ctx, or nil if there is none.
+func TraceFromContext(ctx context.Context) *Trace {
+	trace, _ := ctx.Value(traceContextKey{}).(*Trace)
+	return trace
+}
+
+// WithSpan starts a span on the trace stored in ctx, runs fn and always ends the span.
+//
+// If fn returns an error, the span level is set to ERROR and the error message
+// is recorded as the status message. The error returned by fn is returned as is.
+// Returns ErrNoTraceInContext if ctx does not carry a trace.
+//
+// Example:
+//
+//	ctx = traces.ContextWithTrace(ctx, trace)
+//	err := traces.WithSpan(ctx, "retrieve-documents", func(ctx context.Context, span *traces.Observation) error {
+//		span.Input = query
+//		docs, err := retrieve(ctx, query)
+//		span.Output = docs
+//		return err
+//	})
+func WithSpan(ctx context.Context, name string, fn func(ctx context.Context, span *Observation) error) error {
+	return withObservation(ctx, name, ObservationTypeSpan, fn)
+}
+
+// WithGeneration starts a generation on the trace stored in ctx, runs fn and always ends the generation.
+//
+// It behaves like WithSpan but creates an observation of type GENERATION.
+func WithGeneration(ctx context.Context, name string, fn func(ctx context.Context, generation *Observation) error) error {
+	return withObservation(ctx, name, ObservationTypeGeneration, fn)
+}
+
+func withObservation(ctx context.Context, name string, typ ObservationType, fn func(ctx context.Context, observation *Observation) error) error {
+	trace := TraceFromContext(ctx)
+	if trace == nil {
+		return ErrNoTraceInContext
+	}
+
+	observation := trace.StartObservation(name, typ)
+	defer observation.End()
+
+	if err := fn(ctx, observation); err != nil {
+		observation.Level = ObservationLevelError
+		observation.StatusMessage = err.Error()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package traces
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceFromContext(t *testing.T) {
+	require.Nil(t, TraceFromContext(context.Background()))
+
+	ingestor := NewIngestor(resty.New())
+	trace := ingestor.StartTrace(context.Background(), "test-trace")
+	ctx := ContextWithTrace(context.Background(), trace)
+	require.Equal(t, trace, TraceFromContext(ctx))
+}
+
+func TestWithSpan(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	trace := ingestor.StartTrace(context.Background(), "test-trace")
+	ctx := ContextWithTrace(context.Background(), trace)
+
+	var captured *Observation
+	err := WithSpan(ctx, "test-span", func(ctx context.Context, span *Observation) error {
+		captured = span
+		require.Nil(t, span.EndTime)
+		span.Output = "done"
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, captured)
+	require.Equal(t, "test-span", captured.Name)
+	require.Equal(t, ObservationTypeSpan, captured.Type)
+	require.Equal(t, "done", captured.Output)
+	require.NotNil(t, captured.EndTime)
+	require.Empty(t, captured.Level)
+	require.Len(t, trace.observations, 1)
+}
+
+func TestWithSpan_Error(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	trace := ingestor.StartTrace(context.Background(), "test-trace")
+	ctx := ContextWithTrace(context.Background(), trace)
+
+	wantErr := errors.New("retrieval failed")
+	var captured *Observation
+	err := WithSpan(ctx, "test-span", func(ctx context.Context, span *Observation) error {
+		captured = span
+		return wantErr
+	})
+	require.ErrorIs(t, err, wantErr)
+	require.NotNil(t, captured.EndTime)
+	require.Equal(t, ObservationLevelError, captured.Level)
+	require.Equal(t, "retrieval failed", captured.StatusMessage)
+}
+
+func TestWithSpan_EndsOnPanic(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	trace := ingestor.StartTrace(context.Background(), "test-trace")
+	ctx := ContextWithTrace(context.Background(), trace)
+
+	var captured *Observation
+	require.Panics(t, func() {
+		_ = WithSpan(ctx, "test-span", func(ctx context.Context, span *Observation) error {
+			captured = span
+			panic("boom")
+		})
+	})
+	require.NotNil(t, captured.EndTime)
+}
+
+func TestWithGeneration_Nested(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	trace := ingestor.StartTrace(context.Background(), "test-trace")
+	ctx := ContextWithTrace(context.Background(), trace)
+
+	var span, generation *Observation
+	err := WithSpan(ctx, "parent", func(ctx context.Context, s *Observation) error {
+		span = s
+		return WithGeneration(ctx, "llm-call", func(ctx context.Context, g *Observation) error {
+			generation = g
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	require.Equal(t, ObservationTypeGeneration, generation.Type)
+	require.Equal(t, span.ID, generation.ParentObservationID)
+	require.NotNil(t, generation.EndTime)
+}
+
+func TestWithSpan_NoTraceInContext(t *testing.T) {
+	called := false
+	err := WithSpan(context.Background(), "test-span", func(ctx context.Context, span *Observation) error {
+		called = true
+		return nil
+	})
+	require.ErrorIs(t, err, ErrNoTraceInContext)
+	require.False(t, called)
+}
@@ -0,0 +1,251 @@
+package traces
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/git-hulk/langfuse-go/pkg/models"
+)
+
+const (
+	defaultModelsRefreshInterval = 10 * time.Minute
+	// modelsFailureBackoff is the time a failed fetch of the models is cached before
+	// the models are fetched again, capped by the refresh interval.
+	modelsFailureBackoff = 30 * time.Second
+	modelsPageSize       = 100
+)
+
+// ModelLister lists model definitions with their pricing.
+//
+// It is implemented by models.Client and is used by the CostCalculator
+// to fetch the model pricing configured in Langfuse.
+type ModelLister interface {
+	List(ctx context.Context, params models.ListParams) (*models.ListModels, error)
+}
+
+type pricedModel struct {
+	entry   models.ModelEntry
+	pattern *regexp.Regexp
+}
+
+// CostCalculator computes the cost of generations from their model and usage.
+//
+// Model pricing is fetched from the models API and cached in memory, and the
+// cache is refreshed once the refresh interval elapses. This allows self-hosted
+// setups without server-side pricing to still report cost data. Concurrent callers
+// share a single fetch, and a failed fetch isn't retried for 30 seconds, during
+// which the previously fetched models are used if any.
+//
+// The calculator is safe for concurrent use.
+type CostCalculator struct {
+	lister          ModelLister
+	refreshInterval time.Duration
+
+	mu        sync.Mutex
+	models    []pricedModel
+	fetchedAt time.Time
+	// fetch is the fetch of the models in progress, shared by the concurrent callers.
+	fetch *modelsFetch
+	// fetchErr is the error of the last fetch, cached until failedAt+failureBackoff.
+	fetchErr       error
+	failedAt       time.Time
+	failureBackoff time.Duration
+}
+
+// modelsFetch is a fetch of the models, whose result is set before done is closed.
+type modelsFetch struct {
+	done   chan struct{}
+	models []pricedModel
+	err    error
+}
+
+// NewCostCalculator creates a new CostCalculator which caches the models returned by lister
+// for the given refresh interval. If refreshInterval is not positive, it defaults to 10 minutes.
+func NewCostCalculator(lister ModelLister, refreshInterval time.Duration) *CostCalculator {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultModelsRefreshInterval
+	}
+	return &CostCalculator{
+		lister:          lister,
+		refreshInterval: refreshInterval,
+		failureBackoff:  min(modelsFailureBackoff, refreshInterval),
+	}
+}
+
+// Apply fills the CostDetails of the observation based on the matching model pricing.
+//
+// Observations that are not generations, have no model or usage, or already
+// have CostDetails are left untouched. If no model matches, Apply returns nil
+// without modifying the observation.
+func (c *CostCalculator) Apply(ctx context.Context, observation *Observation) error {
+	if observation.Type != ObservationTypeGeneration || observation.Model == "" {
+		return nil
+	}
+	if len(observation.CostDetails) > 0 {
+		return nil
+	}
+	usage := observation.Usage
+	if usage.IsZero() {
+		return nil
+	}
+
+	pricedModels, err := c.getModels(ctx)
+	if err != nil {
+		return err
+	}
+	model := matchModel(pricedModels, observation.Model, observation.StartTime)
+	if model == nil {
+		return nil
+	}
+	if model.Unit != "" && usage.Unit != "" && model.Unit != string(usage.Unit) {
+		return nil
+	}
+
+	costDetails := computeCostDetails(model, usage)
+	if len(costDetails) > 0 {
+		observation.CostDetails = costDetails
+	}
+	return nil
+}
+
+// getModels returns the cached models, or fetches them once the cache has expired. The
+// lock isn't held while fetching, so the callers only wait for the fetch they share.
+func (c *CostCalculator) getModels(ctx context.Context) ([]pricedModel, error) {
+	c.mu.Lock()
+	if c.models != nil && time.Since(c.fetchedAt) < c.refreshInterval {
+		defer c.mu.Unlock()
+		return c.models, nil
+	}
+	if c.fetchErr != nil && time.Since(c.failedAt) < c.failureBackoff {
+		defer c.mu.Unlock()
+		return c.fallback(c.fetchErr)
+	}
+	fetch := c.fetch
+	if fetch != nil {
+		c.mu.Unlock()
+		select {
+		case <-fetch.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	} else {
+		fetch = &modelsFetch{done: make(chan struct{})}
+		c.fetch = fetch
+		c.mu.Unlock()
+
+		fetch.models, fetch.err = c.listModels(ctx)
+		c.mu.Lock()
+		c.fetch = nil
+		if fetch.err != nil {
+			c.fetchErr = fetch.err
+			c.failedAt = time.Now()
+		} else {
+			c.models = fetch.models
+			c.fetchedAt = time.Now()
+			c.fetchErr = nil
+		}
+		c.mu.Unlock()
+		close(fetch.done)
+	}
+
+	if fetch.err != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.fallback(fetch.err)
+	}
+	return fetch.models, nil
+}
+
+// fallback returns the previously fetched models if any, or the error of the failed fetch.
+// It must be called with the lock held.
+func (c *CostCalculator) fallback(err error) ([]pricedModel, error) {
+	if c.models != nil {
+		return c.models, nil
+	}
+	return nil, err
+}
+
+// listModels fetches all the models and compiles their match patterns.
+func (c *CostCalculator) listModels(ctx context.Context) ([]pricedModel, error) {
+	pricedModels := make([]pricedModel, 0)
+	for page := 1; ; page++ {
+		listModels, err := c.lister.List(ctx, models.ListParams{Page: page, Limit: modelsPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list models: %w", err)
+		}
+		for _, entry := range listModels.Data {
+			pattern, err := regexp.Compile(entry.MatchPattern)
+			if err != nil {
+				// Skip patterns which are not supported by the Go regexp syntax
+				continue
+			}
+			pricedModels = append(pricedModels, pricedModel{entry: entry, pattern: pattern})
+		}
+		if len(listModels.Data) == 0 || page >= listModels.Metadata.TotalPages {
+			break
+		}
+	}
+	return pricedModels, nil
+}
+
+// matchModel returns the model definition that applies to the given model name.
+// Custom models take precedence over Langfuse managed ones, then the newest
+// model whose start date is not after the observation start time wins.
+func matchModel(pricedModels []pricedModel, modelName string, startTime time.Time) *models.ModelEntry {
+	var matched *models.ModelEntry
+	for i := range pricedModels {
+		candidate := &pricedModels[i].entry
+		if !pricedModels[i].pattern.MatchString(modelName) {
+			continue
+		}
+		if !candidate.StartDate.IsZero() && !startTime.IsZero() && candidate.StartDate.After(startTime) {
+			continue
+		}
+		if matched == nil {
+			matched = candidate
+			continue
+		}
+		if matched.IsLangfuseManaged != candidate.IsLangfuseManaged {
+			if !candidate.IsLangfuseManaged {
+				matched = candidate
+			}
+			continue
+		}
+		if candidate.StartDate.After(matched.StartDate) {
+			matched = candidate
+		}
+	}
+	return matched
+}
+
+func computeCostDetails(model *models.ModelEntry, usage Usage) map[string]float64 {
+	inputPrice, outputPrice, totalPrice := model.InputPrice, model.OutputPrice, model.TotalPrice
+	if price, ok := model.Prices["input"]; ok {
+		inputPrice = price.Price
+	}
+	if price, ok := model.Prices["output"]; ok {
+		outputPrice = price.Price
+	}
+	if price, ok := model.Prices["total"]; ok {
+		totalPrice = price.Price
+	}
+
+	costDetails := make(map[string]float64)
+	if inputPrice > 0 || outputPrice > 0 {
+		inputCost := float64(usage.Input) * inputPrice
+		outputCost := float64(usage.Output) * outputPrice
+		costDetails["input"] = inputCost
+		costDetails["output"] = outputCost
+		costDetails["total"] = inputCost + outputCost
+	} else if totalPrice > 0 {
+		total := usage.Total
+		if total == 0 {
+			total = usage.Input + usage.Output
+		}
+		costDetails["total"] = float64(total) * totalPrice
+	}
+	return costDetails
+}
@@ -0,0 +1,301 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+	"github.com/git-hulk/langfuse-go/pkg/models"
+)
+
+type mockModelLister struct {
+	pages     [][]models.ModelEntry
+	listCount int
+	err       error
+}
+
+func (m *mockModelLister) List(_ context.Context, params models.ListParams) (*models.ListModels, error) {
+	m.listCount++
+	if m.err != nil {
+		return nil, m.err
+	}
+	listModels := &models.ListModels{
+		Metadata: common.ListMetadata{Page: params.Page, TotalPages: len(m.pages)},
+	}
+	if params.Page <= len(m.pages) {
+		listModels.Data = m.pages[params.Page-1]
+	}
+	return listModels, nil
+}
+
+func TestCostCalculator_Apply(t *testing.T) {
+	lister := &mockModelLister{
+		pages: [][]models.ModelEntry{
+			{
+				{ModelName: "gpt-4o", MatchPattern: "(?i)^(gpt-4o)$", InputPrice: 0.001, OutputPrice: 0.002, Unit: "TOKENS"},
+			},
+			{
+				{ModelName: "embedding", MatchPattern: "(?i)^(embedding)$", TotalPrice: 0.0001, Unit: "TOKENS"},
+				{
+					ModelName:    "claude",
+					MatchPattern: "(?i)^(claude)$",
+					Unit:         "TOKENS",
+					Prices: map[string]models.ModelPrice{
+						"input":  {Price: 0.01},
+						"output": {Price: 0.03},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		observation Observation
+		want        map[string]float64
+	}{
+		{
+			name: "input and output prices",
+			observation: Observation{
+				Type:  ObservationTypeGeneration,
+				Model: "gpt-4o",
+				Usage: Usage{Input: 100, Output: 50, Unit: UnitTokens},
+			},
+			want: map[string]float64{"input": 0.1, "output": 0.1, "total": 0.2},
+		},
+		{
+			name: "total price only",
+			observation: Observation{
+				Type:  ObservationTypeGeneration,
+				Model: "embedding",
+				Usage: Usage{Total: 1000},
+			},
+			want: map[string]float64{"total": 0.1},
+		},
+		{
+			name: "prices by usage type",
+			observation: Observation{
+				Type:  ObservationTypeGeneration,
+				Model: "claude",
+				Usage: Usage{Input: 10, Output: 10},
+			},
+			want: map[string]float64{"input": 0.1, "output": 0.3, "total": 0.4},
+		},
+		{
+			name: "unknown model",
+			observation: Observation{
+				Type:  ObservationTypeGeneration,
+				Model: "unknown",
+				Usage: Usage{Input: 10, Output: 10},
+			},
+			want: nil,
+		},
+		{
+			name: "unit mismatch",
+			observation: Observation{
+				Type:  ObservationTypeGeneration,
+				Model: "gpt-4o",
+				Usage: Usage{Input: 10, Output: 10, Unit: UnitCharacters},
+			},
+			want: nil,
+		},
+		{
+			name: "not a generation",
+			observation: Observation{
+				Type:  ObservationTypeSpan,
+				Model: "gpt-4o",
+				Usage: Usage{Input: 10, Output: 10},
+			},
+			want: nil,
+		},
+		{
+			name: "existing cost details are kept",
+			observation: Observation{
+				Type:        ObservationTypeGeneration,
+				Model:       "gpt-4o",
+				Usage:       Usage{Input: 10, Output: 10},
+				CostDetails: map[string]float64{"total": 1},
+			},
+			want: map[string]float64{"total": 1},
+		},
+	}
+
+	calculator := NewCostCalculator(lister, time.Minute)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observation := tt.observation
+			require.NoError(t, calculator.Apply(context.Background(), &observation))
+			require.Len(t, observation.CostDetails, len(tt.want))
+			for key, value := range tt.want {
+				require.InDelta(t, value, observation.CostDetails[key], 1e-9)
+			}
+		})
+	}
+	// Models are fetched once (two pages) and then served from the cache.
+	require.Equal(t, 2, lister.listCount)
+}
+
+func TestCostCalculator_Apply_ListError(t *testing.T) {
+	calculator := NewCostCalculator(&mockModelLister{err: errors.New("boom")}, time.Minute)
+	observation := &Observation{
+		Type:  ObservationTypeGeneration,
+		Model: "gpt-4o",
+		Usage: Usage{Input: 10},
+	}
+	err := calculator.Apply(context.Background(), observation)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to list models")
+	require.Nil(t, observation.CostDetails)
+
+	// The failure is cached for the backoff period instead of listing the models per call
+	err = calculator.Apply(context.Background(), observation)
+	require.ErrorContains(t, err, "failed to list models")
+	require.Equal(t, 1, calculator.lister.(*mockModelLister).listCount)
+}
+
+func TestCostCalculator_Apply_StaleModelsOnError(t *testing.T) {
+	lister := &mockModelLister{
+		pages: [][]models.ModelEntry{{
+			{ID: "m1", ModelName: "gpt-4o", MatchPattern: "(?i)^gpt-4o$", InputPrice: 0.001},
+		}},
+	}
+	calculator := NewCostCalculator(lister, time.Minute)
+	observation := &Observation{Type: ObservationTypeGeneration, Model: "gpt-4o", Usage: Usage{Input: 10}}
+	require.NoError(t, calculator.Apply(context.Background(), observation))
+
+	// The cache expires and the refresh fails, the previously fetched models are used
+	calculator.fetchedAt = time.Now().Add(-time.Hour)
+	lister.err = errors.New("boom")
+	observation = &Observation{Type: ObservationTypeGeneration, Model: "gpt-4o", Usage: Usage{Input: 10}}
+	require.NoError(t, calculator.Apply(context.Background(), observation))
+	require.NotNil(t, observation.CostDetails)
+	require.Equal(t, 2, lister.listCount)
+}
+
+type blockingModelLister struct {
+	release   chan struct{}
+	listCount atomic.Int32
+}
+
+func (m *blockingModelLister) List(ctx context.Context, params models.ListParams) (*models.ListModels, error) {
+	m.listCount.Add(1)
+	select {
+	case <-m.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &models.ListModels{
+		Metadata: common.ListMetadata{Page: params.Page, TotalPages: 1},
+		Data:     []models.ModelEntry{{ID: "m1", ModelName: "gpt-4o", MatchPattern: "(?i)^gpt-4o$", InputPrice: 0.001}},
+	}, nil
+}
+
+func TestCostCalculator_Apply_SharedFetch(t *testing.T) {
+	lister := &blockingModelLister{release: make(chan struct{})}
+	calculator := NewCostCalculator(lister, time.Minute)
+
+	const callers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			observation := &Observation{Type: ObservationTypeGeneration, Model: "gpt-4o", Usage: Usage{Input: 10}}
+			errs <- calculator.Apply(context.Background(), observation)
+		}()
+	}
+	require.Eventually(t, func() bool { return lister.listCount.Load() == 1 }, time.Second, 5*time.Millisecond)
+	close(lister.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	// The concurrent callers share a single fetch of the models
+	require.EqualValues(t, 1, lister.listCount.Load())
+
+	// A waiting caller gives up once its context is done
+	calculator.fetchedAt = time.Now().Add(-time.Hour)
+	lister.release = make(chan struct{})
+	go func() { _, _ = calculator.getModels(context.Background()) }()
+	require.Eventually(t, func() bool { return lister.listCount.Load() == 2 }, time.Second, 5*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := calculator.getModels(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	close(lister.release)
+}
+
+func TestMatchModel_Precedence(t *testing.T) {
+	now := time.Now()
+	lister := &mockModelLister{
+		pages: [][]models.ModelEntry{
+			{
+				{ID: "managed", MatchPattern: "gpt-4", IsLangfuseManaged: true, StartDate: now.Add(-time.Hour)},
+				{ID: "custom-old", MatchPattern: "gpt-4", StartDate: now.Add(-48 * time.Hour)},
+				{ID: "custom-new", MatchPattern: "gpt-4", StartDate: now.Add(-24 * time.Hour)},
+				{ID: "custom-future", MatchPattern: "gpt-4", StartDate: now.Add(24 * time.Hour)},
+				{ID: "invalid", MatchPattern: "(?<=gpt)"},
+			},
+		},
+	}
+	calculator := NewCostCalculator(lister, 0)
+	pricedModels, err := calculator.getModels(context.Background())
+	require.NoError(t, err)
+	require.Len(t, pricedModels, 4)
+
+	matched := matchModel(pricedModels, "gpt-4", now)
+	require.NotNil(t, matched)
+	require.Equal(t, "custom-new", matched.ID)
+
+	require.Nil(t, matchModel(pricedModels, "claude", now))
+}
+
+func TestIngestor_Send_WithCostCalculator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Batch []struct {
+				Type string          `json:"type"`
+				Body json.RawMessage `json:"body"`
+			} `json:"batch"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Batch, 2)
+		require.Equal(t, IngestionCreateGeneration, body.Batch[1].Type)
+
+		var observation Observation
+		require.NoError(t, json.Unmarshal(body.Batch[1].Body, &observation))
+		require.InDelta(t, 0.3, observation.CostDetails["total"], 1e-9)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"successes":[],"errors":[]}`))
+	}))
+	defer server.Close()
+
+	lister := &mockModelLister{
+		pages: [][]models.ModelEntry{
+			{{MatchPattern: "gpt-4o", InputPrice: 0.01, OutputPrice: 0.02}},
+		},
+	}
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL), WithCostCalculator(NewCostCalculator(lister, 0)))
+	defer ingestor.Close()
+
+	trace := ingestor.StartTrace(context.Background(), "trace")
+	generation := trace.StartGeneration("generation")
+	generation.Model = "gpt-4o"
+	generation.Usage = Usage{Input: 10, Output: 10}
+	generation.End()
+
+	require.NoError(t, ingestor.Send(context.Background(), []*Trace{trace}))
+}
@@ -0,0 +1,75 @@
+package traces
+
+import (
+	"context"
+	"time"
+
+	"github.com/git-hulk/langfuse-go/pkg/batch"
+)
+
+// ErrPaused is returned by FlushContext and Drain while the ingestion is paused.
+var ErrPaused = batch.ErrPaused
+
+// Pause stops sending ingestion requests, e.g. during the maintenance window of the
+// Langfuse server. The requests which are being sent complete, and the ended traces are
+// kept in the queue, so Submit fails once the queue is full, see batch.Config.BufferSize
+// and batch.Config.MaxQueuedBytes. Flush doesn't send the queue while paused, and
+// FlushContext and Drain return ErrPaused. Closing the ingestor resumes it to send the queue.
+//
+// Example:
+//
+//	http.HandleFunc("/admin/langfuse/pause", func(w http.ResponseWriter, r *http.Request) {
+//		ingestor.Pause()
+//	})
+//	http.HandleFunc("/admin/langfuse/resume", func(w http.ResponseWriter, r *http.Request) {
+//		ingestor.Resume()
+//	})
+func (ingestor *Ingestor) Pause() {
+	ingestor.processor.Pause()
+}
+
+// Resume resumes sending after Pause. It does nothing if the ingestion isn't paused.
+func (ingestor *Ingestor) Resume() {
+	ingestor.processor.Resume()
+}
+
+// Paused reports whether the ingestion is paused by Pause.
+func (ingestor *Ingestor) Paused() bool {
+	return ingestor.processor.Paused()
+}
+
+// Drain sends the queued traces and waits until they have been sent, including the
+// batches queued by the health monitor, or the context is done. Unlike Close, the
+// ingestor keeps accepting traces, e.g. to empty the queue of a relay before a deploy
+// while it's still serving. It returns ErrPaused if the ingestion is paused.
+func (ingestor *Ingestor) Drain(ctx context.Context) error {
+	if ingestor.Paused() {
+		return ErrPaused
+	}
+	if err := ingestor.processor.FlushContext(ctx); err != nil {
+		return err
+	}
+	if ingestor.monitor != nil {
+		return ingestor.monitor.waitDrained(ctx)
+	}
+	return nil
+}
+
+// waitDrained waits until the backlog has been sent, polling every DrainInterval.
+func (m *healthMonitor) waitDrained(ctx context.Context) error {
+	ticker := time.NewTicker(m.config.DrainInterval)
+	defer ticker.Stop()
+	for {
+		m.mu.Lock()
+		drained := (len(m.backlog) == 0 && m.sending == nil) || m.stopped
+		m.mu.Unlock()
+		if drained {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
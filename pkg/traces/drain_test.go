@@ -0,0 +1,104 @@
+package traces
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/batch"
+)
+
+func TestIngestor_PauseResumeDrain(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"successes": [], "errors": []}`))
+	}))
+	defer server.Close()
+
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL), WithBatchConfig(batch.Config{FlushInterval: time.Hour}))
+	defer ingestor.Close()
+	ctx := context.Background()
+
+	ingestor.Pause()
+	require.True(t, ingestor.Paused())
+	ingestor.StartTrace(ctx, "chat").End()
+	require.ErrorIs(t, ingestor.Drain(ctx), ErrPaused)
+
+	require.ErrorIs(t, ingestor.FlushContext(ctx), ErrPaused)
+	ingestor.Flush()
+	require.Zero(t, requests.Load())
+	require.Equal(t, 1, ingestor.QueueStats().Records)
+
+	ingestor.Resume()
+	ingestor.Resume()
+	require.False(t, ingestor.Paused())
+	require.NoError(t, ingestor.Drain(ctx))
+	require.EqualValues(t, 1, requests.Load())
+	require.Zero(t, ingestor.QueueStats().Records)
+}
+
+func TestIngestor_CloseWhilePaused(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"successes": [], "errors": []}`))
+	}))
+	defer server.Close()
+
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL))
+	ingestor.Pause()
+	ingestor.StartTrace(context.Background(), "chat").End()
+	require.NoError(t, ingestor.Close())
+	require.EqualValues(t, 1, requests.Load())
+}
+
+func TestIngestor_FlushWhilePaused(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"successes": [], "errors": []}`))
+	}))
+	defer server.Close()
+
+	const numWorkers, traces = 1, 10
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL), WithBatchConfig(batch.Config{
+		MaxBatchSize:  1,
+		NumWorkers:    numWorkers,
+		FlushInterval: 10 * time.Millisecond,
+	}))
+	defer ingestor.Close()
+	ctx := context.Background()
+
+	ingestor.Pause()
+	// More batches than the workers and the pending batches can hold
+	for i := 0; i < numWorkers*2+1+traces; i++ {
+		ingestor.StartTrace(ctx, "chat").End()
+	}
+
+	flushed := make(chan struct{})
+	go func() {
+		ingestor.Flush()
+		ingestor.Flush()
+		close(flushed)
+	}()
+	select {
+	case <-flushed:
+	case <-time.After(time.Second):
+		t.Fatal("Flush blocked while paused")
+	}
+	time.Sleep(50 * time.Millisecond)
+	require.Zero(t, requests.Load())
+	require.Equal(t, numWorkers*2+1+traces, ingestor.QueueStats().Records)
+
+	ingestor.Resume()
+	require.NoError(t, ingestor.Drain(ctx))
+	require.EqualValues(t, numWorkers*2+1+traces, requests.Load())
+	require.Zero(t, ingestor.QueueStats().Records)
+}
@@ -0,0 +1,54 @@
+package traces
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+type dryRun struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// WithDryRun writes the ingestion requests to the writer as indented JSON instead of
+// sending them, to debug exactly what would be sent without polluting a project. The
+// events are reported as successful.
+//
+// Only the ingestion is affected: the other API calls made while sending the traces,
+// e.g. to upload large values as media or to fetch the model prices, are still made.
+//
+// Example:
+//
+//	ingestor := traces.NewIngestor(restyCli, traces.WithDryRun(os.Stdout))
+func WithDryRun(w io.Writer) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.dryRun = &dryRun{writer: w}
+	}
+}
+
+// write writes the request which would be sent for the events.
+func (d *dryRun) write(events []IngestionEvent) (*IngestionResult, error) {
+	body, err := encodeBatch(events)
+	if err != nil {
+		return nil, err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, body, "", "  "); err != nil {
+		return nil, err
+	}
+	data := indented.Bytes()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, err := d.writer.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+
+	result := &IngestionResult{StatusCode: http.StatusOK, Successes: make([]IngestionSuccess, len(events))}
+	for i, event := range events {
+		result.Successes[i] = IngestionSuccess{ID: event.ID, Status: http.StatusCreated}
+	}
+	return result, nil
+}
@@ -0,0 +1,37 @@
+package traces
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestor_DryRun(t *testing.T) {
+	var out bytes.Buffer
+	// No server is running, the request would fail if it was sent
+	ingestor := NewIngestor(resty.New().SetBaseURL("http://127.0.0.1:0"), WithDryRun(&out))
+	trace := ingestor.StartTrace(context.Background(), "chat")
+	trace.Input = "hello"
+	trace.StartSpan("retrieve").End()
+
+	require.NoError(t, ingestor.Send(context.Background(), []*Trace{trace}))
+	require.True(t, strings.HasPrefix(out.String(), "{\n  \"batch\": [\n"))
+
+	var request struct {
+		Batch []IngestionEvent `json:"batch"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &request))
+	require.Len(t, request.Batch, 2)
+	require.Equal(t, IngestionCreateTrace, request.Batch[0].Type)
+	require.Equal(t, "hello", request.Batch[0].Body.(map[string]any)["input"])
+
+	result, err := ingestor.FlushWithResult(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Succeeded)
+	require.NoError(t, result.Err())
+}
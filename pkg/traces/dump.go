@@ -0,0 +1,60 @@
+package traces
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DumpPending writes the traces and observations which haven't been sent yet to w as
+// newline-delimited JSON in the format of IngestFromReader, so they can be ingested
+// later, e.g. after a crash. It returns the number of written lines.
+//
+// The ended traces waiting to be batched and the batches queued by the health monitor
+// are written, including the one the monitor is sending again, so it may be ingested
+// twice, which the server handles as updates. The batches which are being sent by the
+// workers are skipped, since their traces are modified while they're prepared for the
+// request. The queue isn't modified.
+// Values which can't be encoded skip their line, and their errors are returned
+// together once all other lines have been written.
+func (ingestor *Ingestor) DumpPending(w io.Writer) (int, error) {
+	events := make([]IngestionEvent, 0)
+	for _, trace := range ingestor.processor.Pending() {
+		events = append(events, traceEvents(trace)...)
+	}
+	if ingestor.monitor != nil {
+		events = append(events, ingestor.monitor.pending()...)
+	}
+
+	lines := 0
+	var errs []error
+	for _, event := range events {
+		record, ok := backfillRecord(event)
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(record)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("encode event %s: %w", event.ID, err))
+			continue
+		}
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return lines, err
+		}
+		lines++
+	}
+	return lines, errors.Join(errs...)
+}
+
+// backfillRecord converts an ingestion event created by traceEvents to a BackfillRecord.
+func backfillRecord(event IngestionEvent) (BackfillRecord, bool) {
+	switch body := event.Body.(type) {
+	case *Trace:
+		return BackfillRecord{Trace: &body.TraceEntry}, true
+	case *Observation:
+		return BackfillRecord{Observation: body}, true
+	default:
+		return BackfillRecord{}, false
+	}
+}
@@ -0,0 +1,54 @@
+package traces
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/batch"
+)
+
+func TestIngestor_DumpPending(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Batch []struct {
+				Type string `json:"type"`
+			} `json:"batch"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		for _, event := range request.Batch {
+			received = append(received, event.Type)
+		}
+		_, _ = w.Write([]byte(`{"successes": [], "errors": []}`))
+	}))
+	defer server.Close()
+
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL), WithBatchConfig(batch.Config{FlushInterval: time.Hour}))
+	defer ingestor.Close()
+	ctx := context.Background()
+
+	trace := ingestor.StartTrace(ctx, "chat")
+	span := trace.StartSpan("retrieve")
+	span.End()
+	trace.End()
+
+	var dump bytes.Buffer
+	lines, err := ingestor.DumpPending(&dump)
+	require.NoError(t, err)
+	require.Equal(t, 2, lines)
+	require.Contains(t, dump.String(), `"trace":{"id":"`+trace.ID+`"`)
+
+	// The dump can be ingested again
+	result, err := ingestor.IngestFromReader(ctx, &dump)
+	require.NoError(t, err)
+	require.Equal(t, &BackfillResult{Lines: 2, Traces: 1, Observations: 1}, result)
+	require.Equal(t, []string{IngestionCreateTrace, IngestionCreateSpan}, received)
+}
@@ -0,0 +1,60 @@
+package traces_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/git-hulk/langfuse-go/pkg/traces"
+)
+
+func ExamplePipeline() {
+	ingestor := traces.NewIngestor(resty.New().SetBaseURL("https://cloud.langfuse.com/api/public"))
+	defer ingestor.Close()
+
+	pipeline := traces.NewPipeline("rag").
+		Stage("retrieve", func(ctx context.Context, stage *traces.Observation, input any) (any, error) {
+			stage.SetQuery(input.(string), 2)
+			stage.SetDocuments(traces.Document{ID: "doc-1", Content: "Langfuse traces LLM apps"})
+			return "Langfuse traces LLM apps", nil
+		}, traces.WithStageType(traces.ObservationTypeRetriever)).
+		Stage("generate", func(ctx context.Context, stage *traces.Observation, input any) (any, error) {
+			stage.Model = "gpt-4o"
+			return strings.ToUpper(input.(string)), nil
+		}, traces.WithStageType(traces.ObservationTypeGeneration))
+
+	trace := ingestor.StartTrace(context.Background(), "question")
+	ctx := traces.ContextWithTrace(context.Background(), trace)
+	answer, err := pipeline.Run(ctx, "What is Langfuse?")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(answer)
+	for _, observation := range trace.Observations() {
+		fmt.Println(observation.Type, observation.Name)
+	}
+	// Output:
+	// LANGFUSE TRACES LLM APPS
+	// CHAIN rag
+	// RETRIEVER retrieve
+	// GENERATION generate
+}
+
+func ExampleWithSpan() {
+	ingestor := traces.NewIngestor(resty.New().SetBaseURL("https://cloud.langfuse.com/api/public"))
+	defer ingestor.Close()
+
+	trace := ingestor.StartTrace(context.Background(), "handler")
+	ctx := traces.ContextWithTrace(context.Background(), trace)
+	err := traces.WithSpan(ctx, "load-user", func(ctx context.Context, span *traces.Observation) error {
+		span.Input = map[string]any{"userID": "user-id"}
+		return nil
+	})
+	if err != nil {
+		log.Printf("failed to load the user: %v", err)
+	}
+	trace.End()
+}
@@ -0,0 +1,56 @@
+package traces
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/git-hulk/langfuse-go/pkg/logger"
+)
+
+// TraceExporter receives the traces of each batch sent to Langfuse, e.g. to double-write
+// them to another observability backend.
+type TraceExporter interface {
+	Export(ctx context.Context, traces []*Trace) error
+}
+
+// DefaultExportTimeout bounds the export of a batch by each trace exporter, see WithExportTimeout.
+const DefaultExportTimeout = 10 * time.Second
+
+// WithTraceExporter adds an exporter which receives the traces of each batch right after
+// it's sent to Langfuse, with the usage and cost filled in.
+//
+// Export failures are logged and don't affect the traces sent to Langfuse. Each export is
+// bounded by the export timeout, so a hung backend only delays the next batch.
+func WithTraceExporter(exporter TraceExporter) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.exporters = append(ingestor.exporters, exporter)
+	}
+}
+
+// WithExportTimeout bounds the export of a batch by each trace exporter. Default is
+// DefaultExportTimeout.
+func WithExportTimeout(timeout time.Duration) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.exportTimeout = timeout
+	}
+}
+
+func (ingestor *Ingestor) export(ctx context.Context, traces []*Trace) {
+	timeout := ingestor.exportTimeout
+	if timeout <= 0 {
+		timeout = DefaultExportTimeout
+	}
+	for _, exporter := range ingestor.exporters {
+		exportCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := exporter.Export(exportCtx, traces)
+		cancel()
+		if err != nil {
+			logger.Get().With(
+				zap.Error(err),
+				zap.Int("traces", len(traces)),
+			).Warn("Failed to export traces")
+		}
+	}
+}
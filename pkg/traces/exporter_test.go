@@ -0,0 +1,75 @@
+package traces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingExporter struct {
+	exported []*Trace
+	err      error
+}
+
+func (e *recordingExporter) Export(_ context.Context, traces []*Trace) error {
+	e.exported = append(e.exported, traces...)
+	return e.err
+}
+
+func TestIngestor_WithTraceExporter(t *testing.T) {
+	var sent int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"successes":[],"errors":[]}`))
+	}))
+	defer server.Close()
+
+	ok := &recordingExporter{}
+	failing := &recordingExporter{err: errors.New("backend unavailable")}
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL), WithTraceExporter(failing), WithTraceExporter(ok))
+	defer ingestor.Close()
+
+	trace := ingestor.StartTrace(context.Background(), "chat")
+	trace.StartSpan("step").End()
+
+	require.NoError(t, ingestor.Send(context.Background(), []*Trace{trace}))
+	require.Equal(t, []*Trace{trace}, ok.exported)
+	require.Equal(t, []*Trace{trace}, failing.exported)
+	require.Equal(t, 1, sent)
+}
+
+type blockingExporter struct{}
+
+func (blockingExporter) Export(ctx context.Context, _ []*Trace) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestIngestor_ExportTimeout(t *testing.T) {
+	var sent atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"successes":[],"errors":[]}`))
+	}))
+	defer server.Close()
+
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL),
+		WithTraceExporter(blockingExporter{}), WithExportTimeout(50*time.Millisecond))
+	defer ingestor.Close()
+
+	trace := ingestor.StartTrace(context.Background(), "chat")
+	start := time.Now()
+	// A hung exporter neither delays the request to Langfuse nor blocks the send
+	require.NoError(t, ingestor.Send(context.Background(), []*Trace{trace}))
+	require.EqualValues(t, 1, sent.Load())
+	require.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,59 @@
+package traces
+
+import "strings"
+
+// GuardrailDecision is the outcome of a guardrail check.
+type GuardrailDecision string
+
+const (
+	// GuardrailDecisionAllow lets the content pass unchanged.
+	GuardrailDecisionAllow GuardrailDecision = "allow"
+	// GuardrailDecisionBlock rejects the content.
+	GuardrailDecisionBlock GuardrailDecision = "block"
+	// GuardrailDecisionModify lets the content pass after modifying it, e.g. redacting PII.
+	GuardrailDecisionModify GuardrailDecision = "modify"
+)
+
+// GuardrailResult is the output of a guardrail observation.
+type GuardrailResult struct {
+	Policy         string            `json:"policy,omitempty"`
+	Decision       GuardrailDecision `json:"decision"`
+	TriggeredRules []string          `json:"triggeredRules,omitempty"`
+	Reason         string            `json:"reason,omitempty"`
+	// ModifiedContent is the content after the modification if the decision is modify.
+	ModifiedContent any `json:"modifiedContent,omitempty"`
+}
+
+// StartGuardrail creates a new child observation of type GUARDRAIL within this trace,
+// for a safety check like a moderation, jailbreak or PII detection.
+//
+// Set the checked content as the Input, and end the observation with EndGuardrail.
+//
+// Example:
+//
+//	guardrail := trace.StartGuardrail("moderation")
+//	guardrail.Input = userMessage
+//	guardrail.EndGuardrail(traces.GuardrailResult{
+//		Policy:         "content-safety",
+//		Decision:       traces.GuardrailDecisionBlock,
+//		TriggeredRules: []string{"self-harm"},
+//	})
+func (t *Trace) StartGuardrail(name string) *Observation {
+	return t.StartObservation(name, ObservationTypeGuardrail)
+}
+
+// EndGuardrail records the result of a guardrail check as the output and ends the observation.
+//
+// Blocked content is recorded with the level WARNING and the triggered rules, or the reason
+// if there are none, as the status message, so that blocks stand out in the Langfuse UI.
+func (o *Observation) EndGuardrail(result GuardrailResult) {
+	o.Output = result
+	if result.Decision == GuardrailDecisionBlock {
+		o.Level = ObservationLevelWarning
+		o.StatusMessage = result.Reason
+		if len(result.TriggeredRules) > 0 {
+			o.StatusMessage = "blocked by " + strings.Join(result.TriggeredRules, ", ")
+		}
+	}
+	o.End()
+}
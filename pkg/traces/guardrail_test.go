@@ -0,0 +1,57 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservation_EndGuardrail(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	defer ingestor.Close()
+	trace := ingestor.StartTrace(context.Background(), "chat")
+
+	t.Run("block", func(t *testing.T) {
+		guardrail := trace.StartGuardrail("moderation")
+		require.Equal(t, ObservationTypeGuardrail, guardrail.Type)
+		guardrail.EndGuardrail(GuardrailResult{
+			Policy:         "content-safety",
+			Decision:       GuardrailDecisionBlock,
+			TriggeredRules: []string{"violence", "self-harm"},
+		})
+		require.NotNil(t, guardrail.EndTime)
+		require.Equal(t, ObservationLevelWarning, guardrail.Level)
+		require.Equal(t, "blocked by violence, self-harm", guardrail.StatusMessage)
+
+		data, err := json.Marshal(guardrail)
+		require.NoError(t, err)
+		var encoded map[string]any
+		require.NoError(t, json.Unmarshal(data, &encoded))
+		require.Equal(t, map[string]any{
+			"policy":         "content-safety",
+			"decision":       "block",
+			"triggeredRules": []any{"violence", "self-harm"},
+		}, encoded["output"])
+	})
+
+	t.Run("block with reason", func(t *testing.T) {
+		guardrail := trace.StartGuardrail("jailbreak")
+		guardrail.EndGuardrail(GuardrailResult{Decision: GuardrailDecisionBlock, Reason: "prompt injection detected"})
+		require.Equal(t, "prompt injection detected", guardrail.StatusMessage)
+	})
+
+	t.Run("modify", func(t *testing.T) {
+		guardrail := trace.StartGuardrail("pii")
+		guardrail.Input = "call me at 555-0100"
+		guardrail.EndGuardrail(GuardrailResult{
+			Decision:        GuardrailDecisionModify,
+			TriggeredRules:  []string{"phone-number"},
+			ModifiedContent: "call me at [REDACTED]",
+		})
+		require.Empty(t, guardrail.Level)
+		require.Equal(t, "call me at [REDACTED]", guardrail.Output.(GuardrailResult).ModifiedContent)
+	})
+}
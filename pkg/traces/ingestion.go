@@ -1,19 +1,25 @@
 package traces
 
 import (
+	"bytes"
 	"context"
 	crand "crypto/rand"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/gofrs/uuid/v5"
+	"go.uber.org/zap"
 
 	"github.com/git-hulk/langfuse-go/pkg/batch"
+	"github.com/git-hulk/langfuse-go/pkg/health"
+	"github.com/git-hulk/langfuse-go/pkg/logger"
 )
 
 const (
@@ -101,6 +107,16 @@ func FromSpanID(s string) (SpanID, error) {
 	return id, nil
 }
 
+// Generator generates the IDs of traces and observations, see WithIDGenerator.
+type Generator interface {
+	// NewTraceID returns the ID of a new trace. The context is the one passed to StartTrace,
+	// so upstream correlation IDs can be read from it. Sessions get their ID from NewTraceID
+	// with a background context.
+	NewTraceID(ctx context.Context) string
+	// NewSpanID returns the ID of a new observation of the trace.
+	NewSpanID(traceID string) string
+}
+
 type IDGenerator struct {
 	sync.Mutex
 	source *rand.Rand
@@ -109,12 +125,27 @@ type IDGenerator struct {
 func NewIDGenerator() *IDGenerator {
 	var seed int64
 	_ = binary.Read(crand.Reader, binary.LittleEndian, &seed)
-	source := rand.New(rand.NewSource(seed))
+	return NewSeededIDGenerator(seed)
+}
+
+// NewSeededIDGenerator creates an ID generator which always generates the same sequence
+// of IDs for the same seed, e.g. for deterministic tests.
+func NewSeededIDGenerator(seed int64) *IDGenerator {
 	return &IDGenerator{
-		source: source,
+		source: rand.New(rand.NewSource(seed)),
 	}
 }
 
+// NewTraceID implements Generator with a random 32 hex characters trace ID.
+func (g *IDGenerator) NewTraceID(_ context.Context) string {
+	return g.GenerateTraceID().String()
+}
+
+// NewSpanID implements Generator with a random 16 hex characters span ID.
+func (g *IDGenerator) NewSpanID(_ string) string {
+	return g.GenerateSpanID().String()
+}
+
 func (g *IDGenerator) GenerateTraceID() TraceID {
 	var id TraceID
 	g.Lock()
@@ -136,8 +167,20 @@ type IngestionEvent struct {
 	Timestamp time.Time `json:"timestamp,omitempty"`
 	Type      string    `json:"type,omitempty"`
 	Body      any       `json:"body,omitempty"`
+
+	// body is the encoded Body, so that the events sent by the ingestor are encoded once
+	// to split the batches and to build the request body.
+	body json.RawMessage
 }
 
+// defaultMaxBatchBytes is the default cap of the serialized size of a single
+// ingestion request, which stays below the server's maximum request size.
+const defaultMaxBatchBytes = 3 * 1024 * 1024
+
+// eventOverheadBytes approximates the serialized size of the envelope fields
+// (id, timestamp, type) that wrap each ingestion event body.
+const eventOverheadBytes = 128
+
 type IngestionError struct {
 	ID      string `json:"id,omitempty"`
 	Status  int    `json:"status,omitempty"`
@@ -146,72 +189,482 @@ type IngestionError struct {
 }
 
 type Ingestor struct {
-	restyCli    *resty.Client
-	processor   *batch.Processor[*Trace]
-	idGenerator *IDGenerator
+	restyCli         *resty.Client
+	processor        *batch.Processor[*Trace]
+	idGenerator      Generator
+	costCalculator   *CostCalculator
+	tokenizer        Tokenizer
+	maxBatchBytes    int
+	flushByBytes     bool
+	clock            Clock
+	urlBuilder       func(traceID string) string
+	batchConfig      batch.Config
+	exporters        []TraceExporter
+	exportTimeout    time.Duration
+	limits           *Limits
+	groupByTrace     bool
+	inheritedKeys    []string
+	decorators       []func(*Trace)
+	largeValues      *largeValues
+	errorHandler     func(err error)
+	results          resultRecorder
+	monitor          *healthMonitor
+	sampleRate       float64
+	samplingStrategy SamplingStrategy
+	latencyBuckets   []time.Duration
+	mediaUploader    MediaUploader
+	serializers      []serializer
+	redactor         *redactor
+	sizeBudget       int
+	loopThreshold    int
+	dryRun           *dryRun
+}
+
+// IngestorOption is a function that configures an Ingestor.
+type IngestorOption func(*Ingestor)
+
+// WithCostCalculator enables automatic cost computation for generations.
+//
+// Before a batch is sent, generations which record a model and usage but no
+// CostDetails get their cost filled in from the calculator's model pricing.
+func WithCostCalculator(calculator *CostCalculator) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.costCalculator = calculator
+	}
+}
+
+// WithMaxBatchBytes sets the maximum serialized size in bytes of a single ingestion request.
+//
+// Batches which are too large are split into multiple requests, which is always done with
+// a default cap of 3 MiB. Setting the cap also flushes the batches before their accumulated
+// size exceeds it, which encodes every trace once more when it's submitted to estimate its size.
+func WithMaxBatchBytes(maxBatchBytes int) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.maxBatchBytes = maxBatchBytes
+		ingestor.flushByBytes = maxBatchBytes > 0
+	}
+}
+
+// WithClock sets the clock used for trace and observation timestamps.
+//
+// Default is the system clock. Use a SkewCorrectedClock to correct timestamps
+// on hosts whose clock drifts from the Langfuse server clock.
+func WithClock(clock Clock) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.clock = clock
+	}
+}
+
+// WithBatchConfig sets the batching behavior of the ingestor.
+//
+// Zero fields keep their defaults, and MaxBatchBytes is ignored in favor of WithMaxBatchBytes.
+func WithBatchConfig(config batch.Config) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.batchConfig = config
+	}
+}
+
+// WithTraceDecorator adds a function which is called with every started trace after its
+// options are applied, e.g. to enforce organization-wide tags, user IDs or environments
+// without changing the application code. Decorators are called in the order they were added.
+//
+// Example:
+//
+//	ingestor := traces.NewIngestor(restyCli, traces.WithTraceDecorator(func(trace *traces.Trace) {
+//		trace.Environment = os.Getenv("DEPLOY_ENV")
+//		trace.Tags = append(trace.Tags, "team:search")
+//	}))
+func WithTraceDecorator(decorate func(*Trace)) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.decorators = append(ingestor.decorators, decorate)
+	}
+}
+
+// WithTraceEventsGrouping keeps the events of a trace in the same ingestion request when a
+// batch is split to stay within the maximum batch size, so that the observations don't
+// reach Langfuse before their trace, which makes them briefly appear without parent in the UI.
+//
+// Only a trace whose events exceed the maximum batch size on their own is still split.
+func WithTraceEventsGrouping() IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.groupByTrace = true
+	}
+}
+
+// WithIDGenerator sets the generator of the trace and observation IDs, e.g. a seeded
+// generator for deterministic tests or one which reuses upstream correlation IDs.
+// Default is a random IDGenerator.
+//
+// Example:
+//
+//	type requestIDs struct{ *traces.IDGenerator }
+//
+//	func (g requestIDs) NewTraceID(ctx context.Context) string {
+//		if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+//			return id
+//		}
+//		return g.IDGenerator.NewTraceID(ctx)
+//	}
+//
+//	ingestor := traces.NewIngestor(restyCli, traces.WithIDGenerator(requestIDs{traces.NewIDGenerator()}))
+func WithIDGenerator(generator Generator) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.idGenerator = generator
+	}
 }
 
-func NewIngestor(cli *resty.Client) *Ingestor {
+// WithTraceURLBuilder sets the function used by Trace.URL to build the link to a trace in the Langfuse UI.
+func WithTraceURLBuilder(builder func(traceID string) string) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.urlBuilder = builder
+	}
+}
+
+func NewIngestor(cli *resty.Client, options ...IngestorOption) *Ingestor {
 	collector := &Ingestor{
-		restyCli:    cli,
-		idGenerator: NewIDGenerator(),
+		restyCli:      cli,
+		idGenerator:   NewIDGenerator(),
+		maxBatchBytes: defaultMaxBatchBytes,
+		sampleRate:    1,
+	}
+	for _, option := range options {
+		option(collector)
+	}
+	if collector.idGenerator == nil {
+		collector.idGenerator = NewIDGenerator()
+	}
+	if collector.clock == nil {
+		collector.clock = SystemClock()
+	}
+	if collector.maxBatchBytes <= 0 {
+		collector.maxBatchBytes = defaultMaxBatchBytes
+	}
+	if collector.monitor != nil {
+		collector.monitor.send = collector.sendEvents
+		if collector.monitor.config.Check == nil {
+			collector.monitor.config.Check = checkHealth(health.NewClient(cli))
+		}
 	}
-	collector.processor = batch.NewProcessor[*Trace](collector)
+	collector.processor = batch.NewProcessor[*Trace](collector, collector.batchOptions()...)
 	return collector
 }
 
+func (ingestor *Ingestor) batchOptions() []batch.Option {
+	config := ingestor.batchConfig
+	options := make([]batch.Option, 0)
+	if ingestor.flushByBytes {
+		options = append(options, batch.WithMaxBatchBytes(ingestor.maxBatchBytes))
+	}
+	if config.MaxBatchSize > 0 {
+		options = append(options, batch.WithMaxBatchSize(config.MaxBatchSize))
+	}
+	if config.FlushInterval > 0 {
+		options = append(options, batch.WithFlushInterval(config.FlushInterval))
+	}
+	if config.BufferSize > 0 {
+		options = append(options, batch.WithBufferSize(config.BufferSize))
+	}
+	if config.NumWorkers > 0 {
+		options = append(options, batch.WithNumWorkers(config.NumWorkers))
+	}
+	if config.ShutdownTimeout > 0 {
+		options = append(options, batch.WithShutdownTimeout(config.ShutdownTimeout))
+	}
+	if config.MaxQueuedBytes > 0 {
+		options = append(options, batch.WithMaxQueuedBytes(config.MaxQueuedBytes, config.EvictionPolicy))
+	}
+	return options
+}
+
+// Size estimates the serialized size in bytes of the ingestion events of a trace.
+//
+// It encodes the trace, so the batch processor only calls it when WithMaxBatchBytes
+// or the MaxQueuedBytes of WithBatchConfig is set.
+func (ingestor *Ingestor) Size(trace *Trace) int {
+	size := eventOverheadBytes + jsonSize(trace)
+	for _, observation := range trace.observations {
+		size += eventOverheadBytes + jsonSize(observation)
+	}
+	return size
+}
+
+func jsonSize(v any) int {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// eventSize returns the serialized size of an event, from its encoded body if it's set.
+func eventSize(event IngestionEvent) int {
+	if event.body != nil {
+		return eventOverheadBytes + len(event.body)
+	}
+	return jsonSize(event)
+}
+
+// encodeEvents encodes the bodies of the events of a trace. The trace or observation of
+// an event whose encoding fails is sanitized and encoded again, and the event is dropped
+// if it still can't be encoded.
+func (ingestor *Ingestor) encodeEvents(events []IngestionEvent) []IngestionEvent {
+	encoded := events[:0]
+	for _, event := range events {
+		data, err := json.Marshal(event.Body)
+		if err != nil {
+			switch body := event.Body.(type) {
+			case *Trace:
+				ingestor.sanitizeTrace(body)
+			case *Observation:
+				ingestor.sanitizeObservation(body)
+			}
+			data, err = json.Marshal(event.Body)
+		}
+		if err != nil {
+			logger.Get().With(
+				zap.Error(err),
+				zap.String("event_id", event.ID),
+				zap.String("type", event.Type),
+			).Error("Dropped an ingestion event which can't be encoded as JSON")
+			continue
+		}
+		event.body = data
+		encoded = append(encoded, event)
+	}
+	return encoded
+}
+
+// encodeBatch encodes the request body of the events, reusing their encoded bodies.
+func encodeBatch(events []IngestionEvent) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"batch":[`)
+	for i, event := range events {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		body := event.body
+		if body == nil {
+			data, err := json.Marshal(event)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(data)
+			continue
+		}
+		event.Body = nil
+		envelope, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		// Splice the body into the envelope, which ends with '}'
+		buf.Write(envelope[:len(envelope)-1])
+		if len(envelope) > 2 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`"body":`)
+		buf.Write(body)
+		buf.WriteByte('}')
+	}
+	buf.WriteString("]}")
+	return buf.Bytes(), nil
+}
+
+// splitEvents splits the events into chunks whose serialized size stays within maxBytes.
+// An event which exceeds maxBytes on its own is sent in a chunk of its own.
+func splitEvents(events []IngestionEvent, maxBytes int) [][]IngestionEvent {
+	chunks := make([][]IngestionEvent, 0, 1)
+	chunk := make([]IngestionEvent, 0, len(events))
+	chunkBytes := 0
+	for _, event := range events {
+		size := eventSize(event)
+		if len(chunk) > 0 && chunkBytes+size > maxBytes {
+			chunks = append(chunks, chunk)
+			chunk = make([]IngestionEvent, 0)
+			chunkBytes = 0
+		}
+		chunk = append(chunk, event)
+		chunkBytes += size
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+func (ingestor *Ingestor) applyCosts(ctx context.Context, traces []*Trace) {
+	if ingestor.costCalculator == nil {
+		return
+	}
+	for _, trace := range traces {
+		for _, observation := range trace.observations {
+			if err := ingestor.costCalculator.Apply(ctx, observation); err != nil {
+				logger.Get().With(
+					zap.Error(err),
+					zap.String("observation_id", observation.ID),
+					zap.String("model", observation.Model),
+				).Warn("Failed to compute generation cost")
+			}
+		}
+	}
+}
+
 func (ingestor *Ingestor) TracesToEvents(traces []*Trace) []IngestionEvent {
 	events := make([]IngestionEvent, 0, len(traces))
 	for _, trace := range traces {
+		events = append(events, traceEvents(trace)...)
+	}
+	return events
+}
+
+// traceEvents returns the ingestion events of a trace, with the trace event first.
+func traceEvents(trace *Trace) []IngestionEvent {
+	events := make([]IngestionEvent, 0, len(trace.observations)+1)
+	events = append(events, IngestionEvent{
+		ID:        uuid.Must(uuid.NewV4()).String(),
+		Timestamp: trace.Timestamp,
+		Type:      IngestionCreateTrace,
+		Body:      trace,
+	})
+	for _, observation := range trace.observations {
 		events = append(events, IngestionEvent{
 			ID:        uuid.Must(uuid.NewV4()).String(),
-			Timestamp: trace.Timestamp,
-			Type:      IngestionCreateTrace,
-			Body:      trace,
+			Timestamp: observation.StartTime,
+			Type:      toIngestionType(observation.Type),
+			Body:      observation,
 		})
-		for _, observation := range trace.observations {
-			events = append(events, IngestionEvent{
-				ID:        uuid.Must(uuid.NewV4()).String(),
-				Timestamp: observation.StartTime,
-				Type:      toIngestionType(observation.Type),
-				Body:      observation,
-			})
-		}
 	}
 	return events
 }
 
+// splitTraceEvents splits the events of the traces, grouped by trace, into chunks whose
+// serialized size stays within maxBytes, without splitting the events of a trace across
+// chunks unless they exceed maxBytes on their own.
+func splitTraceEvents(grouped [][]IngestionEvent, maxBytes int) [][]IngestionEvent {
+	chunks := make([][]IngestionEvent, 0, 1)
+	chunk := make([]IngestionEvent, 0)
+	chunkBytes := 0
+	for _, events := range grouped {
+		size := 0
+		for _, event := range events {
+			size += eventSize(event)
+		}
+		if len(chunk) > 0 && chunkBytes+size > maxBytes {
+			chunks = append(chunks, chunk)
+			chunk = make([]IngestionEvent, 0)
+			chunkBytes = 0
+		}
+		if size > maxBytes {
+			chunks = append(chunks, splitEvents(events, maxBytes)...)
+			continue
+		}
+		chunk = append(chunk, events...)
+		chunkBytes += size
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
 func (ingestor *Ingestor) Send(ctx context.Context, traces []*Trace) error {
 	if len(traces) == 0 {
 		return nil
 	}
-	events := ingestor.TracesToEvents(traces)
+	ingestor.serialize(traces)
+	ingestor.redact(traces)
+	ingestor.applySizeBudget(traces)
+	ingestor.replaceLargeValues(ctx, traces)
+	// Estimate the usage first, so that the cost is computed from it
+	ingestor.estimateUsage(traces)
+	ingestor.applyCosts(ctx, traces)
+	// Each event is encoded once, and its encoding is reused to split the batch and send it
+	grouped := make([][]IngestionEvent, 0, len(traces))
+	for _, trace := range traces {
+		grouped = append(grouped, ingestor.encodeEvents(traceEvents(trace)))
+	}
+	var chunks [][]IngestionEvent
+	if ingestor.groupByTrace {
+		chunks = splitTraceEvents(grouped, ingestor.maxBatchBytes)
+	} else {
+		chunks = splitEvents(slices.Concat(grouped...), ingestor.maxBatchBytes)
+	}
+
+	send := ingestor.sender()
+	var errs []error
+	for _, chunk := range chunks {
+		if err := send(ctx, chunk); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	// The exporters run after the send, so that a slow backend doesn't delay Langfuse
+	ingestor.export(ctx, traces)
+	return errors.Join(errs...)
+}
+
+// sender returns the function which sends a chunk of events, through the health monitor if it's enabled.
+func (ingestor *Ingestor) sender() func(ctx context.Context, events []IngestionEvent) error {
+	if ingestor.monitor != nil {
+		return ingestor.monitor.sendEvents
+	}
+	return ingestor.sendEvents
+}
+
+func (ingestor *Ingestor) sendEvents(ctx context.Context, events []IngestionEvent) error {
+	result, err := ingestor.postEvents(ctx, events)
+	ingestor.results.record(result, err)
+	if err != nil && ingestor.errorHandler != nil {
+		ingestor.errorHandler(err)
+	}
+	return err
+}
+
+// postEvents sends the events and parses the outcome of each event from the response.
+func (ingestor *Ingestor) postEvents(ctx context.Context, events []IngestionEvent) (*IngestionResult, error) {
+	if ingestor.dryRun != nil {
+		return ingestor.dryRun.write(events)
+	}
+	body, err := encodeBatch(events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ingestion events: %w", err)
+	}
 	rsp, err := ingestor.restyCli.R().
 		SetContext(ctx).
-		SetBody(map[string]any{"batch": events}).
+		SetHeader("Content-Type", "application/json").
+		SetBody(body).
 		Post("/ingestion")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var ingestResponse struct {
-		Errors []IngestionError `json:"errors"`
-	}
-	if err := json.Unmarshal(rsp.Body(), &ingestResponse); err != nil {
-		return fmt.Errorf("failed to unmarshal ingestion response: %w", err)
-	}
-	if len(ingestResponse.Errors) > 0 {
-		return fmt.Errorf("ingestion errors: %v", ingestResponse.Errors)
+	result := &IngestionResult{StatusCode: rsp.StatusCode()}
+	if err := json.Unmarshal(rsp.Body(), result); err != nil {
+		if rsp.IsError() {
+			return result, &IngestionFailure{Result: *result, Body: rsp.String()}
+		}
+		return result, fmt.Errorf("failed to unmarshal ingestion response: %w", err)
 	}
-	if rsp.IsError() {
-		return fmt.Errorf("send traces got unexpected status code: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	if len(result.Errors) > 0 || rsp.IsError() {
+		return result, &IngestionFailure{Result: *result, Body: rsp.String()}
 	}
-	return nil
+	return result, nil
 }
 
-func (ingestor *Ingestor) StartTrace(_ context.Context, name string) *Trace {
-	traceID := ingestor.idGenerator.GenerateTraceID().String()
-	return ingestor.withTraceID(traceID, name)
+// StartTrace creates a new trace with the given name.
+//
+// The trace can be fully described at creation with TraceOption values like
+// WithUser, WithSession, WithTags and WithInput. Whether the trace is sampled is
+// decided from the context, see WithSampleRate.
+func (ingestor *Ingestor) StartTrace(ctx context.Context, name string, options ...TraceOption) *Trace {
+	traceID := ingestor.idGenerator.NewTraceID(ctx)
+	trace := ingestor.withTraceID(traceID, name)
+	ingestor.sample(ctx, trace)
+	for _, option := range options {
+		option(&trace.TraceEntry)
+	}
+	for _, decorate := range ingestor.decorators {
+		decorate(trace)
+	}
+	return trace
 }
 
 func (ingestor *Ingestor) withTraceID(id, name string) *Trace {
@@ -221,7 +674,7 @@ func (ingestor *Ingestor) withTraceID(id, name string) *Trace {
 		TraceEntry: TraceEntry{
 			ID:        id,
 			Name:      name,
-			Timestamp: time.Now(),
+			Timestamp: ingestor.clock.Now(),
 		},
 	}
 }
@@ -230,6 +683,26 @@ func (ingestor *Ingestor) Flush() {
 	ingestor.processor.Flush()
 }
 
+// QueueStats returns the statistics of the traces which have been ended but not sent yet,
+// the records of the stats are traces. The batches queued by the health monitor are not included.
+func (ingestor *Ingestor) QueueStats() batch.QueueStats {
+	return ingestor.processor.QueueStats()
+}
+
+// FlushContext sends all buffered traces and waits until they have been sent, or the context is done.
+// It returns ErrPaused if the ingestion is paused.
+func (ingestor *Ingestor) FlushContext(ctx context.Context) error {
+	if ingestor.Paused() {
+		return ErrPaused
+	}
+	return ingestor.processor.FlushContext(ctx)
+}
+
 func (ingestor *Ingestor) Close() error {
-	return ingestor.processor.Close()
+	ingestor.Resume()
+	err := ingestor.processor.Close()
+	if ingestor.monitor != nil {
+		ingestor.monitor.stop()
+	}
+	return err
 }
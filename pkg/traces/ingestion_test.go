@@ -2,8 +2,14 @@ package traces
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -11,6 +17,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/git-hulk/langfuse-go/pkg/batch"
 )
 
 func TestFromTraceID(t *testing.T) {
@@ -195,6 +203,41 @@ func TestIngestor_StartTrace_UniqueIDs(t *testing.T) {
 	logger.Info("successfully generated unique trace IDs via ingestor", zap.Int("count", 100))
 }
 
+type correlationKey struct{}
+
+type correlationIDGenerator struct {
+	*IDGenerator
+	spans int
+}
+
+func (g *correlationIDGenerator) NewTraceID(ctx context.Context) string {
+	if id, ok := ctx.Value(correlationKey{}).(string); ok {
+		return id
+	}
+	return g.IDGenerator.NewTraceID(ctx)
+}
+
+func (g *correlationIDGenerator) NewSpanID(traceID string) string {
+	g.spans++
+	return fmt.Sprintf("%s-%d", traceID, g.spans)
+}
+
+func TestIngestor_WithIDGenerator(t *testing.T) {
+	generator := &correlationIDGenerator{IDGenerator: NewSeededIDGenerator(1)}
+	ingestor := NewIngestor(resty.New(), WithIDGenerator(generator))
+
+	ctx := context.WithValue(context.Background(), correlationKey{}, "req-123")
+	trace := ingestor.StartTrace(ctx, "handler")
+	require.Equal(t, "req-123", trace.ID)
+	require.Equal(t, "req-123-1", trace.StartSpan("query").ID)
+	require.Equal(t, "req-123-2", trace.StartGeneration("llm").ID)
+
+	// Falls back to the seeded generator, which is deterministic
+	other := ingestor.StartTrace(context.Background(), "handler")
+	require.Equal(t, NewSeededIDGenerator(1).NewTraceID(context.Background()), other.ID)
+	require.Len(t, ingestor.StartSession("").ID, 32)
+}
+
 func TestIngestor_Send(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	defer logger.Sync()
@@ -262,3 +305,159 @@ func TestIngestor_Send(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitEvents(t *testing.T) {
+	events := []IngestionEvent{
+		{ID: "1", Type: IngestionCreateTrace, Body: strings.Repeat("a", 100)},
+		{ID: "2", Type: IngestionCreateSpan, Body: strings.Repeat("b", 100)},
+		{ID: "3", Type: IngestionCreateSpan, Body: strings.Repeat("c", 1000)},
+		{ID: "4", Type: IngestionCreateSpan, Body: strings.Repeat("d", 10)},
+	}
+	eventSize := jsonSize(events[0])
+
+	chunks := splitEvents(events, 2*eventSize)
+	require.Len(t, chunks, 3)
+	require.Len(t, chunks[0], 2)
+	require.Equal(t, "3", chunks[1][0].ID)
+	require.Equal(t, "4", chunks[2][0].ID)
+
+	require.Len(t, splitEvents(events, 1<<20), 1)
+	require.Empty(t, splitEvents(nil, 1<<20))
+}
+
+func TestSplitTraceEvents(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	defer ingestor.Close()
+
+	newTrace := func(name string, spans int) *Trace {
+		trace := ingestor.StartTrace(context.Background(), name)
+		for i := 0; i < spans; i++ {
+			trace.StartSpan("span").Input = strings.Repeat("x", 100)
+		}
+		return trace
+	}
+	small, medium, large := newTrace("small", 1), newTrace("medium", 2), newTrace("large", 8)
+	maxBytes := ingestor.Size(medium) + ingestor.Size(small)/2
+
+	grouped := make([][]IngestionEvent, 0, 3)
+	for _, trace := range []*Trace{small, medium, large} {
+		grouped = append(grouped, ingestor.encodeEvents(traceEvents(trace)))
+	}
+
+	chunks := splitTraceEvents(grouped, maxBytes)
+	// The medium trace doesn't fit next to the small one, and the large one is split
+	require.Greater(t, len(chunks), 3)
+	require.Len(t, chunks[0], 2)
+	require.Equal(t, small, chunks[0][0].Body)
+	require.Len(t, chunks[1], 3)
+	require.Equal(t, medium, chunks[1][0].Body)
+	require.Equal(t, large, chunks[2][0].Body)
+
+	require.Len(t, splitTraceEvents(grouped, 1<<20), 1)
+	require.Empty(t, splitTraceEvents(nil, 1<<20))
+}
+
+func TestEncodeBatch(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	defer ingestor.Close()
+
+	trace := ingestor.StartTrace(context.Background(), "test-trace")
+	trace.Input = map[string]any{"score": math.NaN(), "text": "hello"}
+	trace.StartSpan("test-span").Input = "x"
+	events := ingestor.encodeEvents(traceEvents(trace))
+	require.Len(t, events, 2)
+	// The trace which can't be encoded is sanitized
+	require.Equal(t, sanitizedNaN, trace.Input.(map[string]any)["score"])
+	events = append(events, IngestionEvent{ID: "raw", Type: IngestionCreateEvent, Body: map[string]any{"name": "raw"}})
+
+	body, err := encodeBatch(events)
+	require.NoError(t, err)
+	expected, err := json.Marshal(map[string]any{"batch": events})
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(body))
+}
+
+func TestIngestor_Size(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	trace := ingestor.StartTrace(context.Background(), "test-trace")
+	emptySize := ingestor.Size(trace)
+	require.Greater(t, emptySize, 0)
+
+	span := trace.StartSpan("test-span")
+	span.Input = strings.Repeat("x", 1000)
+	require.Greater(t, ingestor.Size(trace), emptySize+1000)
+}
+
+func TestIngestor_SizeOptIn(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		options   []IngestorOption
+		wantBytes bool
+	}{
+		{name: "default", options: nil, wantBytes: false},
+		{name: "max batch bytes", options: []IngestorOption{WithMaxBatchBytes(1 << 20)}, wantBytes: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			options := append([]IngestorOption{WithBatchConfig(batch.Config{FlushInterval: time.Hour})}, tt.options...)
+			ingestor := NewIngestor(resty.New(), options...)
+			defer ingestor.Close()
+
+			// The submitted traces are only encoded to estimate their size when it's needed
+			trace := ingestor.StartTrace(context.Background(), "test-trace")
+			require.NoError(t, trace.submit())
+			require.Equal(t, tt.wantBytes, ingestor.QueueStats().Bytes > 0)
+		})
+	}
+}
+
+func TestIngestor_QueueStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL), WithBatchConfig(batch.Config{
+		FlushInterval:  time.Hour,
+		MaxQueuedBytes: 4096,
+	}))
+	defer ingestor.Close()
+	ctx := context.Background()
+
+	trace := ingestor.StartTrace(ctx, "small")
+	require.NoError(t, trace.submit())
+	stats := ingestor.QueueStats()
+	require.Equal(t, 1, stats.Records)
+	require.Equal(t, ingestor.Size(trace), stats.Bytes)
+
+	large := ingestor.StartTrace(ctx, "large")
+	large.Input = strings.Repeat("x", 4096)
+	require.ErrorIs(t, large.submit(), batch.ErrQueueFull)
+	require.Equal(t, 1, ingestor.QueueStats().Rejected)
+
+	require.NoError(t, ingestor.FlushContext(ctx))
+	require.Equal(t, batch.QueueStats{Rejected: 1}, ingestor.QueueStats())
+}
+
+func TestIngestor_Send_SplitsOversizeBatch(t *testing.T) {
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(body), 4096)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"successes":[],"errors":[]}`))
+	}))
+	defer server.Close()
+
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL), WithMaxBatchBytes(4000))
+	trace := ingestor.StartTrace(context.Background(), "test-trace")
+	for i := 0; i < 3; i++ {
+		span := trace.StartSpan("test-span")
+		span.Input = strings.Repeat("x", 3000)
+		span.End()
+	}
+
+	require.NoError(t, ingestor.Send(context.Background(), []*Trace{trace}))
+	require.Equal(t, int32(3), requestCount.Load())
+}
@@ -0,0 +1,214 @@
+package traces
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/git-hulk/langfuse-go/pkg/logger"
+)
+
+// DefaultPreviewBytes is the default size of the preview kept in place of a large value.
+const DefaultPreviewBytes = 1024
+
+// Markers around a compressed value, see CompressLargeValues.
+const (
+	compressedPrefix = "@@@langfuseGoGzip:"
+	compressedSuffix = "@@@"
+)
+
+// LargeValue is a string of the input or output of a trace or observation which exceeds
+// the size limit of WithLargeValues.
+type LargeValue struct {
+	TraceID string
+	// ObservationID is empty for the values of the trace itself.
+	ObservationID string
+	// Field is either "input" or "output".
+	Field string
+	Value string
+}
+
+// LargeValueHandler replaces a large value by a smaller string before it's sent, e.g. a
+// preview with a compressed copy or a reference to the value uploaded as media.
+type LargeValueHandler interface {
+	HandleLargeValue(ctx context.Context, value LargeValue) (string, error)
+}
+
+// LargeValueHandlerFunc is an adapter to use a function as a LargeValueHandler.
+type LargeValueHandlerFunc func(ctx context.Context, value LargeValue) (string, error)
+
+// HandleLargeValue implements LargeValueHandler.
+func (f LargeValueHandlerFunc) HandleLargeValue(ctx context.Context, value LargeValue) (string, error) {
+	return f(ctx, value)
+}
+
+type largeValues struct {
+	maxBytes int
+	handler  LargeValueHandler
+}
+
+// WithLargeValues replaces the strings of the inputs and outputs which are larger than
+// maxBytes with the result of the handler before they're sent, so that a single huge
+// trace doesn't exceed the request size limit and fail its whole batch.
+//
+// The input and output are searched for large strings if they're a string, a []any or
+// a map[string]any, recursively. If the handler fails, the value is truncated to a preview.
+//
+// Example:
+//
+//	ingestor := traces.NewIngestor(restyCli, traces.WithLargeValues(256*1024, traces.CompressLargeValues(0)))
+func WithLargeValues(maxBytes int, handler LargeValueHandler) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.largeValues = &largeValues{maxBytes: maxBytes, handler: handler}
+	}
+}
+
+// CompressLargeValues returns a handler which replaces a large value by a preview of its
+// first previewBytes bytes followed by the gzip-compressed and base64-encoded value, so
+// the Langfuse UI still shows the preview. If previewBytes is not positive, it defaults
+// to DefaultPreviewBytes. Use ExpandLargeValue to restore the value.
+func CompressLargeValues(previewBytes int) LargeValueHandler {
+	if previewBytes <= 0 {
+		previewBytes = DefaultPreviewBytes
+	}
+	return LargeValueHandlerFunc(func(_ context.Context, value LargeValue) (string, error) {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write([]byte(value.Value)); err != nil {
+			return "", err
+		}
+		if err := writer.Close(); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s\n\n%s%s%s", Preview(value.Value, previewBytes),
+			compressedPrefix, base64.StdEncoding.EncodeToString(buf.Bytes()), compressedSuffix), nil
+	})
+}
+
+// ExpandLargeValue restores a value replaced by CompressLargeValues. Other values are
+// returned unchanged.
+func ExpandLargeValue(value string) (string, error) {
+	start := strings.LastIndex(value, compressedPrefix)
+	if start < 0 || !strings.HasSuffix(value, compressedSuffix) {
+		return value, nil
+	}
+	encoded := value[start+len(compressedPrefix) : len(value)-len(compressedSuffix)]
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode compressed value: %w", err)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress value: %w", err)
+	}
+	defer reader.Close()
+	expanded, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress value: %w", err)
+	}
+	return string(expanded), nil
+}
+
+// Preview returns the first maxBytes bytes of the value, without splitting a UTF-8
+// character, followed by a note of the omitted size.
+func Preview(value string, maxBytes int) string {
+	if len(value) <= maxBytes {
+		return value
+	}
+	end := maxBytes
+	for end > 0 && !utf8.RuneStart(value[end]) {
+		end--
+	}
+	return fmt.Sprintf("%s… [%d of %d bytes]", value[:end], end, len(value))
+}
+
+// replaceLargeValues replaces the large strings of the inputs and outputs of the traces.
+func (ingestor *Ingestor) replaceLargeValues(ctx context.Context, traces []*Trace) {
+	if ingestor.largeValues == nil || ingestor.largeValues.maxBytes <= 0 {
+		return
+	}
+	for _, trace := range traces {
+		trace.Input, _ = ingestor.replaceLargeValue(ctx, LargeValue{TraceID: trace.ID, Field: "input"}, trace.Input)
+		trace.Output, _ = ingestor.replaceLargeValue(ctx, LargeValue{TraceID: trace.ID, Field: "output"}, trace.Output)
+		for _, observation := range trace.observations {
+			large := LargeValue{TraceID: trace.ID, ObservationID: observation.ID, Field: "input"}
+			observation.Input, _ = ingestor.replaceLargeValue(ctx, large, observation.Input)
+			large.Field = "output"
+			observation.Output, _ = ingestor.replaceLargeValue(ctx, large, observation.Output)
+		}
+	}
+}
+
+// replaceLargeValue returns the value with its large strings replaced, and whether any was.
+// Maps and slices are copied when one of their strings is replaced, so that the caller's
+// values are kept.
+func (ingestor *Ingestor) replaceLargeValue(ctx context.Context, large LargeValue, value any) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		if len(v) <= ingestor.largeValues.maxBytes {
+			return v, false
+		}
+		large.Value = v
+		return ingestor.handleLargeValue(ctx, large), true
+	case map[string]any:
+		var copied map[string]any
+		for key, item := range v {
+			newItem, replaced := ingestor.replaceLargeValue(ctx, large, item)
+			if !replaced {
+				continue
+			}
+			if copied == nil {
+				copied = maps.Clone(v)
+			}
+			copied[key] = newItem
+		}
+		if copied == nil {
+			return v, false
+		}
+		return copied, true
+	case []any:
+		var copied []any
+		for i, item := range v {
+			newItem, replaced := ingestor.replaceLargeValue(ctx, large, item)
+			if !replaced {
+				continue
+			}
+			if copied == nil {
+				copied = slices.Clone(v)
+			}
+			copied[i] = newItem
+		}
+		if copied == nil {
+			return v, false
+		}
+		return copied, true
+	}
+	return value, false
+}
+
+func (ingestor *Ingestor) handleLargeValue(ctx context.Context, large LargeValue) string {
+	handler := ingestor.largeValues.handler
+	if handler != nil {
+		replaced, err := handler.HandleLargeValue(ctx, large)
+		if err == nil {
+			return replaced
+		}
+		logger.Get().With(
+			zap.Error(err),
+			zap.String("trace_id", large.TraceID),
+			zap.String("observation_id", large.ObservationID),
+			zap.String("field", large.Field),
+			zap.Int("bytes", len(large.Value)),
+		).Warn("Failed to handle large value, truncating it")
+	}
+	return Preview(large.Value, min(DefaultPreviewBytes, ingestor.largeValues.maxBytes))
+}
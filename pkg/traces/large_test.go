@@ -0,0 +1,83 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressLargeValues(t *testing.T) {
+	value := strings.Repeat("héllo wörld ", 1000)
+	replaced, err := CompressLargeValues(16).HandleLargeValue(context.Background(), LargeValue{Value: value})
+	require.NoError(t, err)
+	require.Less(t, len(replaced), len(value)/10)
+	require.True(t, strings.HasPrefix(replaced, "héllo wörld h… [15 of 14000 bytes]\n\n@@@langfuseGoGzip:"))
+
+	expanded, err := ExpandLargeValue(replaced)
+	require.NoError(t, err)
+	require.Equal(t, value, expanded)
+
+	expanded, err = ExpandLargeValue("plain")
+	require.NoError(t, err)
+	require.Equal(t, "plain", expanded)
+}
+
+func TestPreview(t *testing.T) {
+	require.Equal(t, "short", Preview("short", 10))
+	// Doesn't split the two bytes of "é"
+	require.Equal(t, "ab… [2 of 5 bytes]", Preview("abéd", 3))
+}
+
+func TestIngestor_Send_LargeValues(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(`{"successes": [], "errors": []}`))
+	}))
+	defer server.Close()
+
+	var handled []LargeValue
+	handler := LargeValueHandlerFunc(func(_ context.Context, value LargeValue) (string, error) {
+		handled = append(handled, value)
+		if value.Field == "output" {
+			return "", errors.New("upload failed")
+		}
+		return "replaced", nil
+	})
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL), WithLargeValues(10, handler))
+
+	large := strings.Repeat("x", 20)
+	messages := []any{map[string]any{"role": "user", "content": large}, "small"}
+	trace := ingestor.StartTrace(context.Background(), "handler")
+	trace.Input = messages
+	generation := trace.StartGeneration("llm")
+	generation.Output = large
+	generation.Input = "small"
+
+	require.NoError(t, ingestor.Send(context.Background(), []*Trace{trace}))
+	require.Equal(t, []LargeValue{
+		{TraceID: trace.ID, Field: "input", Value: large},
+		{TraceID: trace.ID, ObservationID: generation.ID, Field: "output", Value: large},
+	}, handled)
+	// The caller's values are kept
+	require.Equal(t, large, messages[0].(map[string]any)["content"])
+
+	var request struct {
+		Batch []struct {
+			Body map[string]any `json:"body"`
+		} `json:"batch"`
+	}
+	require.NoError(t, json.Unmarshal(body, &request))
+	require.Equal(t, []any{map[string]any{"role": "user", "content": "replaced"}, "small"}, request.Batch[0].Body["input"])
+	// The failed value is truncated to a preview
+	require.Equal(t, "xxxxxxxxxx… [10 of 20 bytes]", request.Batch[1].Body["output"])
+	require.Equal(t, "small", request.Batch[1].Body["input"])
+}
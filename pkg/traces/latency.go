@@ -0,0 +1,53 @@
+package traces
+
+import (
+	"slices"
+	"time"
+)
+
+// MetadataLatencyBucketKey is the metadata key of the latency bucket of an observation,
+// see WithLatencyBuckets.
+const MetadataLatencyBucketKey = "latency_bucket"
+
+// WithLatencyBuckets adds the latency bucket of every observation to its metadata under
+// MetadataLatencyBucketKey when it ends, e.g. "<=500ms" or ">2s", so the observations can
+// be filtered and grouped by latency in the UI. The bounds are sorted, and an observation
+// falls into the first bucket whose bound isn't exceeded by its latency.
+//
+// Example:
+//
+//	ingestor := traces.NewIngestor(restyCli,
+//		traces.WithLatencyBuckets(100*time.Millisecond, 500*time.Millisecond, 2*time.Second))
+func WithLatencyBuckets(bounds ...time.Duration) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.latencyBuckets = slices.Sorted(slices.Values(bounds))
+	}
+}
+
+// LatencyBucket returns the label of the bucket of the latency, see WithLatencyBuckets.
+func LatencyBucket(latency time.Duration, bounds []time.Duration) string {
+	if len(bounds) == 0 {
+		return ""
+	}
+	for _, bound := range bounds {
+		if latency <= bound {
+			return "<=" + bound.String()
+		}
+	}
+	return ">" + bounds[len(bounds)-1].String()
+}
+
+// setEndTime ends the observation at the given time, and computes its latency like
+// the latency of the trace.
+func (o *Observation) setEndTime(end time.Time) {
+	o.EndTime = &end
+	latency := end.Sub(o.StartTime)
+	o.Latency = latency.Milliseconds()
+	if o.trace == nil || o.trace.ingestor == nil || len(o.trace.ingestor.latencyBuckets) == 0 {
+		return
+	}
+	// The metadata is left as is if it isn't a map
+	_ = o.MergeMetadata(map[string]any{
+		MetadataLatencyBucketKey: LatencyBucket(latency, o.trace.ingestor.latencyBuckets),
+	})
+}
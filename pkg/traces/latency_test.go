@@ -0,0 +1,41 @@
+package traces
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyBucket(t *testing.T) {
+	bounds := []time.Duration{100 * time.Millisecond, 500 * time.Millisecond, 2 * time.Second}
+	require.Equal(t, "<=100ms", LatencyBucket(50*time.Millisecond, bounds))
+	require.Equal(t, "<=500ms", LatencyBucket(500*time.Millisecond, bounds))
+	require.Equal(t, ">2s", LatencyBucket(3*time.Second, bounds))
+	require.Empty(t, LatencyBucket(time.Second, nil))
+}
+
+func TestObservation_Latency(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: start}
+	ingestor := NewIngestor(resty.New(), WithClock(clock),
+		WithLatencyBuckets(2*time.Second, 100*time.Millisecond, 500*time.Millisecond))
+	defer ingestor.Close()
+
+	trace := ingestor.StartTrace(context.Background(), "chat")
+	span := trace.StartSpan("retrieve")
+	generation := trace.StartGeneration("llm")
+	clock.now = start.Add(300 * time.Millisecond)
+	span.End()
+	require.Equal(t, int64(300), span.Latency)
+	require.Equal(t, map[string]any{MetadataLatencyBucketKey: "<=500ms"}, span.Metadata)
+
+	// Observations which are still open are ended with the trace
+	clock.now = start.Add(2500 * time.Millisecond)
+	trace.EndAll()
+	require.Equal(t, int64(2500), generation.Latency)
+	require.Equal(t, map[string]any{MetadataLatencyBucketKey: ">2s"}, generation.Metadata)
+	require.Equal(t, trace.Latency, generation.Latency)
+}
@@ -0,0 +1,92 @@
+package traces
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/git-hulk/langfuse-go/pkg/logger"
+)
+
+// ErrLimitExceeded is wrapped by the errors of traces which exceed the configured Limits.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// Limits are client-side limits of the names and tags of traces and observations, which
+// surface the values that the server would truncate or reject during development.
+// Lengths are counted in characters, and zero fields are not checked.
+type Limits struct {
+	// MaxNameLength limits the length of the trace and observation names.
+	MaxNameLength int
+	// MaxTags limits the number of tags of a trace.
+	MaxTags int
+	// MaxTagLength limits the length of each tag.
+	MaxTagLength int
+	// Strict rejects the traces exceeding the limits. By default, a warning is logged
+	// and the traces are sent anyway.
+	Strict bool
+}
+
+// WithLimits checks the names and tags of the traces against the limits when they end.
+//
+// In strict mode, Trace.End logs the failed check as an error and drops the trace, and
+// Trace.EndAndFlush returns it.
+func WithLimits(limits Limits) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.limits = &limits
+	}
+}
+
+// Check returns the violations of the limits by the trace and its observations, joined
+// in one error wrapping ErrLimitExceeded, or nil if the trace is within the limits.
+func (l *Limits) Check(trace *Trace) error {
+	var errs []error
+	if err := l.checkName("trace", trace.Name); err != nil {
+		errs = append(errs, err)
+	}
+	if l.MaxTags > 0 && len(trace.Tags) > l.MaxTags {
+		errs = append(errs, fmt.Errorf("trace has %d tags, more than %d: %w", len(trace.Tags), l.MaxTags, ErrLimitExceeded))
+	}
+	if l.MaxTagLength > 0 {
+		for _, tag := range trace.Tags {
+			if n := utf8.RuneCountInString(tag); n > l.MaxTagLength {
+				errs = append(errs, fmt.Errorf("tag %q has %d characters, more than %d: %w", tag, n, l.MaxTagLength, ErrLimitExceeded))
+			}
+		}
+	}
+	for _, observation := range trace.observations {
+		if err := l.checkName("observation", observation.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (l *Limits) checkName(kind, name string) error {
+	if l.MaxNameLength <= 0 {
+		return nil
+	}
+	if n := utf8.RuneCountInString(name); n > l.MaxNameLength {
+		return fmt.Errorf("%s name %q has %d characters, more than %d: %w", kind, name, n, l.MaxNameLength, ErrLimitExceeded)
+	}
+	return nil
+}
+
+// checkLimits checks the trace against the limits of the ingestor, and returns the
+// violations only in strict mode, otherwise they're logged as a warning.
+func (ingestor *Ingestor) checkLimits(trace *Trace) error {
+	if ingestor.limits == nil {
+		return nil
+	}
+	err := ingestor.limits.Check(trace)
+	if err == nil || ingestor.limits.Strict {
+		return err
+	}
+	logger.Get().With(
+		zap.Error(err),
+		zap.String("trace_id", trace.ID),
+		zap.String("trace_name", trace.Name),
+	).Warn("Trace exceeds the configured limits")
+	return nil
+}
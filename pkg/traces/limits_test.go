@@ -0,0 +1,45 @@
+package traces
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimits_Check(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	defer ingestor.Close()
+
+	limits := &Limits{MaxNameLength: 5, MaxTags: 2, MaxTagLength: 3}
+	trace := ingestor.StartTrace(context.Background(), "chat", WithTags("a", "b"))
+	trace.StartSpan("retrieve")
+	require.ErrorIs(t, limits.Check(trace), ErrLimitExceeded)
+	require.ErrorContains(t, limits.Check(trace), `observation name "retrieve" has 8 characters, more than 5`)
+
+	trace = ingestor.StartTrace(context.Background(), "日本語のトレース名", WithTags("a", "b", "long"))
+	err := limits.Check(trace)
+	require.ErrorContains(t, err, "has 9 characters, more than 5")
+	require.ErrorContains(t, err, "trace has 3 tags, more than 2")
+	require.ErrorContains(t, err, `tag "long" has 4 characters, more than 3`)
+
+	trace = ingestor.StartTrace(context.Background(), "ok", WithTags("a"))
+	trace.StartSpan("step")
+	require.NoError(t, limits.Check(trace))
+	require.NoError(t, (&Limits{}).Check(trace))
+}
+
+func TestIngestor_WithLimits(t *testing.T) {
+	strict := NewIngestor(resty.New(), WithLimits(Limits{MaxNameLength: 10, Strict: true}))
+	defer strict.Close()
+	trace := strict.StartTrace(context.Background(), strings.Repeat("x", 11))
+	err := trace.EndAndFlush(context.Background())
+	require.ErrorIs(t, err, ErrLimitExceeded)
+
+	lenient := NewIngestor(resty.New(), WithLimits(Limits{MaxNameLength: 10}))
+	defer lenient.Close()
+	trace = lenient.StartTrace(context.Background(), strings.Repeat("x", 11))
+	require.NoError(t, lenient.checkLimits(trace))
+}
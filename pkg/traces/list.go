@@ -0,0 +1,164 @@
+package traces
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+)
+
+// ListParams defines the query parameters for filtering and paginating trace listings.
+//
+// All non-empty filters are combined, and Tags matches the traces which include all
+// of the given tags. Fields selects the field groups of the listed traces, e.g. "core"
+// to skip the input, output and metadata, all fields are listed if empty.
+// Page and Limit control pagination.
+type ListParams struct {
+	Page          int
+	Limit         int
+	UserID        string
+	Name          string
+	SessionID     string
+	Tags          []string
+	Environment   []string
+	Version       string
+	Release       string
+	FromTimestamp time.Time
+	ToTimestamp   time.Time
+	OrderBy       string
+	Fields        string
+
+	pagination common.Pagination
+}
+
+// ToQueryString converts the ListParams to a URL query string.
+func (p *ListParams) ToQueryString() string {
+	parts := make([]string, 0)
+	if p.pagination.HasPage(p.Page) {
+		parts = append(parts, "page="+strconv.Itoa(p.Page))
+	}
+	if p.pagination.HasLimit(p.Limit) {
+		parts = append(parts, "limit="+strconv.Itoa(p.Limit))
+	}
+	if p.UserID != "" {
+		parts = append(parts, "userId="+url.QueryEscape(p.UserID))
+	}
+	if p.Name != "" {
+		parts = append(parts, "name="+url.QueryEscape(p.Name))
+	}
+	if p.SessionID != "" {
+		parts = append(parts, "sessionId="+url.QueryEscape(p.SessionID))
+	}
+	for _, tag := range p.Tags {
+		if tag != "" {
+			parts = append(parts, "tags="+url.QueryEscape(tag))
+		}
+	}
+	for _, env := range p.Environment {
+		if env != "" {
+			parts = append(parts, "environment="+url.QueryEscape(env))
+		}
+	}
+	if p.Version != "" {
+		parts = append(parts, "version="+url.QueryEscape(p.Version))
+	}
+	if p.Release != "" {
+		parts = append(parts, "release="+url.QueryEscape(p.Release))
+	}
+	if !p.FromTimestamp.IsZero() {
+		parts = append(parts, "fromTimestamp="+url.QueryEscape(p.FromTimestamp.Format(time.RFC3339)))
+	}
+	if !p.ToTimestamp.IsZero() {
+		parts = append(parts, "toTimestamp="+url.QueryEscape(p.ToTimestamp.Format(time.RFC3339)))
+	}
+	if p.OrderBy != "" {
+		parts = append(parts, "orderBy="+url.QueryEscape(p.OrderBy))
+	}
+	if p.Fields != "" {
+		parts = append(parts, "fields="+url.QueryEscape(p.Fields))
+	}
+	return strings.Join(parts, "&")
+}
+
+// SetPage sets the page number, even if it is 0.
+func (p *ListParams) SetPage(page int) {
+	p.Page = page
+	p.pagination.MarkPage()
+}
+
+// SetLimit sets the page size, even if it is 0.
+func (p *ListParams) SetLimit(limit int) {
+	p.Limit = limit
+	p.pagination.MarkLimit()
+}
+
+// ListOption configures ListParams, see common.ListOption.
+type ListOption = common.ListOption[ListParams]
+
+// NewListParams creates ListParams for listing traces from the provided options.
+func NewListParams(options ...ListOption) ListParams {
+	return common.NewListParams(options...)
+}
+
+// WithPage sets the page number, even if it is 0.
+func WithPage(page int) ListOption {
+	return common.WithPage[ListParams](page)
+}
+
+// WithLimit sets the page size, even if it is 0.
+func WithLimit(limit int) ListOption {
+	return common.WithLimit[ListParams](limit)
+}
+
+// WithTimeRange sets FromTimestamp and ToTimestamp from the time window, e.g.
+// common.LastHours(24) or common.Today().
+func WithTimeRange(r common.TimeRange) ListOption {
+	return func(p *ListParams) {
+		p.FromTimestamp = r.From
+		p.ToTimestamp = r.To
+	}
+}
+
+// ListTraces represents the paginated response from the list traces API.
+type ListTraces struct {
+	Metadata common.ListMetadata `json:"meta"`
+	Data     []TraceDetails      `json:"data"`
+}
+
+// List retrieves a list of stored traces based on the provided parameters.
+func (c *Client) List(ctx context.Context, params ListParams) (*ListTraces, error) {
+	var listResponse ListTraces
+	rsp, err := c.restyCli.R().
+		SetContext(ctx).
+		SetResult(&listResponse).
+		SetQueryString(params.ToQueryString()).
+		Get("/traces")
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.IsError() {
+		return nil, fmt.Errorf("list traces failed: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	}
+	return &listResponse, nil
+}
+
+// ListAll retrieves the traces of all pages matching the parameters, fetching the pages in parallel.
+//
+// The Page of the parameters is ignored, and Limit sets the page size. Use the common.FetchOption
+// values to configure the number of workers, or to keep the results in page order.
+func (c *Client) ListAll(ctx context.Context, params ListParams, options ...common.FetchOption) ([]TraceDetails, error) {
+	return common.FetchAll(ctx, func(ctx context.Context, page int) ([]TraceDetails, common.ListMetadata, error) {
+		pageParams := params
+		pageParams.Page = page
+		listTraces, err := c.List(ctx, pageParams)
+		if err != nil {
+			return nil, common.ListMetadata{}, err
+		}
+		return listTraces.Data, listTraces.Metadata, nil
+	}, options...)
+}
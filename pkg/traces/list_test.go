@@ -0,0 +1,69 @@
+package traces
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
+)
+
+func TestListParams_ToQueryString(t *testing.T) {
+	require.Empty(t, (&ListParams{}).ToQueryString())
+
+	params := ListParams{
+		Page:          2,
+		Limit:         50,
+		UserID:        "user 1",
+		SessionID:     "session-1",
+		Tags:          []string{"prod", ""},
+		Environment:   []string{"production"},
+		FromTimestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Fields:        "core",
+	}
+	require.Equal(t, "page=2&limit=50&userId=user+1&sessionId=session-1&tags=prod&environment=production"+
+		"&fromTimestamp=2025-01-01T00%3A00%3A00Z&fields=core", params.ToQueryString())
+
+	params = NewListParams(WithPage(0), WithLimit(0))
+	require.Equal(t, "page=0&limit=0", params.ToQueryString())
+
+	params = NewListParams(WithTimeRange(common.TimeRange{To: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)}))
+	require.Equal(t, "toTimestamp=2025-01-02T00%3A00%3A00Z", params.ToQueryString())
+}
+
+func TestTraceClient_ListAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/traces", r.URL.Path)
+		query := r.URL.Query()
+		require.Equal(t, "session-1", query.Get("sessionId"))
+		require.Equal(t, "core", query.Get("fields"))
+		w.Header().Set("Content-Type", "application/json")
+		if query.Get("page") == "1" {
+			_, _ = w.Write([]byte(`{"meta":{"page":1,"limit":2,"totalItems":3,"totalPages":2},
+				"data":[{"id":"trace-1"},{"id":"trace-2"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"meta":{"page":2,"limit":2,"totalItems":3,"totalPages":2},"data":[{"id":"trace-3"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(resty.New().SetBaseURL(server.URL))
+	listTraces, err := client.List(context.Background(), ListParams{Page: 1, Limit: 2, SessionID: "session-1", Fields: "core"})
+	require.NoError(t, err)
+	require.Equal(t, 3, listTraces.Metadata.TotalItems)
+	require.Len(t, listTraces.Data, 2)
+
+	allTraces, err := client.ListAll(context.Background(), ListParams{Limit: 2, SessionID: "session-1", Fields: "core"},
+		common.WithOrderedResults())
+	require.NoError(t, err)
+	ids := make([]string, 0, len(allTraces))
+	for _, trace := range allTraces {
+		ids = append(ids, trace.ID)
+	}
+	require.Equal(t, []string{"trace-1", "trace-2", "trace-3"}, ids)
+}
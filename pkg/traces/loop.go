@@ -0,0 +1,129 @@
+package traces
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LoopDetectedTag is the tag of the traces in which DetectLoops found a loop.
+const LoopDetectedTag = "loop-detected"
+
+// DefaultLoopThreshold is the default number of repetitions reported as a loop.
+const DefaultLoopThreshold = 3
+
+// LoopKind is the kind of repetition found by DetectLoops.
+type LoopKind string
+
+const (
+	// LoopKindToolCall is a tool called repeatedly with identical input.
+	LoopKindToolCall LoopKind = "tool_call"
+	// LoopKindGenerationOutput is a generation output produced repeatedly.
+	LoopKindGenerationOutput LoopKind = "generation_output"
+)
+
+// Loop is a tool call or generation output repeated within a trace.
+type Loop struct {
+	Kind LoopKind `json:"kind"`
+	// Name is the name of the repeated tool or generation.
+	Name           string   `json:"name"`
+	Count          int      `json:"count"`
+	ObservationIDs []string `json:"observationIds"`
+}
+
+// WithLoopDetection runs DetectLoops with the given threshold on every trace when it
+// ends, to surface runaway agents in dashboards. If threshold is less than 2, it
+// defaults to DefaultLoopThreshold.
+func WithLoopDetection(threshold int) IngestorOption {
+	return func(ingestor *Ingestor) {
+		if threshold < 2 {
+			threshold = DefaultLoopThreshold
+		}
+		ingestor.loopThreshold = threshold
+	}
+}
+
+// DetectLoops finds the tool calls with identical input, and the generations with
+// identical output, repeated at least threshold times within the trace. If threshold
+// is less than 2, it defaults to DefaultLoopThreshold.
+//
+// When loops are found, the trace is tagged with LoopDetectedTag and a WARNING event
+// describing the loops is added to it, once per trace.
+//
+// Example:
+//
+//	if loops := trace.DetectLoops(5); len(loops) > 0 {
+//		return errors.New("agent is stuck in a loop")
+//	}
+func (t *Trace) DetectLoops(threshold int) []Loop {
+	if threshold < 2 {
+		threshold = DefaultLoopThreshold
+	}
+	type loopKey struct {
+		kind    LoopKind
+		name    string
+		content string
+	}
+	var keys []loopKey
+	found := make(map[loopKey]*Loop)
+	for _, observation := range t.observations {
+		var kind LoopKind
+		var content any
+		mu := observation.metadataLock()
+		mu.Lock()
+		switch observation.Type {
+		case ObservationTypeTool:
+			kind, content = LoopKindToolCall, observation.Input
+		case ObservationTypeGeneration:
+			kind, content = LoopKindGenerationOutput, observation.Output
+		}
+		mu.Unlock()
+		if kind == "" || content == nil {
+			continue
+		}
+		encoded, err := json.Marshal(content)
+		if err != nil {
+			continue
+		}
+		key := loopKey{kind: kind, name: observation.Name, content: string(encoded)}
+		loop, ok := found[key]
+		if !ok {
+			loop = &Loop{Kind: kind, Name: observation.Name}
+			found[key] = loop
+			keys = append(keys, key)
+		}
+		loop.Count++
+		loop.ObservationIDs = append(loop.ObservationIDs, observation.ID)
+	}
+
+	var loops []Loop
+	for _, key := range keys {
+		if loop := found[key]; loop.Count >= threshold {
+			loops = append(loops, *loop)
+		}
+	}
+	if len(loops) > 0 && !slices.Contains(t.Tags, LoopDetectedTag) {
+		t.reportLoops(loops)
+	}
+	return loops
+}
+
+// reportLoops tags the trace and adds a WARNING event describing the loops.
+func (t *Trace) reportLoops(loops []Loop) {
+	t.Tags = append(t.Tags, LoopDetectedTag)
+	descriptions := make([]string, len(loops))
+	for i, loop := range loops {
+		kind := "tool call"
+		if loop.Kind == LoopKindGenerationOutput {
+			kind = "generation output"
+		}
+		descriptions[i] = fmt.Sprintf("%s %q repeated %d times", kind, loop.Name, loop.Count)
+	}
+	event := t.StartObservation(LoopDetectedTag, ObservationTypeEvent)
+	event.ParentObservationID = ""
+	event.Level = ObservationLevelWarning
+	event.StatusMessage = strings.Join(descriptions, ", ")
+	event.Output = loops
+	event.End()
+}
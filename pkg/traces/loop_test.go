@@ -0,0 +1,61 @@
+package traces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrace_DetectLoops(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	defer ingestor.Close()
+	trace := ingestor.StartTrace(context.Background(), "agent-run")
+	agent := trace.StartAgent("agent")
+	var searches []string
+	for i := 0; i < 3; i++ {
+		search := agent.StartToolCall("search", map[string]any{"query": "umbrella"})
+		search.EndToolCall("no results", nil)
+		searches = append(searches, search.ID)
+		generation := trace.StartGeneration("plan")
+		generation.Output = "I should search again"
+		if i == 2 {
+			generation.Output = "Let me try something else"
+		}
+		generation.End()
+	}
+	other := agent.StartToolCall("search", map[string]any{"query": "rain"})
+	other.EndToolCall(nil, nil)
+
+	loops := trace.DetectLoops(3)
+	require.Equal(t, []Loop{{Kind: LoopKindToolCall, Name: "search", Count: 3, ObservationIDs: searches}}, loops)
+	require.Equal(t, []string{LoopDetectedTag}, trace.Tags)
+	event := trace.observations[len(trace.observations)-1]
+	require.Equal(t, ObservationTypeEvent, event.Type)
+	require.Equal(t, ObservationLevelWarning, event.Level)
+	require.Equal(t, `tool call "search" repeated 3 times`, event.StatusMessage)
+	require.Empty(t, event.ParentObservationID)
+
+	// The generation output is repeated twice
+	loops = trace.DetectLoops(2)
+	require.Len(t, loops, 2)
+	require.Equal(t, LoopKindGenerationOutput, loops[1].Kind)
+	require.Equal(t, 2, loops[1].Count)
+	// The trace is reported once
+	require.Equal(t, []string{LoopDetectedTag}, trace.Tags)
+	require.Equal(t, ObservationTypeEvent, trace.observations[len(trace.observations)-1].Type)
+	require.Len(t, trace.observations, 9)
+}
+
+func TestTrace_DetectLoops_None(t *testing.T) {
+	ingestor := NewIngestor(resty.New(), WithLoopDetection(0))
+	defer ingestor.Close()
+	require.Equal(t, DefaultLoopThreshold, ingestor.loopThreshold)
+	trace := ingestor.StartTrace(context.Background(), "agent-run")
+	agent := trace.StartAgent("agent")
+	agent.StartToolCall("search", "a").EndToolCall(nil, nil)
+	agent.StartToolCall("search", "b").EndToolCall(nil, nil)
+	require.Empty(t, trace.DetectLoops(2))
+	require.Empty(t, trace.Tags)
+}
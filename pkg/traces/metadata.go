@@ -0,0 +1,109 @@
+package traces
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ReservedMetadataPrefix is the prefix of the metadata keys which are reserved for Langfuse.
+const ReservedMetadataPrefix = "langfuse."
+
+// fallbackMetadataMu guards the metadata of observations which were not created
+// by a trace, for example in tests.
+var fallbackMetadataMu sync.Mutex
+
+func (o *Observation) metadataLock() *sync.Mutex {
+	if o.metadataMu != nil {
+		return o.metadataMu
+	}
+	return &fallbackMetadataMu
+}
+
+func validateMetadataKey(key string) error {
+	if key == "" {
+		return errors.New("metadata key cannot be empty")
+	}
+	if strings.HasPrefix(key, ReservedMetadataPrefix) {
+		return fmt.Errorf("metadata key %q is reserved", key)
+	}
+	return nil
+}
+
+// AddMetadata sets a single key of the observation metadata, keeping the other keys.
+//
+// It's safe to call from multiple goroutines. The metadata must be either nil or
+// a map[string]any, and keys starting with ReservedMetadataPrefix are rejected.
+func (o *Observation) AddMetadata(key string, value any) error {
+	return o.MergeMetadata(map[string]any{key: value})
+}
+
+// MergeMetadata merges the given keys into the observation metadata, keeping the other keys.
+//
+// Existing keys are overwritten. The metadata is left unchanged if any key is
+// invalid, see AddMetadata.
+func (o *Observation) MergeMetadata(metadata map[string]any) error {
+	for key := range metadata {
+		if err := validateMetadataKey(key); err != nil {
+			return err
+		}
+	}
+
+	mu := o.metadataLock()
+	mu.Lock()
+	defer mu.Unlock()
+
+	var merged map[string]any
+	switch m := o.Metadata.(type) {
+	case nil:
+		merged = make(map[string]any, len(metadata))
+	case map[string]any:
+		merged = m
+	default:
+		return fmt.Errorf("observation metadata must be map[string]any, got %T", o.Metadata)
+	}
+	for key, value := range metadata {
+		merged[key] = value
+	}
+	o.Metadata = merged
+	return nil
+}
+
+// WithInheritedMetadata copies the given keys of the trace metadata, e.g. a tenant or request
+// ID, to the metadata of every observation when it's started, so they can be filtered on
+// without setting them on each observation. Keys missing from the trace metadata are skipped.
+//
+// The trace metadata must be a map[string]any or map[string]string, and the keys are read
+// when the observation starts, so they should be set when the trace starts.
+func WithInheritedMetadata(keys ...string) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.inheritedKeys = append(ingestor.inheritedKeys, keys...)
+	}
+}
+
+// inheritedMetadata returns the inherited keys of the trace metadata, or nil if there are none.
+func (t *Trace) inheritedMetadata() map[string]any {
+	if len(t.ingestor.inheritedKeys) == 0 {
+		return nil
+	}
+	var inherited map[string]any
+	for _, key := range t.ingestor.inheritedKeys {
+		var value any
+		var ok bool
+		switch m := t.Metadata.(type) {
+		case map[string]any:
+			value, ok = m[key]
+		case map[string]string:
+			value, ok = m[key]
+		}
+		if !ok {
+			continue
+		}
+		if inherited == nil {
+			inherited = make(map[string]any, len(t.ingestor.inheritedKeys))
+		}
+		inherited[key] = value
+	}
+	return inherited
+}
@@ -0,0 +1,70 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservation_AddMetadata(t *testing.T) {
+	observation := &Observation{Metadata: map[string]any{"existing": 1}}
+	require.NoError(t, observation.AddMetadata("key", "value"))
+	require.NoError(t, observation.MergeMetadata(map[string]any{"existing": 2, "other": true}))
+	require.Equal(t, map[string]any{"existing": 2, "key": "value", "other": true}, observation.Metadata)
+
+	require.EqualError(t, observation.AddMetadata("", 1), "metadata key cannot be empty")
+	require.EqualError(t, observation.MergeMetadata(map[string]any{"ok": 1, "langfuse.internal": 1}),
+		`metadata key "langfuse.internal" is reserved`)
+	require.NotContains(t, observation.Metadata, "ok")
+
+	observation = &Observation{Metadata: "not a map"}
+	require.EqualError(t, observation.AddMetadata("key", 1), "observation metadata must be map[string]any, got string")
+}
+
+func TestObservation_AddMetadata_Concurrent(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	trace := ingestor.StartTrace(context.Background(), "test")
+	span := trace.StartSpan("span")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			require.NoError(t, span.AddMetadata(fmt.Sprintf("key-%d", i), i))
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, err := json.Marshal(span)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	require.Len(t, span.Metadata, 50)
+}
+
+func TestIngestor_WithInheritedMetadata(t *testing.T) {
+	ingestor := NewIngestor(resty.New(), WithInheritedMetadata("tenant_id", "request_id"))
+	defer ingestor.Close()
+
+	trace := ingestor.StartTrace(context.Background(), "handler", WithMetadata(map[string]any{
+		"tenant_id": "acme",
+		"user_plan": "pro",
+	}))
+	span := trace.StartSpan("query")
+	require.Equal(t, map[string]any{"tenant_id": "acme"}, span.Metadata)
+	require.NoError(t, span.AddMetadata("rows", 3))
+	generation := trace.StartGeneration("llm")
+	require.Equal(t, map[string]any{"tenant_id": "acme"}, generation.Metadata)
+
+	trace = ingestor.StartTrace(context.Background(), "handler", WithMetadata(map[string]string{"request_id": "req-1"}))
+	require.Equal(t, map[string]any{"request_id": "req-1"}, trace.StartSpan("query").Metadata)
+
+	trace = ingestor.StartTrace(context.Background(), "handler")
+	require.Nil(t, trace.StartSpan("query").Metadata)
+}
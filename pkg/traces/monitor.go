@@ -0,0 +1,316 @@
+package traces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/git-hulk/langfuse-go/pkg/health"
+	"github.com/git-hulk/langfuse-go/pkg/logger"
+)
+
+// HealthMonitorConfig holds the configuration of the health monitor, see WithHealthMonitor.
+type HealthMonitorConfig struct {
+	// FailureThreshold is the number of consecutive failed ingestion requests after which
+	// sending is paused. Default is 3.
+	FailureThreshold int
+	// CheckInterval is the interval at which the health is checked while paused.
+	// Default is 10 seconds.
+	CheckInterval time.Duration
+	// DrainInterval is the pause between two queued requests when the backlog is drained
+	// after the server is healthy again. Default is 200 milliseconds.
+	DrainInterval time.Duration
+	// MaxQueuedEvents caps the number of queued events, the oldest requests are dropped
+	// when it's exceeded. Default is 100000.
+	MaxQueuedEvents int
+	// Check reports whether the server is healthy. Default checks the health endpoint.
+	Check func(ctx context.Context) error
+	// OnPause is called when sending is paused. Optional.
+	OnPause func()
+	// OnResume is called when the server is healthy again, before the backlog is drained. Optional.
+	OnResume func()
+}
+
+func (c *HealthMonitorConfig) normalize() {
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = 3
+	}
+	if c.CheckInterval <= 0 {
+		c.CheckInterval = 10 * time.Second
+	}
+	if c.DrainInterval <= 0 {
+		c.DrainInterval = 200 * time.Millisecond
+	}
+	if c.MaxQueuedEvents <= 0 {
+		c.MaxQueuedEvents = 100000
+	}
+}
+
+// WithHealthMonitor pauses sending after consecutive failed ingestion requests, e.g. while
+// the Langfuse server is down, and queues the batches locally instead of dropping them.
+// While paused, the health of the server is polled, and once it's healthy again the
+// backlog is drained one request per DrainInterval before new batches are sent.
+//
+// Only network errors and responses with a 5xx or 429 status code count as failures.
+// The queue is kept in memory, so it's lost when the ingestor is closed while paused.
+//
+// Example:
+//
+//	ingestor := traces.NewIngestor(restyCli, traces.WithHealthMonitor(traces.HealthMonitorConfig{
+//		OnPause:  func() { log.Println("langfuse is unhealthy, queuing traces") },
+//		OnResume: func() { log.Println("langfuse is healthy again") },
+//	}))
+func WithHealthMonitor(config HealthMonitorConfig) IngestorOption {
+	return func(ingestor *Ingestor) {
+		config.normalize()
+		ingestor.monitor = &healthMonitor{config: config, quitCh: make(chan struct{})}
+	}
+}
+
+type healthMonitor struct {
+	config HealthMonitorConfig
+	send   func(ctx context.Context, events []IngestionEvent) error
+
+	mu         sync.Mutex
+	failures   int
+	paused     bool
+	recovering bool
+	stopped    bool
+	backlog    [][]IngestionEvent
+	queued     int
+	// sending is the request of the backlog which is being sent by drain, it's removed
+	// from the backlog meanwhile, so that enqueue can't drop it.
+	sending []IngestionEvent
+
+	quitCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// isUnhealthy reports whether the error of an ingestion request hints at an unhealthy server.
+func isUnhealthy(err error) bool {
+	if err == nil {
+		return false
+	}
+	var failure *IngestionFailure
+	if !errors.As(err, &failure) {
+		return true
+	}
+	kind := ClassifyStatus(failure.Result.StatusCode)
+	return kind == IngestionErrorServer || kind == IngestionErrorRateLimited
+}
+
+// sendEvents sends the events unless sending is paused or a backlog is drained, in which
+// case they're queued.
+func (m *healthMonitor) sendEvents(ctx context.Context, events []IngestionEvent) error {
+	m.mu.Lock()
+	if !m.stopped && (m.paused || m.recovering) {
+		m.enqueue(events)
+		m.mu.Unlock()
+		return nil
+	}
+	m.mu.Unlock()
+
+	err := m.send(ctx, events)
+	m.mu.Lock()
+	if !isUnhealthy(err) {
+		m.failures = 0
+		m.mu.Unlock()
+		return err
+	}
+	m.failures++
+	if m.failures < m.config.FailureThreshold || m.stopped {
+		m.mu.Unlock()
+		return err
+	}
+	// The failed request is sent again once the server is healthy
+	m.enqueue(events)
+	started := m.pause()
+	m.mu.Unlock()
+	m.notifyPause()
+	if started {
+		m.wg.Add(1)
+		go m.recover()
+	}
+	return nil
+}
+
+// pause pauses sending and reports whether the recovery must be started. It must be
+// called with the lock held, and OnPause must be called once the lock is released.
+func (m *healthMonitor) pause() bool {
+	m.paused = true
+	m.failures = 0
+	logger.Get().Warn("Langfuse is unhealthy, pausing the ingestion", zap.Int("queued_events", m.queued))
+	if m.recovering {
+		return false
+	}
+	m.recovering = true
+	return true
+}
+
+func (m *healthMonitor) notifyPause() {
+	if m.config.OnPause != nil {
+		m.config.OnPause()
+	}
+}
+
+// enqueue adds the events to the backlog, dropping the oldest requests if the backlog is
+// full. It must be called with the lock held.
+func (m *healthMonitor) enqueue(events []IngestionEvent) {
+	m.backlog = append(m.backlog, events)
+	m.queued += len(events)
+	m.dropOldest()
+}
+
+// requeue puts back the events at the head of the backlog, dropping them if the backlog
+// filled up meanwhile. It must be called with the lock held.
+func (m *healthMonitor) requeue(events []IngestionEvent) {
+	m.backlog = append([][]IngestionEvent{events}, m.backlog...)
+	m.queued += len(events)
+	m.dropOldest()
+}
+
+// dropOldest drops the oldest requests while the backlog is full. It must be called with
+// the lock held.
+func (m *healthMonitor) dropOldest() {
+	dropped := 0
+	for m.queued > m.config.MaxQueuedEvents && len(m.backlog) > 1 {
+		dropped += len(m.backlog[0])
+		m.queued -= len(m.backlog[0])
+		m.backlog = m.backlog[1:]
+	}
+	if dropped > 0 {
+		logger.Get().Warn("Dropped queued ingestion events, the backlog is full", zap.Int("dropped_events", dropped))
+	}
+}
+
+// recover polls the health until the server is healthy, then drains the backlog.
+func (m *healthMonitor) recover() {
+	defer m.wg.Done()
+	for {
+		if !m.waitHealthy() {
+			return
+		}
+		m.mu.Lock()
+		m.paused = false
+		m.mu.Unlock()
+		logger.Get().Info("Langfuse is healthy again, resuming the ingestion")
+		if m.config.OnResume != nil {
+			m.config.OnResume()
+		}
+		if m.drain() {
+			return
+		}
+	}
+}
+
+// waitHealthy polls the health until the server is healthy, or returns false if the
+// monitor is stopped before.
+func (m *healthMonitor) waitHealthy() bool {
+	ticker := time.NewTicker(m.config.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.quitCh:
+			return false
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), m.config.CheckInterval)
+		err := m.config.Check(ctx)
+		cancel()
+		if err == nil {
+			return true
+		}
+		logger.Get().Debug("Langfuse is still unhealthy", zap.Error(err))
+	}
+}
+
+// drain sends the queued requests one per DrainInterval. It returns true once the backlog
+// is empty or the monitor is stopped, and false if sending is paused again.
+func (m *healthMonitor) drain() bool {
+	for {
+		m.mu.Lock()
+		if len(m.backlog) == 0 || m.stopped {
+			m.recovering = false
+			m.mu.Unlock()
+			return true
+		}
+		// The head is removed while it's sent, so that enqueue doesn't drop it meanwhile
+		events := m.backlog[0]
+		m.backlog = m.backlog[1:]
+		m.queued -= len(events)
+		m.sending = events
+		m.mu.Unlock()
+
+		err := m.send(context.Background(), events)
+		m.mu.Lock()
+		m.sending = nil
+		if isUnhealthy(err) {
+			m.requeue(events)
+			m.failures++
+			if m.failures >= m.config.FailureThreshold {
+				m.pause()
+				m.mu.Unlock()
+				m.notifyPause()
+				return false
+			}
+		} else {
+			// Requests rejected for other reasons won't succeed when sent again
+			m.failures = 0
+		}
+		m.mu.Unlock()
+
+		select {
+		case <-m.quitCh:
+			return true
+		case <-time.After(m.config.DrainInterval):
+		}
+	}
+}
+
+// pending returns the queued events without removing them from the backlog.
+func (m *healthMonitor) pending() []IngestionEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	events := make([]IngestionEvent, 0, m.queued+len(m.sending))
+	events = append(events, m.sending...)
+	for _, chunk := range m.backlog {
+		events = append(events, chunk...)
+	}
+	return events
+}
+
+// stop stops polling and draining, the queued events are dropped.
+func (m *healthMonitor) stop() {
+	m.mu.Lock()
+	if m.stopped {
+		m.mu.Unlock()
+		return
+	}
+	m.stopped = true
+	queued := m.queued
+	m.mu.Unlock()
+	close(m.quitCh)
+	m.wg.Wait()
+	if queued > 0 {
+		logger.Get().Warn("Dropped queued ingestion events on close", zap.Int("dropped_events", queued))
+	}
+}
+
+// checkHealth reports an error unless the health endpoint reports the status OK.
+func checkHealth(client *health.Client) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		rsp, err := client.Check(ctx)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(rsp.Status, "OK") {
+			return fmt.Errorf("unhealthy status: %s", rsp.Status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,132 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestor_WithHealthMonitor(t *testing.T) {
+	var healthy atomic.Bool
+	var mu sync.Mutex
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var request struct {
+			Batch []struct {
+				Body map[string]any `json:"body"`
+			} `json:"batch"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		mu.Lock()
+		for _, event := range request.Batch {
+			received = append(received, event.Body["name"].(string))
+		}
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"successes": [], "errors": []}`))
+	}))
+	defer server.Close()
+
+	var paused, resumed atomic.Int32
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL), WithHealthMonitor(HealthMonitorConfig{
+		FailureThreshold: 2,
+		CheckInterval:    10 * time.Millisecond,
+		DrainInterval:    time.Millisecond,
+		Check: func(ctx context.Context) error {
+			if !healthy.Load() {
+				return errors.New("unavailable")
+			}
+			return nil
+		},
+		OnPause:  func() { paused.Add(1) },
+		OnResume: func() { resumed.Add(1) },
+	}))
+	defer ingestor.Close()
+	ctx := context.Background()
+
+	// The first failure is below the threshold and reported to the caller
+	require.Error(t, ingestor.Send(ctx, []*Trace{ingestor.StartTrace(ctx, "first")}))
+	// The second one pauses sending and queues the failed batch
+	require.NoError(t, ingestor.Send(ctx, []*Trace{ingestor.StartTrace(ctx, "second")}))
+	require.NoError(t, ingestor.Send(ctx, []*Trace{ingestor.StartTrace(ctx, "third")}))
+	require.EqualValues(t, 1, paused.Load())
+	require.EqualValues(t, 0, resumed.Load())
+
+	healthy.Store(true)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.EqualValues(t, 1, resumed.Load())
+
+	require.NoError(t, ingestor.Send(ctx, []*Trace{ingestor.StartTrace(ctx, "fourth")}))
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	require.Equal(t, []string{"second", "third", "fourth"}, received)
+	mu.Unlock()
+}
+
+func TestHealthMonitor_Enqueue_DropsOldest(t *testing.T) {
+	monitor := &healthMonitor{config: HealthMonitorConfig{MaxQueuedEvents: 3}}
+	monitor.enqueue([]IngestionEvent{{ID: "1"}, {ID: "2"}})
+	monitor.enqueue([]IngestionEvent{{ID: "3"}})
+	monitor.enqueue([]IngestionEvent{{ID: "4"}, {ID: "5"}})
+	require.Equal(t, 3, monitor.queued)
+	require.Equal(t, [][]IngestionEvent{{{ID: "3"}}, {{ID: "4"}, {ID: "5"}}}, monitor.backlog)
+}
+
+func TestHealthMonitor_Drain_EnqueueWhileSending(t *testing.T) {
+	sent := make(chan []IngestionEvent)
+	results := make(chan error)
+	monitor := &healthMonitor{
+		config: HealthMonitorConfig{MaxQueuedEvents: 2, FailureThreshold: 1, DrainInterval: time.Millisecond},
+		send: func(ctx context.Context, events []IngestionEvent) error {
+			sent <- events
+			return <-results
+		},
+		quitCh:     make(chan struct{}),
+		recovering: true,
+	}
+	monitor.enqueue([]IngestionEvent{{ID: "1"}})
+	monitor.enqueue([]IngestionEvent{{ID: "2"}})
+
+	drained := make(chan bool)
+	go func() { drained <- monitor.drain() }()
+
+	// The backlog is filled up while the head is sent, which must not drop the sent request
+	require.Equal(t, []IngestionEvent{{ID: "1"}}, <-sent)
+	monitor.mu.Lock()
+	monitor.enqueue([]IngestionEvent{{ID: "3"}})
+	monitor.mu.Unlock()
+	require.Equal(t, []IngestionEvent{{ID: "1"}, {ID: "2"}, {ID: "3"}}, monitor.pending())
+	results <- nil
+
+	require.Equal(t, []IngestionEvent{{ID: "2"}}, <-sent)
+	results <- errors.New("connection refused")
+	require.False(t, <-drained)
+
+	// The failed request is back at the head of the backlog
+	monitor.mu.Lock()
+	defer monitor.mu.Unlock()
+	require.True(t, monitor.paused)
+	require.Equal(t, [][]IngestionEvent{{{ID: "2"}}, {{ID: "3"}}}, monitor.backlog)
+	require.Equal(t, 2, monitor.queued)
+}
@@ -1,7 +1,11 @@
 package traces
 
 import (
+	"encoding/json"
+	"sync"
 	"time"
+
+	"github.com/git-hulk/langfuse-go/pkg/common"
 )
 
 type ObservationType string
@@ -19,15 +23,15 @@ const (
 	ObservationTypeGuardrail  ObservationType = "GUARDRAIL"
 )
 
-type UnitType string
+type UnitType = common.UnitType
 
 const (
-	UnitCharacters   UnitType = "CHARACTERS"
-	UnitTokens       UnitType = "TOKENS"
-	UnitMilliseconds UnitType = "MILLISECONDS"
-	UnitSeconds      UnitType = "SECONDS"
-	UnitImages       UnitType = "IMAGES"
-	UnitRequests     UnitType = "REQUESTS"
+	UnitCharacters   = common.UnitCharacters
+	UnitTokens       = common.UnitTokens
+	UnitMilliseconds = common.UnitMilliseconds
+	UnitSeconds      = common.UnitSeconds
+	UnitImages       = common.UnitImages
+	UnitRequests     = common.UnitRequests
 )
 
 type ObservationLevel string
@@ -39,37 +43,59 @@ const (
 	ObservationLevelError   ObservationLevel = "ERROR"
 )
 
-type Usage struct {
-	Input  int      `json:"input,omitempty"`
-	Output int      `json:"output,omitempty"`
-	Total  int      `json:"total,omitempty"`
-	Unit   UnitType `json:"unit,omitempty"`
-}
+// Usage is an alias of common.Usage, kept for backwards compatibility.
+type Usage = common.Usage
 
 type Observation struct {
-	ID                  string           `json:"id,omitempty"`
-	TraceID             string           `json:"traceId,omitempty"`
-	Type                ObservationType  `json:"type"`
-	Name                string           `json:"name,omitempty"`
-	PromptName          string           `json:"promptName,omitempty"`
-	PromptVersion       int              `json:"promptVersion,omitempty"`
-	StartTime           time.Time        `json:"startTime,omitempty"`
-	EndTime             *time.Time       `json:"endTime,omitempty"`
-	CompletionStartTime *time.Time       `json:"completionStartTime,omitempty"`
-	Model               string           `json:"model,omitempty"`
-	ModelParameters     map[string]any   `json:"modelParameters,omitempty"`
-	Input               any              `json:"input,omitempty"`
-	Version             string           `json:"version,omitempty"`
-	Metadata            any              `json:"metadata,omitempty"`
-	Output              any              `json:"output,omitempty"`
-	Usage               Usage            `json:"usage,omitempty"`
-	Level               ObservationLevel `json:"level,omitempty"`
-	StatusMessage       string           `json:"statusMessage,omitempty"`
-	ParentObservationID string           `json:"parentObservationId,omitempty"`
-	Environment         string           `json:"environment,omitempty"`
+	ID                  string             `json:"id,omitempty"`
+	TraceID             string             `json:"traceId,omitempty"`
+	Type                ObservationType    `json:"type"`
+	Name                string             `json:"name,omitempty"`
+	PromptName          string             `json:"promptName,omitempty"`
+	PromptVersion       int                `json:"promptVersion,omitempty"`
+	StartTime           time.Time          `json:"startTime,omitempty"`
+	EndTime             *time.Time         `json:"endTime,omitempty"`
+	Latency             int64              `json:"latency,omitempty"` // in milliseconds, set by End
+	CompletionStartTime *time.Time         `json:"completionStartTime,omitempty"`
+	Model               string             `json:"model,omitempty"`
+	ModelParameters     map[string]any     `json:"modelParameters,omitempty"`
+	Input               any                `json:"input,omitempty"`
+	Version             string             `json:"version,omitempty"`
+	Metadata            any                `json:"metadata,omitempty"`
+	Output              any                `json:"output,omitempty"`
+	Usage               Usage              `json:"usage,omitempty"`
+	UsageDetails        map[string]int     `json:"usageDetails,omitempty"`
+	CostDetails         map[string]float64 `json:"costDetails,omitempty"`
+	Level               ObservationLevel   `json:"level,omitempty"`
+	StatusMessage       string             `json:"statusMessage,omitempty"`
+	ParentObservationID string             `json:"parentObservationId,omitempty"`
+	Environment         string             `json:"environment,omitempty"`
+
+	clock      Clock
+	metadataMu *sync.Mutex
+	trace      *Trace
+}
+
+// MarshalJSON fills the usage details from Usage if they're not set explicitly,
+// so the cached input and reasoning output tokens are reported as well.
+func (o *Observation) MarshalJSON() ([]byte, error) {
+	mu := o.metadataLock()
+	mu.Lock()
+	defer mu.Unlock()
+
+	type Alias Observation
+	alias := Alias(*o)
+	if alias.UsageDetails == nil {
+		alias.UsageDetails = o.Usage.Details()
+	}
+	return json.Marshal(alias)
 }
 
+// End sets the end time of the observation and computes its latency.
 func (o *Observation) End() {
 	now := time.Now()
-	o.EndTime = &now
+	if o.clock != nil {
+		now = o.clock.Now()
+	}
+	o.setEndTime(now)
 }
@@ -1,6 +1,7 @@
 package traces
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -103,3 +104,26 @@ func TestUsage_Fields(t *testing.T) {
 	assert.Equal(t, 150, usage.Total)
 	assert.Equal(t, UnitTokens, usage.Unit)
 }
+
+func TestObservation_MarshalJSON_UsageDetails(t *testing.T) {
+	observation := Observation{
+		Type:  ObservationTypeGeneration,
+		Usage: Usage{Input: 100, Output: 50, InputCached: 80, Unit: UnitTokens},
+	}
+	data, err := json.Marshal(&observation)
+	require.NoError(t, err)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(data, &body))
+	require.Equal(t, map[string]any{"input": float64(100), "output": float64(50), "unit": "TOKENS"}, body["usage"])
+	require.Equal(t, map[string]any{
+		"input":               float64(100),
+		"output":              float64(50),
+		"input_cached_tokens": float64(80),
+	}, body["usageDetails"])
+
+	observation.UsageDetails = map[string]int{"input": 1}
+	data, err = json.Marshal(&observation)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"usageDetails":{"input":1}`)
+}
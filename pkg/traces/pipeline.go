@@ -0,0 +1,141 @@
+package traces
+
+import (
+	"context"
+	"iter"
+)
+
+// StageFunc runs a stage of a Pipeline. It receives the output of the previous stage,
+// or the pipeline input for the first stage, and the observation of the stage to add
+// details like the model and usage of a generation.
+type StageFunc func(ctx context.Context, stage *Observation, input any) (any, error)
+
+// StageOption configures a stage of a Pipeline.
+type StageOption func(*pipelineStage)
+
+// WithStageType sets the observation type of a stage, e.g. ObservationTypeRetriever
+// for a retrieval step or ObservationTypeGeneration for an LLM call. Default is SPAN.
+func WithStageType(typ ObservationType) StageOption {
+	return func(s *pipelineStage) {
+		s.typ = typ
+	}
+}
+
+type pipelineStage struct {
+	name string
+	typ  ObservationType
+	fn   StageFunc
+}
+
+// StageResult is the outcome of a stage of a Pipeline.
+type StageResult struct {
+	Name        string
+	Observation *Observation
+	Output      any
+}
+
+// Pipeline runs a sequence of stages, each traced as a span with its input and output
+// captured automatically.
+//
+// The pipeline itself is traced as a CHAIN observation which holds the stages, which fits
+// RAG pipelines composed of retrieval, rerank and generate steps.
+//
+// Example:
+//
+//	pipeline := traces.NewPipeline("rag").
+//		Stage("retrieve", retrieve, traces.WithStageType(traces.ObservationTypeRetriever)).
+//		Stage("rerank", rerank).
+//		Stage("generate", generate, traces.WithStageType(traces.ObservationTypeGeneration))
+//
+//	ctx = traces.ContextWithTrace(ctx, trace)
+//	answer, err := pipeline.Run(ctx, question)
+type Pipeline struct {
+	name   string
+	stages []pipelineStage
+}
+
+// NewPipeline creates an empty pipeline with the given name.
+func NewPipeline(name string) *Pipeline {
+	return &Pipeline{name: name}
+}
+
+// Stage appends a stage to the pipeline and returns the pipeline for chaining.
+func (p *Pipeline) Stage(name string, fn StageFunc, options ...StageOption) *Pipeline {
+	stage := pipelineStage{name: name, typ: ObservationTypeSpan, fn: fn}
+	for _, option := range options {
+		option(&stage)
+	}
+	p.stages = append(p.stages, stage)
+	return p
+}
+
+// Run runs the stages on the trace stored in ctx and returns the output of the last stage.
+//
+// The pipeline stops at the first stage which fails, and its error is returned.
+// Returns ErrNoTraceInContext if ctx does not carry a trace.
+func (p *Pipeline) Run(ctx context.Context, input any) (any, error) {
+	output := input
+	for result, err := range p.Steps(ctx, input) {
+		if err != nil {
+			return nil, err
+		}
+		output = result.Output
+	}
+	return output, nil
+}
+
+// Steps returns an iterator which runs the stages on the trace stored in ctx one at a time,
+// yielding the result of each stage.
+//
+// Stopping the iteration skips the remaining stages. If a stage fails, its result is yielded
+// together with the error and the iteration stops. If ctx does not carry a trace, a nil
+// result is yielded with ErrNoTraceInContext.
+//
+// Example:
+//
+//	for result, err := range pipeline.Steps(ctx, question) {
+//		if err != nil {
+//			return err
+//		}
+//		log.Printf("stage %s done", result.Name)
+//	}
+func (p *Pipeline) Steps(ctx context.Context, input any) iter.Seq2[*StageResult, error] {
+	return func(yield func(*StageResult, error) bool) {
+		trace := TraceFromContext(ctx)
+		if trace == nil {
+			yield(nil, ErrNoTraceInContext)
+			return
+		}
+
+		chain := trace.StartObservation(p.name, ObservationTypeChain)
+		chain.Input = input
+		defer chain.End()
+
+		value := input
+		for _, stage := range p.stages {
+			observation := trace.StartObservation(stage.name, stage.typ)
+			// The stage may start observations itself, so the parent isn't inferred
+			observation.ParentObservationID = chain.ID
+			observation.Input = value
+
+			output, err := stage.fn(ctx, observation, value)
+			observation.Output = output
+			if err != nil {
+				observation.Level = ObservationLevelError
+				observation.StatusMessage = err.Error()
+				observation.End()
+				chain.Level = ObservationLevelError
+				chain.StatusMessage = stage.name + ": " + err.Error()
+				yield(&StageResult{Name: stage.name, Observation: observation, Output: output}, err)
+				return
+			}
+			observation.End()
+
+			value = output
+			if !yield(&StageResult{Name: stage.name, Observation: observation, Output: output}, nil) {
+				return
+			}
+		}
+		chain.Output = value
+	}
+}
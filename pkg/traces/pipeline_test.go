@@ -0,0 +1,116 @@
+package traces
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func newPipelineTrace(t *testing.T) (context.Context, *Trace) {
+	ingestor := NewIngestor(resty.New())
+	t.Cleanup(func() { _ = ingestor.Close() })
+	trace := ingestor.StartTrace(context.Background(), "rag")
+	return ContextWithTrace(context.Background(), trace), trace
+}
+
+func TestPipeline_Run(t *testing.T) {
+	ctx, trace := newPipelineTrace(t)
+
+	pipeline := NewPipeline("answer").
+		Stage("retrieve", func(ctx context.Context, stage *Observation, input any) (any, error) {
+			// Observations started by a stage don't change the parent of the next stage
+			TraceFromContext(ctx).StartSpan("search").End()
+			return []string{"doc-b", "doc-a"}, nil
+		}, WithStageType(ObservationTypeRetriever)).
+		Stage("rerank", func(_ context.Context, _ *Observation, input any) (any, error) {
+			docs := append([]string(nil), input.([]string)...)
+			docs[0], docs[1] = docs[1], docs[0]
+			return docs, nil
+		}).
+		Stage("generate", func(_ context.Context, stage *Observation, input any) (any, error) {
+			stage.Model = "gpt-4o"
+			return "answer from " + strings.Join(input.([]string), ","), nil
+		}, WithStageType(ObservationTypeGeneration))
+
+	output, err := pipeline.Run(ctx, "question")
+	require.NoError(t, err)
+	require.Equal(t, "answer from doc-a,doc-b", output)
+
+	observations := trace.Observations()
+	require.Len(t, observations, 5)
+	chain := observations[0]
+	require.Equal(t, ObservationTypeChain, chain.Type)
+	require.Equal(t, "question", chain.Input)
+	require.Equal(t, output, chain.Output)
+	require.NotNil(t, chain.EndTime)
+
+	children := chain.Children()
+	require.Len(t, children, 3)
+	require.Equal(t, "retrieve", children[0].Name)
+	require.Equal(t, ObservationTypeRetriever, children[0].Type)
+	require.Equal(t, "question", children[0].Input)
+	require.Equal(t, []string{"doc-b", "doc-a"}, children[1].Input)
+	require.Equal(t, ObservationTypeGeneration, children[2].Type)
+	require.Equal(t, "gpt-4o", children[2].Model)
+	for _, child := range children {
+		require.NotNil(t, child.EndTime)
+	}
+}
+
+func TestPipeline_StageError(t *testing.T) {
+	ctx, trace := newPipelineTrace(t)
+	stageErr := errors.New("index unavailable")
+
+	var generated bool
+	pipeline := NewPipeline("answer").
+		Stage("retrieve", func(context.Context, *Observation, any) (any, error) {
+			return nil, stageErr
+		}).
+		Stage("generate", func(context.Context, *Observation, any) (any, error) {
+			generated = true
+			return "answer", nil
+		})
+
+	output, err := pipeline.Run(ctx, "question")
+	require.ErrorIs(t, err, stageErr)
+	require.Nil(t, output)
+	require.False(t, generated)
+
+	observations := trace.Observations()
+	require.Len(t, observations, 2)
+	require.Equal(t, ObservationLevelError, observations[0].Level)
+	require.Equal(t, "retrieve: index unavailable", observations[0].StatusMessage)
+	require.Equal(t, ObservationLevelError, observations[1].Level)
+	require.Equal(t, "index unavailable", observations[1].StatusMessage)
+}
+
+func TestPipeline_Steps(t *testing.T) {
+	ctx, trace := newPipelineTrace(t)
+	increment := func(_ context.Context, _ *Observation, input any) (any, error) {
+		return input.(int) + 1, nil
+	}
+	pipeline := NewPipeline("count").Stage("one", increment).Stage("two", increment).Stage("three", increment)
+
+	var names []string
+	for result, err := range pipeline.Steps(ctx, 0) {
+		require.NoError(t, err)
+		names = append(names, result.Name)
+		if result.Output == 2 {
+			break
+		}
+	}
+	require.Equal(t, []string{"one", "two"}, names)
+	// The remaining stages are skipped, but the pipeline observation is ended
+	observations := trace.Observations()
+	require.Len(t, observations, 3)
+	require.NotNil(t, observations[0].EndTime)
+}
+
+func TestPipeline_NoTrace(t *testing.T) {
+	_, err := NewPipeline("answer").Run(context.Background(), "question")
+	require.ErrorIs(t, err, ErrNoTraceInContext)
+}
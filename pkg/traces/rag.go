@@ -0,0 +1,62 @@
+package traces
+
+// Document is a document found by a retrieval or ordered by a rerank observation.
+type Document struct {
+	ID       string         `json:"id,omitempty"`
+	Content  string         `json:"content,omitempty"`
+	Score    float64        `json:"score,omitempty"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// RetrievalInput is the input of retrieval and rerank observations.
+type RetrievalInput struct {
+	Query string `json:"query"`
+	TopK  int    `json:"topK,omitempty"`
+}
+
+// StartRetrieval creates a new child observation of type RETRIEVER within this trace,
+// for a lookup in a vector store or search index.
+//
+// Use SetQuery and SetDocuments to record the query and the retrieved documents.
+//
+// Example:
+//
+//	retrieval := trace.StartRetrieval("vector-search")
+//	retrieval.SetQuery(question, 5)
+//	docs := search(ctx, question, 5)
+//	retrieval.SetDocuments(docs...)
+//	retrieval.End()
+func (t *Trace) StartRetrieval(name string) *Observation {
+	return t.StartObservation(name, ObservationTypeRetriever)
+}
+
+// StartEmbedding creates a new child observation of type EMBEDDING within this trace.
+//
+// Set the Model and Usage of the observation like for a generation, so that the
+// cost of the embedding call is tracked.
+func (t *Trace) StartEmbedding(name string) *Observation {
+	return t.StartObservation(name, ObservationTypeEmbedding)
+}
+
+// StartRerank creates a new child observation for reranking retrieved documents.
+//
+// Langfuse has no dedicated observation type for reranking, so it's a RETRIEVER
+// observation as it refines the retrieval. Use SetQuery and SetDocuments to record
+// the query and the reranked documents with their scores.
+func (t *Trace) StartRerank(name string) *Observation {
+	return t.StartObservation(name, ObservationTypeRetriever)
+}
+
+// SetQuery records the query and the number of requested documents as the input of
+// a retrieval or rerank observation. A topK of 0 is omitted.
+func (o *Observation) SetQuery(query string, topK int) {
+	o.Input = RetrievalInput{Query: query, TopK: topK}
+}
+
+// SetDocuments records the documents as the output of a retrieval or rerank observation.
+func (o *Observation) SetDocuments(documents ...Document) {
+	if documents == nil {
+		documents = []Document{}
+	}
+	o.Output = documents
+}
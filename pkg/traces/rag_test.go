@@ -0,0 +1,45 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrace_RAGObservations(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	defer ingestor.Close()
+	trace := ingestor.StartTrace(context.Background(), "rag")
+
+	embedding := trace.StartEmbedding("embed-query")
+	require.Equal(t, ObservationTypeEmbedding, embedding.Type)
+	embedding.End()
+
+	retrieval := trace.StartRetrieval("vector-search")
+	require.Equal(t, ObservationTypeRetriever, retrieval.Type)
+	retrieval.SetQuery("what is langfuse?", 2)
+	retrieval.SetDocuments(
+		Document{ID: "doc-1", Content: "Langfuse is an LLM engineering platform.", Score: 0.82},
+		Document{ID: "doc-2", Content: "Tracing", Score: 0.61, Metadata: map[string]any{"source": "docs"}},
+	)
+	retrieval.End()
+
+	data, err := json.Marshal(retrieval)
+	require.NoError(t, err)
+	var encoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &encoded))
+	require.Equal(t, map[string]any{"query": "what is langfuse?", "topK": float64(2)}, encoded["input"])
+	require.Equal(t, []any{
+		map[string]any{"id": "doc-1", "content": "Langfuse is an LLM engineering platform.", "score": 0.82},
+		map[string]any{"id": "doc-2", "content": "Tracing", "score": 0.61, "metadata": map[string]any{"source": "docs"}},
+	}, encoded["output"])
+
+	rerank := trace.StartRerank("rerank")
+	require.Equal(t, ObservationTypeRetriever, rerank.Type)
+	rerank.SetDocuments()
+	require.Equal(t, []Document{}, rerank.Output)
+	rerank.End()
+}
@@ -0,0 +1,184 @@
+package traces
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+)
+
+// RedactedPlaceholder replaces the redacted secrets, see WithRedaction.
+const RedactedPlaceholder = "[REDACTED]"
+
+// RedactionRule finds a kind of secret in the strings of traces and observations.
+type RedactionRule struct {
+	Name    string
+	Pattern *regexp.Regexp
+	// Replacement replaces the matches, and may refer to the submatches of the pattern
+	// like regexp.Regexp.ReplaceAllString, e.g. to keep a prefix. Default is RedactedPlaceholder.
+	Replacement string
+}
+
+// Built-in redaction rules, see DefaultRedactionRules.
+var (
+	// RedactAPIKeys redacts API keys with well-known prefixes, e.g. the secret keys of
+	// Langfuse and OpenAI ("sk-..."), AWS access keys ("AKIA..."), GitHub tokens ("ghp_...")
+	// and Slack tokens ("xoxb-...").
+	RedactAPIKeys = RedactionRule{
+		Name:    "api_key",
+		Pattern: regexp.MustCompile(`\b(?:(?:sk|pk|rk)-[A-Za-z0-9_-]{16,}|AKIA[0-9A-Z]{16}|gh[pousr]_[A-Za-z0-9]{36,}|xox[abprs]-[A-Za-z0-9-]{10,})`),
+	}
+	// RedactBearerTokens redacts the tokens of bearer authorization headers, keeping
+	// the "Bearer" scheme.
+	RedactBearerTokens = RedactionRule{
+		Name:        "bearer_token",
+		Pattern:     regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9._~+/-]+=*`),
+		Replacement: "${1}" + RedactedPlaceholder,
+	}
+	// RedactEmails redacts email addresses.
+	RedactEmails = RedactionRule{
+		Name:    "email",
+		Pattern: regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`),
+	}
+)
+
+// DefaultRedactionRules returns the rules used by WithRedaction unless WithRedactionRules
+// is given: RedactAPIKeys, RedactBearerTokens and RedactEmails.
+func DefaultRedactionRules() []RedactionRule {
+	return []RedactionRule{RedactAPIKeys, RedactBearerTokens, RedactEmails}
+}
+
+type redactor struct {
+	rules     []RedactionRule
+	allowlist map[string]bool
+}
+
+// RedactionOption configures WithRedaction.
+type RedactionOption func(*redactor)
+
+// WithRedactionRules sets the rules to apply instead of DefaultRedactionRules, e.g. to add
+// custom ones: WithRedactionRules(append(traces.DefaultRedactionRules(), rule)...).
+func WithRedactionRules(rules ...RedactionRule) RedactionOption {
+	return func(r *redactor) {
+		r.rules = rules
+	}
+}
+
+// WithRedactionAllowlist keeps the matches of the rules which are equal to one of the
+// values, e.g. the public support email address.
+func WithRedactionAllowlist(values ...string) RedactionOption {
+	return func(r *redactor) {
+		for _, value := range values {
+			r.allowlist[value] = true
+		}
+	}
+}
+
+// WithRedaction replaces the secret-looking substrings of the input, output and metadata of
+// traces and observations before they're sent, to reduce accidental leaks of secrets into
+// the traces. The strings are found with DefaultRedactionRules, see WithRedactionRules.
+//
+// Strings are searched recursively in maps and slices, and in the fields of structs, which
+// are converted to maps when one of their strings is redacted. The caller's values are kept.
+//
+// Example:
+//
+//	ingestor := traces.NewIngestor(restyCli,
+//		traces.WithRedaction(traces.WithRedactionAllowlist("support@example.com")))
+func WithRedaction(options ...RedactionOption) IngestorOption {
+	r := &redactor{rules: DefaultRedactionRules(), allowlist: make(map[string]bool)}
+	for _, option := range options {
+		option(r)
+	}
+	return func(ingestor *Ingestor) {
+		ingestor.redactor = r
+	}
+}
+
+// redact redacts the secrets of the traces and their observations.
+func (ingestor *Ingestor) redact(traces []*Trace) {
+	r := ingestor.redactor
+	if r == nil || len(r.rules) == 0 {
+		return
+	}
+	for _, trace := range traces {
+		trace.Input, _ = r.redactValue(trace.Input)
+		trace.Output, _ = r.redactValue(trace.Output)
+		trace.Metadata, _ = r.redactValue(trace.Metadata)
+		for _, observation := range trace.observations {
+			mu := observation.metadataLock()
+			mu.Lock()
+			observation.Input, _ = r.redactValue(observation.Input)
+			observation.Output, _ = r.redactValue(observation.Output)
+			observation.Metadata, _ = r.redactValue(observation.Metadata)
+			mu.Unlock()
+		}
+	}
+}
+
+// redactValue returns the value with its secrets redacted, and whether any was. Maps and
+// slices are copied when one of their strings is redacted.
+func (r *redactor) redactValue(value any) (any, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, false
+	case string:
+		redacted := r.redactString(v)
+		return redacted, redacted != v
+	case map[string]any:
+		var copied map[string]any
+		for key, item := range v {
+			newItem, redacted := r.redactValue(item)
+			if !redacted {
+				continue
+			}
+			if copied == nil {
+				copied = maps.Clone(v)
+			}
+			copied[key] = newItem
+		}
+		if copied == nil {
+			return v, false
+		}
+		return copied, true
+	case []any:
+		var copied []any
+		for i, item := range v {
+			newItem, redacted := r.redactValue(item)
+			if !redacted {
+				continue
+			}
+			if copied == nil {
+				copied = slices.Clone(v)
+			}
+			copied[i] = newItem
+		}
+		if copied == nil {
+			return v, false
+		}
+		return copied, true
+	case bool, int, int64, float64:
+		return v, false
+	}
+	// Other values, e.g. structs and typed maps, are searched in their JSON form
+	generic, _ := Sanitize(value)
+	if redacted, ok := r.redactValue(generic); ok {
+		return redacted, true
+	}
+	return value, false
+}
+
+func (r *redactor) redactString(value string) string {
+	for _, rule := range r.rules {
+		replacement := rule.Replacement
+		if replacement == "" {
+			replacement = RedactedPlaceholder
+		}
+		value = rule.Pattern.ReplaceAllStringFunc(value, func(match string) string {
+			if r.allowlist[match] {
+				return match
+			}
+			return rule.Pattern.ReplaceAllString(match, replacement)
+		})
+	}
+	return value
+}
@@ -0,0 +1,68 @@
+package traces
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+const (
+	// ReplayTag is the tag of the traces created by Replay.
+	ReplayTag = "replay"
+	// MetadataReplayOfKey is the metadata key of the ID of the trace a replay was created from.
+	MetadataReplayOfKey = "replay_of"
+	// MetadataReplayErrorKey is the metadata key of the error returned by the handler of a replay.
+	MetadataReplayErrorKey = "replay_error"
+)
+
+// ReplayHandler handles the input of a replayed trace and returns the new output. The trace
+// of the replay is stored in the context, so observations can be created with WithSpan and
+// WithGeneration, or on the trace directly.
+type ReplayHandler func(ctx context.Context, trace *Trace, input any) (output any, err error)
+
+// Replay runs the handler again with the input of a stored trace, e.g. to debug a regression
+// against captured traffic, see Client.Get.
+//
+// The replay is recorded in a new trace with the name, user, session and tags of the original
+// one, tagged with ReplayTag and linked to the original trace by MetadataReplayOfKey. If the
+// handler fails, the error is recorded under MetadataReplayErrorKey and returned along with
+// the trace, which is ended in any case.
+//
+// Example:
+//
+//	original, err := tracesClient.Get(ctx, traceID)
+//	if err != nil {
+//		return err
+//	}
+//	replay, err := ingestor.Replay(ctx, original, func(ctx context.Context, trace *traces.Trace, input any) (any, error) {
+//		return answer(ctx, input)
+//	})
+//	log.Printf("replayed %s as %s", traceID, replay.URL())
+func (ingestor *Ingestor) Replay(ctx context.Context, original *TraceDetails, handler ReplayHandler) (*Trace, error) {
+	if original == nil || original.ID == "" {
+		return nil, errors.New("'original.id' is required")
+	}
+	if handler == nil {
+		return nil, errors.New("'handler' is required")
+	}
+
+	tags := slices.Clone(original.Tags)
+	if !slices.Contains(tags, ReplayTag) {
+		tags = append(tags, ReplayTag)
+	}
+	trace := ingestor.StartTrace(ctx, original.Name,
+		WithUser(original.UserID),
+		WithSession(original.SessionID),
+		WithTags(tags...),
+		WithInput(original.Input),
+		WithMetadata(map[string]any{MetadataReplayOfKey: original.ID}),
+	)
+
+	output, err := handler(ContextWithTrace(ctx, trace), trace, original.Input)
+	trace.Output = output
+	if metadata, ok := trace.Metadata.(map[string]any); ok && err != nil {
+		metadata[MetadataReplayErrorKey] = err.Error()
+	}
+	trace.End()
+	return trace, err
+}
@@ -0,0 +1,42 @@
+package traces
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/batch"
+)
+
+func TestIngestor_Replay(t *testing.T) {
+	ingestor := NewIngestor(resty.New(), WithBatchConfig(batch.Config{FlushInterval: time.Hour}))
+	defer ingestor.Close()
+	original := &TraceDetails{ID: "trace-1", Name: "chat", UserID: "user-1", Input: "refund?", Tags: []string{"prod"}}
+
+	replay, err := ingestor.Replay(context.Background(), original, func(ctx context.Context, trace *Trace, input any) (any, error) {
+		require.Equal(t, trace, TraceFromContext(ctx))
+		require.NoError(t, WithSpan(ctx, "retrieve", func(context.Context, *Observation) error { return nil }))
+		return "issued: " + input.(string), nil
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, original.ID, replay.ID)
+	require.Equal(t, "chat", replay.Name)
+	require.Equal(t, "user-1", replay.UserID)
+	require.Equal(t, []string{"prod", ReplayTag}, replay.Tags)
+	require.Equal(t, "issued: refund?", replay.Output)
+	require.Equal(t, map[string]any{MetadataReplayOfKey: "trace-1"}, replay.Metadata)
+	require.Len(t, replay.Observations(), 1)
+	require.Equal(t, []string{"prod"}, original.Tags)
+
+	failure := errors.New("timeout")
+	replay, err = ingestor.Replay(context.Background(), original, func(context.Context, *Trace, any) (any, error) {
+		return nil, failure
+	})
+	require.ErrorIs(t, err, failure)
+	require.Equal(t, "timeout", replay.Metadata.(map[string]any)[MetadataReplayErrorKey])
+	require.Equal(t, 2, ingestor.QueueStats().Records)
+}
@@ -0,0 +1,203 @@
+package traces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// maxRecordedErrors caps the number of errors kept for FlushWithResult.
+const maxRecordedErrors = 1000
+
+// IngestionErrorKind classifies the failure of an ingestion event or request by its status code.
+type IngestionErrorKind string
+
+const (
+	IngestionErrorValidation  IngestionErrorKind = "validation"
+	IngestionErrorAuth        IngestionErrorKind = "auth"
+	IngestionErrorNotFound    IngestionErrorKind = "not_found"
+	IngestionErrorTooLarge    IngestionErrorKind = "too_large"
+	IngestionErrorRateLimited IngestionErrorKind = "rate_limited"
+	IngestionErrorServer      IngestionErrorKind = "server"
+	IngestionErrorUnknown     IngestionErrorKind = "unknown"
+)
+
+// ClassifyStatus returns the kind of failure of the status code.
+func ClassifyStatus(status int) IngestionErrorKind {
+	switch {
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return IngestionErrorValidation
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return IngestionErrorAuth
+	case status == http.StatusNotFound:
+		return IngestionErrorNotFound
+	case status == http.StatusRequestEntityTooLarge:
+		return IngestionErrorTooLarge
+	case status == http.StatusTooManyRequests:
+		return IngestionErrorRateLimited
+	case status >= 500 && status <= 599:
+		return IngestionErrorServer
+	}
+	return IngestionErrorUnknown
+}
+
+// Kind classifies the error by its status.
+func (e IngestionError) Kind() IngestionErrorKind {
+	return ClassifyStatus(e.Status)
+}
+
+// Retryable reports whether sending the event again may succeed.
+func (e IngestionError) Retryable() bool {
+	kind := e.Kind()
+	return kind == IngestionErrorRateLimited || kind == IngestionErrorServer
+}
+
+// IngestionSuccess is an event accepted by the ingestion API.
+type IngestionSuccess struct {
+	ID     string `json:"id"`
+	Status int    `json:"status"`
+}
+
+// IngestionResult is the parsed response of an ingestion request, which reports the
+// outcome of each event of the batch.
+type IngestionResult struct {
+	StatusCode int                `json:"-"`
+	Successes  []IngestionSuccess `json:"successes"`
+	Errors     []IngestionError   `json:"errors"`
+}
+
+// IngestionFailure is the error of an ingestion request which failed as a whole, or whose
+// events were rejected in part. Use errors.As to inspect the failed events.
+//
+// Example:
+//
+//	var failure *traces.IngestionFailure
+//	if errors.As(err, &failure) {
+//		for _, rejected := range failure.Result.Errors {
+//			log.Printf("event %s rejected (%s): %s", rejected.ID, rejected.Kind(), rejected.Message)
+//		}
+//	}
+type IngestionFailure struct {
+	Result IngestionResult
+	// Body is the raw response body.
+	Body string
+}
+
+func (f *IngestionFailure) Error() string {
+	if len(f.Result.Errors) > 0 {
+		return fmt.Sprintf("ingestion errors: %v", f.Result.Errors)
+	}
+	return fmt.Sprintf("send traces got unexpected status code: %s, got status code: %d", f.Body, f.Result.StatusCode)
+}
+
+// Kind classifies the failure by the status code of the request, or of the first
+// rejected event if the request succeeded.
+func (f *IngestionFailure) Kind() IngestionErrorKind {
+	if ClassifyStatus(f.Result.StatusCode) == IngestionErrorUnknown && len(f.Result.Errors) > 0 {
+		return f.Result.Errors[0].Kind()
+	}
+	return ClassifyStatus(f.Result.StatusCode)
+}
+
+// Retryable reports whether sending the batch again may succeed.
+func (f *IngestionFailure) Retryable() bool {
+	kind := f.Kind()
+	return kind == IngestionErrorRateLimited || kind == IngestionErrorServer
+}
+
+// WithIngestionErrorHandler sets a function which is called with the error of every failed
+// ingestion request, e.g. to count the dropped events in a metric. Events rejected by
+// Langfuse are reported as *IngestionFailure, other errors are network failures.
+//
+// The handler is called from the goroutines sending the batches, so it must be safe for
+// concurrent use.
+func WithIngestionErrorHandler(handler func(err error)) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.errorHandler = handler
+	}
+}
+
+// FlushResult reports the outcome of the ingestion requests sent since the previous
+// FlushWithResult, or since the ingestor was created.
+type FlushResult struct {
+	Requests  int
+	Succeeded int
+	// Errors holds the rejected events, at most 1000 of them.
+	Errors []IngestionError
+	// RequestErrors holds the errors of requests which failed as a whole, e.g. network
+	// failures, at most 1000 of them.
+	RequestErrors []error
+}
+
+// Err returns the joined errors of the result, or nil if all events were accepted.
+func (r *FlushResult) Err() error {
+	errs := make([]error, 0, len(r.RequestErrors)+1)
+	if len(r.Errors) > 0 {
+		errs = append(errs, &IngestionFailure{Result: IngestionResult{Errors: r.Errors}})
+	}
+	errs = append(errs, r.RequestErrors...)
+	return errors.Join(errs...)
+}
+
+// resultRecorder accumulates the ingestion results for FlushWithResult.
+type resultRecorder struct {
+	mu     sync.Mutex
+	result FlushResult
+}
+
+func (r *resultRecorder) record(result *IngestionResult, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result.Requests++
+	var failure *IngestionFailure
+	if err != nil && !errors.As(err, &failure) {
+		if len(r.result.RequestErrors) < maxRecordedErrors {
+			r.result.RequestErrors = append(r.result.RequestErrors, err)
+		}
+		return
+	}
+	if result == nil {
+		return
+	}
+	r.result.Succeeded += len(result.Successes)
+	if len(result.Errors) == 0 && err != nil {
+		// The request failed as a whole with a status code
+		if len(r.result.RequestErrors) < maxRecordedErrors {
+			r.result.RequestErrors = append(r.result.RequestErrors, err)
+		}
+		return
+	}
+	room := maxRecordedErrors - len(r.result.Errors)
+	r.result.Errors = append(r.result.Errors, result.Errors[:min(room, len(result.Errors))]...)
+}
+
+func (r *resultRecorder) take() *FlushResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := r.result
+	r.result = FlushResult{}
+	return &result
+}
+
+// FlushWithResult sends all buffered traces, waits until they have been sent, and returns
+// the outcome of the ingestion requests sent since the previous call, including those sent
+// in the background. The error is only set if the context is done before, or ErrPaused if
+// the ingestion is paused.
+//
+// Example:
+//
+//	result, err := ingestor.FlushWithResult(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	if err := result.Err(); err != nil {
+//		log.Printf("%d events rejected: %v", len(result.Errors), err)
+//	}
+func (ingestor *Ingestor) FlushWithResult(ctx context.Context) (*FlushResult, error) {
+	if err := ingestor.FlushContext(ctx); err != nil {
+		return nil, err
+	}
+	return ingestor.results.take(), nil
+}
@@ -0,0 +1,88 @@
+package traces
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyStatus(t *testing.T) {
+	require.Equal(t, IngestionErrorValidation, ClassifyStatus(http.StatusBadRequest))
+	require.Equal(t, IngestionErrorAuth, ClassifyStatus(http.StatusUnauthorized))
+	require.Equal(t, IngestionErrorTooLarge, ClassifyStatus(http.StatusRequestEntityTooLarge))
+	require.Equal(t, IngestionErrorRateLimited, ClassifyStatus(http.StatusTooManyRequests))
+	require.Equal(t, IngestionErrorServer, ClassifyStatus(http.StatusBadGateway))
+	require.Equal(t, IngestionErrorUnknown, ClassifyStatus(http.StatusOK))
+
+	require.True(t, IngestionError{Status: http.StatusServiceUnavailable}.Retryable())
+	require.False(t, IngestionError{Status: http.StatusBadRequest}.Retryable())
+}
+
+func TestIngestor_IngestionResults(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusMultiStatus)
+			_, _ = w.Write([]byte(`{"successes": [{"id": "event-1", "status": 201}],
+				"errors": [{"id": "event-2", "status": 400, "message": "invalid body"}]}`))
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`<html>bad gateway</html>`))
+		default:
+			w.WriteHeader(http.StatusMultiStatus)
+			_, _ = w.Write([]byte(`{"successes": [{"id": "event-3", "status": 201}], "errors": []}`))
+		}
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var handled []error
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL), WithIngestionErrorHandler(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, err)
+	}))
+	defer ingestor.Close()
+	ctx := context.Background()
+
+	err := ingestor.Send(ctx, []*Trace{ingestor.StartTrace(ctx, "first")})
+	var failure *IngestionFailure
+	require.ErrorAs(t, err, &failure)
+	require.Equal(t, IngestionErrorValidation, failure.Kind())
+	require.False(t, failure.Retryable())
+	require.Equal(t, []IngestionSuccess{{ID: "event-1", Status: 201}}, failure.Result.Successes)
+	require.Equal(t, "invalid body", failure.Result.Errors[0].Message)
+
+	err = ingestor.Send(ctx, []*Trace{ingestor.StartTrace(ctx, "second")})
+	require.ErrorAs(t, err, &failure)
+	require.Equal(t, IngestionErrorServer, failure.Kind())
+	require.True(t, failure.Retryable())
+	require.Equal(t, "send traces got unexpected status code: <html>bad gateway</html>, got status code: 502", err.Error())
+
+	trace := ingestor.StartTrace(ctx, "third")
+	trace.End()
+	result, err := ingestor.FlushWithResult(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Requests)
+	require.Equal(t, 2, result.Succeeded)
+	require.Len(t, result.Errors, 1)
+	require.Len(t, result.RequestErrors, 1)
+	require.ErrorAs(t, result.Err(), &failure)
+
+	mu.Lock()
+	require.Len(t, handled, 2)
+	mu.Unlock()
+
+	// The result is reset after each call
+	result, err = ingestor.FlushWithResult(ctx)
+	require.NoError(t, err)
+	require.Zero(t, result.Requests)
+	require.NoError(t, result.Err())
+}
@@ -0,0 +1,165 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"math"
+	"strconv"
+)
+
+// SamplingStrategy determines when the sample rate is applied to a trace, see WithSamplingStrategy.
+type SamplingStrategy int
+
+const (
+	// SampleOnStart decides whether a trace is sampled when it starts.
+	SampleOnStart SamplingStrategy = iota
+	// SampleErrorBiased keeps all traces with errors and applies the sample rate to the
+	// other ones. A trace has errors if any of its observations has the ERROR level, or
+	// the metadata of the trace or one of its observations has an AttributeHTTPStatusCode
+	// outside of 2xx. The decision is made when the trace ends.
+	SampleErrorBiased
+)
+
+type samplingContextKey struct{}
+
+// ForceSample returns a copy of ctx which makes the traces started with it sampled,
+// regardless of the sample rate, e.g. for the requests of VIP customers.
+func ForceSample(ctx context.Context) context.Context {
+	return context.WithValue(ctx, samplingContextKey{}, true)
+}
+
+// NeverSample returns a copy of ctx which makes the traces started with it not sampled,
+// regardless of the sample rate, e.g. for health checks.
+func NeverSample(ctx context.Context) context.Context {
+	return context.WithValue(ctx, samplingContextKey{}, false)
+}
+
+// WithSampleRate keeps only a fraction of the traces, between 0 and 1, to reduce the
+// ingestion volume. Default is 1, which keeps all traces.
+//
+// The decision is made when a trace is started, from its ID, so that the same trace ID
+// is always sampled the same way. It can be overridden for a request with ForceSample
+// and NeverSample, or for a trace with Trace.SetSampled, e.g. to keep the traces of
+// failed requests, see also SampleErrorBiased. The traces which aren't sampled are
+// dropped when they end.
+//
+// Example:
+//
+//	ingestor := traces.NewIngestor(restyCli, traces.WithSampleRate(0.1))
+//	trace := ingestor.StartTrace(traces.ForceSample(ctx), "checkout")
+func WithSampleRate(rate float64) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.sampleRate = min(max(rate, 0), 1)
+	}
+}
+
+// WithSamplingStrategy sets when the sample rate is applied. Default is SampleOnStart.
+//
+// Example:
+//
+//	// Keep 10% of the successful traces and all the failed ones
+//	ingestor := traces.NewIngestor(restyCli, traces.WithSampleRate(0.1),
+//		traces.WithSamplingStrategy(traces.SampleErrorBiased))
+func WithSamplingStrategy(strategy SamplingStrategy) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.samplingStrategy = strategy
+	}
+}
+
+// sample sets the sampling decision of a trace started with ctx. The decision of the
+// sample rate is revised when the trace ends with the SampleErrorBiased strategy, but
+// not the overrides of the context.
+func (ingestor *Ingestor) sample(ctx context.Context, trace *Trace) {
+	if sampled, ok := ctx.Value(samplingContextKey{}).(bool); ok {
+		trace.unsampled = !sampled
+		return
+	}
+	trace.unsampled = !ingestor.shouldSample(trace.ID)
+	trace.keepOnError = trace.unsampled && ingestor.samplingStrategy == SampleErrorBiased
+}
+
+// shouldSample decides whether a trace is sampled by the sample rate.
+func (ingestor *Ingestor) shouldSample(traceID string) bool {
+	if ingestor.sampleRate >= 1 {
+		return true
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(traceID))
+	return float64(hash.Sum64())/math.MaxUint64 < ingestor.sampleRate
+}
+
+// Sampled reports whether the trace is sent when it ends, see WithSampleRate. With the
+// SampleErrorBiased strategy, it's only final once the trace has ended.
+func (t *Trace) Sampled() bool {
+	return !t.unsampled || (t.keepOnError && t.hasError())
+}
+
+// SetSampled overrides the sampling decision of the trace, e.g. to keep a trace which
+// wasn't sampled because its request failed.
+//
+// Example:
+//
+//	if err != nil {
+//		trace.SetSampled(true)
+//	}
+//	trace.End()
+func (t *Trace) SetSampled(sampled bool) {
+	t.unsampled = !sampled
+	t.keepOnError = false
+}
+
+// hasError reports whether the trace has errors, see SampleErrorBiased.
+func (t *Trace) hasError() bool {
+	if isErrorStatus(t.Metadata) {
+		return true
+	}
+	for _, observation := range t.observations {
+		if observation.Level == ObservationLevelError {
+			return true
+		}
+		mu := observation.metadataLock()
+		mu.Lock()
+		failed := isErrorStatus(observation.Metadata)
+		mu.Unlock()
+		if failed {
+			return true
+		}
+	}
+	return false
+}
+
+// isErrorStatus reports whether the metadata has an AttributeHTTPStatusCode outside of 2xx.
+func isErrorStatus(metadata any) bool {
+	var value any
+	switch m := metadata.(type) {
+	case map[string]any:
+		value = m[AttributeHTTPStatusCode]
+	case map[string]string:
+		value = m[AttributeHTTPStatusCode]
+	}
+	var code int
+	switch v := value.(type) {
+	case int:
+		code = v
+	case int64:
+		code = int(v)
+	case float64:
+		code = int(v)
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return false
+		}
+		code = int(n)
+	case string:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return false
+		}
+		code = n
+	default:
+		return false
+	}
+	return code < 200 || code > 299
+}
@@ -0,0 +1,84 @@
+package traces
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/batch"
+)
+
+func TestIngestor_SampleRate(t *testing.T) {
+	// The batches must not be sent before the queue is checked
+	ingestor := NewIngestor(resty.New(), WithSampleRate(0.5),
+		WithBatchConfig(batch.Config{MaxBatchSize: 2000, FlushInterval: time.Hour}))
+	defer ingestor.Close()
+	ctx := context.Background()
+
+	sampled := 0
+	for range 1000 {
+		trace := ingestor.StartTrace(ctx, "chat")
+		if trace.Sampled() {
+			sampled++
+		}
+		trace.End()
+	}
+	require.InDelta(t, 500, sampled, 100)
+	require.Equal(t, sampled, ingestor.QueueStats().Records)
+
+	// The decision only depends on the trace ID
+	trace := ingestor.StartTrace(ctx, "chat")
+	require.Equal(t, trace.Sampled(), ingestor.shouldSample(trace.ID))
+}
+
+func TestIngestor_SampleOverride(t *testing.T) {
+	ingestor := NewIngestor(resty.New(), WithSampleRate(0), WithBatchConfig(batch.Config{FlushInterval: time.Hour}))
+	defer ingestor.Close()
+	ctx := context.Background()
+
+	require.False(t, ingestor.StartTrace(ctx, "chat").Sampled())
+	require.True(t, ingestor.StartTrace(ForceSample(ctx), "vip").Sampled())
+
+	failed := ingestor.StartTrace(ctx, "failed")
+	failed.SetSampled(true)
+	failed.End()
+	require.Equal(t, 1, ingestor.QueueStats().Records)
+
+	ingestor = NewIngestor(resty.New())
+	defer ingestor.Close()
+	require.True(t, ingestor.StartTrace(ctx, "chat").Sampled())
+	require.False(t, ingestor.StartTrace(NeverSample(ForceSample(ctx)), "health").Sampled())
+}
+
+func TestIngestor_SampleErrorBiased(t *testing.T) {
+	ingestor := NewIngestor(resty.New(), WithSampleRate(0), WithSamplingStrategy(SampleErrorBiased),
+		WithBatchConfig(batch.Config{FlushInterval: time.Hour}))
+	defer ingestor.Close()
+	ctx := context.Background()
+
+	succeeded := ingestor.StartTrace(ctx, "succeeded", WithMetadata(map[string]any{AttributeHTTPStatusCode: 200}))
+	succeeded.StartSpan("retrieve").End()
+	require.False(t, succeeded.Sampled())
+	succeeded.End()
+
+	failed := ingestor.StartTrace(ctx, "failed")
+	span := failed.StartSpan("retrieve")
+	span.Level = ObservationLevelError
+	span.End()
+	require.True(t, failed.Sampled())
+	failed.End()
+
+	rejected := ingestor.StartTrace(ctx, "rejected", WithMetadata(map[string]string{AttributeHTTPStatusCode: "429"}))
+	rejected.End()
+
+	// The overrides of the context are final
+	skipped := ingestor.StartTrace(NeverSample(ctx), "skipped")
+	skipped.StartSpan("retrieve").Level = ObservationLevelError
+	require.False(t, skipped.Sampled())
+	skipped.End()
+
+	require.Equal(t, 2, ingestor.QueueStats().Records)
+}
@@ -0,0 +1,439 @@
+package traces
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/git-hulk/langfuse-go/pkg/logger"
+)
+
+// MetadataKeySanitizedPaths is the metadata key which lists the paths of the values replaced
+// by Sanitize before a trace or observation was sent.
+const MetadataKeySanitizedPaths = ReservedMetadataPrefix + "sanitized_paths"
+
+// Placeholders of the values which can't be encoded as JSON.
+const (
+	sanitizedCycle = "<cycle>"
+	sanitizedNaN   = "NaN"
+	sanitizedInf   = "+Inf"
+	sanitizedNInf  = "-Inf"
+)
+
+// Sanitize returns a copy of the value which can always be encoded as JSON, and the paths
+// of the values which were replaced, e.g. "messages[2].callback".
+//
+// Channels, functions and complex numbers are replaced by a description of their type,
+// NaN and infinite floats by "NaN", "+Inf" and "-Inf", and cyclic references by "<cycle>".
+// Structs are converted to maps following their json tags, and values implementing
+// json.Marshaler are kept as they're encoded, unless their encoding fails.
+//
+// The ingestor sanitizes the input, output, metadata and model parameters of traces and
+// observations which can't be encoded before sending them, instead of dropping the events,
+// and lists the replaced paths in the metadata under MetadataKeySanitizedPaths.
+func Sanitize(value any) (any, []string) {
+	return sanitizeValue(value, "")
+}
+
+func sanitizeValue(value any, root string) (any, []string) {
+	s := newSanitizer(nil)
+	return s.sanitize(reflect.ValueOf(value), root), s.paths
+}
+
+type sanitizer struct {
+	paths []string
+	// visiting holds the references on the current path, to detect cycles
+	visiting    map[any]bool
+	serializers []serializer
+	// serialized is the number of values converted by the serializers
+	serialized int
+	// fallbacks is the number of json.Marshaler values whose encoding failed
+	fallbacks int
+}
+
+func newSanitizer(serializers []serializer) *sanitizer {
+	return &sanitizer{visiting: make(map[any]bool), serializers: serializers}
+}
+
+type sliceRef struct {
+	ptr uintptr
+	len int
+}
+
+var (
+	marshalerType     = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+func (s *sanitizer) replace(path string, placeholder any) any {
+	if path == "" {
+		path = "$"
+	}
+	s.paths = append(s.paths, path)
+	return placeholder
+}
+
+func (s *sanitizer) sanitize(v reflect.Value, path string) any {
+	if !v.IsValid() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return nil
+		}
+	}
+	if serialized, ok := s.serialize(v, path); ok {
+		return serialized
+	}
+	if marshaler, ok := marshalerOf(v); ok {
+		if v.Kind() == reflect.Pointer && s.visiting[v.Pointer()] {
+			return s.replace(path, sanitizedCycle)
+		}
+		if data, err := json.Marshal(marshaler); err == nil {
+			return json.RawMessage(data)
+		}
+		s.fallbacks++
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+			// Fall back to the contents of the value
+		default:
+			return s.replace(path, fmt.Sprintf("<unsupported: %s>", v.Type()))
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		return s.sanitize(v.Elem(), path)
+	case reflect.Pointer:
+		ref := v.Pointer()
+		if s.visiting[ref] {
+			return s.replace(path, sanitizedCycle)
+		}
+		s.visiting[ref] = true
+		defer delete(s.visiting, ref)
+		return s.sanitize(v.Elem(), path)
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		switch {
+		case math.IsNaN(f):
+			return s.replace(path, sanitizedNaN)
+		case math.IsInf(f, 1):
+			return s.replace(path, sanitizedInf)
+		case math.IsInf(f, -1):
+			return s.replace(path, sanitizedNInf)
+		}
+		return f
+	case reflect.String:
+		return v.String()
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Encoded as base64 like encoding/json
+			return v.Bytes()
+		}
+		ref := sliceRef{ptr: v.Pointer(), len: v.Len()}
+		if s.visiting[ref] {
+			return s.replace(path, sanitizedCycle)
+		}
+		s.visiting[ref] = true
+		defer delete(s.visiting, ref)
+		return s.sanitizeList(v, path)
+	case reflect.Array:
+		return s.sanitizeList(v, path)
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		ref := v.Pointer()
+		if s.visiting[ref] {
+			return s.replace(path, sanitizedCycle)
+		}
+		s.visiting[ref] = true
+		defer delete(s.visiting, ref)
+		result := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := mapKey(iter.Key())
+			result[key] = s.sanitize(iter.Value(), joinPath(path, key))
+		}
+		return result
+	case reflect.Struct:
+		result := make(map[string]any, v.NumField())
+		s.sanitizeFields(v, path, result)
+		return result
+	}
+	// Channels, functions, complex numbers and unsafe pointers
+	return s.replace(path, fmt.Sprintf("<unsupported: %s>", v.Type()))
+}
+
+// serialize converts the value with the first serializer registered for its type, if any.
+func (s *sanitizer) serialize(v reflect.Value, path string) (any, bool) {
+	if len(s.serializers) == 0 || !v.CanInterface() {
+		return nil, false
+	}
+	for _, serializer := range s.serializers {
+		if !serializer.matches(v.Type()) {
+			continue
+		}
+		serialized, err := serializer.serialize(v.Interface())
+		if err != nil {
+			logger.Get().With(
+				zap.String("path", path),
+				zap.String("type", v.Type().String()),
+				zap.Error(err),
+			).Warn("Failed to serialize the value, encoding it as usual")
+			return nil, false
+		}
+		s.serialized++
+		return serialized, true
+	}
+	return nil, false
+}
+
+func (s *sanitizer) sanitizeList(v reflect.Value, path string) []any {
+	result := make([]any, v.Len())
+	for i := range result {
+		result[i] = s.sanitize(v.Index(i), path+"["+strconv.Itoa(i)+"]")
+	}
+	return result
+}
+
+// sanitizeFields adds the fields of the struct to the result, following the json tags.
+func (s *sanitizer) sanitizeFields(v reflect.Value, path string, result map[string]any) {
+	typ := v.Type()
+	// The fields of embedded structs are added first, so that the outer fields win
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, ok := jsonField(field)
+		if !ok || !field.Anonymous || name != "" || !isStruct(field.Type) {
+			continue
+		}
+		embedded := v.Field(i)
+		if embedded.Kind() == reflect.Pointer {
+			if embedded.IsNil() {
+				continue
+			}
+			embedded = embedded.Elem()
+		}
+		s.sanitizeFields(embedded, path, result)
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, omitEmpty, ok := jsonField(field)
+		if !ok || !field.IsExported() || (field.Anonymous && name == "" && isStruct(field.Type)) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		value := v.Field(i)
+		if omitEmpty && isEmptyValue(value) {
+			continue
+		}
+		result[name] = s.sanitize(value, joinPath(path, name))
+	}
+}
+
+// marshalerOf returns the value as a json.Marshaler or encoding.TextMarshaler, if it is one.
+func marshalerOf(v reflect.Value) (any, bool) {
+	if !v.CanInterface() {
+		return nil, false
+	}
+	if v.Type().Implements(marshalerType) || v.Type().Implements(textMarshalerType) {
+		return v.Interface(), true
+	}
+	if v.CanAddr() {
+		ptrType := reflect.PointerTo(v.Type())
+		if ptrType.Implements(marshalerType) || ptrType.Implements(textMarshalerType) {
+			return v.Addr().Interface(), true
+		}
+	}
+	return nil, false
+}
+
+func isStruct(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct
+}
+
+// isEmptyValue reports whether the value is omitted by the omitempty option like encoding/json
+// does, and additionally omits nil functions and channels.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer, reflect.Func, reflect.Chan:
+		return v.IsZero()
+	}
+	return false
+}
+
+// jsonField returns the JSON name of the struct field, and whether it's encoded at all.
+func jsonField(field reflect.StructField) (string, bool, bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false, false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	omitEmpty := false
+	for _, option := range strings.Split(options, ",") {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, true
+}
+
+func mapKey(key reflect.Value) string {
+	switch key.Kind() {
+	case reflect.String:
+		return key.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10)
+	}
+	if !key.CanInterface() {
+		return key.Type().String()
+	}
+	if marshaler, ok := key.Interface().(encoding.TextMarshaler); ok {
+		if text, err := marshaler.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	return fmt.Sprint(key.Interface())
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// serialize applies the serializers registered with WithSerializer to the traces and
+// observations, and replaces their values which can't be encoded as JSON meanwhile.
+// The other traces are only sanitized when their encoding fails, see encodeEvents.
+func (ingestor *Ingestor) serialize(traces []*Trace) {
+	if len(ingestor.serializers) == 0 {
+		return
+	}
+	for _, trace := range traces {
+		ingestor.sanitizeTrace(trace)
+		for _, observation := range trace.observations {
+			ingestor.sanitizeObservation(observation)
+		}
+	}
+}
+
+// sanitizeTrace replaces the values of the trace which can't be encoded as JSON, which
+// would otherwise fail the whole ingestion request.
+func (ingestor *Ingestor) sanitizeTrace(trace *Trace) {
+	if paths := sanitizeEntry(&trace.Input, &trace.Output, &trace.Metadata, nil, ingestor.serializers); len(paths) > 0 {
+		logger.Get().With(
+			zap.String("trace_id", trace.ID),
+			zap.Strings("paths", paths),
+		).Warn("Replaced trace values which can't be encoded as JSON")
+	}
+}
+
+// sanitizeObservation replaces the values of the observation which can't be encoded as JSON.
+func (ingestor *Ingestor) sanitizeObservation(observation *Observation) {
+	mu := observation.metadataLock()
+	mu.Lock()
+	paths := sanitizeEntry(&observation.Input, &observation.Output, &observation.Metadata, &observation.ModelParameters, ingestor.serializers)
+	mu.Unlock()
+	if len(paths) > 0 {
+		logger.Get().With(
+			zap.String("trace_id", observation.TraceID),
+			zap.String("observation_id", observation.ID),
+			zap.Strings("paths", paths),
+		).Warn("Replaced observation values which can't be encoded as JSON")
+	}
+}
+
+func sanitizeEntry(input, output, metadata *any, modelParameters *map[string]any, serializers []serializer) []string {
+	var paths []string
+	for _, field := range []struct {
+		name  string
+		value *any
+	}{{"input", input}, {"output", output}, {"metadata", metadata}} {
+		sanitized, fieldPaths, ok := sanitizeField(*field.value, field.name, serializers)
+		if ok {
+			*field.value = sanitized
+			paths = append(paths, fieldPaths...)
+		}
+	}
+	if modelParameters != nil && *modelParameters != nil {
+		sanitized, fieldPaths, ok := sanitizeField(*modelParameters, "modelParameters", serializers)
+		if ok {
+			*modelParameters, _ = sanitized.(map[string]any)
+			paths = append(paths, fieldPaths...)
+		}
+	}
+	if len(paths) > 0 {
+		*metadata = withSanitizedPaths(*metadata, paths)
+	}
+	return paths
+}
+
+// sanitizeField returns the sanitized value of a field, and whether it must replace the
+// value because some of its values were replaced or serialized. The value is walked
+// instead of encoded, so that the callers only pay for an encoding they reuse.
+func sanitizeField(value any, name string, serializers []serializer) (any, []string, bool) {
+	s := newSanitizer(serializers)
+	sanitized := s.sanitize(reflect.ValueOf(value), name)
+	if len(s.paths) == 0 && s.serialized == 0 && s.fallbacks == 0 {
+		return nil, nil, false
+	}
+	return sanitized, s.paths, true
+}
+
+// withSanitizedPaths lists the sanitized paths in the metadata, if it's a map.
+func withSanitizedPaths(metadata any, paths []string) any {
+	return withMetadataValue(metadata, MetadataKeySanitizedPaths, paths)
+}
+
+// withMetadataValue returns a copy of the metadata with the value set under the key, if
+// the metadata is a map, otherwise the metadata is returned unchanged.
+func withMetadataValue(metadata any, key string, value any) any {
+	var result map[string]any
+	switch m := metadata.(type) {
+	case nil:
+		result = make(map[string]any, 1)
+	case map[string]any:
+		result = maps.Clone(m)
+	case map[string]string:
+		result = make(map[string]any, len(m)+1)
+		for key, value := range m {
+			result[key] = value
+		}
+	default:
+		return metadata
+	}
+	result[key] = value
+	return result
+}
@@ -0,0 +1,125 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type sanitizeNode struct {
+	Name     string        `json:"name"`
+	Score    float64       `json:"score,omitempty"`
+	Callback func()        `json:"callback,omitempty"`
+	Parent   *sanitizeNode `json:"parent,omitempty"`
+	Skipped  string        `json:"-"`
+	internal string
+}
+
+func TestSanitize(t *testing.T) {
+	root := &sanitizeNode{Name: "root", internal: "hidden"}
+	child := &sanitizeNode{Name: "child", Score: math.NaN(), Callback: func() {}, Parent: root, Skipped: "x"}
+	root.Parent = child
+	cyclic := map[string]any{"name": "loop"}
+	cyclic["self"] = cyclic
+
+	value := map[string]any{
+		"node":      child,
+		"events":    make(chan int),
+		"ratios":    []float64{1, math.Inf(1), math.Inf(-1)},
+		"complex":   complex(1, 2),
+		"cyclic":    cyclic,
+		"createdAt": time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		"counts":    map[int]string{1: "one"},
+	}
+	_, err := json.Marshal(value)
+	require.Error(t, err)
+
+	sanitized, paths := Sanitize(value)
+	require.ElementsMatch(t, []string{
+		"node.score",
+		"node.callback",
+		"node.parent.parent",
+		"events",
+		"ratios[1]",
+		"ratios[2]",
+		"complex",
+		"cyclic.self",
+	}, paths)
+
+	data, err := json.Marshal(sanitized)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"node": {
+			"name": "child",
+			"score": "NaN",
+			"callback": "<unsupported: func()>",
+			"parent": {"name": "root", "parent": "<cycle>"}
+		},
+		"events": "<unsupported: chan int>",
+		"ratios": [1, "+Inf", "-Inf"],
+		"complex": "<unsupported: complex128>",
+		"cyclic": {"name": "loop", "self": "<cycle>"},
+		"createdAt": "2024-06-01T00:00:00Z",
+		"counts": {"1": "one"}
+	}`, string(data))
+
+	// Values which can be encoded are left as they are
+	sanitized, paths = Sanitize([]int{1, 2})
+	require.Equal(t, []any{int64(1), int64(2)}, sanitized)
+	require.Empty(t, paths)
+}
+
+func TestIngestor_Send_Sanitizes(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"successes": [], "errors": []}`))
+	}))
+	defer server.Close()
+
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL))
+	trace := ingestor.StartTrace(context.Background(), "handler")
+	trace.Input = map[string]any{"done": make(chan struct{})}
+	trace.Metadata = map[string]any{"tenant": "acme"}
+	generation := trace.StartGeneration("llm")
+	generation.Output = math.NaN()
+	generation.ModelParameters = map[string]any{"temperature": math.Inf(1)}
+	span := trace.StartSpan("ok")
+	span.Input = "plain"
+
+	require.NoError(t, ingestor.Send(context.Background(), []*Trace{trace}))
+
+	var request struct {
+		Batch []struct {
+			Body map[string]any `json:"body"`
+		} `json:"batch"`
+	}
+	require.NoError(t, json.Unmarshal(body, &request))
+	require.Len(t, request.Batch, 3)
+
+	traceBody := request.Batch[0].Body
+	require.Equal(t, map[string]any{"done": "<unsupported: chan struct {}>"}, traceBody["input"])
+	require.Equal(t, map[string]any{
+		"tenant":                  "acme",
+		MetadataKeySanitizedPaths: []any{"input.done"},
+	}, traceBody["metadata"])
+
+	generationBody := request.Batch[1].Body
+	require.Equal(t, "NaN", generationBody["output"])
+	require.Equal(t, map[string]any{"temperature": "+Inf"}, generationBody["modelParameters"])
+	require.Equal(t, map[string]any{
+		MetadataKeySanitizedPaths: []any{"output", "modelParameters.temperature"},
+	}, generationBody["metadata"])
+
+	require.Equal(t, "plain", request.Batch[2].Body["input"])
+	require.Nil(t, request.Batch[2].Body["metadata"])
+}
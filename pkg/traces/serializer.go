@@ -0,0 +1,53 @@
+package traces
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// serializer converts the values of a type before they're embedded into the ingestion events.
+type serializer struct {
+	typ       reflect.Type
+	serialize func(value any) (any, error)
+}
+
+// matches reports whether the serializer converts values of the given type. Serializers
+// registered for an interface convert all the types implementing it.
+func (s serializer) matches(typ reflect.Type) bool {
+	if s.typ.Kind() == reflect.Interface {
+		return typ.Implements(s.typ)
+	}
+	return typ == s.typ
+}
+
+// WithSerializer registers a custom serialization for the values of type T in the input,
+// output, metadata and model parameters of traces and observations, including the nested
+// ones, e.g. to encode protobuf messages with protojson. If T is an interface, the values
+// of all the types implementing it are serialized. The serializers are tried in the order
+// they're registered.
+//
+// The returned value is embedded instead of the original one, return a json.RawMessage to
+// embed JSON which is already encoded. If the serialization fails, the value is encoded
+// as usual.
+//
+// Example:
+//
+//	ingestor := traces.NewIngestor(restyCli,
+//		traces.WithSerializer(func(message proto.Message) (any, error) {
+//			data, err := protojson.Marshal(message)
+//			return json.RawMessage(data), err
+//		}))
+func WithSerializer[T any](serialize func(value T) (any, error)) IngestorOption {
+	return func(ingestor *Ingestor) {
+		ingestor.serializers = append(ingestor.serializers, serializer{
+			typ: reflect.TypeFor[T](),
+			serialize: func(value any) (any, error) {
+				typed, ok := value.(T)
+				if !ok {
+					return nil, fmt.Errorf("unexpected type %T", value)
+				}
+				return serialize(typed)
+			},
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type testMessage struct {
+	Text string
+}
+
+type testStringer struct{}
+
+func (testStringer) String() string { return "stringer" }
+
+func TestIngestor_Send_Serializers(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(`{"successes": [], "errors": []}`))
+	}))
+	defer server.Close()
+
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL),
+		WithSerializer(func(message *testMessage) (any, error) {
+			if message.Text == "" {
+				return nil, errors.New("empty message")
+			}
+			return json.RawMessage(fmt.Sprintf(`{"text":%q,"encoding":"custom"}`, message.Text)), nil
+		}),
+		WithSerializer(func(stringer fmt.Stringer) (any, error) {
+			return stringer.String(), nil
+		}))
+	trace := ingestor.StartTrace(context.Background(), "handler")
+	trace.Input = map[string]any{"request": &testMessage{Text: "hello"}, "count": 1}
+	trace.Output = &testMessage{}
+	span := trace.StartSpan("span")
+	span.Input = []any{testStringer{}}
+	span.Output = "plain"
+
+	require.NoError(t, ingestor.Send(context.Background(), []*Trace{trace}))
+
+	var request struct {
+		Batch []struct {
+			Body map[string]any `json:"body"`
+		} `json:"batch"`
+	}
+	require.NoError(t, json.Unmarshal(body, &request))
+	require.Len(t, request.Batch, 2)
+
+	traceBody := request.Batch[0].Body
+	require.Equal(t, map[string]any{
+		"request": map[string]any{"text": "hello", "encoding": "custom"},
+		"count":   float64(1),
+	}, traceBody["input"])
+	// The serialization failed, the value is encoded as usual
+	require.Equal(t, map[string]any{"Text": ""}, traceBody["output"])
+	require.Nil(t, traceBody["metadata"])
+
+	spanBody := request.Batch[1].Body
+	require.Equal(t, []any{"stringer"}, spanBody["input"])
+	require.Equal(t, "plain", spanBody["output"])
+}
@@ -0,0 +1,86 @@
+package traces
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Session groups the traces of a multi-turn conversation.
+//
+// Langfuse creates sessions implicitly from the session ID of their traces, so the
+// session handle attaches its ID, user and metadata to every trace started from it.
+// It's safe for concurrent use.
+type Session struct {
+	ID string
+
+	ingestor *Ingestor
+	mu       sync.Mutex
+	userID   string
+	metadata map[string]any
+}
+
+// StartSession returns a handle for the session with the given ID. If the ID is empty,
+// a new one is generated.
+//
+// Example:
+//
+//	session := ingestor.StartSession(conversationID)
+//	session.SetUser(userID)
+//	session.SetMetadata("channel", "web")
+//
+//	trace := session.StartTrace(ctx, "turn")
+func (ingestor *Ingestor) StartSession(id string) *Session {
+	if id == "" {
+		id = ingestor.idGenerator.NewTraceID(context.Background())
+	}
+	return &Session{ID: id, ingestor: ingestor}
+}
+
+// SetUser sets the user of the traces started afterward, unless they set one themselves.
+func (s *Session) SetUser(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.userID = userID
+}
+
+// SetMetadata sets a metadata key for the traces started afterward.
+//
+// The session metadata is merged into the metadata of each trace, the keys set on a
+// trace take precedence. Traces whose metadata isn't a map[string]any keep it as is.
+func (s *Session) SetMetadata(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.metadata == nil {
+		s.metadata = make(map[string]any)
+	}
+	s.metadata[key] = value
+}
+
+// StartTrace creates a new trace in the session, see Ingestor.StartTrace.
+func (s *Session) StartTrace(ctx context.Context, name string, options ...TraceOption) *Trace {
+	return s.ingestor.StartTrace(ctx, name, append(slices.Clip(options), s.applyTo)...)
+}
+
+// applyTo sets the session, user and metadata of the session on a trace.
+func (s *Session) applyTo(trace *TraceEntry) {
+	trace.SessionID = s.ID
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if trace.UserID == "" {
+		trace.UserID = s.userID
+	}
+	if len(s.metadata) == 0 {
+		return
+	}
+	switch metadata := trace.Metadata.(type) {
+	case nil:
+		trace.Metadata = maps.Clone(s.metadata)
+	case map[string]any:
+		merged := maps.Clone(s.metadata)
+		maps.Copy(merged, metadata)
+		trace.Metadata = merged
+	}
+}
@@ -0,0 +1,78 @@
+package traces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_StartTrace(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	defer ingestor.Close()
+	ctx := context.Background()
+
+	session := ingestor.StartSession("conversation-1")
+	require.Equal(t, "conversation-1", session.ID)
+
+	first := session.StartTrace(ctx, "turn")
+	require.Equal(t, "conversation-1", first.SessionID)
+	require.Empty(t, first.UserID)
+	require.Nil(t, first.Metadata)
+
+	session.SetUser("user-1")
+	session.SetMetadata("channel", "web")
+	session.SetMetadata("locale", "en")
+
+	second := session.StartTrace(ctx, "turn", WithMetadata(map[string]any{"locale": "de", "turn": 2}))
+	require.Equal(t, "conversation-1", second.SessionID)
+	require.Equal(t, "user-1", second.UserID)
+	require.Equal(t, map[string]any{"channel": "web", "locale": "de", "turn": 2}, second.Metadata)
+
+	third := session.StartTrace(ctx, "turn", WithUser("user-2"), WithMetadata("raw"))
+	require.Equal(t, "user-2", third.UserID)
+	require.Equal(t, "raw", third.Metadata)
+
+	// The traces don't share the metadata of the session
+	second.Metadata.(map[string]any)["channel"] = "api"
+	require.Equal(t, map[string]any{"channel": "web", "locale": "en"}, session.StartTrace(ctx, "turn").Metadata)
+}
+
+func TestIngestor_StartSession_GeneratesID(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	defer ingestor.Close()
+
+	session := ingestor.StartSession("")
+	require.NotEmpty(t, session.ID)
+	require.NotEqual(t, session.ID, ingestor.StartSession("").ID)
+}
+
+func TestIngestor_WithTraceDecorator(t *testing.T) {
+	var decorated []string
+	ingestor := NewIngestor(resty.New(),
+		WithTraceDecorator(func(trace *Trace) {
+			trace.Environment = "production"
+			trace.Tags = append(trace.Tags, "team:search")
+			if trace.UserID == "" {
+				trace.UserID = "anonymous"
+			}
+		}),
+		WithTraceDecorator(func(trace *Trace) {
+			decorated = append(decorated, trace.Name)
+		}))
+	defer ingestor.Close()
+
+	trace := ingestor.StartTrace(context.Background(), "search", WithTags("api"))
+	require.Equal(t, "production", trace.Environment)
+	require.Equal(t, []string{"api", "team:search"}, trace.Tags)
+	require.Equal(t, "anonymous", trace.UserID)
+
+	// Decorators see the fields set by the session
+	session := ingestor.StartSession("session-1")
+	session.SetUser("user-1")
+	trace = session.StartTrace(context.Background(), "turn")
+	require.Equal(t, "session-1", trace.SessionID)
+	require.Equal(t, "user-1", trace.UserID)
+	require.Equal(t, []string{"search", "turn"}, decorated)
+}
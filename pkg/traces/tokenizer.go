@@ -0,0 +1,129 @@
+package traces
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MetadataUsageEstimatedKey is the metadata key which marks generations whose usage was
+// estimated by a Tokenizer instead of being reported by the model provider.
+const MetadataUsageEstimatedKey = "usage_estimated"
+
+// Tokenizer counts the tokens of a text for a model.
+//
+// Plug in an exact tokenizer, e.g. one based on tiktoken, with WithTokenizer. The
+// ApproximateTokenizer is used if none is provided.
+type Tokenizer interface {
+	CountTokens(model, text string) int
+}
+
+// ApproximateTokenizer estimates the number of tokens without a vocabulary.
+//
+// It counts about 4 characters of Latin text per token and one token per character
+// of scripts like Chinese or Japanese, which is close to the tokenizers of common
+// models but not exact.
+type ApproximateTokenizer struct{}
+
+// CountTokens implements Tokenizer.
+func (ApproximateTokenizer) CountTokens(_ string, text string) int {
+	var latin, other int
+	for _, r := range text {
+		switch {
+		case r < utf8.RuneSelf || unicode.Is(unicode.Latin, r) || unicode.IsSpace(r):
+			latin++
+		default:
+			other++
+		}
+	}
+	return (latin+3)/4 + other
+}
+
+// WithTokenizer enables the estimation of the usage of generations which don't report one.
+//
+// Before a batch is sent, generations without usage get their input and output tokens
+// counted by the tokenizer, and are marked with the MetadataUsageEstimatedKey metadata.
+// Pass nil to use the ApproximateTokenizer.
+func WithTokenizer(tokenizer Tokenizer) IngestorOption {
+	return func(ingestor *Ingestor) {
+		if tokenizer == nil {
+			tokenizer = ApproximateTokenizer{}
+		}
+		ingestor.tokenizer = tokenizer
+	}
+}
+
+// EstimateUsage counts the tokens of the input and output of the observation with the
+// tokenizer, if the observation has no usage yet. The counted text is the string values
+// of the input and output, e.g. the contents of chat messages.
+//
+// The usage is marked as estimated with the MetadataUsageEstimatedKey metadata. It returns
+// false if the observation already had a usage.
+func (o *Observation) EstimateUsage(tokenizer Tokenizer) bool {
+	if !o.Usage.IsZero() || len(o.UsageDetails) > 0 {
+		return false
+	}
+	if tokenizer == nil {
+		tokenizer = ApproximateTokenizer{}
+	}
+	input := tokenizer.CountTokens(o.Model, textOf(o.Input))
+	output := tokenizer.CountTokens(o.Model, textOf(o.Output))
+	o.Usage = Usage{Input: input, Output: output, Total: input + output, Unit: UnitTokens}
+	// Metadata which isn't a map can't be marked, but the usage is still useful
+	_ = o.AddMetadata(MetadataUsageEstimatedKey, true)
+	return true
+}
+
+func (ingestor *Ingestor) estimateUsage(traces []*Trace) {
+	if ingestor.tokenizer == nil {
+		return
+	}
+	for _, trace := range traces {
+		for _, observation := range trace.observations {
+			if observation.Type == ObservationTypeGeneration {
+				observation.EstimateUsage(ingestor.tokenizer)
+			}
+		}
+	}
+}
+
+// textOf joins the string values of a value which is encoded to JSON, skipping the keys.
+func textOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return ""
+	}
+	var sb strings.Builder
+	collectText(decoded, &sb)
+	return sb.String()
+}
+
+func collectText(value any, sb *strings.Builder) {
+	switch v := value.(type) {
+	case string:
+		if sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(v)
+	case []any:
+		for _, item := range v {
+			collectText(item, sb)
+		}
+	case map[string]any:
+		for _, item := range v {
+			collectText(item, sb)
+		}
+	}
+}
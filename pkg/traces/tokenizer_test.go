@@ -0,0 +1,71 @@
+package traces
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type wordTokenizer struct{}
+
+func (wordTokenizer) CountTokens(_ string, text string) int {
+	return len(strings.Fields(text))
+}
+
+func TestApproximateTokenizer(t *testing.T) {
+	tokenizer := ApproximateTokenizer{}
+	require.Equal(t, 0, tokenizer.CountTokens("gpt-4o", ""))
+	require.Equal(t, 4, tokenizer.CountTokens("gpt-4o", "Hello, world!"))
+	require.Equal(t, 4, tokenizer.CountTokens("gpt-4o", "你好世界"))
+}
+
+func TestObservation_EstimateUsage(t *testing.T) {
+	generation := &Observation{
+		Type:  ObservationTypeGeneration,
+		Model: "gpt-4o",
+		Input: []map[string]any{
+			{"role": "system", "content": "Be brief."},
+			{"role": "user", "content": "What is Langfuse?"},
+		},
+		Output: "An LLM engineering platform.",
+	}
+	require.True(t, generation.EstimateUsage(wordTokenizer{}))
+	// The roles are values too: system, user, plus 2 and 3 words of content
+	require.Equal(t, Usage{Input: 7, Output: 4, Total: 11, Unit: UnitTokens}, generation.Usage)
+	require.Equal(t, map[string]any{MetadataUsageEstimatedKey: true}, generation.Metadata)
+
+	// A reported usage is kept
+	reported := &Observation{Type: ObservationTypeGeneration, Input: "hi", Usage: Usage{Input: 1, Unit: UnitTokens}}
+	require.False(t, reported.EstimateUsage(wordTokenizer{}))
+	require.Equal(t, 1, reported.Usage.Input)
+	require.Nil(t, reported.Metadata)
+}
+
+func TestIngestor_WithTokenizer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"successes":[],"errors":[]}`))
+	}))
+	defer server.Close()
+
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL), WithTokenizer(nil))
+	defer ingestor.Close()
+
+	trace := ingestor.StartTrace(context.Background(), "chat")
+	span := trace.StartSpan("prepare")
+	span.Input = "not a generation"
+	span.End()
+	generation := trace.StartGeneration("llm")
+	generation.Input = "Hello, world!"
+	generation.Output = "Hi!"
+	generation.End()
+
+	require.NoError(t, ingestor.Send(context.Background(), []*Trace{trace}))
+	require.Equal(t, Usage{Input: 4, Output: 1, Total: 5, Unit: UnitTokens}, generation.Usage)
+	require.True(t, span.Usage.IsZero())
+}
@@ -6,6 +6,8 @@
 package traces
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -13,6 +15,9 @@ import (
 	"github.com/git-hulk/langfuse-go/pkg/logger"
 )
 
+// defaultFlushTimeout bounds EndAndFlush if the context has no deadline.
+const defaultFlushTimeout = 5 * time.Second
+
 // TraceEntry represents the core data structure for a trace in Langfuse.
 //
 // A trace captures a single execution flow in your application with timing,
@@ -45,6 +50,8 @@ type Trace struct {
 
 	ingestor     *Ingestor
 	observations []*Observation
+	unsampled    bool
+	keepOnError  bool
 }
 
 // End finalizes the trace by calculating its latency and submitting it for batch processing.
@@ -53,8 +60,7 @@ type Trace struct {
 // then submits the trace to the batch processor for efficient ingestion to Langfuse.
 // If submission fails, an error is logged but the method does not return an error.
 func (t *Trace) End() {
-	t.Latency = time.Since(t.Timestamp).Milliseconds()
-	if err := t.ingestor.processor.Submit(t); err != nil {
+	if err := t.submit(); err != nil {
 		logger.Get().With(
 			zap.Error(err),
 			zap.String("trace_name", t.Name),
@@ -62,6 +68,97 @@ func (t *Trace) End() {
 	}
 }
 
+// EndAndFlush finalizes the trace like End, then flushes the buffered traces and
+// waits until they have been sent.
+//
+// The flush is bounded by the context, so passing the request context keeps it within
+// the remaining request deadline. If the context has no deadline, the flush is bounded
+// by a default timeout of 5 seconds. This is meant for serverless environments like
+// AWS Lambda or Cloud Run, where the process may be frozen right after the handler returns.
+// It returns ErrPaused if the ingestion is paused, as the trace is kept in the queue.
+//
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		trace := client.StartTrace(r.Context(), "handler")
+//		defer func() {
+//			if err := trace.EndAndFlush(r.Context()); err != nil {
+//				log.Printf("failed to flush trace: %v", err)
+//			}
+//		}()
+//		// ... handle the request
+//	}
+func (t *Trace) EndAndFlush(ctx context.Context) error {
+	if err := t.submit(); err != nil {
+		return err
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultFlushTimeout)
+		defer cancel()
+	}
+	return t.ingestor.FlushContext(ctx)
+}
+
+// EndAll ends all observations which are still open, then ends the trace like End.
+//
+// The observations are ended children first with one shared end time, so that no child
+// ends after its parent. This prevents dangling observations when an early return skips
+// their End calls.
+//
+// Example:
+//
+//	trace := client.StartTrace(ctx, "handler")
+//	defer trace.EndAll()
+//
+//	span := trace.StartSpan("retrieve")
+//	if err := retrieve(ctx); err != nil {
+//		return err // the span is ended by EndAll
+//	}
+//	span.End()
+func (t *Trace) EndAll() {
+	t.endOpenObservations(t.ingestor.clock.Now())
+	t.End()
+}
+
+// endOpenObservations sets the end time of the observations which haven't ended yet.
+// Children are started after their parents, so the reverse start order ends children first.
+func (t *Trace) endOpenObservations(endTime time.Time) {
+	for i := len(t.observations) - 1; i >= 0; i-- {
+		observation := t.observations[i]
+		if observation.EndTime == nil || observation.EndTime.IsZero() {
+			observation.setEndTime(endTime)
+		}
+	}
+}
+
+// submit calculates the latency of the trace and submits it for batch processing.
+// Traces which aren't sampled are dropped.
+func (t *Trace) submit() error {
+	if !t.Sampled() {
+		return nil
+	}
+	if t.ingestor.loopThreshold > 0 {
+		t.DetectLoops(t.ingestor.loopThreshold)
+	}
+	if err := t.ingestor.checkLimits(t); err != nil {
+		return err
+	}
+	t.Latency = t.ingestor.clock.Now().Sub(t.Timestamp).Milliseconds()
+	return t.ingestor.processor.Submit(t)
+}
+
+// URL returns the link to the trace in the Langfuse UI.
+//
+// It returns an empty string if the ingestor has no URL builder configured or
+// the link couldn't be built, for example because the project lookup failed.
+func (t *Trace) URL() string {
+	if t.ingestor == nil || t.ingestor.urlBuilder == nil {
+		return ""
+	}
+	return t.ingestor.urlBuilder(t.ID)
+}
+
 func (t *Trace) getParentObservationID() string {
 	if len(t.observations) == 0 {
 		return t.ID // If no observations, use trace ID as parent
@@ -90,14 +187,20 @@ func (t *Trace) StartSpan(name string) *Observation {
 // The observation's start time is set to the current time.
 // Returns an Observation that can be used to add data and end the observation.
 func (t *Trace) StartObservation(name string, typ ObservationType) *Observation {
-	observationID := t.ingestor.idGenerator.GenerateSpanID().String()
+	observationID := t.ingestor.idGenerator.NewSpanID(t.ID)
 	observation := &Observation{
 		TraceID:             t.ID,
 		ID:                  observationID,
 		Name:                name,
 		Type:                typ,
 		ParentObservationID: t.getParentObservationID(),
-		StartTime:           time.Now(),
+		StartTime:           t.ingestor.clock.Now(),
+		clock:               t.ingestor.clock,
+		metadataMu:          &sync.Mutex{},
+		trace:               t,
+	}
+	if inherited := t.inheritedMetadata(); inherited != nil {
+		observation.Metadata = inherited
 	}
 	t.observations = append(t.observations, observation)
 	return observation
@@ -0,0 +1,60 @@
+package traces
+
+// TraceOption describes a trace at creation, see Ingestor.StartTrace.
+type TraceOption func(*TraceEntry)
+
+// WithUser sets the ID of the user the trace belongs to.
+func WithUser(userID string) TraceOption {
+	return func(entry *TraceEntry) {
+		entry.UserID = userID
+	}
+}
+
+// WithSession groups the trace into the session with the given ID.
+func WithSession(sessionID string) TraceOption {
+	return func(entry *TraceEntry) {
+		entry.SessionID = sessionID
+	}
+}
+
+// WithTags adds tags to the trace.
+func WithTags(tags ...string) TraceOption {
+	return func(entry *TraceEntry) {
+		entry.Tags = append(entry.Tags, tags...)
+	}
+}
+
+// WithInput sets the input of the trace.
+func WithInput(input any) TraceOption {
+	return func(entry *TraceEntry) {
+		entry.Input = input
+	}
+}
+
+// WithMetadata sets the metadata of the trace.
+func WithMetadata(metadata any) TraceOption {
+	return func(entry *TraceEntry) {
+		entry.Metadata = metadata
+	}
+}
+
+// WithRelease sets the release of the application which produced the trace.
+func WithRelease(release string) TraceOption {
+	return func(entry *TraceEntry) {
+		entry.Release = release
+	}
+}
+
+// WithVersion sets the version of the trace, e.g. to compare the behavior of code changes.
+func WithVersion(version string) TraceOption {
+	return func(entry *TraceEntry) {
+		entry.Version = version
+	}
+}
+
+// WithEnvironment sets the environment of the trace, e.g. "production" or "staging".
+func WithEnvironment(environment string) TraceOption {
+	return func(entry *TraceEntry) {
+		entry.Environment = environment
+	}
+}
@@ -0,0 +1,36 @@
+package traces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestor_StartTrace_Options(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	trace := ingestor.StartTrace(context.Background(), "chat",
+		WithUser("user-1"),
+		WithSession("session-1"),
+		WithTags("production"),
+		WithTags("chat", "v2"),
+		WithInput(map[string]string{"question": "hi"}),
+		WithMetadata(map[string]any{"region": "eu"}),
+		WithRelease("1.2.3"),
+		WithVersion("v2"),
+		WithEnvironment("production"),
+	)
+
+	require.NotEmpty(t, trace.ID)
+	require.False(t, trace.Timestamp.IsZero())
+	require.Equal(t, "chat", trace.Name)
+	require.Equal(t, "user-1", trace.UserID)
+	require.Equal(t, "session-1", trace.SessionID)
+	require.Equal(t, []string{"production", "chat", "v2"}, trace.Tags)
+	require.Equal(t, map[string]string{"question": "hi"}, trace.Input)
+	require.Equal(t, map[string]any{"region": "eu"}, trace.Metadata)
+	require.Equal(t, "1.2.3", trace.Release)
+	require.Equal(t, "v2", trace.Version)
+	require.Equal(t, "production", trace.Environment)
+}
@@ -1,8 +1,10 @@
 package traces
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -411,3 +413,47 @@ func TestTrace_StartGeneration(t *testing.T) {
 	assert.Equal(t, generation2.Type, observation.Type, "StartGeneration should be equivalent to StartObservation with Generation type")
 	assert.Equal(t, ObservationTypeGeneration, generation2.Type, "StartGeneration should create observations with Generation type")
 }
+
+func TestTrace_EndAndFlush(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"successes":[],"errors":[]}`))
+	}))
+	defer server.Close()
+
+	ingestor := NewIngestor(resty.New().SetBaseURL(server.URL))
+	defer func() { require.NoError(t, ingestor.Close()) }()
+
+	trace := ingestor.StartTrace(context.Background(), "handler")
+	trace.StartSpan("step").End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, trace.EndAndFlush(ctx))
+	require.EqualValues(t, 1, received.Load())
+}
+
+func TestTrace_EndAll(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: start}
+	ingestor := NewIngestor(resty.New(), WithClock(clock))
+	defer ingestor.Close()
+
+	trace := ingestor.StartTrace(context.Background(), "handler")
+	ended := trace.StartSpan("ended")
+	clock.now = start.Add(time.Second)
+	ended.End()
+	parent := trace.StartSpan("parent")
+	child := trace.StartGeneration("child")
+	require.Equal(t, parent.ID, child.ParentObservationID)
+
+	clock.now = start.Add(3 * time.Second)
+	trace.EndAll()
+
+	require.Equal(t, start.Add(time.Second), *ended.EndTime)
+	require.Equal(t, start.Add(3*time.Second), *parent.EndTime)
+	require.Equal(t, start.Add(3*time.Second), *child.EndTime)
+	require.Equal(t, int64(3000), trace.Latency)
+}
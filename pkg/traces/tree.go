@@ -0,0 +1,82 @@
+package traces
+
+import "encoding/json"
+
+// ObservationNode is an observation with its child observations in the observation tree of a trace.
+type ObservationNode struct {
+	Observation *Observation       `json:"observation"`
+	Children    []*ObservationNode `json:"children,omitempty"`
+}
+
+// ObservationTree is the in-memory state of a trace with its observations arranged as a tree.
+type ObservationTree struct {
+	Trace        TraceEntry         `json:"trace"`
+	Observations []*ObservationNode `json:"observations,omitempty"`
+}
+
+// Observations returns the observations of the trace in the order they were started.
+//
+// The returned slice is a copy, but the observations are shared with the trace.
+func (t *Trace) Observations() []*Observation {
+	return append([]*Observation(nil), t.observations...)
+}
+
+// Children returns the direct child observations in the order they were started.
+//
+// It returns nil for observations which were not started from a trace.
+func (o *Observation) Children() []*Observation {
+	if o.trace == nil {
+		return nil
+	}
+	var children []*Observation
+	for _, observation := range o.trace.observations {
+		if observation != o && observation.ParentObservationID == o.ID {
+			children = append(children, observation)
+		}
+	}
+	return children
+}
+
+// Tree arranges the observations of the trace as a tree.
+//
+// Observations whose parent is the trace itself or is unknown are roots of the tree.
+func (t *Trace) Tree() *ObservationTree {
+	parentIDs := make(map[string]string, len(t.observations))
+	nodes := make(map[string]*ObservationNode, len(t.observations))
+	for _, observation := range t.observations {
+		parentIDs[observation.ID] = observation.ParentObservationID
+		nodes[observation.ID] = &ObservationNode{Observation: observation}
+	}
+
+	tree := &ObservationTree{Trace: t.TraceEntry}
+	for _, observation := range t.observations {
+		node := nodes[observation.ID]
+		parent, ok := nodes[observation.ParentObservationID]
+		if !ok || isAncestor(parentIDs, observation.ID, observation.ParentObservationID) {
+			tree.Observations = append(tree.Observations, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	return tree
+}
+
+// isAncestor reports whether id is reached by following the parents starting at parentID,
+// which means that attaching id to parentID would create a cycle.
+func isAncestor(parentIDs map[string]string, id, parentID string) bool {
+	for steps := 0; parentID != "" && steps <= len(parentIDs); steps++ {
+		if parentID == id {
+			return true
+		}
+		parentID = parentIDs[parentID]
+	}
+	return false
+}
+
+// ExportTree encodes the observation tree of the trace as indented JSON.
+//
+// This is useful for debugging or attaching the trace to an error report before
+// it's sent to Langfuse.
+func (t *Trace) ExportTree() ([]byte, error) {
+	return json.MarshalIndent(t.Tree(), "", "  ")
+}
@@ -0,0 +1,64 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrace_Tree(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	trace := ingestor.StartTrace(context.Background(), "request")
+	agent := trace.StartObservation("agent", ObservationTypeAgent)
+	tool := trace.StartObservation("tool", ObservationTypeTool)
+	tool.End()
+	generation := trace.StartGeneration("llm")
+	generation.End()
+	agent.End()
+	event := trace.StartObservation("done", ObservationTypeEvent)
+	event.ParentObservationID = trace.ID
+
+	require.Equal(t, []*Observation{agent, tool, generation, event}, trace.Observations())
+	require.Equal(t, []*Observation{tool, generation}, agent.Children())
+	require.Empty(t, tool.Children())
+	require.Nil(t, (&Observation{ID: "detached"}).Children())
+
+	tree := trace.Tree()
+	require.Equal(t, trace.ID, tree.Trace.ID)
+	require.Len(t, tree.Observations, 2)
+	require.Equal(t, agent, tree.Observations[0].Observation)
+	require.Equal(t, event, tree.Observations[1].Observation)
+	require.Len(t, tree.Observations[0].Children, 2)
+	require.Equal(t, generation, tree.Observations[0].Children[1].Observation)
+
+	data, err := trace.ExportTree()
+	require.NoError(t, err)
+	var exported struct {
+		Trace        map[string]any `json:"trace"`
+		Observations []struct {
+			Observation map[string]any `json:"observation"`
+			Children    []any          `json:"children"`
+		} `json:"observations"`
+	}
+	require.NoError(t, json.Unmarshal(data, &exported))
+	require.Equal(t, "request", exported.Trace["name"])
+	require.Equal(t, "agent", exported.Observations[0].Observation["name"])
+	require.Len(t, exported.Observations[0].Children, 2)
+}
+
+func TestTrace_Tree_Cycle(t *testing.T) {
+	ingestor := NewIngestor(resty.New())
+	trace := ingestor.StartTrace(context.Background(), "request")
+	first := trace.StartSpan("first")
+	second := trace.StartSpan("second")
+	first.ParentObservationID = second.ID
+
+	tree := trace.Tree()
+	// Both observations are roots as attaching either to the other would create a cycle
+	require.Len(t, tree.Observations, 2)
+	_, err := trace.ExportTree()
+	require.NoError(t, err)
+}
@@ -0,0 +1,27 @@
+package users_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	langfuse "github.com/git-hulk/langfuse-go"
+	"github.com/git-hulk/langfuse-go/pkg/users"
+)
+
+func ExampleClient_List() {
+	client := langfuse.NewClient("https://cloud.langfuse.com", "pk-lf-...", "sk-lf-...")
+	defer client.Close()
+
+	listUsers, err := client.Users().List(context.Background(), users.ListParams{
+		FromTimestamp: time.Now().AddDate(0, 0, -7),
+		Limit:         50,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, user := range listUsers.Data {
+		fmt.Println(user.ID, user.TraceCount, user.TotalCost)
+	}
+}
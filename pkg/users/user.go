@@ -0,0 +1,271 @@
+// Package users provides functionality for looking up users and their usage in Langfuse.
+//
+// Langfuse tracks the userId attached to traces. This package aggregates traces
+// by user through the metrics API so that tooling such as customer support
+// dashboards can look up how many traces a user produced and what they cost.
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	traceCountField = "count_count"
+	totalCostField  = "sum_totalCost"
+)
+
+// User represents a distinct user observed on traces with aggregated usage.
+//
+// TraceCount is the number of traces attributed to the user and TotalCost is the
+// sum of the cost (in USD) of those traces within the requested time range.
+type User struct {
+	ID         string  `json:"userId"`
+	TraceCount int     `json:"traceCount"`
+	TotalCost  float64 `json:"totalCost"`
+}
+
+// ListParams defines the parameters for listing users and their usage.
+//
+// FromTimestamp is required and ToTimestamp defaults to the current time.
+// Environment can filter traces by specific environments, and Limit caps
+// the number of users returned.
+type ListParams struct {
+	FromTimestamp time.Time
+	ToTimestamp   time.Time
+	Environment   []string
+	Limit         int
+}
+
+func (p *ListParams) validate() error {
+	if p.FromTimestamp.IsZero() {
+		return errors.New("'fromTimestamp' is required")
+	}
+	if !p.ToTimestamp.IsZero() && !p.ToTimestamp.After(p.FromTimestamp) {
+		return errors.New("'toTimestamp' must be after 'fromTimestamp'")
+	}
+	if p.Limit < 0 {
+		return errors.New("'limit' must be greater than or equal to 0")
+	}
+	return nil
+}
+
+// ListUsers represents the response from listing users.
+type ListUsers struct {
+	Data []User `json:"data"`
+}
+
+type metricsDimension struct {
+	Field string `json:"field"`
+}
+
+type metricsMetric struct {
+	Measure     string `json:"measure"`
+	Aggregation string `json:"aggregation"`
+}
+
+type metricsFilter struct {
+	Column   string `json:"column"`
+	Operator string `json:"operator"`
+	Value    any    `json:"value"`
+	Type     string `json:"type"`
+}
+
+type metricsOrderBy struct {
+	Field     string `json:"field"`
+	Direction string `json:"direction"`
+}
+
+type metricsConfig struct {
+	RowLimit int `json:"row_limit,omitempty"`
+}
+
+type metricsQuery struct {
+	View          string             `json:"view"`
+	Dimensions    []metricsDimension `json:"dimensions"`
+	Metrics       []metricsMetric    `json:"metrics"`
+	Filters       []metricsFilter    `json:"filters"`
+	FromTimestamp string             `json:"fromTimestamp"`
+	ToTimestamp   string             `json:"toTimestamp"`
+	OrderBy       []metricsOrderBy   `json:"orderBy,omitempty"`
+	Config        *metricsConfig     `json:"config,omitempty"`
+}
+
+type metricsResponse struct {
+	Data []map[string]any `json:"data"`
+}
+
+func newMetricsQuery(params ListParams) *metricsQuery {
+	toTimestamp := params.ToTimestamp
+	if toTimestamp.IsZero() {
+		toTimestamp = time.Now()
+	}
+
+	query := &metricsQuery{
+		View:       "traces",
+		Dimensions: []metricsDimension{{Field: "userId"}},
+		Metrics: []metricsMetric{
+			{Measure: "count", Aggregation: "count"},
+			{Measure: "totalCost", Aggregation: "sum"},
+		},
+		Filters:       make([]metricsFilter, 0),
+		FromTimestamp: params.FromTimestamp.UTC().Format(time.RFC3339),
+		ToTimestamp:   toTimestamp.UTC().Format(time.RFC3339),
+		OrderBy:       []metricsOrderBy{{Field: traceCountField, Direction: "desc"}},
+	}
+	if len(params.Environment) > 0 {
+		query.Filters = append(query.Filters, metricsFilter{
+			Column:   "environment",
+			Operator: "any of",
+			Value:    params.Environment,
+			Type:     "stringOptions",
+		})
+	}
+	if params.Limit > 0 {
+		query.Config = &metricsConfig{RowLimit: params.Limit}
+	}
+	return query
+}
+
+// Client provides methods for looking up users and their usage in Langfuse.
+//
+// The client queries the metrics API to aggregate traces by userId.
+type Client struct {
+	restyCli *resty.Client
+}
+
+// NewClient creates a new users client with the provided HTTP client.
+//
+// The resty client should be pre-configured with authentication and base URL.
+func NewClient(cli *resty.Client) *Client {
+	return &Client{restyCli: cli}
+}
+
+// List retrieves distinct users with their trace counts and total cost, ordered by trace count.
+//
+// Traces without a userId are not included in the result.
+func (c *Client) List(ctx context.Context, params ListParams) (*ListUsers, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.queryMetrics(ctx, newMetricsQuery(params))
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]User, 0, len(rows))
+	for _, row := range rows {
+		user, err := userFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		if user.ID == "" {
+			continue
+		}
+		users = append(users, *user)
+	}
+	return &ListUsers{Data: users}, nil
+}
+
+// Get retrieves the aggregated usage of a specific user.
+//
+// If the user has no traces within the requested time range, a User with
+// zero usage is returned.
+func (c *Client) Get(ctx context.Context, userID string, params ListParams) (*User, error) {
+	if userID == "" {
+		return nil, errors.New("'userID' is required")
+	}
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	query := newMetricsQuery(params)
+	query.Filters = append(query.Filters, metricsFilter{
+		Column:   "userId",
+		Operator: "=",
+		Value:    userID,
+		Type:     "string",
+	})
+	rows, err := c.queryMetrics(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		user, err := userFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		if user.ID == userID {
+			return user, nil
+		}
+	}
+	return &User{ID: userID}, nil
+}
+
+func (c *Client) queryMetrics(ctx context.Context, query *metricsQuery) ([]map[string]any, error) {
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metrics query: %w", err)
+	}
+
+	var response metricsResponse
+	rsp, err := c.restyCli.R().
+		SetContext(ctx).
+		SetResult(&response).
+		SetQueryParam("query", string(queryBytes)).
+		Get("/metrics")
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.IsError() {
+		return nil, fmt.Errorf("query user metrics failed: %s, got status code: %d", rsp.String(), rsp.StatusCode())
+	}
+	return response.Data, nil
+}
+
+func userFromRow(row map[string]any) (*User, error) {
+	user := &User{}
+	if userID, ok := row["userId"].(string); ok {
+		user.ID = userID
+	}
+
+	traceCount, err := toFloat(row[traceCountField])
+	if err != nil {
+		return nil, fmt.Errorf("invalid '%s' in metrics response: %w", traceCountField, err)
+	}
+	user.TraceCount = int(traceCount)
+
+	totalCost, err := toFloat(row[totalCostField])
+	if err != nil {
+		return nil, fmt.Errorf("invalid '%s' in metrics response: %w", totalCostField, err)
+	}
+	user.TotalCost = totalCost
+	return user, nil
+}
+
+// toFloat converts a metrics value to float64. The metrics API may return
+// aggregated values either as JSON numbers or as numeric strings.
+func toFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return v, nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("unexpected type %T", value)
+	}
+}
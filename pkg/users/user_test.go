@@ -0,0 +1,186 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListParams_validate(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		params  ListParams
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid with from timestamp only",
+			params:  ListParams{FromTimestamp: now.Add(-time.Hour)},
+			wantErr: false,
+		},
+		{
+			name:    "valid with time range",
+			params:  ListParams{FromTimestamp: now.Add(-time.Hour), ToTimestamp: now},
+			wantErr: false,
+		},
+		{
+			name:    "missing from timestamp",
+			params:  ListParams{},
+			wantErr: true,
+			errMsg:  "'fromTimestamp' is required",
+		},
+		{
+			name:    "to timestamp before from timestamp",
+			params:  ListParams{FromTimestamp: now, ToTimestamp: now.Add(-time.Hour)},
+			wantErr: true,
+			errMsg:  "'toTimestamp' must be after 'fromTimestamp'",
+		},
+		{
+			name:    "negative limit",
+			params:  ListParams{FromTimestamp: now, Limit: -1},
+			wantErr: true,
+			errMsg:  "'limit' must be greater than or equal to 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUserClient_List(t *testing.T) {
+	fromTimestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	toTimestamp := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/metrics", r.URL.Path)
+			require.Equal(t, "GET", r.Method)
+
+			var query metricsQuery
+			err := json.Unmarshal([]byte(r.URL.Query().Get("query")), &query)
+			require.NoError(t, err)
+			require.Equal(t, "traces", query.View)
+			require.Equal(t, []metricsDimension{{Field: "userId"}}, query.Dimensions)
+			require.Equal(t, "2025-01-01T00:00:00Z", query.FromTimestamp)
+			require.Equal(t, "2025-01-31T00:00:00Z", query.ToTimestamp)
+			require.Len(t, query.Filters, 1)
+			require.Equal(t, "environment", query.Filters[0].Column)
+			require.NotNil(t, query.Config)
+			require.Equal(t, 10, query.Config.RowLimit)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, err = w.Write([]byte(`{"data":[
+				{"userId":"user-1","count_count":"12","sum_totalCost":0.25},
+				{"userId":"user-2","count_count":3,"sum_totalCost":"0.05"},
+				{"userId":null,"count_count":"7","sum_totalCost":0.1}
+			]}`))
+			require.NoError(t, err)
+		}))
+	defer server.Close()
+
+	cli := resty.New().SetBaseURL(server.URL)
+	client := NewClient(cli)
+
+	users, err := client.List(context.Background(), ListParams{
+		FromTimestamp: fromTimestamp,
+		ToTimestamp:   toTimestamp,
+		Environment:   []string{"production"},
+		Limit:         10,
+	})
+	require.NoError(t, err)
+	require.Len(t, users.Data, 2)
+	require.Equal(t, User{ID: "user-1", TraceCount: 12, TotalCost: 0.25}, users.Data[0])
+	require.Equal(t, User{ID: "user-2", TraceCount: 3, TotalCost: 0.05}, users.Data[1])
+}
+
+func TestUserClient_List_ValidationError(t *testing.T) {
+	cli := resty.New()
+	client := NewClient(cli)
+	_, err := client.List(context.Background(), ListParams{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'fromTimestamp' is required")
+}
+
+func TestUserClient_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/metrics", r.URL.Path)
+
+			var query metricsQuery
+			err := json.Unmarshal([]byte(r.URL.Query().Get("query")), &query)
+			require.NoError(t, err)
+			require.Len(t, query.Filters, 1)
+			require.Equal(t, "userId", query.Filters[0].Column)
+			require.Equal(t, "=", query.Filters[0].Operator)
+			require.Equal(t, "user-1", query.Filters[0].Value)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, err = w.Write([]byte(`{"data":[{"userId":"user-1","count_count":"5","sum_totalCost":1.5}]}`))
+			require.NoError(t, err)
+		}))
+	defer server.Close()
+
+	cli := resty.New().SetBaseURL(server.URL)
+	client := NewClient(cli)
+
+	user, err := client.Get(context.Background(), "user-1", ListParams{FromTimestamp: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	require.Equal(t, &User{ID: "user-1", TraceCount: 5, TotalCost: 1.5}, user)
+}
+
+func TestUserClient_Get_NoUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, err := w.Write([]byte(`{"data":[]}`))
+			require.NoError(t, err)
+		}))
+	defer server.Close()
+
+	cli := resty.New().SetBaseURL(server.URL)
+	client := NewClient(cli)
+
+	user, err := client.Get(context.Background(), "user-1", ListParams{FromTimestamp: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	require.Equal(t, &User{ID: "user-1"}, user)
+}
+
+func TestUserClient_Get_MissingUserID(t *testing.T) {
+	cli := resty.New()
+	client := NewClient(cli)
+	_, err := client.Get(context.Background(), "", ListParams{FromTimestamp: time.Now()})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'userID' is required")
+}
+
+func TestUserClient_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"invalid query"}`))
+		}))
+	defer server.Close()
+
+	cli := resty.New().SetBaseURL(server.URL)
+	client := NewClient(cli)
+
+	_, err := client.List(context.Background(), ListParams{FromTimestamp: time.Now().Add(-time.Hour)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "got status code: 400")
+}
@@ -0,0 +1,37 @@
+package webhooks_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/git-hulk/langfuse-go/pkg/webhooks"
+)
+
+func ExampleHandler() {
+	handler, err := webhooks.NewHandler("webhook-secret")
+	if err != nil {
+		log.Fatal(err)
+	}
+	handler.OnPromptVersion(func(ctx context.Context, event *webhooks.Event) error {
+		log.Printf("prompt %s changed to version %d", event.Prompt.Name, event.Prompt.Version)
+		return nil
+	})
+	_ = handler // http.Handle("/webhooks/langfuse", handler)
+}
+
+func ExampleVerifySignature() {
+	payload := []byte(`{"id":"event-1","type":"prompt-version","action":"created","prompt":{"name":"greeting","type":"text","prompt":"Hi","version":2}}`)
+	signature := webhooks.Sign(payload, "webhook-secret", time.Now())
+
+	if err := webhooks.VerifySignature(payload, signature, "webhook-secret", webhooks.DefaultTolerance); err != nil {
+		log.Fatal(err)
+	}
+	event, err := webhooks.Parse(payload)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(event.Type, event.Action, event.Prompt.Name, event.Prompt.Version)
+	// Output: prompt-version created greeting 2
+}
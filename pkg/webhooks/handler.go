@@ -0,0 +1,135 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/git-hulk/langfuse-go/pkg/logger"
+)
+
+// defaultMaxPayloadBytes bounds the size of the webhook payloads read by the Handler.
+const defaultMaxPayloadBytes = 1 << 20
+
+// HandlerFunc handles a webhook event. Returning an error answers the webhook request
+// with 500, so that Langfuse retries its delivery.
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// HandlerOption configures a Handler.
+type HandlerOption func(*Handler)
+
+// WithTolerance sets the maximum age of the accepted signatures. Default is DefaultTolerance,
+// and 0 disables the check.
+func WithTolerance(tolerance time.Duration) HandlerOption {
+	return func(h *Handler) {
+		h.tolerance = tolerance
+	}
+}
+
+// Handler is an http.Handler which verifies, parses and dispatches webhook requests
+// to the handlers registered for their event type.
+type Handler struct {
+	secret    string
+	tolerance time.Duration
+	now       func() time.Time
+
+	mu       sync.RWMutex
+	handlers map[EventType][]HandlerFunc
+	fallback []HandlerFunc
+}
+
+// NewHandler creates a Handler verifying the signatures with the secret of the webhook.
+// It returns an error if the secret is empty, as any request could be signed with it.
+func NewHandler(secret string, options ...HandlerOption) (*Handler, error) {
+	if secret == "" {
+		return nil, errors.New("'secret' is required")
+	}
+	h := &Handler{
+		secret:    secret,
+		tolerance: DefaultTolerance,
+		now:       time.Now,
+		handlers:  make(map[EventType][]HandlerFunc),
+	}
+	for _, option := range options {
+		option(h)
+	}
+	return h, nil
+}
+
+// On registers a handler for the events of the given type.
+func (h *Handler) On(typ EventType, handler HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[typ] = append(h.handlers[typ], handler)
+}
+
+// OnPromptVersion registers a handler for the events of created, updated or deleted prompt versions.
+func (h *Handler) OnPromptVersion(handler HandlerFunc) {
+	h.On(EventTypePromptVersion, handler)
+}
+
+// OnScore registers a handler for the score events.
+func (h *Handler) OnScore(handler HandlerFunc) {
+	h.On(EventTypeScore, handler)
+}
+
+// OnAny registers a handler for all events, including the ones of unknown types.
+func (h *Handler) OnAny(handler HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fallback = append(h.fallback, handler)
+}
+
+// Dispatch calls the handlers registered for the type of the event, and returns their
+// joined errors.
+func (h *Handler) Dispatch(ctx context.Context, event *Event) error {
+	h.mu.RLock()
+	handlers := append(append([]HandlerFunc(nil), h.handlers[event.Type]...), h.fallback...)
+	h.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ServeHTTP verifies and dispatches a webhook request. It answers 401 if the signature
+// is invalid, 400 if the payload can't be parsed and 500 if a handler fails.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := io.ReadAll(io.LimitReader(r.Body, defaultMaxPayloadBytes))
+	if err != nil {
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+		return
+	}
+	if err := verifySignature(payload, r.Header.Get(SignatureHeader), h.secret, h.tolerance, h.now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	event, err := Parse(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.Dispatch(r.Context(), event); err != nil {
+		logger.Get().With(
+			zap.Error(err),
+			zap.String("event_id", event.ID),
+			zap.String("event_type", string(event.Type)),
+		).Error("Failed to handle webhook event")
+		http.Error(w, "failed to handle event", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
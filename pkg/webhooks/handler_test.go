@@ -0,0 +1,66 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newWebhookRequest(payload, signature string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(payload))
+	req.Header.Set(SignatureHeader, signature)
+	return req
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	handler, err := NewHandler("secret")
+	require.NoError(t, err)
+	var prompts, all []string
+	handler.OnPromptVersion(func(_ context.Context, event *Event) error {
+		prompts = append(prompts, event.Prompt.Name)
+		return nil
+	})
+	handler.OnScore(func(_ context.Context, event *Event) error {
+		return errors.New("score store unavailable")
+	})
+	handler.OnAny(func(_ context.Context, event *Event) error {
+		all = append(all, event.ID)
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newWebhookRequest(promptPayload, Sign([]byte(promptPayload), "secret", time.Now())))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, []string{"greeting"}, prompts)
+	require.Equal(t, []string{"event-1"}, all)
+
+	scorePayload := `{"id": "event-2", "type": "score", "action": "created", "score": {"id": "score-1"}}`
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newWebhookRequest(scorePayload, Sign([]byte(scorePayload), "secret", time.Now())))
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newWebhookRequest(promptPayload, Sign([]byte(promptPayload), "other", time.Now())))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newWebhookRequest(`{}`, Sign([]byte(`{}`), "secret", time.Now())))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhooks", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	require.Len(t, prompts, 1)
+}
+
+func TestNewHandler_EmptySecret(t *testing.T) {
+	handler, err := NewHandler("")
+	require.EqualError(t, err, "'secret' is required")
+	require.Nil(t, handler)
+}
@@ -0,0 +1,157 @@
+// Package webhooks receives the webhooks sent by Langfuse.
+//
+// It verifies the signature of the webhook requests, parses the event payloads and
+// dispatches them to the registered handlers, e.g. to invalidate a local prompt cache
+// as soon as a new prompt version is published.
+//
+// Example:
+//
+//	handler, err := webhooks.NewHandler(os.Getenv("LANGFUSE_WEBHOOK_SECRET"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	handler.OnPromptVersion(func(ctx context.Context, event *webhooks.Event) error {
+//		promptCache.Delete(event.Prompt.Name)
+//		return nil
+//	})
+//	http.Handle("/webhooks/langfuse", handler)
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/git-hulk/langfuse-go/pkg/prompts"
+	"github.com/git-hulk/langfuse-go/pkg/scores"
+)
+
+// SignatureHeader is the header holding the signature of a webhook request.
+const SignatureHeader = "X-Langfuse-Signature"
+
+// DefaultTolerance is the maximum age of a webhook signature accepted by default.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	// ErrMissingSignature is returned when the signature header is absent or malformed.
+	ErrMissingSignature = errors.New("missing webhook signature")
+	// ErrInvalidSignature is returned when the signature doesn't match the payload.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrSignatureExpired is returned when the signature is older than the tolerance.
+	ErrSignatureExpired = errors.New("webhook signature expired")
+)
+
+// EventType is the type of a webhook event.
+type EventType string
+
+const (
+	EventTypePromptVersion EventType = "prompt-version"
+	EventTypeScore         EventType = "score"
+)
+
+// Action is the change which triggered a webhook event.
+type Action string
+
+const (
+	ActionCreated Action = "created"
+	ActionUpdated Action = "updated"
+	ActionDeleted Action = "deleted"
+)
+
+// Event is the payload of a webhook request.
+//
+// Prompt is set for prompt-version events and Score for score events. The payload of
+// event types which are unknown to this SDK version is kept in Raw.
+type Event struct {
+	ID         string               `json:"id"`
+	Timestamp  time.Time            `json:"timestamp"`
+	Type       EventType            `json:"type"`
+	APIVersion string               `json:"apiVersion,omitempty"`
+	Action     Action               `json:"action"`
+	Prompt     *prompts.PromptEntry `json:"prompt,omitempty"`
+	Score      *scores.Score        `json:"score,omitempty"`
+	Raw        json.RawMessage      `json:"-"`
+}
+
+// Parse parses the payload of a webhook request.
+func Parse(payload []byte) (*Event, error) {
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
+	}
+	if event.Type == "" {
+		return nil, errors.New("'type' is required")
+	}
+	event.Raw = append(json.RawMessage(nil), payload...)
+	return &event, nil
+}
+
+// Sign computes the signature header value of the payload at the given time, which is
+// useful to test webhook handlers.
+func Sign(payload []byte, secret string, timestamp time.Time) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + unix + ",v1=" + computeSignature(payload, secret, unix)
+}
+
+// VerifySignature verifies the signature header of a webhook request, which has the format
+// "t=<unix timestamp>,v1=<hex HMAC-SHA256 of '<timestamp>.<payload>'>".
+//
+// Signatures older than the tolerance are rejected to prevent replays, a tolerance of 0
+// disables this check.
+func VerifySignature(payload []byte, header, secret string, tolerance time.Duration) error {
+	return verifySignature(payload, header, secret, tolerance, time.Now())
+}
+
+func verifySignature(payload []byte, header, secret string, tolerance time.Duration, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return ErrMissingSignature
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrMissingSignature
+	}
+
+	expected := computeSignature(payload, secret, timestamp)
+	matched := false
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return ErrInvalidSignature
+	}
+	if tolerance > 0 && now.Sub(time.Unix(unix, 0)) > tolerance {
+		return ErrSignatureExpired
+	}
+	return nil
+}
+
+func computeSignature(payload []byte, secret, timestamp string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,52 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const promptPayload = `{
+	"id": "event-1",
+	"timestamp": "2025-01-01T00:00:00Z",
+	"type": "prompt-version",
+	"apiVersion": "v1",
+	"action": "created",
+	"prompt": {"name": "greeting", "type": "text", "prompt": "Hello {{name}}", "version": 3, "labels": ["production"]}
+}`
+
+func TestVerifySignature(t *testing.T) {
+	payload := []byte(promptPayload)
+	now := time.Unix(1700000000, 0)
+	header := Sign(payload, "secret", now)
+
+	require.NoError(t, verifySignature(payload, header, "secret", DefaultTolerance, now.Add(time.Minute)))
+	require.ErrorIs(t, verifySignature(payload, header, "other", DefaultTolerance, now), ErrInvalidSignature)
+	require.ErrorIs(t, verifySignature([]byte("{}"), header, "secret", DefaultTolerance, now), ErrInvalidSignature)
+	require.ErrorIs(t, verifySignature(payload, header, "secret", DefaultTolerance, now.Add(time.Hour)), ErrSignatureExpired)
+	require.NoError(t, verifySignature(payload, header, "secret", 0, now.Add(time.Hour)))
+	require.ErrorIs(t, verifySignature(payload, "", "secret", 0, now), ErrMissingSignature)
+	require.ErrorIs(t, verifySignature(payload, "v1=abc", "secret", 0, now), ErrMissingSignature)
+}
+
+func TestParse(t *testing.T) {
+	event, err := Parse([]byte(promptPayload))
+	require.NoError(t, err)
+	require.Equal(t, "event-1", event.ID)
+	require.Equal(t, EventTypePromptVersion, event.Type)
+	require.Equal(t, ActionCreated, event.Action)
+	require.Equal(t, "greeting", event.Prompt.Name)
+	require.Equal(t, 3, event.Prompt.Version)
+	require.Nil(t, event.Score)
+	require.JSONEq(t, promptPayload, string(event.Raw))
+
+	event, err = Parse([]byte(`{"id": "event-2", "type": "score", "action": "created", "score": {"id": "score-1", "name": "accuracy", "value": 0.9}}`))
+	require.NoError(t, err)
+	require.Equal(t, "score-1", event.Score.ID)
+
+	_, err = Parse([]byte(`{"id": "event-3"}`))
+	require.EqualError(t, err, "'type' is required")
+	_, err = Parse([]byte(`not json`))
+	require.Error(t, err)
+}
@@ -0,0 +1,39 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WithStartupHealthCheck checks at client creation that Langfuse is reachable and healthy,
+// and that the credentials are valid, waiting up to timeout for the checks to complete.
+//
+// This detects a misconfigured host or credentials at boot rather than at the first flush,
+// whose failure is only logged. NewClient logs a failed check as a warning, while New returns
+// it as an error.
+func WithStartupHealthCheck(timeout time.Duration) ClientOption {
+	return func(config *clientConfig) {
+		config.startupHealthCheckTimeout = timeout
+	}
+}
+
+// checkStartup pings the health endpoint, then verifies the credentials by looking up
+// the project of the API key, which is kept for building trace URLs.
+func (c *Langfuse) checkStartup(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	health, err := c.health.Check(ctx)
+	if err != nil {
+		return fmt.Errorf("langfuse health check failed: %w", err)
+	}
+	if !strings.EqualFold(health.Status, "OK") {
+		return fmt.Errorf("langfuse is unhealthy: got status %q", health.Status)
+	}
+	if _, err := c.getProjectID(ctx); err != nil {
+		return fmt.Errorf("langfuse credentials check failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package langfuse
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newStartupServer(t *testing.T, healthStatus int, health, projects string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/public/health":
+			w.WriteHeader(healthStatus)
+			_, _ = w.Write([]byte(health))
+		case "/api/public/projects":
+			user, password, ok := r.BasicAuth()
+			if !ok || user != "public-key" || password != "secret-key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"message":"Invalid credentials"}`))
+				return
+			}
+			_, _ = w.Write([]byte(projects))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestNew_StartupHealthCheck(t *testing.T) {
+	server := newStartupServer(t, http.StatusOK, `{"status":"OK","version":"3.0.0"}`, `{"data":[{"id":"project-1"}]}`)
+	defer server.Close()
+
+	client, err := New(server.URL, "public-key", "secret-key", WithStartupHealthCheck(time.Second))
+	require.NoError(t, err)
+	defer client.Close()
+	require.Equal(t, "project-1", client.projectID)
+
+	_, err = New(server.URL, "public-key", "wrong-key", WithStartupHealthCheck(time.Second))
+	require.ErrorContains(t, err, "langfuse credentials check failed")
+
+	// Without the option, no request is sent
+	client, err = New("http://127.0.0.1:0", "public-key", "secret-key")
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+}
+
+func TestNew_StartupHealthCheck_Unhealthy(t *testing.T) {
+	server := newStartupServer(t, http.StatusServiceUnavailable, `{"status":"DOWN"}`, `{}`)
+	defer server.Close()
+
+	_, err := New(server.URL, "public-key", "secret-key", WithStartupHealthCheck(time.Second))
+	require.ErrorContains(t, err, "langfuse health check failed")
+
+	_, err = New("http://127.0.0.1:1", "public-key", "secret-key", WithStartupHealthCheck(100*time.Millisecond))
+	require.ErrorContains(t, err, "langfuse health check failed")
+}
+
+func TestNewClient_StartupHealthCheck(t *testing.T) {
+	server := newStartupServer(t, http.StatusOK, `{"status":"DEGRADED"}`, `{}`)
+	defer server.Close()
+
+	// A failed check is only logged
+	client := NewClient(server.URL, "public-key", "secret-key", WithStartupHealthCheck(time.Second))
+	require.NotNil(t, client)
+	require.NoError(t, client.Close())
+}
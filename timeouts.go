@@ -0,0 +1,89 @@
+package langfuse
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ingestionPath is the path of the ingestion API relative to the API base path, whose
+// requests are bounded by the flush timeout.
+const ingestionPath = "/ingestion"
+
+// TimeoutConfig holds the timeouts of the API requests per operation class.
+//
+// Each timeout bounds a single attempt of a request including the read of the response
+// body, on top of the deadline of the request context. A timed-out attempt is retried
+// like other network errors if the client retries requests. A zero timeout leaves the
+// requests of its class unbounded.
+type TimeoutConfig struct {
+	// Read bounds the GET and HEAD requests, e.g. to fetch prompts or list traces.
+	Read time.Duration
+	// Write bounds the requests which create, update or delete resources.
+	Write time.Duration
+	// Upload bounds the upload of media content to the storage provider.
+	Upload time.Duration
+	// Flush bounds the requests which send batches of traces to the ingestion API.
+	Flush time.Duration
+}
+
+// timeoutFor returns the timeout of the request, based on its method and path.
+func (c *TimeoutConfig) timeoutFor(req *http.Request) time.Duration {
+	switch {
+	case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, ingestionPath):
+		return c.Flush
+	case req.Method == http.MethodGet || req.Method == http.MethodHead:
+		return c.Read
+	default:
+		return c.Write
+	}
+}
+
+// timeoutTransport bounds every attempt of a request by the timeout of its operation class.
+type timeoutTransport struct {
+	base     http.RoundTripper
+	timeouts TimeoutConfig
+}
+
+// installTimeouts makes the resty client send its requests through a timeoutTransport,
+// wrapping the transport the client currently uses.
+func installTimeouts(cli *resty.Client, timeouts TimeoutConfig) {
+	base := cli.GetClient().Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	cli.SetTransport(&timeoutTransport{base: base, timeouts: timeouts})
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timeout := t.timeouts.timeoutFor(req)
+	if timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	rsp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The timeout covers the read of the body, so it's released once the body is closed
+	rsp.Body = &cancelOnClose{ReadCloser: rsp.Body, cancel: cancel}
+	return rsp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,98 @@
+package langfuse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/git-hulk/langfuse-go/pkg/httpcache"
+)
+
+func TestTimeoutConfig_timeoutFor(t *testing.T) {
+	timeouts := TimeoutConfig{Read: time.Second, Write: 2 * time.Second, Flush: 3 * time.Second}
+
+	newRequest := func(method, path string) *http.Request {
+		return httptest.NewRequest(method, "https://cloud.langfuse.com/api/public"+path, nil)
+	}
+	require.Equal(t, time.Second, timeouts.timeoutFor(newRequest(http.MethodGet, "/v2/prompts")))
+	require.Equal(t, 2*time.Second, timeouts.timeoutFor(newRequest(http.MethodDelete, "/traces/1")))
+	require.Equal(t, 2*time.Second, timeouts.timeoutFor(newRequest(http.MethodPost, "/scores")))
+	require.Equal(t, 3*time.Second, timeouts.timeoutFor(newRequest(http.MethodPost, "/ingestion")))
+}
+
+func TestWithTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "public-key", "secret-key", WithTimeouts(TimeoutConfig{
+		Read:  50 * time.Millisecond,
+		Write: time.Second,
+	}))
+	defer client.Close()
+
+	ctx := context.Background()
+	_, err := client.restyCli.R().SetContext(ctx).Get("/projects")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	rsp, err := client.restyCli.R().SetContext(ctx).SetBody(`{}`).Post("/scores")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rsp.StatusCode())
+	require.JSONEq(t, `{}`, rsp.String())
+}
+
+func TestWithTimeouts_HTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	transport := &http.Transport{}
+	httpClient := &http.Client{Transport: transport}
+	client := NewClient(server.URL, "public-key", "secret-key", WithHTTPClient(httpClient),
+		WithTimeouts(TimeoutConfig{Read: time.Second}),
+		WithResponseCache(httpcache.NewMemoryCache(10)),
+		WithIngestionTransport(IngestionTransportConfig{Compress: true}))
+	defer client.Close()
+
+	rsp, err := client.restyCli.R().Get("/projects")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rsp.StatusCode())
+	// The client of the caller isn't modified
+	require.Same(t, transport, httpClient.Transport)
+}
+
+func TestWithTimeouts_Retry(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "public-key", "secret-key", WithTimeouts(TimeoutConfig{Read: 50 * time.Millisecond}))
+	defer client.Close()
+	client.restyCli.SetRetryCount(1).SetRetryWaitTime(time.Millisecond)
+
+	// Every attempt gets its own timeout
+	rsp, err := client.restyCli.R().SetContext(context.Background()).Get("/projects")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rsp.StatusCode())
+	require.EqualValues(t, 2, attempts.Load())
+}
@@ -0,0 +1,28 @@
+package langfuse
+
+import (
+	"net/http"
+)
+
+// WithTransport sends all API requests through the given round tripper instead of the
+// default HTTP transport, e.g. an instrumented transport which is shared with the rest of
+// the application, or an adapter to another HTTP library.
+//
+// The requests are complete, i.e. they carry the absolute URL, the authentication and the
+// body, so the round tripper only has to send them. The redirects and cookies are still
+// handled by the HTTP client, and the timeouts, the circuit breaker and the response cache
+// still apply on top of the round tripper, while the proxy, TLS and ingestion transport
+// options are ignored since the round tripper owns the connections.
+//
+// The API clients of the sub-packages are still built on resty v2, whose client their
+// NewClient functions accept, so this doesn't remove the dependency on resty.
+//
+// Example:
+//
+//	client := langfuse.NewClient(host, publicKey, secretKey, langfuse.WithTransport(
+//		otelhttp.NewTransport(http.DefaultTransport)))
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(config *clientConfig) {
+		config.transport = transport
+	}
+}
@@ -0,0 +1,42 @@
+package langfuse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/public/health", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "OK", "version": "3.0.0"}`))
+	}))
+	defer server.Close()
+
+	var requests atomic.Int32
+	client := NewClient(server.URL, "public-key", "secret-key", WithTransport(
+		roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requests.Add(1)
+			user, _, ok := req.BasicAuth()
+			require.True(t, ok)
+			require.Equal(t, "public-key", user)
+			return server.Client().Transport.RoundTrip(req)
+		})))
+	defer client.Close()
+
+	health, err := client.Health().Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "3.0.0", health.Version)
+	require.EqualValues(t, 1, requests.Load())
+}